@@ -2,31 +2,49 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log"
+	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/config"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/delivery/httpapi"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/delivery/telegram"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/logger"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/metrics"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/storage"
+	"github.com/aliskhannn/asma-ul-husna-bot/migrations"
 )
 
+// telegramRequestTimeout bounds one Telegram Bot API request, including the 60 second
+// long poll of getUpdates.
+const telegramRequestTimeout = 90 * time.Second
+
 func main() {
 	// Load application configuration.
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal()
+		log.Fatalf("failed to load config: %v", err)
 	}
 
 	// Initialize structured logger.
-	lg, err := logger.New(cfg)
+	lg, logControls, err := logger.New(cfg)
 	if err != nil {
 		panic(err)
 	}
@@ -34,14 +52,38 @@ func main() {
 		_ = lg.Sync()
 	}()
 
-	// Create Telegram Bot API client.
-	bot, err := tgbotapi.NewBotAPI(cfg.TelegramAPIToken)
+	// Subcommands do their job and exit without starting the bot:
+	// "migrate [up|down|status]" manages the schema, "seed-names" loads the names dataset.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg, os.Args[2:]); err != nil {
+				lg.Fatal("migration failed", zap.Error(err))
+			}
+			return
+		case "seed-names":
+			if err := runSeedNames(cfg, os.Args[2:]); err != nil {
+				lg.Fatal("seeding names failed", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	lg.Info("effective config", zap.Any("config", cfg.Effective()))
+
+	// Create Telegram Bot API client. Requests are bounded so that a hung one cannot stall
+	// update handling; the limit leaves room for the long poll of getUpdates.
+	bot, err := tgbotapi.NewBotAPIWithClient(cfg.TelegramAPIToken, tgbotapi.APIEndpoint,
+		&http.Client{Timeout: telegramRequestTimeout})
 	if err != nil {
 		lg.Fatal("failed to create bot",
 			zap.Error(err),
 		)
 	}
 
+	// Sign button data, so that forged or tampered callback queries are rejected.
+	telegram.SetCallbackSecret(cfg.CallbackSecret)
+
 	// Set commands.
 	commands := []tgbotapi.BotCommand{
 		{
@@ -60,10 +102,34 @@ func main() {
 			Command:     "progress",
 			Description: "Показать прогресс изучения",
 		},
+		{
+			Command:     "schedule",
+			Description: "Расписание повторений",
+		},
+		{
+			Command:     "report",
+			Description: "Отчёт за месяц",
+		},
+		{
+			Command:     "goal",
+			Description: "Начать все имена к дате",
+		},
 		{
 			Command:     "random",
 			Description: "Случайное имя",
 		},
+		{
+			Command:     "name",
+			Description: "Открыть имя по номеру: /name 5",
+		},
+		{
+			Command:     "favorites",
+			Description: "Избранные имена",
+		},
+		{
+			Command:     "find",
+			Description: "Поиск имени: /find милостивый",
+		},
 		{
 			Command:     "all",
 			Description: "Показать все 99 имён",
@@ -72,14 +138,38 @@ func main() {
 			Command:     "settings",
 			Description: "Настройки",
 		},
+		{
+			Command:     "export",
+			Description: "Выгрузить свои данные",
+		},
+		{
+			Command:     "import",
+			Description: "Восстановить данные из /export",
+		},
+		{
+			Command:     "app",
+			Description: "Открыть приложение",
+		},
 		{
 			Command:     "help",
 			Description: "Помощь и список команд",
 		},
+		{
+			Command:     "feedback",
+			Description: "Написать разработчикам",
+		},
+		{
+			Command:     "whatsnew",
+			Description: "Что нового в боте",
+		},
 		{
 			Command:     "reset",
 			Description: "Сброс прогресса и настроек",
 		},
+		{
+			Command:     "deletemydata",
+			Description: "Удалить аккаунт и все данные",
+		},
 	}
 
 	// Register bot commands with Telegram API.
@@ -90,7 +180,7 @@ func main() {
 		)
 	}
 
-	bot.Debug = cfg.Env == "local" // enable debug mode for development
+	bot.Debug = cfg.Debug // log API traffic, off in prod unless the config turns it on
 
 	lg.Info("authorized on account",
 		zap.String("username", bot.Self.UserName),
@@ -117,10 +207,44 @@ func main() {
 		lg.Fatal("failed to get database DSN", zap.Error(err))
 	}
 
-	pool, err := postgres.NewPool(ctx, connString, postgres.PoolConfig{
-		MaxConns:        cfg.DB.MaxConnections,
-		MaxConnLifetime: cfg.DB.MaxConnLifetime,
-	})
+	queryCounter := metrics.NewCounterVec("asma_db_queries_total",
+		"Database queries made by this instance.", "outcome")
+	poolConfig := postgres.PoolConfig{
+		MaxConns:           cfg.DB.MaxConnections,
+		MaxConnLifetime:    cfg.DB.MaxConnLifetime,
+		ConnectAttempts:    cfg.DB.ConnectAttempts,
+		StatementTimeout:   cfg.DB.StatementTimeout,
+		SlowQueryThreshold: cfg.DB.SlowQueryThreshold,
+		OnConnectRetry: func(attempt int, wait time.Duration, err error) {
+			lg.Warn("database is unavailable, retrying",
+				zap.Int("attempt", attempt),
+				zap.Duration("wait", wait),
+				zap.Error(err),
+			)
+		},
+		OnSlowQuery: func(e postgres.QueryEvent) {
+			lg.Warn("slow query",
+				zap.String("sql", e.SQL),
+				zap.Duration("duration", e.Duration),
+				zap.Int64("rows", e.Rows),
+				zap.Bool("timed_out", e.TimedOut),
+				zap.String(requestid.FieldName, e.RequestID),
+				zap.Error(e.Err),
+			)
+		},
+		OnQuery: func(e postgres.QueryEvent) {
+			switch {
+			case e.TimedOut:
+				queryCounter.Inc("timeout")
+			case e.Err != nil:
+				queryCounter.Inc("error")
+			default:
+				queryCounter.Inc("ok")
+			}
+		},
+	}
+
+	pool, err := postgres.NewPool(ctx, connString, poolConfig)
 	if err != nil {
 		lg.Fatal("failed to connect to db",
 			zap.Error(err),
@@ -128,32 +252,177 @@ func main() {
 	}
 	defer pool.Close()
 
+	// Stats and reports are read from the replica when one is configured, from the primary otherwise.
+	replica := pool
+	if cfg.DB.ReplicaURL != "" {
+		replica, err = postgres.NewPool(ctx, cfg.DB.ReplicaURL, poolConfig)
+		if err != nil {
+			lg.Fatal("failed to connect to replica db",
+				zap.Error(err),
+			)
+		}
+		defer replica.Close()
+	}
+
+	if cfg.DB.AutoMigrate {
+		migrator, err := postgres.NewMigrator(pool, migrations.FS)
+		if err != nil {
+			lg.Fatal("failed to load migrations", zap.Error(err))
+		}
+		applied, err := migrator.Up(ctx)
+		_ = migrator.Close()
+		if err != nil {
+			lg.Fatal("failed to apply migrations", zap.Error(err))
+		}
+		if applied > 0 {
+			lg.Info("migrations applied", zap.Int("count", applied))
+		}
+	}
+
 	tr := postgres.NewTransactor(pool)
 
+	// Keep state shared by replicas in Redis when it is configured, in process memory otherwise.
+	var stateStore storage.KV = storage.NewMemoryKV()
+	if cfg.Redis.Addr != "" {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer func() {
+			_ = redisClient.Close()
+		}()
+
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			lg.Fatal("failed to connect to redis",
+				zap.Error(err),
+			)
+		}
+		stateStore = storage.NewRedisKV(redisClient, "asma:")
+	}
+
 	// Initialize repositories and services.
 	userRepo := repository.NewUserRepository(pool)
 	userService := service.NewUserService(tr, userRepo)
 
-	settingsRepo := repository.NewSettingsRepository(pool)
-	settingsService := service.NewSettingsService(settingsRepo)
+	settingsRepo := repository.NewCachedSettingsRepository(repository.NewSettingsRepository(pool), stateStore, lg)
+	progressRepo := repository.NewProgressRepository(pool).WithReplica(replica)
+	userAuditService := service.NewUserAuditService(repository.NewUserAuditRepository(pool), lg)
+	settingsService := service.NewSettingsService(settingsRepo, progressRepo, userAuditService)
 
-	progressRepo := repository.NewProgressRepository(pool)
-	progressService := service.NewProgressService(progressRepo, settingsRepo)
+	streakRepo := repository.NewStreakRepository(pool)
+	xpRepo := repository.NewXPRepository(pool)
+	progressService := service.NewProgressService(progressRepo, settingsRepo, streakRepo, xpRepo)
 
 	dailyNameRepo := repository.NewDailyNameRepository(pool)
-	dailyNameService := service.NewDailyNameService(dailyNameRepo, progressRepo)
+	dailyNameService := service.NewDailyNameService(tr, dailyNameRepo, progressRepo, userAuditService)
 
 	quizRepo := repository.NewQuizRepository(pool)
-	quizService := service.NewQuizService(tr, nameRepo, progressRepo, quizRepo, settingsRepo, dailyNameRepo, lg)
+	favoritesRepo := repository.NewFavoritesRepository(pool)
+	webhookService := service.NewWebhookService(cfg.Webhooks.URL, cfg.Webhooks.Secret)
+	quizService := service.NewQuizService(tr, nameRepo, progressRepo, quizRepo, settingsRepo, dailyNameRepo, favoritesRepo, webhookService, lg)
 
 	remindersRepo := repository.NewRemindersRepository(pool)
-	remindersService := service.NewReminderService(remindersRepo, progressRepo, settingsRepo, nameRepo, dailyNameRepo, lg)
+	notificationJobRepo := repository.NewNotificationJobRepository(pool)
+	reminderLogRepo := repository.NewReminderLogRepository(pool)
+	deliveryStatsService := service.NewDeliveryStatsService(repository.NewReminderLogRepository(replica), repository.NewOverviewRepository(replica))
+	deliveryCounter := metrics.NewCounterVec("asma_notification_attempts_total",
+		"Notification delivery attempts made by this instance.", "kind", "outcome")
+	notificationWorker := service.NewNotificationWorker(notificationJobRepo, reminderLogRepo, deliveryCounter, webhookService, lg)
+	remindersService := service.NewReminderService(tr, remindersRepo, progressRepo, settingsRepo, nameRepo, dailyNameRepo, streakRepo, userAuditService, lg)
+
+	resetService := service.NewResetService(tr, settingsRepo, userAuditService)
+
+	retentionRepo := repository.NewRetentionRepository(pool)
+	retentionService := service.NewRetentionService(retentionRepo, service.RetentionPolicy{
+		QuizHistoryDays: cfg.Retention.QuizHistoryDays,
+		QuizArchiveDays: cfg.Retention.QuizArchiveDays,
+		InactiveMonths:  cfg.Retention.InactiveMonths,
+		WarningDays:     cfg.Retention.WarningDays,
+	}, lg)
+
+	messageCleanupService := service.NewMessageCleanupService(repository.NewEphemeralMessageRepository(pool), service.MessageCleanupPolicy{
+		After:         cfg.Messages.CleanupAfter,
+		ReminderTTL:   cfg.Messages.ReminderTTL,
+		EditReminders: cfg.Messages.ReminderExpiry == "edit",
+	}, lg)
+
+	favoritesService := service.NewFavoritesService(favoritesRepo, nameRepo)
+
+	notesRepo := repository.NewNotesRepository(pool)
+	notesService := service.NewNotesService(notesRepo)
+
+	importService := service.NewImportService(tr, settingsRepo)
+	exportService := service.NewExportService(settingsRepo, remindersRepo, progressRepo, quizRepo, favoritesRepo, notesRepo, streakRepo, xpRepo, nameRepo)
+
+	channelSchedules := make([]service.ChannelSchedule, 0, len(cfg.Channels))
+	for _, ch := range cfg.Channels {
+		schedule, err := service.NewChannelSchedule(ch.ChatID, ch.Time, ch.Timezone, ch.Language)
+		if err != nil {
+			lg.Fatal("invalid channel configuration",
+				zap.Int64("chat_id", ch.ChatID),
+				zap.Error(err),
+			)
+		}
+		channelSchedules = append(channelSchedules, schedule)
+	}
+	adminRepo := repository.NewAdminRepository(pool)
+	adminService := service.NewAdminService(adminRepo, cfg.AdminIDs, lg)
+
+	blockListService := service.NewBlockListService(repository.NewBlockListRepository(pool), adminService, lg)
+	if err := blockListService.Load(ctx); err != nil {
+		lg.Error("failed to load block list",
+			zap.Error(err),
+		)
+	}
+
+	feedbackRecipients := cfg.AdminIDs
+	if cfg.FeedbackChatID != 0 {
+		feedbackRecipients = []int64{cfg.FeedbackChatID}
+	}
+	ticketService := service.NewTicketService(repository.NewTicketRepository(pool), feedbackRecipients)
+	reportService := service.NewContentReportService(repository.NewContentReportRepository(pool), feedbackRecipients)
+	surveyService := service.NewSurveyService(repository.NewSurveyRepository(pool))
+	retentionReportService := service.NewRetentionReportService(repository.NewRetentionCohortRepository(pool), feedbackRecipients, lg)
+
+	releaseNotesRepo, err := repository.NewReleaseNotesRepository(cfg.ChangelogPath)
+	if err != nil {
+		lg.Fatal("failed to init release notes repository",
+			zap.Error(err),
+		)
+	}
+	changelogService := service.NewChangelogService(releaseNotesRepo, repository.NewChangelogRepository(pool), lg)
+	analyticsService := service.NewAnalyticsService(repository.NewEventRepository(pool).WithReplica(replica), lg)
+	apiTokenService := service.NewAPITokenService(repository.NewAPITokenRepository(pool), cfg.API.Addr != "")
+
+	// The Mini App and the web admin are served by the HTTP API, so they need both the API and a public URL.
+	webAppURL, adminWebURL := "", ""
+	if cfg.API.Addr != "" {
+		webAppURL, adminWebURL = cfg.WebApp.URL, cfg.AdminWeb.URL
+	}
+	adminSessionService := service.NewAdminSessionService(cfg.TelegramAPIToken, adminWebURL)
 
-	resetService := service.NewResetService(tr)
+	nameContentService := service.NewNameContentService(nameRepo, nameService, repository.NewNameOverrideRepository(pool), lg)
+	if err := nameContentService.Apply(ctx); err != nil {
+		lg.Error("failed to apply name edits",
+			zap.Error(err),
+		)
+	}
+	broadcastService := service.NewBroadcastService(repository.NewBroadcastRepository(pool))
 
-	// Initialize in-memory storages for quiz sessions and reminders.
-	quizStorage := storage.NewQuizStorage()
-	reminderStorage := storage.NewReminderStorage()
+	channelPostRepo := repository.NewChannelPostRepository(pool)
+	groupChatRepo := repository.NewGroupChatRepository(pool)
+	groupChatService := service.NewGroupChatService(groupChatRepo, channelPostRepo)
+
+	channelPublisher := service.NewChannelPublisher(channelPostRepo, groupChatRepo, nameRepo, channelSchedules, lg)
+
+	// Initialize storages for quiz sessions and reminders, shared through Redis if it is configured.
+	var quizStorage telegram.QuizStorage = storage.NewQuizStorage()
+	var reminderStorage telegram.ReminderStorage = storage.NewReminderStorage()
+	if cfg.Redis.Addr != "" {
+		quizStorage = storage.NewKVQuizStorage(stateStore, lg)
+		reminderStorage = storage.NewKVReminderStorage(stateStore, lg)
+	}
 
 	// Construct Telegram updates handler with all dependencies.
 	handler := telegram.NewHandler(
@@ -168,14 +437,118 @@ func main() {
 		remindersService,
 		dailyNameService,
 		reminderStorage,
+		stateStore,
 		resetService,
+		favoritesService,
+		notesService,
+		exportService,
+		importService,
+		groupChatService,
+		deliveryStatsService,
+		adminService,
+		userAuditService,
+		blockListService,
+		ticketService,
+		reportService,
+		surveyService,
+		changelogService,
+		analyticsService,
+		apiTokenService,
+		webAppURL,
+		adminSessionService,
 	)
 
-	// Register Telegram notifier in reminders service.
-	remindersService.SetNotifier(handler)
+	// Register Telegram notifier in notification worker.
+	notificationWorker.SetNotifier(handler)
+
+	// Register Telegram notifier in retention service.
+	retentionService.SetNotifier(handler)
+
+	// Register Telegram notifier in retention report service.
+	retentionReportService.SetNotifier(handler)
+
+	// Register Telegram poster in channel publisher.
+	channelPublisher.SetPoster(handler)
+
+	// Schedule quiz questions, prompts and reminders for removal and remove them with the bot.
+	messageCleanupService.SetExpirer(handler)
+	handler.SetMessageTracker(messageCleanupService)
+	handler.SetEditReminders(cfg.Messages.ReminderExpiry == "edit")
 
-	// Start background reminder scheduler.
-	go remindersService.Start(ctx)
+	// Start background reminder scheduler, data retention cleanup and reporting jobs.
+	// With several replicas only the instance holding the advisory lock runs them.
+	go runExclusive(ctx, lg, pool, "reminder_scheduler", remindersService.Start)
+	go runExclusive(ctx, lg, pool, "retention_cleanup", retentionService.Start)
+	go runExclusive(ctx, lg, pool, "channel_publisher", channelPublisher.Start)
+	go runExclusive(ctx, lg, pool, "retention_report", retentionReportService.Start)
+	go runExclusive(ctx, lg, pool, "message_cleanup", messageCleanupService.Start)
+
+	// Start queued notification delivery; workers on all replicas share the queue.
+	go notificationWorker.Start(ctx)
+
+	// Pick up name card edits made on other replicas.
+	go nameContentService.Start(ctx)
+
+	// Pick up users blocked or unblocked on other replicas.
+	go blockListService.Start(ctx)
+
+	// Announce a newly deployed release to subscribers; the release is claimed, so replicas announce it once.
+	if err := changelogService.AnnounceLatest(ctx); err != nil {
+		lg.Error("failed to announce release",
+			zap.Error(err),
+		)
+	}
+
+	// Shed updates that arrive while the backlog is full instead of letting it grow.
+	shedCounter := metrics.NewCounterVec("asma_updates_shed_total",
+		"Updates dropped by this instance because the backlog was full.", "kind")
+	handler.SetBacklog(cfg.Updates.MaxBacklog, shedCounter)
+	handler.SetHandlerTimeout(cfg.Updates.HandlerTimeout)
+
+	// Expose delivery metrics for Prometheus, and profiles if allowed, if enabled.
+	if cfg.Metrics.Addr != "" {
+		var pprof http.Handler
+		if cfg.Metrics.Pprof {
+			pprof = metrics.Pprof(cfg.Metrics.PprofToken)
+		}
+		registerBacklogGauges(handler)
+		prometheus.MustRegister(newDeliveryStatsCollector(deliveryStatsService))
+		go metrics.Serve(ctx, cfg.Metrics.Addr, metrics.Handler(lg), pool.Ping, pprof, lg)
+	}
+
+	// Serve the HTTP API for companion apps and the Mini App if enabled.
+	if cfg.API.Addr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/api/", httpapi.NewServer(nameService, progressService, apiTokenService, lg).Handler())
+		if webAppURL != "" {
+			webApp := httpapi.NewWebApp(cfg.TelegramAPIToken, nameService, progressService, userService, lg)
+			mux.Handle("/webapp/", webApp.Handler())
+			setWebAppMenuButton(bot, webAppURL, lg)
+		}
+		if adminWebURL != "" {
+			adminWeb := httpapi.NewAdminWeb(
+				adminSessionService,
+				adminService,
+				userService,
+				progressService,
+				nameService,
+				nameContentService,
+				broadcastService,
+				deliveryStatsService,
+				analyticsService,
+				retentionReportService,
+				lg,
+			)
+			mux.Handle("/admin/", adminWeb.Handler())
+		}
+		go httpapi.Serve(ctx, cfg.API.Addr, mux, lg)
+	}
+
+	// Apply log level, owners, rate limits and feature flags again on SIGHUP or /admin_reload.
+	reloader := newConfigReloader(cfg, logControls, handler, adminService, lg)
+	handler.SetReloader(reloader)
+	handler.SetLogControls(logControls)
+	go reloader.Watch(ctx)
 
 	// Start main Telegram updates handling loop.
 	if err := handler.Run(ctx); err != nil {
@@ -188,3 +561,93 @@ func main() {
 
 	lg.Info("shutdown signal received")
 }
+
+// runExclusive runs a background job only while this instance holds its advisory lock.
+func runExclusive(ctx context.Context, lg *zap.Logger, pool *pgxpool.Pool, job string, start func(ctx context.Context)) {
+	lock := postgres.NewAdvisoryLock(pool, job)
+
+	err := lock.RunExclusive(ctx, func(ctx context.Context) {
+		lg.Info("advisory lock acquired, starting background job", zap.String("job", job))
+		start(ctx)
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		lg.Error("background job stopped", zap.String("job", job), zap.Error(err))
+	}
+}
+
+// registerBacklogGauges reports the updates of this instance waiting to be handled and the shedding limit.
+func registerBacklogGauges(handler *telegram.Handler) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "asma_updates_backlog",
+		Help: "Updates received by this instance and waiting to be handled.",
+	}, func() float64 { return float64(handler.BacklogDepth()) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "asma_updates_backlog_limit",
+		Help: "Waiting updates after which new ones are shed, 0 if unlimited.",
+	}, func() float64 { return float64(handler.BacklogLimit()) })
+}
+
+// deliveryStatsCollector reports delivery attempts of all instances over the last day and the queue size.
+// Unlike the in-process counter these gauges come from the database, so every replica reports the same values.
+type deliveryStatsCollector struct {
+	stats    *service.DeliveryStatsService
+	attempts *prometheus.Desc
+	queue    *prometheus.Desc
+}
+
+func newDeliveryStatsCollector(stats *service.DeliveryStatsService) *deliveryStatsCollector {
+	return &deliveryStatsCollector{
+		stats: stats,
+		attempts: prometheus.NewDesc("asma_notification_attempts_24h",
+			"Notification delivery attempts over the last 24 hours.", []string{"kind", "outcome"}, nil),
+		queue: prometheus.NewDesc("asma_notification_queue_jobs",
+			"Notification jobs in the queue by status.", []string{"status"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *deliveryStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.attempts
+	ch <- c.queue
+}
+
+// Collect implements prometheus.Collector. A failed query is reported as an invalid metric,
+// which the handler logs and leaves out of the scrape.
+func (c *deliveryStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metrics.ScrapeTimeout)
+	defer cancel()
+
+	stats, err := c.stats.GetDeliveryStats(ctx, 24*time.Hour)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.attempts, err)
+		return
+	}
+
+	for kind, counts := range stats.ByKind {
+		for _, outcome := range entities.DeliveryOutcomes {
+			ch <- prometheus.MustNewConstMetric(c.attempts, prometheus.GaugeValue,
+				float64(counts[outcome]), string(kind), string(outcome))
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.queue, prometheus.GaugeValue, float64(stats.QueuePending), "pending")
+	ch <- prometheus.MustNewConstMetric(c.queue, prometheus.GaugeValue, float64(stats.QueueFailed), "failed")
+}
+
+// setWebAppMenuButton makes the chat menu button open the Mini App.
+// tgbotapi has no setChatMenuButton, so the request is built by hand.
+func setWebAppMenuButton(bot *tgbotapi.BotAPI, url string, lg *zap.Logger) {
+	button, err := json.Marshal(map[string]any{
+		"type":    "web_app",
+		"text":    "Приложение",
+		"web_app": map[string]string{"url": url},
+	})
+	if err == nil {
+		_, err = bot.MakeRequest("setChatMenuButton", tgbotapi.Params{"menu_button": string(button)})
+	}
+	if err != nil {
+		lg.Warn("failed to set menu button",
+			zap.Error(err),
+		)
+	}
+}
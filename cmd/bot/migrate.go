@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/config"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+	"github.com/aliskhannn/asma-ul-husna-bot/migrations"
+)
+
+// newCommandPool opens a small connection pool for a one-off subcommand.
+func newCommandPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	connString, err := cfg.DB.DSN()
+	if err != nil {
+		return nil, err
+	}
+
+	return postgres.NewPool(ctx, connString, postgres.PoolConfig{
+		MaxConns:         2,
+		MaxConnLifetime:  cfg.DB.MaxConnLifetime,
+		ConnectAttempts:  cfg.DB.ConnectAttempts,
+		StatementTimeout: cfg.DB.StatementTimeout,
+	})
+}
+
+// runMigrate runs the migrate subcommand: "up" (the default) applies pending migrations,
+// "down" rolls back the latest one and "status" lists them.
+func runMigrate(cfg *config.Config, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := newCommandPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	migrator, err := postgres.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", applied)
+
+	case "down":
+		m, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			fmt.Println("no migration to roll back")
+			return nil
+		}
+		fmt.Printf("rolled back %s\n", m.Name)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			state := "pending"
+			if st.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%-8s %s\n", state, st.Migration.Name)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, want up, down or status", command)
+	}
+
+	return nil
+}
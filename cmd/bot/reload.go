@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/config"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/delivery/telegram"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/logger"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// configReloader rereads the configuration on SIGHUP or /admin_reload and applies the
+// keys listed in config.ReloadableKeys; the bot keeps running and polling for updates.
+type configReloader struct {
+	logs    *logger.Controls
+	handler *telegram.Handler
+	admins  *service.AdminService
+	logger  *zap.Logger
+
+	mu      sync.Mutex
+	current *config.Config
+}
+
+func newConfigReloader(
+	cfg *config.Config,
+	logs *logger.Controls,
+	handler *telegram.Handler,
+	admins *service.AdminService,
+	lg *zap.Logger,
+) *configReloader {
+	r := &configReloader{logs: logs, handler: handler, admins: admins, logger: lg, current: cfg}
+	handler.ApplyRuntime(runtimeSettings(cfg))
+	return r
+}
+
+// Watch reloads the configuration on every SIGHUP until ctx is done.
+func (r *configReloader) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if _, _, err := r.Reload(); err != nil {
+				r.logger.Error("failed to reload config", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Reload loads the configuration and applies its reloadable keys. It returns the applied
+// changes as "key: old → new" and the other changed keys, which need a restart.
+// A configuration that fails to load or validate is rejected and the current one is kept.
+func (r *configReloader) Reload() (applied, restart []string, err error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before, after := r.current.Effective(), cfg.Effective()
+	for _, key := range changedKeys(before, after) {
+		if !reloadable(key) {
+			restart = append(restart, key)
+			continue
+		}
+		// Secrets are never reloadable, so both values can be shown.
+		applied = append(applied, fmt.Sprintf("%s: %s → %s", key, orNone(before[key]), orNone(after[key])))
+	}
+
+	if err := r.logs.SetLevel(cfg.LogLevel); err != nil {
+		return nil, nil, fmt.Errorf("log_level: %w", err)
+	}
+	r.admins.SetOwners(cfg.AdminIDs)
+	r.handler.ApplyRuntime(runtimeSettings(cfg))
+
+	// Keep the startup values of keys that need a restart, so they are reported until then.
+	reloaded := *r.current
+	reloaded.LogLevel = cfg.LogLevel
+	reloaded.AdminIDs = cfg.AdminIDs
+	reloaded.RateLimits = cfg.RateLimits
+	reloaded.Features = cfg.Features
+	r.current = &reloaded
+
+	r.logger.Info("config reloaded",
+		zap.Strings("applied", applied),
+		zap.Strings("restart_required", restart),
+	)
+	return applied, restart, nil
+}
+
+// runtimeSettings returns the handler settings of cfg.
+func runtimeSettings(cfg *config.Config) telegram.RuntimeSettings {
+	return telegram.RuntimeSettings{
+		UserUpdatesPerMinute: cfg.RateLimits.UserUpdatesPerMinute,
+		UserBurst:            cfg.RateLimits.UserBurst,
+		UserRefill:           cfg.RateLimits.UserRefill,
+		Features:             cfg.Features,
+	}
+}
+
+// changedKeys returns the sorted keys whose values differ, including added and removed ones.
+func changedKeys(before, after map[string]string) []string {
+	var keys []string
+	for key, value := range after {
+		if old, ok := before[key]; !ok || old != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// reloadable reports whether a key is listed in config.ReloadableKeys.
+func reloadable(key string) bool {
+	for _, k := range config.ReloadableKeys {
+		if key == k || strings.HasSuffix(k, ".") && strings.HasPrefix(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// orNone shows an empty value as a dash.
+func orNone(value string) string {
+	if value == "" {
+		return "—"
+	}
+	return value
+}
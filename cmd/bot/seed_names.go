@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/config"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+	"github.com/aliskhannn/asma-ul-husna-bot/migrations"
+)
+
+// runSeedNames runs the seed-names subcommand: it loads the names JSON file into the
+// names table and prints what changed. With -dry-run it only prints the differences.
+func runSeedNames(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed-names", flag.ContinueOnError)
+	file := flags.String("file", cfg.NamesJSONPath, "names JSON file")
+	dryRun := flags.Bool("dry-run", false, "print the differences without writing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	nameRepo, err := repository.NewNameRepository(*file)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", *file, err)
+	}
+	names, err := nameRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := newCommandPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	if cfg.DB.AutoMigrate {
+		migrator, err := postgres.NewMigrator(pool, migrations.FS)
+		if err != nil {
+			return err
+		}
+		_, err = migrator.Up(ctx)
+		_ = migrator.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	seeder := service.NewNameSeedService(repository.NewNameDatasetRepository(pool))
+	diff, err := seeder.Seed(ctx, names, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range diff.Added {
+		fmt.Printf("+ %d\n", n)
+	}
+	for _, c := range diff.Changed {
+		fmt.Printf("~ %d %s: %q -> %q\n", c.Number, c.Field, c.Old, c.New)
+	}
+	for _, n := range diff.Extra {
+		fmt.Printf("? %d is not in %s, left as is\n", n, *file)
+	}
+
+	if diff.Empty() {
+		fmt.Printf("names table is up to date (%d names)\n", diff.Unchanged)
+		return nil
+	}
+
+	verb := "seeded"
+	if *dryRun {
+		verb = "would seed"
+	}
+	fmt.Printf("%s %d added, %d field(s) changed, %d unchanged\n", verb, len(diff.Added), len(diff.Changed), diff.Unchanged)
+
+	return nil
+}
@@ -1,8 +1,6 @@
 #!/bin/sh
 set -e
 
-echo "Running migrations..."
-goose -dir ./migrations postgres "$DATABASE_URL" up
-
+# Migrations are embedded in the binary and applied on startup (database.auto_migrate).
 echo "Starting bot..."
 exec ./husna-bot
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,19 +14,132 @@ import (
 
 var ErrMissingEnvironmentVariables = errors.New("missing required environment variables")
 
+// Environments. Each may have a profile, config/config.<env>.yml, whose keys override config/config.yml.
+const (
+	EnvLocal = "local"
+	EnvDev   = "dev"
+	EnvProd  = "prod"
+)
+
+// envAliases maps older environment names to the current ones.
+var envAliases = map[string]string{
+	"development": EnvDev,
+	"production":  EnvProd,
+}
+
 // Config holds application configuration loaded from files and environment variables.
 type Config struct {
-	Env              string `mapstructure:"env"`             // current application environment (local, dev, prod etc)
-	TelegramAPIToken string `mapstructure:"-"`               // Telegram API token loaded from environment
-	NamesJSONPath    string `mapstructure:"names_json_path"` // path to JSON file with 99 Names metadata
-	DB               DB     `mapstructure:"database"`        // database configuration section
+	Env              string          `mapstructure:"env"`              // current application environment: local, dev or prod
+	Debug            bool            `mapstructure:"debug"`            // log Telegram API requests and responses
+	LogLevel         string          `mapstructure:"log_level"`        // minimum log level: debug, info, warn or error
+	TelegramAPIToken string          `mapstructure:"-"`                // Telegram API token loaded from environment
+	CallbackSecret   string          `mapstructure:"callback_secret"`  // signs the data of inline buttons, empty leaves it unsigned
+	AdminIDs         []int64         `mapstructure:"-"`                // Telegram IDs allowed to use admin commands, from admin_ids or ADMIN_IDS
+	FeedbackChatID   int64           `mapstructure:"feedback_chat_id"` // chat that receives /feedback messages, ADMIN_IDS if zero
+	NamesJSONPath    string          `mapstructure:"names_json_path"`  // path to JSON file with 99 Names metadata
+	ChangelogPath    string          `mapstructure:"changelog_path"`   // path to JSON file with release notes for /whatsnew
+	DB               DB              `mapstructure:"database"`         // database configuration section
+	Retention        Retention       `mapstructure:"retention"`        // data retention configuration section
+	Metrics          Metrics         `mapstructure:"metrics"`          // metrics endpoint configuration section
+	API              API             `mapstructure:"api"`              // HTTP API configuration section
+	WebApp           WebApp          `mapstructure:"webapp"`           // Telegram Mini App configuration section
+	AdminWeb         AdminWeb        `mapstructure:"admin_web"`        // web admin configuration section
+	Webhooks         Webhooks        `mapstructure:"webhooks"`         // outgoing webhooks configuration section
+	Redis            Redis           `mapstructure:"redis"`            // shared state configuration section
+	Channels         []Channel       `mapstructure:"channels"`         // channels that receive the name of the day
+	RateLimits       RateLimits      `mapstructure:"rate_limits"`      // per-user limits on incoming updates
+	Updates          Updates         `mapstructure:"updates"`          // handling of incoming updates under load
+	Messages         Messages        `mapstructure:"messages"`         // cleanup of messages sent by the bot
+	Features         map[string]bool `mapstructure:"features"`         // feature flags by name; a missing flag is on
+}
+
+// ReloadableKeys are the keys, or key prefixes ending in a dot, applied again without a restart
+// on SIGHUP or /admin_reload. Other keys are read once on startup.
+var ReloadableKeys = []string{"log_level", "admin_ids", "rate_limits.", "features."}
+
+// RateLimits contains limits on how often a user can reach the bot.
+type RateLimits struct {
+	UserUpdatesPerMinute int           `mapstructure:"user_updates_per_minute"` // messages and button presses per user per minute, 0 for no limit
+	UserBurst            int           `mapstructure:"user_burst"`              // messages and button presses a user can send in a quick row, 0 for no limit
+	UserRefill           time.Duration `mapstructure:"user_refill"`             // time after which one more quick message is allowed
+}
+
+// Updates contains parameters of handling incoming Telegram updates.
+type Updates struct {
+	MaxBacklog     int           `mapstructure:"max_backlog"`     // updates waiting to be handled after which new ones are shed, 0 never sheds
+	HandlerTimeout time.Duration `mapstructure:"handler_timeout"` // limit on handling one update, 0 for no limit
+}
+
+// Messages contains parameters of deleting bot messages that are only useful for a while.
+type Messages struct {
+	CleanupAfter   time.Duration `mapstructure:"cleanup_after"`   // age after which quiz questions, prompts and reminders are deleted, 0 keeps them
+	ReminderTTL    time.Duration `mapstructure:"reminder_ttl"`    // age after which reminders expire, 0 to follow cleanup_after
+	ReminderExpiry string        `mapstructure:"reminder_expiry"` // how expired or replaced reminders go away: delete or edit
+}
+
+// Channel is a Telegram channel the bot posts the name of the day to.
+// The bot must be a channel administrator allowed to post messages.
+type Channel struct {
+	ChatID   int64  `mapstructure:"chat_id"`  // channel ID, e.g. -1001234567890
+	Time     string `mapstructure:"time"`     // local posting time, HH:MM
+	Timezone string `mapstructure:"timezone"` // IANA name or UTC offset, UTC if empty
+	Language string `mapstructure:"language"` // post language, ru or en, ru if empty
+}
+
+// Metrics contains parameters of the Prometheus metrics endpoint.
+type Metrics struct {
+	Addr       string `mapstructure:"addr"`        // listen address of the /metrics endpoint, empty disables it
+	Pprof      bool   `mapstructure:"pprof"`       // serve net/http/pprof profiles at /debug/pprof/ on addr
+	PprofToken string `mapstructure:"pprof_token"` // bearer token required by /debug/pprof/
+}
+
+// API contains parameters of the HTTP JSON API for companion apps and widgets.
+type API struct {
+	Addr string `mapstructure:"addr"` // listen address of the API, empty disables it
+}
+
+// WebApp contains parameters of the Telegram Mini App served by the HTTP API.
+type WebApp struct {
+	URL string `mapstructure:"url"` // public HTTPS URL of the Mini App (ending in /webapp/), empty disables it
+}
+
+// AdminWeb contains parameters of the web admin served by the HTTP API.
+type AdminWeb struct {
+	URL string `mapstructure:"url"` // public HTTPS URL of the web admin (ending in /admin/), empty disables it
+}
+
+// Webhooks contains parameters of outgoing webhooks about user learning events.
+type Webhooks struct {
+	URL    string `mapstructure:"url"`    // endpoint that receives signed JSON events, empty disables them
+	Secret string `mapstructure:"secret"` // HMAC-SHA256 key of the X-Webhook-Signature header
+}
+
+// Redis contains parameters of the Redis server that keeps quiz state, conversation state
+// and caches shared by bot replicas.
+type Redis struct {
+	Addr     string `mapstructure:"addr"`     // host:port of the server, empty keeps the state in process memory
+	Password string `mapstructure:"password"` // AUTH password, empty if not required
+	DB       int    `mapstructure:"db"`       // database number
+}
+
+// Retention contains data retention parameters. Zero values disable the corresponding cleanup.
+type Retention struct {
+	QuizHistoryDays int `mapstructure:"quiz_history_days"` // quiz sessions and answers older than this are pruned
+	QuizArchiveDays int `mapstructure:"quiz_archive_days"` // quiz answers older than this are moved to the archive table
+	InactiveMonths  int `mapstructure:"inactive_months"`   // users inactive this long are warned and then deleted
+	WarningDays     int `mapstructure:"warning_days"`      // grace period between the warning and deletion
 }
 
 // DB contains database-related configuration parameters.
 type DB struct {
-	URL             string        `mapstructure:"-"`                 // database connection string loaded from environment
-	MaxConnections  int32         `mapstructure:"max_connections"`   // maximum number of open connections in the pool
-	MaxConnLifetime time.Duration `mapstructure:"max_conn_lifetime"` // maximum lifetime of a single connection
+	URL                string        `mapstructure:"-"`                    // database connection string loaded from environment
+	ReplicaURL         string        `mapstructure:"-"`                    // optional read-only replica for stats and reports, from environment
+	MaxConnections     int32         `mapstructure:"max_connections"`      // maximum number of open connections in the pool
+	MaxConnLifetime    time.Duration `mapstructure:"max_conn_lifetime"`    // maximum lifetime of a single connection
+	AutoMigrate        bool          `mapstructure:"auto_migrate"`         // apply pending migrations on startup
+	ConnectAttempts    int           `mapstructure:"connect_attempts"`     // tries to reach the database on startup
+	StatementTimeout   time.Duration `mapstructure:"statement_timeout"`    // server-side limit of one statement; zero keeps the server default
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // queries at least this long are logged; zero disables the log
 }
 
 // DSN returns the database connection string if it is configured.
@@ -44,18 +160,57 @@ func Load() (*Config, error) {
 
 	// Set default values for configuration keys.
 	v.SetDefault("env", "local")
+	v.SetDefault("feedback_chat_id", 0)
+	v.SetDefault("callback_secret", "")
 	v.SetDefault("names_json_path", "assets/asma-ul-husna-ru.json")
+	v.SetDefault("changelog_path", "assets/data/changelog.json")
 	v.SetDefault("database.max_connections", 20)
 	v.SetDefault("database.max_conn_lifetime", "30s")
+	v.SetDefault("database.auto_migrate", true)
+	v.SetDefault("database.connect_attempts", 10)
+	v.SetDefault("database.statement_timeout", "30s")
+	v.SetDefault("database.slow_query_threshold", "500ms")
+	v.SetDefault("retention.quiz_history_days", 0)
+	v.SetDefault("retention.quiz_archive_days", 90)
+	v.SetDefault("retention.inactive_months", 0)
+	v.SetDefault("retention.warning_days", 7)
+	v.SetDefault("metrics.addr", "")
+	v.SetDefault("metrics.pprof", false)
+	v.SetDefault("metrics.pprof_token", "")
+	v.SetDefault("api.addr", "")
+	v.SetDefault("webapp.url", "")
+	v.SetDefault("admin_web.url", "")
+	v.SetDefault("webhooks.url", "")
+	v.SetDefault("webhooks.secret", "")
+	v.SetDefault("redis.addr", "")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+	v.SetDefault("rate_limits.user_updates_per_minute", 0)
+	v.SetDefault("rate_limits.user_burst", 0)
+	v.SetDefault("rate_limits.user_refill", "1s")
+	v.SetDefault("updates.max_backlog", 100)
+	v.SetDefault("updates.handler_timeout", "30s")
+	v.SetDefault("messages.cleanup_after", "24h")
+	v.SetDefault("messages.reminder_ttl", "0s")
+	v.SetDefault("messages.reminder_expiry", "delete")
 
-	// Configure environment variable handling and key mapping.
+	// Configure environment variable handling and key mapping. Every key with a default
+	// can be overridden this way, e.g. database.max_connections by DATABASE_MAX_CONNECTIONS.
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // map nested keys to ENV style names
 	v.AutomaticEnv()
 
 	// Bind explicit environment variables to configuration keys.
 	_ = v.BindEnv("telegram_api_token", "TELEGRAM_API_TOKEN")
 	_ = v.BindEnv("database_url", "DATABASE_URL")
+	_ = v.BindEnv("database_replica_url", "DATABASE_REPLICA_URL")
 	_ = v.BindEnv("env", "APP_ENV")
+	_ = v.BindEnv("admin_ids", "ADMIN_IDS")
+	_ = v.BindEnv("feedback_chat_id", "FEEDBACK_CHAT_ID")
+
+	// Secrets may come from mounted files or Vault instead of plain environment variables.
+	if err := loadSecrets(v); err != nil {
+		return nil, err
+	}
 
 	// Try to read configuration file if present.
 	if err := v.ReadInConfig(); err != nil {
@@ -65,13 +220,41 @@ func Load() (*Config, error) {
 		}
 	}
 
+	// Overlay the profile of the environment chosen by APP_ENV or the base file.
+	env := normalizeEnv(v.GetString("env"))
+	v.Set("env", env)
+	v.SetConfigName("config." + env)
+	if err := v.MergeInConfig(); err != nil {
+		var fileLookupErr viper.ConfigFileNotFoundError
+		if !errors.As(err, &fileLookupErr) {
+			return nil, fmt.Errorf("error loading %s profile: %w", env, err)
+		}
+	}
+
+	// Debug output is on by default outside production; a profile or DEBUG can still set it.
+	v.SetDefault("debug", env != EnvProd)
+	if env == EnvProd {
+		v.SetDefault("log_level", "info")
+	} else {
+		v.SetDefault("log_level", "debug")
+	}
+
+	// A list of structs has no ENV style name; CHANNELS holds it as a JSON array instead.
+	if raw := os.Getenv("CHANNELS"); raw != "" {
+		var channels []map[string]any
+		if err := json.Unmarshal([]byte(raw), &channels); err != nil {
+			return nil, fmt.Errorf("error parsing CHANNELS: %w", err)
+		}
+		v.Set("channels", channels)
+	}
+
 	// Unmarshal configuration into strongly typed struct.
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshalling config: %w", err)
 	}
 
-	// Load sensitive values from environment variables.
+	// Load sensitive values from environment variables, files or Vault.
 	cfg.TelegramAPIToken = v.GetString("telegram_api_token")
 	if cfg.TelegramAPIToken == "" {
 		return nil, ErrMissingEnvironmentVariables
@@ -81,6 +264,45 @@ func Load() (*Config, error) {
 	if cfg.DB.URL == "" {
 		return nil, ErrMissingEnvironmentVariables
 	}
+	cfg.DB.ReplicaURL = v.GetString("database_replica_url")
+
+	adminIDs, err := parseIDs(v.GetString("admin_ids"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ADMIN_IDS: %w", err)
+	}
+	cfg.AdminIDs = adminIDs
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 
 	return &cfg, nil
 }
+
+// normalizeEnv lower-cases an environment name and resolves its aliases.
+func normalizeEnv(env string) string {
+	env = strings.ToLower(strings.TrimSpace(env))
+	if alias, ok := envAliases[env]; ok {
+		return alias
+	}
+	return env
+}
+
+// parseIDs parses a comma-separated list of Telegram IDs.
+func parseIDs(s string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
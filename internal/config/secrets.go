@@ -0,0 +1,150 @@
+package config
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/viper"
+)
+
+// vaultTimeout bounds fetching secrets from Vault at startup.
+const vaultTimeout = 10 * time.Second
+
+// secretEnvs maps the config keys of secrets to their environment variables. Each can also
+// be read from the file named by <VAR>_FILE, e.g. a Docker or Kubernetes secret mount,
+// or from Vault under the variable's name.
+var secretEnvs = map[string]string{
+	"telegram_api_token":   "TELEGRAM_API_TOKEN",
+	"callback_secret":      "CALLBACK_SECRET",
+	"database_url":         "DATABASE_URL",
+	"database_replica_url": "DATABASE_REPLICA_URL",
+	"webhooks.secret":      "WEBHOOKS_SECRET",
+	"redis.password":       "REDIS_PASSWORD",
+	"metrics.pprof_token":  "METRICS_PPROF_TOKEN",
+}
+
+// loadSecrets sets secrets that are not in the environment from <VAR>_FILE files and then,
+// if VAULT_ADDR and VAULT_SECRET_PATH are set, from Vault: the secret at VAULT_SECRET_PATH
+// in the KV engine mounted at VAULT_KV_MOUNT ("secret" by default) of version
+// VAULT_KV_VERSION (2 by default).
+func loadSecrets(v *viper.Viper) error {
+	missing := make(map[string]string) // key -> environment variable
+	for key, env := range secretEnvs {
+		value, ok, err := secretFromFile(env)
+		if err != nil {
+			return err
+		}
+		if ok {
+			v.Set(key, value)
+			continue
+		}
+		if os.Getenv(env) == "" {
+			missing[key] = env
+		}
+	}
+
+	addr, path := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_SECRET_PATH")
+	if addr == "" || path == "" || len(missing) == 0 {
+		return nil
+	}
+
+	token, ok, err := secretFromFile("VAULT_TOKEN")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+
+	kv := vaultKV{
+		Addr:      addr,
+		Token:     token,
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Mount:     cmp.Or(os.Getenv("VAULT_KV_MOUNT"), "secret"),
+		Path:      path,
+		Version:   cmp.Or(os.Getenv("VAULT_KV_VERSION"), "2"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+	defer cancel()
+
+	secrets, err := fetchVaultSecrets(ctx, kv)
+	if err != nil {
+		return fmt.Errorf("error fetching secrets from vault: %w", err)
+	}
+	for key, env := range missing {
+		if value := secrets[env]; value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+// secretFromFile reads the secret from the file named by <env>_FILE, without trailing newlines.
+// Setting both the variable and its file is an error, as it is unclear which one is meant.
+func secretFromFile(env string) (string, bool, error) {
+	path := os.Getenv(env + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+	if os.Getenv(env) != "" {
+		return "", false, fmt.Errorf("both %s and %s_FILE are set", env, env)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading %s_FILE: %w", env, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// vaultKV locates a secret in a Vault KV secrets engine.
+type vaultKV struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Mount     string // mount path of the engine, e.g. "secret"
+	Path      string // path of the secret within the mount, e.g. "husna-bot"
+	Version   string // engine version, "1" or "2"
+}
+
+// fetchVaultSecrets reads the secret with the Vault client and returns its string fields by name.
+func fetchVaultSecrets(ctx context.Context, kv vaultKV) (map[string]string, error) {
+	cfg := api.DefaultConfig()
+	cfg.Address = kv.Addr
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	client.SetToken(kv.Token)
+	if kv.Namespace != "" {
+		client.SetNamespace(kv.Namespace)
+	}
+
+	var secret *api.KVSecret
+	switch kv.Version {
+	case "1":
+		secret, err = client.KVv1(kv.Mount).Get(ctx, kv.Path)
+	case "2":
+		secret, err = client.KVv2(kv.Mount).Get(ctx, kv.Path)
+	default:
+		return nil, fmt.Errorf("unsupported KV version %q, want 1 or 2", kv.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s/%s: %w", kv.Mount, kv.Path, err)
+	}
+
+	secrets := make(map[string]string, len(secret.Data))
+	for name, value := range secret.Data {
+		if s, ok := value.(string); ok {
+			secrets[name] = s
+		}
+	}
+	return secrets, nil
+}
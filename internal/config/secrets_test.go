@@ -0,0 +1,162 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/viper"
+)
+
+// vaultServer serves body with status for path and 404 for any other path.
+// It fails the test if a request comes without the expected token.
+func vaultServer(t *testing.T, path string, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Vault-Token"); got != "test-token" {
+			t.Errorf("X-Vault-Token = %q, want %q", got, "test-token")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchVaultSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		path     string // request path served by the test server
+		status   int
+		body     string
+		want     map[string]string
+		wantErr  bool
+		notFound bool
+	}{
+		{
+			name:    "kv v1",
+			version: "1",
+			path:    "/v1/secret/husna-bot",
+			status:  http.StatusOK,
+			body:    `{"data":{"TELEGRAM_API_TOKEN":"v1-token","DATABASE_URL":"postgres://v1"}}`,
+			want:    map[string]string{"TELEGRAM_API_TOKEN": "v1-token", "DATABASE_URL": "postgres://v1"},
+		},
+		{
+			name:    "kv v2",
+			version: "2",
+			path:    "/v1/secret/data/husna-bot",
+			status:  http.StatusOK,
+			body: `{"data":{"data":{"TELEGRAM_API_TOKEN":"v2-token","REDIS_PASSWORD":"pw"},
+				"metadata":{"created_time":"2026-01-02T03:04:05Z","version":3,"destroyed":false}}}`,
+			want: map[string]string{"TELEGRAM_API_TOKEN": "v2-token", "REDIS_PASSWORD": "pw"},
+		},
+		{
+			name:    "non-string fields are skipped",
+			version: "2",
+			path:    "/v1/secret/data/husna-bot",
+			status:  http.StatusOK,
+			body: `{"data":{"data":{"TELEGRAM_API_TOKEN":"token","RETRIES":3,"NESTED":{"a":"b"}},
+				"metadata":{"version":1}}}`,
+			want: map[string]string{"TELEGRAM_API_TOKEN": "token"},
+		},
+		{
+			name:    "permission denied",
+			version: "2",
+			path:    "/v1/secret/data/husna-bot",
+			status:  http.StatusForbidden,
+			body:    `{"errors":["1 error occurred:\n\t* permission denied\n\n"]}`,
+			wantErr: true,
+		},
+		{
+			name:     "missing secret",
+			version:  "2",
+			path:     "/v1/secret/data/other",
+			status:   http.StatusOK,
+			body:     `{}`,
+			wantErr:  true,
+			notFound: true,
+		},
+		{
+			name:    "unsupported version",
+			version: "3",
+			path:    "/v1/secret/husna-bot",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := vaultServer(t, tt.path, tt.status, tt.body)
+
+			got, err := fetchVaultSecrets(context.Background(), vaultKV{
+				Addr:    srv.URL,
+				Token:   "test-token",
+				Mount:   "secret",
+				Path:    "husna-bot",
+				Version: tt.version,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fetchVaultSecrets() = %v, want an error", got)
+				}
+				if tt.notFound && !errors.Is(err, api.ErrSecretNotFound) {
+					t.Fatalf("fetchVaultSecrets() error = %v, want %v", err, api.ErrSecretNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetchVaultSecrets() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("fetchVaultSecrets() = %v, want %v", got, tt.want)
+			}
+			for name, value := range tt.want {
+				if got[name] != value {
+					t.Errorf("secret %s = %q, want %q", name, got[name], value)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSecretsMissingKey(t *testing.T) {
+	srv := vaultServer(t, "/v1/secret/data/husna-bot", http.StatusOK,
+		`{"data":{"data":{"TELEGRAM_API_TOKEN":"from-vault"},"metadata":{"version":1}}}`)
+
+	for _, env := range secretEnvs {
+		t.Setenv(env, "")
+		t.Setenv(env+"_FILE", "")
+	}
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	t.Setenv("VAULT_TOKEN_FILE", "")
+	t.Setenv("VAULT_SECRET_PATH", "husna-bot")
+	t.Setenv("VAULT_KV_MOUNT", "")
+	t.Setenv("VAULT_KV_VERSION", "")
+	t.Setenv("VAULT_NAMESPACE", "")
+
+	v := viper.New()
+	if err := loadSecrets(v); err != nil {
+		t.Fatalf("loadSecrets() error = %v", err)
+	}
+
+	if got := v.GetString("telegram_api_token"); got != "from-vault" {
+		t.Errorf("telegram_api_token = %q, want %q", got, "from-vault")
+	}
+	// Keys the secret does not have stay unset, so defaults and validation apply.
+	if v.IsSet("database_url") {
+		t.Errorf("database_url is set to %q, want unset", v.GetString("database_url"))
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maskedValue replaces secrets in the effective config.
+const maskedValue = "***"
+
+// Effective returns the settings in use keyed by their config keys, with secrets masked,
+// for logging at startup. Empty secrets are left empty, so a missing one is visible.
+func (c *Config) Effective() map[string]string {
+	settings := map[string]string{
+		"env":                           c.Env,
+		"debug":                         strconv.FormatBool(c.Debug),
+		"log_level":                     c.LogLevel,
+		"telegram_api_token":            mask(c.TelegramAPIToken),
+		"callback_secret":               mask(c.CallbackSecret),
+		"admin_ids":                     joinIDs(c.AdminIDs),
+		"feedback_chat_id":              strconv.FormatInt(c.FeedbackChatID, 10),
+		"names_json_path":               c.NamesJSONPath,
+		"changelog_path":                c.ChangelogPath,
+		"database.url":                  maskDSN(c.DB.URL),
+		"database.replica_url":          maskDSN(c.DB.ReplicaURL),
+		"database.max_connections":      strconv.Itoa(int(c.DB.MaxConnections)),
+		"database.max_conn_lifetime":    c.DB.MaxConnLifetime.String(),
+		"database.auto_migrate":         strconv.FormatBool(c.DB.AutoMigrate),
+		"database.connect_attempts":     strconv.Itoa(c.DB.ConnectAttempts),
+		"database.statement_timeout":    c.DB.StatementTimeout.String(),
+		"database.slow_query_threshold": c.DB.SlowQueryThreshold.String(),
+		"retention.quiz_history_days":   strconv.Itoa(c.Retention.QuizHistoryDays),
+		"retention.quiz_archive_days":   strconv.Itoa(c.Retention.QuizArchiveDays),
+		"retention.inactive_months":     strconv.Itoa(c.Retention.InactiveMonths),
+		"retention.warning_days":        strconv.Itoa(c.Retention.WarningDays),
+		"metrics.addr":                  c.Metrics.Addr,
+		"metrics.pprof":                 strconv.FormatBool(c.Metrics.Pprof),
+		"metrics.pprof_token":           mask(c.Metrics.PprofToken),
+		"api.addr":                      c.API.Addr,
+		"webapp.url":                    c.WebApp.URL,
+		"admin_web.url":                 c.AdminWeb.URL,
+		"webhooks.url":                  c.Webhooks.URL,
+		"webhooks.secret":               mask(c.Webhooks.Secret),
+		"redis.addr":                    c.Redis.Addr,
+		"redis.password":                mask(c.Redis.Password),
+		"redis.db":                      strconv.Itoa(c.Redis.DB),
+
+		"rate_limits.user_updates_per_minute": strconv.Itoa(c.RateLimits.UserUpdatesPerMinute),
+		"rate_limits.user_burst":              strconv.Itoa(c.RateLimits.UserBurst),
+		"rate_limits.user_refill":             c.RateLimits.UserRefill.String(),
+		"updates.max_backlog":                 strconv.Itoa(c.Updates.MaxBacklog),
+		"updates.handler_timeout":             c.Updates.HandlerTimeout.String(),
+		"messages.cleanup_after":              c.Messages.CleanupAfter.String(),
+		"messages.reminder_ttl":               c.Messages.ReminderTTL.String(),
+		"messages.reminder_expiry":            c.Messages.ReminderExpiry,
+	}
+
+	for name, on := range c.Features {
+		settings["features."+name] = strconv.FormatBool(on)
+	}
+
+	for i, ch := range c.Channels {
+		settings[fmt.Sprintf("channels[%d]", i)] = fmt.Sprintf("chat_id=%d time=%s timezone=%s language=%s",
+			ch.ChatID, ch.Time, ch.Timezone, ch.Language)
+	}
+
+	return settings
+}
+
+// mask hides a non-empty secret.
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return maskedValue
+}
+
+// maskDSN hides the password of a postgres:// URL and the whole of any other connection string.
+func maskDSN(dsn string) string {
+	if dsn == "" {
+		return ""
+	}
+	u, err := url.Parse(dsn)
+	if err != nil || u.Host == "" {
+		return maskedValue
+	}
+	// Query parameters such as sslpassword may hold secrets too.
+	u.RawQuery = ""
+	return u.Redacted()
+}
+
+// joinIDs formats Telegram IDs as a comma-separated list.
+func joinIDs(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Accepted ranges of numeric settings.
+const (
+	maxConnectionsLimit  = 1000
+	maxConnectAttempts   = 100
+	maxRetentionDays     = 10 * 365
+	maxRetentionMonths   = 10 * 12
+	maxRedisDB           = 15
+	maxUpdatesBacklog    = 100000
+	minConnLifetimeLimit = time.Second
+	maxMessageCleanup    = 48 * time.Hour // Telegram lets bots delete messages only this long
+)
+
+// logLevels are the accepted values of log_level.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// reminderExpiries are the accepted values of messages.reminder_expiry.
+var reminderExpiries = []string{"delete", "edit"}
+
+// Validate checks that settings are within their ranges. It reports every invalid key at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: "+format, append([]any{key}, args...)...))
+		}
+	}
+
+	check(c.Env == EnvLocal || c.Env == EnvDev || c.Env == EnvProd,
+		"env", "must be %s, %s or %s, got %q", EnvLocal, EnvDev, EnvProd, c.Env)
+
+	check(slices.Contains(logLevels, c.LogLevel),
+		"log_level", "must be one of %s, got %q", strings.Join(logLevels, ", "), c.LogLevel)
+	check(c.RateLimits.UserUpdatesPerMinute >= 0,
+		"rate_limits.user_updates_per_minute", "must not be negative, got %d", c.RateLimits.UserUpdatesPerMinute)
+	check(c.RateLimits.UserBurst >= 0, "rate_limits.user_burst", "must not be negative, got %d", c.RateLimits.UserBurst)
+	check(c.RateLimits.UserBurst == 0 || c.RateLimits.UserRefill > 0,
+		"rate_limits.user_refill", "must be positive when rate_limits.user_burst is set, got %s", c.RateLimits.UserRefill)
+	check(c.Updates.MaxBacklog >= 0 && c.Updates.MaxBacklog <= maxUpdatesBacklog,
+		"updates.max_backlog", "must be between 0 and %d, got %d", maxUpdatesBacklog, c.Updates.MaxBacklog)
+	check(c.Updates.HandlerTimeout >= 0, "updates.handler_timeout", "must not be negative, got %s", c.Updates.HandlerTimeout)
+	check(c.Messages.CleanupAfter >= 0 && c.Messages.CleanupAfter < maxMessageCleanup,
+		"messages.cleanup_after", "must not be negative and must be under %s, got %s", maxMessageCleanup, c.Messages.CleanupAfter)
+	check(slices.Contains(reminderExpiries, c.Messages.ReminderExpiry),
+		"messages.reminder_expiry", "must be one of %s, got %q", strings.Join(reminderExpiries, ", "), c.Messages.ReminderExpiry)
+	// Edited reminders may expire at any age; deleted ones only within Telegram's window.
+	check(c.Messages.ReminderTTL >= 0 && (c.Messages.ReminderExpiry == "edit" || c.Messages.ReminderTTL < maxMessageCleanup),
+		"messages.reminder_ttl", "must not be negative and, unless reminders are edited, must be under %s, got %s", maxMessageCleanup, c.Messages.ReminderTTL)
+
+	check(c.DB.MaxConnections >= 1 && c.DB.MaxConnections <= maxConnectionsLimit,
+		"database.max_connections", "must be between 1 and %d, got %d", maxConnectionsLimit, c.DB.MaxConnections)
+	check(c.DB.MaxConnLifetime >= minConnLifetimeLimit,
+		"database.max_conn_lifetime", "must be at least %s, got %s", minConnLifetimeLimit, c.DB.MaxConnLifetime)
+	check(c.DB.ConnectAttempts >= 1 && c.DB.ConnectAttempts <= maxConnectAttempts,
+		"database.connect_attempts", "must be between 1 and %d, got %d", maxConnectAttempts, c.DB.ConnectAttempts)
+	check(c.DB.StatementTimeout >= 0, "database.statement_timeout", "must not be negative, got %s", c.DB.StatementTimeout)
+	check(c.DB.SlowQueryThreshold >= 0, "database.slow_query_threshold", "must not be negative, got %s", c.DB.SlowQueryThreshold)
+
+	check(c.Retention.QuizHistoryDays >= 0 && c.Retention.QuizHistoryDays <= maxRetentionDays,
+		"retention.quiz_history_days", "must be between 0 and %d, got %d", maxRetentionDays, c.Retention.QuizHistoryDays)
+	check(c.Retention.QuizArchiveDays >= 0 && c.Retention.QuizArchiveDays <= maxRetentionDays,
+		"retention.quiz_archive_days", "must be between 0 and %d, got %d", maxRetentionDays, c.Retention.QuizArchiveDays)
+	check(c.Retention.InactiveMonths >= 0 && c.Retention.InactiveMonths <= maxRetentionMonths,
+		"retention.inactive_months", "must be between 0 and %d, got %d", maxRetentionMonths, c.Retention.InactiveMonths)
+	check(c.Retention.WarningDays >= 0 && c.Retention.WarningDays <= maxRetentionDays,
+		"retention.warning_days", "must be between 0 and %d, got %d", maxRetentionDays, c.Retention.WarningDays)
+
+	check(validAddr(c.Metrics.Addr), "metrics.addr", "must be host:port or empty, got %q", c.Metrics.Addr)
+	check(!c.Metrics.Pprof || c.Metrics.Addr != "", "metrics.pprof", "needs metrics.addr")
+	// Profiles expose memory contents and can be used to load the process.
+	check(!c.Metrics.Pprof || c.Metrics.PprofToken != "", "metrics.pprof_token", "is required when metrics.pprof is on")
+	check(validAddr(c.API.Addr), "api.addr", "must be host:port or empty, got %q", c.API.Addr)
+	check(validAddr(c.Redis.Addr), "redis.addr", "must be host:port or empty, got %q", c.Redis.Addr)
+	check(c.Redis.DB >= 0 && c.Redis.DB <= maxRedisDB, "redis.db", "must be between 0 and %d, got %d", maxRedisDB, c.Redis.DB)
+
+	check(validURL(c.WebApp.URL), "webapp.url", "must be an absolute http(s) URL or empty, got %q", c.WebApp.URL)
+	check(validURL(c.AdminWeb.URL), "admin_web.url", "must be an absolute http(s) URL or empty, got %q", c.AdminWeb.URL)
+	check(validURL(c.Webhooks.URL), "webhooks.url", "must be an absolute http(s) URL or empty, got %q", c.Webhooks.URL)
+	// Unsigned events could be forged by anyone who knows the endpoint.
+	check(c.Webhooks.URL == "" || c.Webhooks.Secret != "", "webhooks.secret", "is required when webhooks.url is set")
+
+	for i, ch := range c.Channels {
+		check(ch.ChatID != 0, fmt.Sprintf("channels[%d].chat_id", i), "is required")
+	}
+
+	return errors.Join(errs...)
+}
+
+// validAddr reports whether addr is empty or a host:port listen or dial address.
+func validAddr(addr string) bool {
+	if addr == "" {
+		return true
+	}
+	_, _, err := net.SplitHostPort(addr)
+	return err == nil
+}
+
+// validURL reports whether s is empty or an absolute http or https URL.
+func validURL(s string) bool {
+	if s == "" {
+		return true
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,586 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// Web admin tuning.
+const (
+	adminSessionCookie   = "admin_session"
+	adminUserSearchLimit = 20
+	adminBroadcastsLimit = 10
+	adminCohortsLimit    = 12
+	adminActivityDays    = 30
+	adminStatsPeriod     = 7 * 24 * time.Hour
+	adminFunnelPeriod    = 30 * 24 * time.Hour
+)
+
+//go:embed admin
+var adminTemplateFiles embed.FS
+
+// adminTemplates are the web admin pages; each is parsed together with the layout.
+var adminTemplates = func() map[string]*template.Template {
+	funcs := template.FuncMap{
+		"percent": percent,
+		"date":    func(t time.Time) string { return t.UTC().Format(time.DateOnly) },
+		"datetime": func(t time.Time) string {
+			return t.UTC().Format("2006-01-02 15:04")
+		},
+	}
+
+	pages := []string{"message", "dashboard", "users", "names", "name", "broadcast"}
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		templates[page] = template.Must(template.New("layout.html").Funcs(funcs).
+			ParseFS(adminTemplateFiles, "admin/layout.html", "admin/"+page+".html"))
+	}
+	return templates
+}()
+
+// AdminWeb serves the web admin: a dashboard with key charts, user search,
+// name card editing and a broadcast composer. Admins sign in with a link from
+// /admin_web, and every page checks the admin permission it needs.
+type AdminWeb struct {
+	sessions   AdminSessionService
+	admins     AdminService
+	users      UserSearchService
+	progress   ProgressService
+	names      NameService
+	content    NameContentService
+	broadcasts BroadcastService
+	stats      DeliveryStatsService
+	analytics  AnalyticsService
+	retention  RetentionService
+	logger     *zap.Logger
+}
+
+// NewAdminWeb creates a new web admin server.
+func NewAdminWeb(
+	sessions AdminSessionService,
+	admins AdminService,
+	users UserSearchService,
+	progress ProgressService,
+	names NameService,
+	content NameContentService,
+	broadcasts BroadcastService,
+	stats DeliveryStatsService,
+	analytics AnalyticsService,
+	retention RetentionService,
+	logger *zap.Logger,
+) *AdminWeb {
+	return &AdminWeb{
+		sessions:   sessions,
+		admins:     admins,
+		users:      users,
+		progress:   progress,
+		names:      names,
+		content:    content,
+		broadcasts: broadcasts,
+		stats:      stats,
+		analytics:  analytics,
+		retention:  retention,
+		logger:     logger,
+	}
+}
+
+// Handler returns the web admin routes.
+func (a *AdminWeb) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/login", a.handleLogin)
+	mux.HandleFunc("POST /admin/logout", a.handleLogout)
+	mux.Handle("GET /admin/{$}", a.authorized(entities.AdminPermSupport, a.handleDashboard))
+	mux.Handle("GET /admin/users", a.authorized(entities.AdminPermSupport, a.handleUsers))
+	mux.Handle("GET /admin/names", a.authorized(entities.AdminPermContent, a.handleNames))
+	mux.Handle("GET /admin/names/{number}", a.authorized(entities.AdminPermContent, a.handleName))
+	mux.Handle("POST /admin/names/{number}", a.authorized(entities.AdminPermContent, a.handleNameSave))
+	mux.Handle("GET /admin/broadcast", a.authorized(entities.AdminPermBroadcast, a.handleBroadcasts))
+	mux.Handle("POST /admin/broadcast", a.authorized(entities.AdminPermBroadcast, a.handleBroadcastSend))
+	return mux
+}
+
+// adminPage is the data every page template gets.
+type adminPage struct {
+	Title   string
+	AdminID int64
+	Notice  string
+	Error   string
+}
+
+// messagePage is a page with a single message.
+type messagePage struct {
+	adminPage
+	Text string
+}
+
+// handleLogin exchanges a login link token for a session cookie: GET /admin/login?token=...
+func (a *AdminWeb) handleLogin(w http.ResponseWriter, r *http.Request) {
+	session, expires, err := a.sessions.Login(r.URL.Query().Get("token"))
+	if err != nil {
+		a.renderMessage(w, r, http.StatusUnauthorized, 0, "Ссылка для входа недействительна или устарела. Получите новую командой /admin_web в боте.")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     adminSessionCookie,
+		Value:    session,
+		Path:     "/admin/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/admin/", http.StatusSeeOther)
+}
+
+// handleLogout drops the session cookie: POST /admin/logout.
+func (a *AdminWeb) handleLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     adminSessionCookie,
+		Value:    "",
+		Path:     "/admin/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	a.renderMessage(w, r, http.StatusOK, 0, "Вы вышли. Чтобы войти снова, отправьте /admin_web в боте.")
+}
+
+// authorized resolves the session cookie to an admin with the permission and rejects other requests.
+// Mutating routes are POST-only and the cookie is SameSite=Lax, so cross-site forms cannot use it.
+func (a *AdminWeb) authorized(perm entities.AdminPermission, next authenticatedFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		cookie, err := r.Cookie(adminSessionCookie)
+		if err != nil {
+			a.renderMessage(w, r, http.StatusUnauthorized, 0, "Чтобы войти, отправьте /admin_web в боте и откройте ссылку.")
+			return
+		}
+
+		adminID, err := a.sessions.Authenticate(cookie.Value)
+		if err != nil {
+			a.renderMessage(w, r, http.StatusUnauthorized, 0, "Сессия истекла. Отправьте /admin_web в боте, чтобы войти снова.")
+			return
+		}
+
+		allowed, err := a.admins.Can(ctx, adminID, perm)
+		if err != nil {
+			a.internalError(w, r, adminID, err)
+			return
+		}
+		if !allowed {
+			a.renderMessage(w, r, http.StatusForbidden, adminID, "Недостаточно прав для этого раздела.")
+			return
+		}
+
+		next(w, r, adminID)
+	})
+}
+
+// chartBar is a bar of a chart; Height is relative to the highest bar, in percent.
+type chartBar struct {
+	Label  string
+	Value  int
+	Height int
+}
+
+// funnelRow is a step of the onboarding funnel chart.
+type funnelRow struct {
+	Step      int
+	Shown     int
+	Completed int
+	Rate      int // completed of shown, percent
+	Width     int // shown relative to the first step, percent
+}
+
+// deliveryRow is the delivery attempts of one notification kind.
+type deliveryRow struct {
+	Kind      string
+	Delivered int
+	Retry     int
+	Failed    int
+	Rate      int // delivered of all attempts, percent
+}
+
+// dashboardPage is the data of the dashboard.
+type dashboardPage struct {
+	adminPage
+	Overview *entities.BotOverview
+	Activity []chartBar
+	Delivery []deliveryRow // by kind, then the total
+	Pending  int
+	Failed   int
+	Funnel   []funnelRow
+	Finished int
+	Cohorts  []entities.RetentionCohort
+}
+
+// handleDashboard renders the key numbers and charts: GET /admin/.
+func (a *AdminWeb) handleDashboard(w http.ResponseWriter, r *http.Request, adminID int64) {
+	ctx := r.Context()
+
+	overview, err := a.stats.GetOverview(ctx)
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+	activity, err := a.analytics.DailyActiveUsers(ctx, adminActivityDays)
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+	delivery, err := a.stats.GetDeliveryStats(ctx, adminStatsPeriod)
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+	funnel, err := a.analytics.OnboardingFunnel(ctx, adminFunnelPeriod)
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+	cohorts, err := a.retention.List(ctx, adminCohortsLimit)
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+
+	a.render(w, r, http.StatusOK, "dashboard", dashboardPage{
+		adminPage: adminPage{Title: "Обзор", AdminID: adminID},
+		Overview:  overview,
+		Activity:  activityChart(activity),
+		Delivery:  deliveryTable(delivery),
+		Pending:   delivery.QueuePending,
+		Failed:    delivery.QueueFailed,
+		Funnel:    funnelChart(funnel),
+		Finished:  funnel.Finished,
+		Cohorts:   cohorts,
+	})
+}
+
+// usersPage is the data of the user search page.
+type usersPage struct {
+	adminPage
+	Query    string
+	Users    []entities.UserInfo
+	Progress *service.ProgressSummary // set when a single user is found
+}
+
+// handleUsers finds users by Telegram ID: GET /admin/users?q=...
+func (a *AdminWeb) handleUsers(w http.ResponseWriter, r *http.Request, adminID int64) {
+	page := usersPage{
+		adminPage: adminPage{Title: "Пользователи", AdminID: adminID},
+		Query:     strings.TrimSpace(r.URL.Query().Get("q")),
+	}
+
+	if page.Query != "" {
+		users, err := a.users.Search(r.Context(), page.Query, adminUserSearchLimit)
+		if err != nil {
+			a.internalError(w, r, adminID, err)
+			return
+		}
+		page.Users = users
+
+		if len(users) == 1 {
+			page.Progress, err = a.progress.GetProgressSummary(r.Context(), users[0].ID)
+			if err != nil {
+				a.internalError(w, r, adminID, err)
+				return
+			}
+		}
+		if len(users) == 0 {
+			page.Notice = "Никого не нашли. Ищите по Telegram ID или его началу."
+		}
+	}
+
+	a.render(w, r, http.StatusOK, "users", page)
+}
+
+// namesPage is the data of the name list.
+type namesPage struct {
+	adminPage
+	Names []*entities.Name
+}
+
+// handleNames lists the name cards: GET /admin/names.
+func (a *AdminWeb) handleNames(w http.ResponseWriter, r *http.Request, adminID int64) {
+	names, err := a.names.GetAll(r.Context())
+	if err != nil {
+		a.internalError(w, r, adminID, err)
+		return
+	}
+
+	a.render(w, r, http.StatusOK, "names", namesPage{
+		adminPage: adminPage{Title: "Карточки имён", AdminID: adminID},
+		Names:     names,
+	})
+}
+
+// namePage is the data of the name card editor.
+type namePage struct {
+	adminPage
+	Name *entities.Name
+}
+
+// handleName renders the editor of a name card: GET /admin/names/{number}.
+func (a *AdminWeb) handleName(w http.ResponseWriter, r *http.Request, adminID int64) {
+	name, ok := a.pathName(w, r, adminID)
+	if !ok {
+		return
+	}
+
+	page := namePage{adminPage: adminPage{Title: fmt.Sprintf("Имя %d", name.Number), AdminID: adminID}, Name: name}
+	if r.URL.Query().Has("saved") {
+		page.Notice = "Сохранено. Бот уже показывает новый текст."
+	}
+	a.render(w, r, http.StatusOK, "name", page)
+}
+
+// handleNameSave stores the edited texts of a name card: POST /admin/names/{number}.
+func (a *AdminWeb) handleNameSave(w http.ResponseWriter, r *http.Request, adminID int64) {
+	name, ok := a.pathName(w, r, adminID)
+	if !ok {
+		return
+	}
+
+	edit := entities.Name{
+		Number:          name.Number,
+		ArabicName:      r.PostFormValue("arabic"),
+		Transliteration: r.PostFormValue("transliteration"),
+		Translation:     r.PostFormValue("translation"),
+		Meaning:         r.PostFormValue("meaning"),
+	}
+
+	if _, err := a.content.Update(r.Context(), adminID, edit); err != nil {
+		if errors.Is(err, service.ErrNameContentEmpty) {
+			a.render(w, r, http.StatusBadRequest, "name", namePage{
+				adminPage: adminPage{Title: fmt.Sprintf("Имя %d", name.Number), AdminID: adminID, Error: "Заполните все поля."},
+				Name:      &edit,
+			})
+			return
+		}
+		a.internalError(w, r, adminID, err)
+		return
+	}
+
+	a.admins.LogAction(r.Context(), &entities.AdminAction{
+		AdminID: adminID,
+		Action:  "name_edit",
+		Details: fmt.Sprintf("name %d", name.Number),
+	})
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/names/%d?saved", name.Number), http.StatusSeeOther)
+}
+
+// broadcastPage is the data of the broadcast composer.
+type broadcastPage struct {
+	adminPage
+	Segments   []entities.SurveySegment
+	Segment    string
+	Text       string
+	MaxLength  int
+	Broadcasts []entities.Broadcast
+}
+
+// handleBroadcasts renders the composer and the latest broadcasts: GET /admin/broadcast.
+func (a *AdminWeb) handleBroadcasts(w http.ResponseWriter, r *http.Request, adminID int64) {
+	page := broadcastPage{adminPage: adminPage{Title: "Рассылка", AdminID: adminID}}
+	if sent := r.URL.Query().Get("sent"); sent != "" {
+		page.Notice = fmt.Sprintf("Рассылка #%s поставлена в очередь.", sent)
+	}
+	a.renderBroadcasts(w, r, http.StatusOK, page)
+}
+
+// handleBroadcastSend queues a broadcast to a segment: POST /admin/broadcast.
+func (a *AdminWeb) handleBroadcastSend(w http.ResponseWriter, r *http.Request, adminID int64) {
+	segment, text := r.PostFormValue("segment"), r.PostFormValue("text")
+
+	broadcast, err := a.broadcasts.Send(r.Context(), adminID, segment, text)
+	if err != nil {
+		page := broadcastPage{
+			adminPage: adminPage{Title: "Рассылка", AdminID: adminID},
+			Segment:   segment,
+			Text:      text,
+		}
+		switch {
+		case errors.Is(err, service.ErrBroadcastEmpty):
+			page.Error = "Введите текст сообщения."
+		case errors.Is(err, service.ErrBroadcastTooLong):
+			page.Error = fmt.Sprintf("Текст слишком длинный. Максимум — %d символов.", entities.MaxBroadcastLength)
+		case errors.Is(err, service.ErrBroadcastSegment):
+			page.Error = "Выберите сегмент."
+		default:
+			a.internalError(w, r, adminID, err)
+			return
+		}
+		a.renderBroadcasts(w, r, http.StatusBadRequest, page)
+		return
+	}
+
+	a.admins.LogAction(r.Context(), &entities.AdminAction{
+		AdminID: adminID,
+		Action:  "broadcast",
+		Details: fmt.Sprintf("broadcast #%d, %s, %d users", broadcast.ID, broadcast.Segment, broadcast.Queued),
+	})
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/broadcast?sent=%d", broadcast.ID), http.StatusSeeOther)
+}
+
+// renderBroadcasts renders the composer page with the latest broadcasts.
+func (a *AdminWeb) renderBroadcasts(w http.ResponseWriter, r *http.Request, status int, page broadcastPage) {
+	broadcasts, err := a.broadcasts.List(r.Context(), adminBroadcastsLimit)
+	if err != nil {
+		a.internalError(w, r, page.AdminID, err)
+		return
+	}
+
+	page.Segments = entities.SurveySegments
+	page.MaxLength = entities.MaxBroadcastLength
+	page.Broadcasts = broadcasts
+	a.render(w, r, status, "broadcast", page)
+}
+
+// pathName returns the name from the {number} path value, rendering an error page if there is none.
+func (a *AdminWeb) pathName(w http.ResponseWriter, r *http.Request, adminID int64) (*entities.Name, bool) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 || number > 99 {
+		a.renderMessage(w, r, http.StatusNotFound, adminID, "Номер имени должен быть от 1 до 99.")
+		return nil, false
+	}
+
+	name, err := a.names.GetByNumber(r.Context(), number)
+	switch {
+	case errors.Is(err, repository.ErrNameNotFound):
+		a.renderMessage(w, r, http.StatusNotFound, adminID, "Имя не найдено.")
+		return nil, false
+	case err != nil:
+		a.internalError(w, r, adminID, err)
+		return nil, false
+	}
+
+	return name, true
+}
+
+// render executes a page template.
+func (a *AdminWeb) render(w http.ResponseWriter, r *http.Request, status int, page string, data any) {
+	var buf bytes.Buffer
+	if err := adminTemplates[page].Execute(&buf, data); err != nil {
+		a.logger.Error("failed to render admin page",
+			zap.String("path", r.URL.Path),
+			zap.String("page", page),
+			zap.Error(err),
+		)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
+}
+
+// renderMessage renders a page with a single message.
+func (a *AdminWeb) renderMessage(w http.ResponseWriter, r *http.Request, status int, adminID int64, text string) {
+	a.render(w, r, status, "message", messagePage{adminPage: adminPage{Title: "Админка", AdminID: adminID}, Text: text})
+}
+
+// internalError logs err and renders a generic error page.
+func (a *AdminWeb) internalError(w http.ResponseWriter, r *http.Request, adminID int64, err error) {
+	a.logger.Error("admin request failed",
+		zap.String("path", r.URL.Path),
+		zap.Int64("admin_id", adminID),
+		zap.Error(err),
+	)
+	a.renderMessage(w, r, http.StatusInternalServerError, adminID, "Внутренняя ошибка. Попробуйте позже.")
+}
+
+// activityChart converts daily active users to chart bars.
+func activityChart(days []entities.DailyCount) []chartBar {
+	highest := 0
+	for _, d := range days {
+		highest = max(highest, d.Count)
+	}
+
+	bars := make([]chartBar, 0, len(days))
+	for _, d := range days {
+		bars = append(bars, chartBar{
+			Label:  d.Day.Format("02.01"),
+			Value:  d.Count,
+			Height: percent(d.Count, highest),
+		})
+	}
+	return bars
+}
+
+// deliveryTable converts delivery stats to rows by kind followed by the total row.
+func deliveryTable(stats *entities.DeliveryStats) []deliveryRow {
+	kinds := make([]entities.NotificationKind, 0, len(stats.ByKind))
+	for kind := range stats.ByKind {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	rows := make([]deliveryRow, 0, len(kinds)+1)
+	for _, kind := range kinds {
+		rows = append(rows, newDeliveryRow(string(kind), stats.ByKind[kind]))
+	}
+	return append(rows, newDeliveryRow("всего", stats.Totals()))
+}
+
+// newDeliveryRow converts the counts of one row.
+func newDeliveryRow(kind string, c entities.DeliveryCounts) deliveryRow {
+	return deliveryRow{
+		Kind:      kind,
+		Delivered: c[entities.DeliveryDelivered],
+		Retry:     c[entities.DeliveryRetry],
+		Failed:    c[entities.DeliveryFailed],
+		Rate:      percent(c[entities.DeliveryDelivered], c.Total()),
+	}
+}
+
+// funnelChart converts the onboarding funnel to chart rows.
+func funnelChart(f *entities.OnboardingFunnel) []funnelRow {
+	first := 0
+	if len(f.Steps) > 0 {
+		first = f.Steps[0].Shown
+	}
+
+	rows := make([]funnelRow, 0, len(f.Steps))
+	for _, s := range f.Steps {
+		rows = append(rows, funnelRow{
+			Step:      s.Step,
+			Shown:     s.Shown,
+			Completed: s.Completed,
+			Rate:      percent(s.Completed, s.Shown),
+			Width:     percent(s.Shown, first),
+		})
+	}
+	return rows
+}
+
+// percent returns part of total in whole percent, 0 if total is zero.
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
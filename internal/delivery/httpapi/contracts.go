@@ -0,0 +1,84 @@
+package httpapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// NameService interface for reading the names dataset.
+type NameService interface {
+	GetByNumber(ctx context.Context, number int) (*entities.Name, error)
+	GetAll(ctx context.Context) ([]*entities.Name, error)
+}
+
+// ProgressService interface for reading a user's progress.
+type ProgressService interface {
+	GetProgressSummary(ctx context.Context, userID int64) (*service.ProgressSummary, error)
+}
+
+// TokenService interface for authenticating API requests.
+type TokenService interface {
+	Authenticate(ctx context.Context, token string) (int64, error)
+}
+
+// ReviewService interface for browsing and reviewing names in the Mini App.
+type ReviewService interface {
+	GetByNumbers(ctx context.Context, userID int64, nums []int) (map[int]*entities.UserProgress, error)
+	GetNameStats(ctx context.Context, userID int64, nameNumber int) (*service.NameStats, error)
+	GetDueNames(ctx context.Context, userID int64, limit int) ([]int, error)
+	GetLearningNames(ctx context.Context, userID int64, limit int) ([]int, error)
+	RecordSelfReview(ctx context.Context, userID int64, nameNumber int, remembered bool) (*entities.UserProgress, error)
+}
+
+// UserService interface for checking that a Mini App user has started the bot.
+type UserService interface {
+	Exists(ctx context.Context, userID int64) (bool, error)
+}
+
+// AdminSessionService interface for web admin sign-in.
+type AdminSessionService interface {
+	Login(token string) (string, time.Time, error)
+	Authenticate(session string) (int64, error)
+}
+
+// AdminService interface for admin permissions and the audit log.
+type AdminService interface {
+	Can(ctx context.Context, userID int64, perm entities.AdminPermission) (bool, error)
+	LogAction(ctx context.Context, action *entities.AdminAction)
+}
+
+// UserSearchService interface for finding users in the web admin.
+type UserSearchService interface {
+	Search(ctx context.Context, query string, limit int) ([]entities.UserInfo, error)
+}
+
+// NameContentService interface for editing name cards.
+type NameContentService interface {
+	Update(ctx context.Context, adminID int64, edit entities.Name) (*entities.Name, error)
+}
+
+// BroadcastService interface for messages to user segments.
+type BroadcastService interface {
+	Send(ctx context.Context, adminID int64, segment, text string) (*entities.Broadcast, error)
+	List(ctx context.Context, limit int) ([]entities.Broadcast, error)
+}
+
+// DeliveryStatsService interface for usage and delivery statistics.
+type DeliveryStatsService interface {
+	GetOverview(ctx context.Context) (*entities.BotOverview, error)
+	GetDeliveryStats(ctx context.Context, period time.Duration) (*entities.DeliveryStats, error)
+}
+
+// AnalyticsService interface for charts built from analytics events.
+type AnalyticsService interface {
+	OnboardingFunnel(ctx context.Context, period time.Duration) (*entities.OnboardingFunnel, error)
+	DailyActiveUsers(ctx context.Context, days int) ([]entities.DailyCount, error)
+}
+
+// RetentionService interface for weekly retention cohorts.
+type RetentionService interface {
+	List(ctx context.Context, limit int) ([]entities.RetentionCohort, error)
+}
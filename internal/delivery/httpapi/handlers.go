@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// nameResponse is a name of the dataset.
+type nameResponse struct {
+	Number          int    `json:"number"`
+	Arabic          string `json:"arabic"`
+	Transliteration string `json:"transliteration"`
+	Translation     string `json:"translation"`
+	Meaning         string `json:"meaning"`
+}
+
+// progressResponse is the caller's progress summary, the same numbers /progress shows.
+type progressResponse struct {
+	Learned        int            `json:"learned"`
+	InProgress     int            `json:"in_progress"`
+	NotStarted     int            `json:"not_started"`
+	Percentage     float64        `json:"percentage"`
+	Accuracy       float64        `json:"accuracy"`
+	DueToday       int            `json:"due_today"`
+	DueTomorrow    int            `json:"due_tomorrow"`
+	DueThisWeek    int            `json:"due_this_week"`
+	CurrentStreak  int            `json:"current_streak"`
+	BestStreak     int            `json:"best_streak"`
+	ActiveToday    bool           `json:"active_today"`
+	TotalXP        int            `json:"total_xp"`
+	Level          int            `json:"level"`
+	DaysToComplete int            `json:"days_to_complete"`
+	ByPhase        map[string]int `json:"by_phase"`
+}
+
+// handleNames returns all 99 names: GET /api/v1/names.
+func (s *Server) handleNames(w http.ResponseWriter, r *http.Request, _ int64) {
+	names, err := s.names.GetAll(r.Context())
+	if err != nil {
+		internalError(s.logger, w, r, err)
+		return
+	}
+
+	resp := make([]nameResponse, 0, len(names))
+	for _, n := range names {
+		resp = append(resp, newNameResponse(n))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleName returns one name: GET /api/v1/names/{number}.
+func (s *Server) handleName(w http.ResponseWriter, r *http.Request, _ int64) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 || number > 99 {
+		writeError(w, http.StatusBadRequest, "name number must be between 1 and 99")
+		return
+	}
+
+	name, err := s.names.GetByNumber(r.Context(), number)
+	switch {
+	case errors.Is(err, repository.ErrNameNotFound):
+		writeError(w, http.StatusNotFound, "name not found")
+		return
+	case err != nil:
+		internalError(s.logger, w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newNameResponse(name))
+}
+
+// handleProgress returns the token owner's progress summary: GET /api/v1/me/progress.
+func (s *Server) handleProgress(w http.ResponseWriter, r *http.Request, userID int64) {
+	summary, err := s.progress.GetProgressSummary(r.Context(), userID)
+	if err != nil {
+		internalError(s.logger, w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newProgressResponse(summary))
+}
+
+// newNameResponse converts a name to its API form.
+func newNameResponse(n *entities.Name) nameResponse {
+	return nameResponse{
+		Number:          n.Number,
+		Arabic:          n.ArabicName,
+		Transliteration: n.Transliteration,
+		Translation:     n.Translation,
+		Meaning:         n.Meaning,
+	}
+}
+
+// newProgressResponse converts a progress summary to its API form.
+func newProgressResponse(p *service.ProgressSummary) progressResponse {
+	return progressResponse{
+		Learned:        p.Learned,
+		InProgress:     p.InProgress,
+		NotStarted:     p.NotStarted,
+		Percentage:     p.Percentage,
+		Accuracy:       p.Accuracy,
+		DueToday:       p.DueToday,
+		DueTomorrow:    p.DueTomorrow,
+		DueThisWeek:    p.DueThisWeek,
+		CurrentStreak:  p.CurrentStreak,
+		BestStreak:     p.BestStreak,
+		ActiveToday:    p.ActiveToday,
+		TotalXP:        p.TotalXP,
+		Level:          p.Level.Level,
+		DaysToComplete: p.DaysToComplete,
+		ByPhase: map[string]int{
+			string(entities.PhaseNew):      p.NewCount,
+			string(entities.PhaseLearning): p.LearningCount,
+			string(entities.PhaseMastered): p.MasteredCount,
+		},
+	}
+}
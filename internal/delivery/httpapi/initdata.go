@@ -0,0 +1,80 @@
+package httpapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// initDataMaxAge is how long a Mini App launch stays valid.
+const initDataMaxAge = 24 * time.Hour
+
+var (
+	errInitDataInvalid = errors.New("invalid init data")
+	errInitDataExpired = errors.New("init data expired")
+)
+
+// validateInitData checks the signature of Telegram WebApp initData and returns the ID of the user who opened the app.
+// See https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app.
+func validateInitData(initData, botToken string, now time.Time) (int64, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return 0, errInitDataInvalid
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return 0, errInitDataInvalid
+	}
+	values.Del("hash")
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+"="+values.Get(k))
+	}
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(botToken))
+	expected := hmacSHA256(secret, []byte(strings.Join(lines, "\n")))
+
+	got, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(got, expected) {
+		return 0, errInitDataInvalid
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return 0, errInitDataInvalid
+	}
+	if now.Sub(time.Unix(authDate, 0)) > initDataMaxAge {
+		return 0, errInitDataExpired
+	}
+
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return 0, errInitDataInvalid
+	}
+
+	return user.ID, nil
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of msg with key.
+func hmacSHA256(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
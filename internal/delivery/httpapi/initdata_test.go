@@ -0,0 +1,122 @@
+package httpapi
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBotToken = "123456:test-bot-token"
+
+// signInitData builds initData from fields signed with botToken the way Telegram does.
+func signInitData(fields map[string]string, botToken string) url.Values {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	values := url.Values{}
+	for _, k := range keys {
+		lines = append(lines, k+"="+fields[k])
+		values.Set(k, fields[k])
+	}
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(botToken))
+	values.Set("hash", hex.EncodeToString(hmacSHA256(secret, []byte(strings.Join(lines, "\n")))))
+	return values
+}
+
+func TestValidateInitData(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	fields := func(authDate time.Time) map[string]string {
+		return map[string]string{
+			"auth_date": strconv.FormatInt(authDate.Unix(), 10),
+			"query_id":  "AAHdF6IQAAAAAN0XohDhrOrc",
+			"user":      `{"id":42,"first_name":"Amina","language_code":"ru"}`,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		initData func() string
+		wantID   int64
+		wantErr  error
+	}{
+		{
+			name: "valid hash",
+			initData: func() string {
+				return signInitData(fields(now.Add(-time.Hour)), testBotToken).Encode()
+			},
+			wantID: 42,
+		},
+		{
+			name: "tampered field",
+			initData: func() string {
+				values := signInitData(fields(now.Add(-time.Hour)), testBotToken)
+				values.Set("user", `{"id":43,"first_name":"Amina","language_code":"ru"}`)
+				return values.Encode()
+			},
+			wantErr: errInitDataInvalid,
+		},
+		{
+			name: "wrong bot token",
+			initData: func() string {
+				return signInitData(fields(now.Add(-time.Hour)), "654321:other-bot-token").Encode()
+			},
+			wantErr: errInitDataInvalid,
+		},
+		{
+			name: "expired auth_date",
+			initData: func() string {
+				return signInitData(fields(now.Add(-initDataMaxAge-time.Minute)), testBotToken).Encode()
+			},
+			wantErr: errInitDataExpired,
+		},
+		{
+			name: "missing hash",
+			initData: func() string {
+				values := signInitData(fields(now.Add(-time.Hour)), testBotToken)
+				values.Del("hash")
+				return values.Encode()
+			},
+			wantErr: errInitDataInvalid,
+		},
+		{
+			name: "malformed hash",
+			initData: func() string {
+				values := signInitData(fields(now.Add(-time.Hour)), testBotToken)
+				values.Set("hash", "not-hex")
+				return values.Encode()
+			},
+			wantErr: errInitDataInvalid,
+		},
+		{
+			name: "signed without user",
+			initData: func() string {
+				f := fields(now.Add(-time.Hour))
+				delete(f, "user")
+				return signInitData(f, testBotToken).Encode()
+			},
+			wantErr: errInitDataInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := validateInitData(tt.initData(), testBotToken, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateInitData() error = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.wantID {
+				t.Errorf("validateInitData() = %d, want %d", id, tt.wantID)
+			}
+		})
+	}
+}
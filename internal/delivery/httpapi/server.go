@@ -0,0 +1,123 @@
+// Package httpapi serves the HTTP side of the bot: a token-authenticated JSON API with
+// the names dataset and the caller's progress for companion apps and widgets, and the
+// Telegram Mini App authenticated with WebApp initData.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// requestTimeout bounds the handling of a single request.
+const requestTimeout = 10 * time.Second
+
+// Server serves the HTTP API.
+type Server struct {
+	names    NameService
+	progress ProgressService
+	tokens   TokenService
+	logger   *zap.Logger
+}
+
+// NewServer creates a new API server.
+func NewServer(names NameService, progress ProgressService, tokens TokenService, logger *zap.Logger) *Server {
+	return &Server{names: names, progress: progress, tokens: tokens, logger: logger}
+}
+
+// Handler returns the API routes. Every route requires an "Authorization: Bearer <token>" header.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/names", s.authenticated(s.handleNames))
+	mux.Handle("GET /api/v1/names/{number}", s.authenticated(s.handleName))
+	mux.Handle("GET /api/v1/me/progress", s.authenticated(s.handleProgress))
+	return mux
+}
+
+// Serve exposes handler on addr until ctx is done.
+func Serve(ctx context.Context, addr string, handler http.Handler, logger *zap.Logger) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      requestTimeout + 5*time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("http server started", zap.String("addr", addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("http server failed", zap.Error(err))
+	}
+}
+
+// authenticatedFunc handles a request on behalf of the token owner.
+type authenticatedFunc func(w http.ResponseWriter, r *http.Request, userID int64)
+
+// authenticated resolves the bearer token to a user and rejects requests without a valid one.
+func (s *Server) authenticated(next authenticatedFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		userID, err := s.tokens.Authenticate(ctx, strings.TrimSpace(token))
+		switch {
+		case errors.Is(err, service.ErrAPITokenInvalid):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			writeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		case err != nil:
+			internalError(s.logger, w, r, err)
+			return
+		}
+
+		next(w, r, userID)
+	})
+}
+
+// internalError logs err and replies with a generic 500.
+func internalError(logger *zap.Logger, w http.ResponseWriter, r *http.Request, err error) {
+	logger.Error("api request failed",
+		zap.String("path", r.URL.Path),
+		zap.Error(err),
+	)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// errorResponse is the body of every non-2xx response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,250 @@
+package httpapi
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// webAppReviewLimit is the number of cards in one review round of the Mini App.
+const webAppReviewLimit = 20
+
+//go:embed webapp
+var webAppFiles embed.FS
+
+// WebApp serves the Telegram Mini App: its static pages and the JSON endpoints they call.
+type WebApp struct {
+	botToken string
+	names    NameService
+	reviews  ReviewService
+	users    UserService
+	logger   *zap.Logger
+}
+
+// NewWebApp creates a new Mini App server; botToken is used to validate initData.
+func NewWebApp(botToken string, names NameService, reviews ReviewService, users UserService, logger *zap.Logger) *WebApp {
+	return &WebApp{botToken: botToken, names: names, reviews: reviews, users: users, logger: logger}
+}
+
+// Handler returns the Mini App routes. Pages are public; every /webapp/api/ route requires
+// an "Authorization: tma <initData>" header with the initData Telegram passed to the app.
+func (a *WebApp) Handler() http.Handler {
+	static, _ := fs.Sub(webAppFiles, "webapp")
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /webapp/", http.StripPrefix("/webapp/", http.FileServerFS(static)))
+	mux.Handle("GET /webapp/api/names", a.authenticated(a.handleNames))
+	mux.Handle("GET /webapp/api/names/{number}", a.authenticated(a.handleName))
+	mux.Handle("GET /webapp/api/review", a.authenticated(a.handleReviewQueue))
+	mux.Handle("POST /webapp/api/review/{number}", a.authenticated(a.handleReview))
+	return mux
+}
+
+// authenticated resolves initData to a bot user and rejects requests without a valid one.
+func (a *WebApp) authenticated(next authenticatedFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		initData, ok := strings.CutPrefix(r.Header.Get("Authorization"), "tma ")
+		if !ok || initData == "" {
+			writeError(w, http.StatusUnauthorized, "missing init data")
+			return
+		}
+
+		userID, err := validateInitData(initData, a.botToken, time.Now())
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		exists, err := a.users.Exists(ctx, userID)
+		if err != nil {
+			internalError(a.logger, w, r, err)
+			return
+		}
+		if !exists {
+			writeError(w, http.StatusForbidden, "start the bot first")
+			return
+		}
+
+		next(w, r, userID)
+	})
+}
+
+// webAppNameResponse is a name with the user's learning phase.
+type webAppNameResponse struct {
+	nameResponse
+	Phase string `json:"phase"`
+}
+
+// webAppNameDetailsResponse is a name card with the user's statistics for it.
+type webAppNameDetailsResponse struct {
+	webAppNameResponse
+	Streak         int        `json:"streak"`
+	ReviewCount    int        `json:"review_count"`
+	TotalAnswers   int        `json:"total_answers"`
+	CorrectAnswers int        `json:"correct_answers"`
+	Accuracy       float64    `json:"accuracy"`
+	NextReviewAt   *time.Time `json:"next_review_at,omitempty"`
+}
+
+// webAppReviewRequest is the self-assessment of a review card.
+type webAppReviewRequest struct {
+	Remembered *bool `json:"remembered"`
+}
+
+// handleNames returns all 99 names with the user's phases: GET /webapp/api/names.
+func (a *WebApp) handleNames(w http.ResponseWriter, r *http.Request, userID int64) {
+	names, err := a.names.GetAll(r.Context())
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+
+	nums := make([]int, 0, len(names))
+	for _, n := range names {
+		nums = append(nums, n.Number)
+	}
+
+	progress, err := a.reviews.GetByNumbers(r.Context(), userID, nums)
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+
+	resp := make([]webAppNameResponse, 0, len(names))
+	for _, n := range names {
+		resp = append(resp, newWebAppNameResponse(n, progress[n.Number]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleName returns a name card with the user's statistics: GET /webapp/api/names/{number}.
+func (a *WebApp) handleName(w http.ResponseWriter, r *http.Request, userID int64) {
+	name, ok := a.pathName(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := a.reviews.GetNameStats(r.Context(), userID, name.Number)
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+
+	resp := webAppNameDetailsResponse{
+		webAppNameResponse: newWebAppNameResponse(name, stats.Progress),
+		TotalAnswers:       stats.TotalAnswers,
+		CorrectAnswers:     stats.CorrectAnswers,
+		Accuracy:           stats.Accuracy(),
+	}
+	if p := stats.Progress; p != nil {
+		resp.Streak = p.Streak
+		resp.ReviewCount = p.ReviewCount
+		resp.NextReviewAt = p.NextReviewAt
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleReviewQueue returns the cards of a review round: names due for review,
+// or names being learned if nothing is due: GET /webapp/api/review.
+func (a *WebApp) handleReviewQueue(w http.ResponseWriter, r *http.Request, userID int64) {
+	nums, err := a.reviews.GetDueNames(r.Context(), userID, webAppReviewLimit)
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+	if len(nums) == 0 {
+		nums, err = a.reviews.GetLearningNames(r.Context(), userID, webAppReviewLimit)
+		if err != nil {
+			internalError(a.logger, w, r, err)
+			return
+		}
+	}
+
+	progress, err := a.reviews.GetByNumbers(r.Context(), userID, nums)
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+
+	resp := make([]webAppNameResponse, 0, len(nums))
+	for _, num := range nums {
+		name, err := a.names.GetByNumber(r.Context(), num)
+		if err != nil {
+			internalError(a.logger, w, r, err)
+			return
+		}
+		resp = append(resp, newWebAppNameResponse(name, progress[num]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleReview records a swipe on a review card: POST /webapp/api/review/{number}
+// with {"remembered": true|false}. It returns the updated card.
+func (a *WebApp) handleReview(w http.ResponseWriter, r *http.Request, userID int64) {
+	name, ok := a.pathName(w, r)
+	if !ok {
+		return
+	}
+
+	var req webAppReviewRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil || req.Remembered == nil {
+		writeError(w, http.StatusBadRequest, `body must be {"remembered": true|false}`)
+		return
+	}
+
+	progress, err := a.reviews.RecordSelfReview(r.Context(), userID, name.Number, *req.Remembered)
+	if err != nil {
+		internalError(a.logger, w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWebAppNameResponse(name, progress))
+}
+
+// pathName returns the name from the {number} path value, replying with an error if there is none.
+func (a *WebApp) pathName(w http.ResponseWriter, r *http.Request) (*entities.Name, bool) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 || number > 99 {
+		writeError(w, http.StatusBadRequest, "name number must be between 1 and 99")
+		return nil, false
+	}
+
+	name, err := a.names.GetByNumber(r.Context(), number)
+	switch {
+	case errors.Is(err, repository.ErrNameNotFound):
+		writeError(w, http.StatusNotFound, "name not found")
+		return nil, false
+	case err != nil:
+		internalError(a.logger, w, r, err)
+		return nil, false
+	}
+
+	return name, true
+}
+
+// newWebAppNameResponse converts a name and the user's progress on it, nil if not studied, to its Mini App form.
+func newWebAppNameResponse(n *entities.Name, p *entities.UserProgress) webAppNameResponse {
+	phase := entities.PhaseNew
+	if p != nil {
+		phase = p.Phase
+	}
+	return webAppNameResponse{nameResponse: newNameResponse(n), Phase: string(phase)}
+}
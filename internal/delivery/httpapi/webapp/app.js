@@ -0,0 +1,207 @@
+"use strict";
+
+const tg = window.Telegram.WebApp;
+tg.ready();
+tg.expand();
+
+const SWIPE_THRESHOLD = 80;
+const phaseTitles = { new: "новое", learning: "изучается", mastered: "выучено" };
+
+const state = { names: [], queue: [], reviewed: 0 };
+
+async function api(path, options = {}) {
+  const resp = await fetch("api/" + path, {
+    ...options,
+    headers: { "Authorization": "tma " + tg.initData, "Content-Type": "application/json" },
+  });
+  const body = await resp.json();
+  if (!resp.ok) {
+    throw new Error(body.error || resp.statusText);
+  }
+  return body;
+}
+
+function el(tag, className, text) {
+  const node = document.createElement(tag);
+  if (className) node.className = className;
+  if (text !== undefined) node.textContent = text;
+  return node;
+}
+
+function showError(err) {
+  const box = document.getElementById("error");
+  box.textContent = err.message === "start the bot first"
+    ? "Сначала откройте бота и нажмите «Старт»."
+    : "Не удалось загрузить данные. Попробуйте позже.";
+  box.hidden = false;
+}
+
+function showView(id) {
+  document.querySelectorAll(".view").forEach((v) => v.classList.toggle("active", v.id === id));
+  document.querySelectorAll(".tab").forEach((t) => t.classList.toggle("active", t.dataset.view === id));
+  if (id === "details") {
+    tg.BackButton.show();
+  } else {
+    tg.BackButton.hide();
+  }
+}
+
+// Grid of the 99 names.
+
+async function loadNames() {
+  state.names = await api("names");
+  const grid = document.getElementById("names");
+  grid.replaceChildren(...state.names.map((n) => {
+    const cell = el("button", "cell " + n.phase);
+    cell.append(el("span", "num", n.number), el("span", "ar", n.arabic));
+    cell.addEventListener("click", () => openName(n.number).catch(showError));
+    return cell;
+  }));
+}
+
+// Name details.
+
+async function openName(number) {
+  const n = await api("names/" + number);
+  const card = document.getElementById("card");
+  card.replaceChildren(
+    el("div", "muted", n.number + " · " + phaseTitles[n.phase]),
+    el("div", "ar", n.arabic),
+    el("div", "tr", n.transliteration),
+    el("p", "", n.translation),
+    el("p", "meaning", n.meaning),
+    statsBlock(n),
+  );
+  showView("details");
+}
+
+function statsBlock(n) {
+  const stats = el("div", "stats");
+  if (n.review_count === 0 && n.total_answers === 0) {
+    stats.append(el("p", "", "Вы ещё не изучали это имя."));
+    return stats;
+  }
+  stats.append(
+    el("p", "", "Повторений: " + n.review_count + " · серия: " + n.streak),
+    el("p", "", "Ответов в квизах: " + n.correct_answers + " из " + n.total_answers +
+      " (" + Math.round(n.accuracy) + "%)"),
+  );
+  if (n.next_review_at) {
+    stats.append(el("p", "", "Следующее повторение: " + new Date(n.next_review_at).toLocaleDateString("ru-RU")));
+  }
+  return stats;
+}
+
+// Swipe review.
+
+async function loadReview() {
+  state.queue = await api("review");
+  state.reviewed = 0;
+  renderReview();
+}
+
+function renderReview() {
+  const stack = document.getElementById("review-stack");
+  const counter = document.getElementById("review-counter");
+  const actions = document.getElementById("review-actions");
+
+  if (state.queue.length === 0) {
+    counter.textContent = state.reviewed > 0
+      ? "Готово! Повторено имён: " + state.reviewed + "."
+      : "Сейчас нечего повторять. Изучите новые имена в боте.";
+    stack.replaceChildren();
+    actions.hidden = true;
+    return;
+  }
+
+  const n = state.queue[0];
+  counter.textContent = "Осталось: " + state.queue.length;
+  actions.hidden = false;
+
+  const card = el("div", "card");
+  const answer = el("div", "answer");
+  answer.append(el("div", "tr", n.transliteration), el("p", "", n.translation));
+  card.append(el("div", "muted", String(n.number)), el("div", "ar", n.arabic), answer);
+  card.addEventListener("click", () => card.classList.add("revealed"));
+  attachSwipe(card);
+
+  stack.replaceChildren(card);
+}
+
+function attachSwipe(card) {
+  let startX = null;
+
+  card.addEventListener("pointerdown", (e) => {
+    startX = e.clientX;
+    card.classList.add("dragging");
+    card.setPointerCapture(e.pointerId);
+  });
+
+  card.addEventListener("pointermove", (e) => {
+    if (startX === null) return;
+    const dx = e.clientX - startX;
+    card.style.transform = "translateX(" + dx + "px) rotate(" + dx / 20 + "deg)";
+  });
+
+  const finish = (e) => {
+    if (startX === null) return;
+    const dx = e.clientX - startX;
+    startX = null;
+    card.classList.remove("dragging");
+
+    if (Math.abs(dx) < SWIPE_THRESHOLD) {
+      card.style.transform = "";
+      return;
+    }
+    submitReview(dx > 0).catch(showError);
+  };
+  card.addEventListener("pointerup", finish);
+  card.addEventListener("pointercancel", finish);
+}
+
+async function submitReview(remembered) {
+  const n = state.queue[0];
+  if (!n) return;
+
+  const card = document.querySelector("#review-stack .card");
+  if (card) {
+    card.style.transform = "translateX(" + (remembered ? 400 : -400) + "px)";
+    card.style.opacity = "0";
+  }
+  tg.HapticFeedback.impactOccurred("light");
+
+  const updated = await api("review/" + n.number, {
+    method: "POST",
+    body: JSON.stringify({ remembered }),
+  });
+
+  const listed = state.names.find((x) => x.number === updated.number);
+  if (listed) listed.phase = updated.phase;
+
+  state.queue.shift();
+  state.reviewed++;
+  renderReview();
+}
+
+// Wiring.
+
+document.querySelectorAll(".tab").forEach((tab) => {
+  tab.addEventListener("click", () => {
+    const view = tab.dataset.view;
+    showView(view);
+    const load = view === "review" ? loadReview : loadNames;
+    load().catch(showError);
+  });
+});
+
+document.getElementById("back").addEventListener("click", () => showView("grid"));
+tg.BackButton.onClick(() => showView("grid"));
+
+document.getElementById("remembered").addEventListener("click", () => submitReview(true).catch(showError));
+document.getElementById("forgot").addEventListener("click", () => submitReview(false).catch(showError));
+document.getElementById("show").addEventListener("click", () => {
+  const card = document.querySelector("#review-stack .card");
+  if (card) card.classList.add("revealed");
+});
+
+loadNames().catch(showError);
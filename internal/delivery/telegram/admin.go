@@ -0,0 +1,598 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// adminCommandPermissions maps admin commands to the permission they require.
+var adminCommandPermissions = map[string]entities.AdminPermission{
+	"admin_backup":     entities.AdminPermSupport,
+	"admin_stats":      entities.AdminPermSupport,
+	"admin_tickets":    entities.AdminPermSupport,
+	"admin_onboarding": entities.AdminPermSupport,
+	"admin_web":        entities.AdminPermSupport,
+	"admin_audit":      entities.AdminPermSupport,
+	"admin_blocked":    entities.AdminPermSupport,
+	"admin_block":      entities.AdminPermMaintenance,
+	"admin_unblock":    entities.AdminPermMaintenance,
+	"admin_restore":    entities.AdminPermMaintenance,
+	"admin_loglevel":   entities.AdminPermMaintenance,
+	"admin_list":       entities.AdminPermManageAdmins,
+	"admin_add":        entities.AdminPermManageAdmins,
+	"admin_remove":     entities.AdminPermManageAdmins,
+	"admin_log":        entities.AdminPermManageAdmins,
+	"admin_reload":     entities.AdminPermManageAdmins,
+	"admin_reports":    entities.AdminPermContent,
+	"admin_survey":     entities.AdminPermBroadcast,
+}
+
+const (
+	// adminLogDefaultLimit is the number of actions /admin_log shows without an argument.
+	adminLogDefaultLimit = 20
+	// adminLogMaxLimit caps the number of actions /admin_log shows.
+	adminLogMaxLimit = 100
+)
+
+// handleAdminMessage checks the permission for an admin command, records it in the
+// audit log and runs it. Users without the permission get the unknown command reply.
+func (h *Handler) handleAdminMessage(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	adminID := msg.From.ID
+	command := msg.Command()
+	args := strings.TrimSpace(msg.CommandArguments())
+
+	allowed, err := h.adminService.Can(ctx, adminID, adminCommandPermissions[command])
+	if err != nil {
+		h.log(ctx).Error("failed to check admin permission",
+			zap.Int64("user_id", adminID),
+			zap.String("command", command),
+			zap.Error(err),
+		)
+		_ = h.send(h.internalErrorMessage(ctx, chatID))
+		return
+	}
+	if !allowed {
+		h.log(ctx).Warn("admin command denied",
+			zap.Int64("user_id", adminID),
+			zap.String("command", command),
+		)
+		_ = h.send(newPlainMessage(chatID, msgUnknownCommand))
+		return
+	}
+
+	h.adminService.LogAction(ctx, &entities.AdminAction{
+		AdminID:      adminID,
+		Action:       command,
+		TargetUserID: adminTargetID(args),
+		Details:      args,
+	})
+
+	var handler HandlerFunc
+	switch command {
+	case "admin_backup", "admin_restore":
+		handler = h.handleAdminCommand(adminID, command, args)
+	case "admin_stats":
+		handler = h.handleAdminStats()
+	case "admin_tickets":
+		handler = h.handleAdminTickets(args)
+	case "admin_onboarding":
+		handler = h.handleAdminOnboarding(args)
+	case "admin_web":
+		handler = h.handleAdminWeb(adminID)
+	case "admin_list":
+		handler = h.handleAdminList()
+	case "admin_add":
+		handler = h.handleAdminAdd(adminID, args)
+	case "admin_remove":
+		handler = h.handleAdminRemove(args)
+	case "admin_log":
+		handler = h.handleAdminLog(args)
+	case "admin_audit":
+		handler = h.handleAdminAudit(args)
+	case "admin_block":
+		handler = h.handleAdminBlock(adminID, args)
+	case "admin_unblock":
+		handler = h.handleAdminUnblock(args)
+	case "admin_blocked":
+		handler = h.handleAdminBlocked()
+	case "admin_reports":
+		handler = h.handleAdminReports()
+	case "admin_survey":
+		handler = h.handleAdminSurvey(adminID, args)
+	case "admin_reload":
+		handler = h.handleAdminReload()
+	case "admin_loglevel":
+		handler = h.handleAdminLogLevel(args)
+	default:
+		return
+	}
+
+	_ = h.withErrorHandling(handler)(ctx, chatID)
+}
+
+// adminTargetID returns the user ID an admin command is applied to, or 0 if args do not start with one.
+func adminTargetID(args string) int64 {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return 0
+	}
+	id, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || id <= 0 {
+		return 0
+	}
+	return id
+}
+
+// handleAdminList shows owners from the configuration and admins granted in the bot.
+func (h *Handler) handleAdminList() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		admins, err := h.adminService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		var sb strings.Builder
+		sb.WriteString("👮 Администраторы\n")
+		for _, a := range admins {
+			if a.FromConfig {
+				fmt.Fprintf(&sb, "\n%d — %s (ADMIN_IDS)", a.UserID, a.Role)
+				continue
+			}
+			fmt.Fprintf(&sb, "\n%d — %s, выдал %d %s", a.UserID, a.Role, a.AddedBy, a.CreatedAt.UTC().Format(time.DateOnly))
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// handleAdminAdd grants an admin role: /admin_add <user_id> <role>.
+func (h *Handler) handleAdminAdd(adminID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		fields := strings.Fields(args)
+		targetID := adminTargetID(args)
+		if len(fields) != 2 || targetID == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminAddUsage))
+		}
+
+		role := strings.ToLower(fields[1])
+		err := h.adminService.Grant(ctx, targetID, role, adminID)
+		switch {
+		case errors.Is(err, service.ErrInvalidAdminRole):
+			return h.send(newPlainMessage(chatID, msgAdminAddUsage))
+		case errors.Is(err, service.ErrConfigAdmin):
+			return h.send(newPlainMessage(chatID, msgAdminConfigOwner))
+		case err != nil:
+			return err
+		}
+
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminGranted, targetID, role)))
+	}
+}
+
+// handleAdminRemove revokes an admin role: /admin_remove <user_id>.
+func (h *Handler) handleAdminRemove(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		targetID := adminTargetID(args)
+		if len(strings.Fields(args)) != 1 || targetID == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminRemoveUsage))
+		}
+
+		err := h.adminService.Revoke(ctx, targetID)
+		switch {
+		case errors.Is(err, service.ErrConfigAdmin):
+			return h.send(newPlainMessage(chatID, msgAdminConfigOwner))
+		case errors.Is(err, repository.ErrAdminNotFound):
+			return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminNotAdmin, targetID)))
+		case err != nil:
+			return err
+		}
+
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminRevoked, targetID)))
+	}
+}
+
+// handleAdminBlock blocks a user: /admin_block <user_id> [reason]. Their updates are
+// ignored from then on and they get no broadcasts or reminders.
+func (h *Handler) handleAdminBlock(adminID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		targetID := adminTargetID(args)
+		if targetID == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminBlockUsage))
+		}
+		reason := strings.TrimSpace(strings.TrimPrefix(args, strings.Fields(args)[0]))
+
+		err := h.blockList.Block(ctx, targetID, reason, adminID)
+		switch {
+		case errors.Is(err, service.ErrCannotBlockAdmin):
+			return h.send(newPlainMessage(chatID, msgAdminBlockAdmin))
+		case err != nil:
+			return err
+		}
+
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminBlocked, targetID)))
+	}
+}
+
+// handleAdminUnblock unblocks a user: /admin_unblock <user_id>.
+func (h *Handler) handleAdminUnblock(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		targetID := adminTargetID(args)
+		if len(strings.Fields(args)) != 1 || targetID == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminUnblockUsage))
+		}
+
+		removed, err := h.blockList.Unblock(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminNotBlocked, targetID)))
+		}
+
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminUnblocked, targetID)))
+	}
+}
+
+// handleAdminBlocked lists the blocked users: /admin_blocked.
+func (h *Handler) handleAdminBlocked() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		users, err := h.blockList.List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminBlockedEmpty))
+		}
+
+		var sb strings.Builder
+		sb.WriteString("🚫 Заблокированные пользователи\n")
+		for _, u := range users {
+			fmt.Fprintf(&sb, "\n%d — заблокировал %d %s", u.UserID, u.BlockedBy, u.CreatedAt.UTC().Format(time.DateOnly))
+			if u.Reason != "" {
+				sb.WriteString(": " + u.Reason)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// handleAdminReload rereads the configuration and applies the settings that can change
+// without a restart: /admin_reload. A configuration that fails to load leaves the old one in place.
+func (h *Handler) handleAdminReload() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if h.reloader == nil {
+			return h.send(newPlainMessage(chatID, msgAdminReloadDisabled))
+		}
+
+		applied, restart, err := h.reloader.Reload()
+		if err != nil {
+			return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminReloadFailed, err)))
+		}
+		if len(applied) == 0 && len(restart) == 0 {
+			return h.send(newPlainMessage(chatID, msgAdminReloadNothing))
+		}
+
+		var sb strings.Builder
+		sb.WriteString("🔄 Конфигурация перечитана")
+		if len(applied) > 0 {
+			sb.WriteString("\n\nПрименено:")
+			for _, change := range applied {
+				sb.WriteString("\n• " + change)
+			}
+		}
+		if len(restart) > 0 {
+			sb.WriteString("\n\nИзменено, вступит в силу после перезапуска:")
+			for _, key := range restart {
+				sb.WriteString("\n• " + key)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// handleAdminLogLevel shows or switches logging: /admin_loglevel [debug|info|warn|error],
+// /admin_loglevel sampling on|off. Changes last until the next restart or config reload.
+func (h *Handler) handleAdminLogLevel(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if h.logControls == nil {
+			return h.send(newPlainMessage(chatID, msgAdminLogLevelDisabled))
+		}
+
+		fields := strings.Fields(strings.ToLower(args))
+		switch {
+		case len(fields) == 0:
+		case len(fields) == 1:
+			if err := h.logControls.SetLevel(fields[0]); err != nil {
+				return h.send(newPlainMessage(chatID, msgAdminLogLevelUsage))
+			}
+			h.log(ctx).Warn("log level changed", zap.String("level", h.logControls.Level()))
+		case len(fields) == 2 && fields[0] == "sampling" && (fields[1] == "on" || fields[1] == "off"):
+			h.logControls.SetSampling(fields[1] == "on")
+			h.log(ctx).Warn("log sampling changed", zap.Bool("sampling", h.logControls.Sampling()))
+		default:
+			return h.send(newPlainMessage(chatID, msgAdminLogLevelUsage))
+		}
+
+		sampling := "выкл"
+		if h.logControls.Sampling() {
+			sampling = "вкл"
+		}
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminLogLevel, h.logControls.Level(), sampling)))
+	}
+}
+
+// handleAdminLog shows the latest admin actions: /admin_log [N].
+func (h *Handler) handleAdminLog(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		limit := adminLogDefaultLimit
+		if n, err := strconv.Atoi(args); err == nil && n > 0 {
+			limit = min(n, adminLogMaxLimit)
+		}
+
+		actions, err := h.adminService.RecentActions(ctx, limit)
+		if err != nil {
+			return err
+		}
+		if len(actions) == 0 {
+			return h.send(newPlainMessage(chatID, "Журнал действий администраторов пуст."))
+		}
+
+		var sb strings.Builder
+		sb.WriteString("📜 Действия администраторов (UTC)\n")
+		for _, a := range actions {
+			fmt.Fprintf(&sb, "\n%s · %d · /%s", a.CreatedAt.UTC().Format("2006-01-02 15:04"), a.AdminID, a.Action)
+			if a.Details != "" {
+				sb.WriteString(" " + a.Details)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// handleAdminAudit shows the latest changes a user made to their settings, reminders
+// and plan: /admin_audit <user_id> [N].
+func (h *Handler) handleAdminAudit(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		fields := strings.Fields(args)
+		if len(fields) == 0 || len(fields) > 2 {
+			return h.send(newPlainMessage(chatID, msgAdminAuditUsage))
+		}
+		userID, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil || userID <= 0 {
+			return h.send(newPlainMessage(chatID, msgAdminAuditUsage))
+		}
+		limit := adminLogDefaultLimit
+		if len(fields) == 2 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n <= 0 {
+				return h.send(newPlainMessage(chatID, msgAdminAuditUsage))
+			}
+			limit = min(n, adminLogMaxLimit)
+		}
+
+		entries, err := h.userAuditService.History(ctx, userID, limit)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminAuditEmpty, userID)))
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "🗂 Изменения пользователя %d (UTC)\n", userID)
+		for _, e := range entries {
+			fmt.Fprintf(&sb, "\n%s · %s: %s → %s", e.CreatedAt.UTC().Format("2006-01-02 15:04"), e.Action,
+				auditValue(e.OldValue), auditValue(e.NewValue))
+			if e.RequestID != "" {
+				sb.WriteString(" · " + e.RequestID)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// auditValue formats a value from the user audit log, with a dash for no value.
+func auditValue(v []byte) string {
+	if len(v) == 0 {
+		return "—"
+	}
+	return string(v)
+}
+
+// handleAdminCommand dispatches /admin_backup and /admin_restore.
+func (h *Handler) handleAdminCommand(adminID int64, command, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		targetID, err := strconv.ParseInt(strings.TrimSpace(args), 10, 64)
+		if err != nil || targetID <= 0 {
+			return h.send(newPlainMessage(chatID, fmt.Sprintf("Использование: /%s <user_id>", command)))
+		}
+
+		exists, err := h.userService.Exists(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return h.send(newPlainMessage(chatID, msgAdminUserNotFound))
+		}
+
+		if command == "admin_restore" {
+			prompt := newPlainMessage(chatID, fmt.Sprintf(msgAdminRestorePrompt, targetID))
+			prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+
+			sent, err := h.bot.Send(prompt)
+			if err != nil {
+				return err
+			}
+			h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+			h.setImportWaitState(ctx, adminID, importWaitState{
+				ChatID:          chatID,
+				PromptMessageID: sent.MessageID,
+				RestoreUserID:   targetID,
+			})
+			return nil
+		}
+
+		return h.sendUserSnapshot(ctx, chatID, targetID, "backup")
+	}
+}
+
+// deliveryStatsPeriods are the windows shown by /admin_stats.
+var deliveryStatsPeriods = []struct {
+	Label  string
+	Period time.Duration
+}{
+	{"24 часа", 24 * time.Hour},
+	{"7 дней", 7 * 24 * time.Hour},
+}
+
+// handleAdminStats shows the usage overview followed by notification delivery statistics.
+func (h *Handler) handleAdminStats() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		overview, err := h.statsService.GetOverview(ctx)
+		if err != nil {
+			return err
+		}
+
+		var sb strings.Builder
+		sb.WriteString(formatOverview(overview))
+		sb.WriteString("\n📊 Доставка уведомлений\n")
+
+		for i, p := range deliveryStatsPeriods {
+			stats, err := h.statsService.GetDeliveryStats(ctx, p.Period)
+			if err != nil {
+				return err
+			}
+
+			sb.WriteString("\n")
+			sb.WriteString(formatDeliveryStats(p.Label, stats))
+
+			if i == len(deliveryStatsPeriods)-1 {
+				fmt.Fprintf(&sb, "\nОчередь: %d ожидают, %d не доставлены\n", stats.QueuePending, stats.QueueFailed)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, sb.String()))
+	}
+}
+
+// formatOverview renders the usage overview at the top of /admin_stats.
+func formatOverview(o *entities.BotOverview) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📈 Обзор на %s UTC\n\n", o.At.UTC().Format("2006-01-02 15:04"))
+	fmt.Fprintf(&sb, "Пользователей: %d\n", o.TotalUsers)
+	fmt.Fprintf(&sb, "DAU: %d · WAU: %d\n", o.DAU, o.WAU)
+	fmt.Fprintf(&sb, "Напоминания включены: %d\n", o.ActiveReminders)
+	fmt.Fprintf(&sb, "Квизов сегодня: %d (завершено %d)\n", o.QuizzesToday, o.QuizzesCompleted)
+	fmt.Fprintf(&sb, "Напоминаний отправлено за 24 часа: %d\n", o.RemindersSent24h)
+	fmt.Fprintf(&sb, "Ошибок доставки за 24 часа: %d · задач в статусе failed: %d\n", o.DeliveryErrors24h, o.FailedJobs)
+	return sb.String()
+}
+
+// formatDeliveryStats renders attempts by notification kind for one period.
+func formatDeliveryStats(label string, stats *entities.DeliveryStats) string {
+	var sb strings.Builder
+
+	totals := stats.Totals()
+	fmt.Fprintf(&sb, "За %s: %s\n", label, formatDeliveryCounts(totals))
+	if totals.Total() == 0 {
+		return sb.String()
+	}
+
+	kinds := make([]entities.NotificationKind, 0, len(stats.ByKind))
+	for kind := range stats.ByKind {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	for _, kind := range kinds {
+		fmt.Fprintf(&sb, "  %s: %s\n", kind, formatDeliveryCounts(stats.ByKind[kind]))
+	}
+
+	return sb.String()
+}
+
+// formatDeliveryCounts renders counts with the delivery rate.
+func formatDeliveryCounts(c entities.DeliveryCounts) string {
+	total := c.Total()
+	if total == 0 {
+		return "нет попыток"
+	}
+
+	rate := float64(c[entities.DeliveryDelivered]) * 100 / float64(total)
+	return fmt.Sprintf("✅ %d · 🔁 %d · ❌ %d (%.1f%% успешно)",
+		c[entities.DeliveryDelivered], c[entities.DeliveryRetry], c[entities.DeliveryFailed], rate)
+}
+
+// restoreUser replaces the user's state with the uploaded snapshot.
+// The current state is sent to the admin first so a mistaken restore can be undone.
+func (h *Handler) restoreUser(ctx context.Context, chatID, adminID, targetID int64, data []byte) error {
+	if err := h.sendUserSnapshot(ctx, chatID, targetID, "before-restore"); err != nil {
+		h.clearImportWait(ctx, adminID)
+		return err
+	}
+
+	result, err := h.importService.Restore(ctx, targetID, data)
+	switch {
+	case errors.Is(err, service.ErrImportInvalid):
+		return h.send(newPlainMessage(chatID, msgImportInvalid))
+	case errors.Is(err, service.ErrImportForeignUser):
+		return h.send(newPlainMessage(chatID, fmt.Sprintf(msgAdminSnapshotMismatch, targetID)))
+	case errors.Is(err, repository.ErrUserNotFound):
+		h.clearImportWait(ctx, adminID)
+		return h.send(newPlainMessage(chatID, msgAdminUserNotFound))
+	case err != nil:
+		h.log(ctx).Error("failed to restore user data",
+			zap.Int64("admin_id", adminID),
+			zap.Int64("target_user_id", targetID),
+			zap.Error(err),
+		)
+		h.clearImportWait(ctx, adminID)
+		return h.send(h.internalErrorMessage(ctx, chatID))
+	}
+
+	h.adminService.LogAction(ctx, &entities.AdminAction{
+		AdminID:      adminID,
+		Action:       "admin_restore",
+		TargetUserID: targetID,
+		Details:      fmt.Sprintf("snapshot applied, %d progress records", result.ProgressRestored),
+	})
+
+	h.clearImportWait(ctx, adminID)
+	return h.send(newMessage(chatID, formatImportResult(result)))
+}
+
+// sendUserSnapshot sends the full state of the user as a JSON document.
+func (h *Handler) sendUserSnapshot(ctx context.Context, chatID, targetID int64, label string) error {
+	export, err := h.exportService.Export(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("export user %d: %w", targetID, err)
+	}
+
+	data, err := export.JSON()
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s.json", label, targetID, export.ExportedAt.Format("20060102-150405"))
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: data})
+	doc.Caption = fmt.Sprintf("Снимок пользователя %d на %s UTC", targetID, export.ExportedAt.UTC().Format(time.DateTime))
+	return h.send(doc)
+}
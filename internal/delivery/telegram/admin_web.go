@@ -0,0 +1,29 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// handleAdminWeb sends a short-lived sign-in link to the web admin: /admin_web.
+func (h *Handler) handleAdminWeb(adminID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		link, err := h.adminSessionService.LoginLink(adminID)
+		switch {
+		case errors.Is(err, service.ErrAdminWebDisabled):
+			return h.send(newPlainMessage(chatID, msgAdminWebDisabled))
+		case err != nil:
+			return err
+		}
+		return h.send(newPlainMessage(chatID, msgAdminWebLink+"\n\n"+link))
+	}
+}
+
+// SendBroadcast sends an admin broadcast to a user.
+func (h *Handler) SendBroadcast(ctx context.Context, userID, chatID int64, message entities.BroadcastMessage) error {
+	_, err := h.sendNotification(ctx, newPlainMessage(chatID, message.Text))
+	return err
+}
@@ -0,0 +1,59 @@
+package telegram
+
+import "strings"
+
+// commandAliases maps short command aliases to the commands they stand for.
+// Commands addressed to the bot, e.g. /start@botname, need no alias.
+var commandAliases = map[string]string{
+	"q":   "quiz",
+	"t":   "today",
+	"p":   "progress",
+	"s":   "settings",
+	"r":   "random",
+	"f":   "find",
+	"h":   "help",
+	"fav": "favorites",
+}
+
+// textShortcuts maps whole messages, in lower case, to the commands they run.
+var textShortcuts = map[string]string{
+	"квиз":       "quiz",
+	"викторина":  "quiz",
+	"тест":       "quiz",
+	"quiz":       "quiz",
+	"сегодня":    "today",
+	"today":      "today",
+	"прогресс":   "progress",
+	"статистика": "progress",
+	"progress":   "progress",
+	"stats":      "progress",
+	"настройки":  "settings",
+	"settings":   "settings",
+	"случайное":  "random",
+	"random":     "random",
+	"избранное":  "favorites",
+	"favorites":  "favorites",
+	"расписание": "schedule",
+	"schedule":   "schedule",
+	"помощь":     "help",
+	"help":       "help",
+}
+
+// resolveCommand returns the command an alias stands for, or the command itself.
+func resolveCommand(command string) string {
+	command = strings.ToLower(command)
+	if target, ok := commandAliases[command]; ok {
+		return target
+	}
+	return command
+}
+
+// textShortcut returns the command a free-text message stands for. Case, "ё" and
+// trailing punctuation are ignored; only the whole message is matched.
+func textShortcut(text string) (string, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	text = strings.TrimRight(text, ".!?")
+	text = strings.ReplaceAll(text, "ё", "е")
+	command, ok := textShortcuts[text]
+	return command, ok
+}
@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// handleAPIToken manages the personal HTTP API token: /apitoken [new|revoke].
+func (h *Handler) handleAPIToken(userID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		switch strings.ToLower(strings.TrimSpace(args)) {
+		case "new":
+			token, err := h.apiTokenService.Issue(ctx, userID)
+			switch {
+			case errors.Is(err, service.ErrAPIDisabled):
+				return h.send(newPlainMessage(chatID, msgAPIDisabled))
+			case err != nil:
+				return err
+			}
+			return h.send(newMessage(chatID, formatAPIToken(token)))
+
+		case "revoke":
+			revoked, err := h.apiTokenService.Revoke(ctx, userID)
+			if err != nil {
+				return err
+			}
+			if !revoked {
+				return h.send(newPlainMessage(chatID, msgAPITokenNone))
+			}
+			return h.send(newPlainMessage(chatID, msgAPITokenRevoked))
+
+		default:
+			return h.send(newPlainMessage(chatID, msgAPITokenUsage))
+		}
+	}
+}
+
+// formatAPIToken renders a newly issued token (MarkdownV2 safe).
+func formatAPIToken(token string) string {
+	return md("🔑 Ваш токен для HTTP API (предыдущий больше не действует):") + "\n\n" +
+		"`" + token + "`" + "\n\n" +
+		md("Передавайте его в заголовке «Authorization: Bearer <токен>». Токен показывается один раз — "+
+			"храните его как пароль. Отозвать: /apitoken revoke")
+}
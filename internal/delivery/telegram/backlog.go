@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"context"
+	"sync/atomic"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// UpdateCounter counts updates by label values, e.g. updates shed under load.
+type UpdateCounter interface {
+	Inc(labelValues ...string)
+}
+
+// backlog is the bounded queue of updates received from Telegram and not handled yet.
+// When it is full, new updates are shed instead of waiting behind the ones queued.
+type backlog struct {
+	limit    int           // queue capacity, 0 handles updates as they arrive without shedding
+	shed     UpdateCounter // counts shed updates by kind, may be nil
+	depth    atomic.Int64  // updates queued right now
+	shedding atomic.Bool   // the last update was shed, to log the start of an overload once
+}
+
+// SetBacklog limits the number of updates waiting to be handled. Updates arriving while
+// limit updates wait are shed: callback queries are answered with a "try later" notice,
+// other updates are dropped; shed counts them by kind. A limit of 0 disables shedding.
+// It must be called before Run.
+func (h *Handler) SetBacklog(limit int, shed UpdateCounter) {
+	h.backlog.limit = max(limit, 0)
+	h.backlog.shed = shed
+}
+
+// BacklogDepth returns the number of updates waiting to be handled.
+func (h *Handler) BacklogDepth() int {
+	return int(h.backlog.depth.Load())
+}
+
+// BacklogLimit returns the number of waiting updates after which new ones are shed, 0 if unlimited.
+func (h *Handler) BacklogLimit() int {
+	return h.backlog.limit
+}
+
+// runBacklog receives updates into the bounded queue and handles them one by one, in order,
+// shedding those that do not fit.
+func (h *Handler) runBacklog(ctx context.Context, updates tgbotapi.UpdatesChannel) error {
+	queue := make(chan tgbotapi.Update, h.backlog.limit)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update := <-queue:
+				h.backlog.depth.Add(-1)
+				h.handleUpdate(ctx, update)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update := <-updates:
+			h.backlog.depth.Add(1)
+			select {
+			case queue <- update:
+				if h.backlog.shedding.CompareAndSwap(true, false) {
+					h.log(ctx).Info("update backlog recovered, shedding stopped")
+				}
+			default:
+				h.backlog.depth.Add(-1)
+				h.shedUpdate(ctx, update)
+			}
+		}
+	}
+}
+
+// shedUpdate drops an update that does not fit in the backlog. A callback query is still
+// answered, so the button stops spinning and the user knows to press it again later.
+func (h *Handler) shedUpdate(ctx context.Context, update tgbotapi.Update) {
+	if h.backlog.shedding.CompareAndSwap(false, true) {
+		h.log(ctx).Warn("update backlog is full, shedding updates",
+			zap.Int("limit", h.backlog.limit),
+		)
+	}
+
+	kind := "other"
+	switch {
+	case update.CallbackQuery != nil:
+		kind = "callback"
+		_ = h.answerCallback(update.CallbackQuery.ID, msgOverloaded)
+	case update.Message != nil:
+		kind = "message"
+	case update.InlineQuery != nil:
+		kind = "inline"
+	}
+
+	if h.backlog.shed != nil {
+		h.backlog.shed.Inc(kind)
+	}
+}
@@ -1,8 +1,16 @@
 package telegram
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"strconv"
 	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 )
 
 // Callback action constants.
@@ -16,6 +24,25 @@ const (
 	actionOnboarding = "onboarding"
 	actionToday      = "today"
 	actionReset      = "reset"
+	actionDeleteData = "delete_data"
+	actionNameStats  = "name_stats"
+	actionNamePages  = "name_pages"
+	actionNameIndex  = "name_index"
+	actionFind       = "find"
+	actionOpenName   = "open"
+	actionFavorite   = "fav"
+	actionNote       = "note"
+	actionGroup      = "group"
+	actionReport     = "report"
+	actionTicket     = "ticket"
+	actionSurvey     = "survey"
+	actionWhatsNew   = "whatsnew"
+)
+
+// Target date presets for settingsTargetDate; other values are a number of days.
+const (
+	targetDateRamadan = "ramadan"
+	targetDateOff     = "off"
 )
 
 // Settings sub-actions.
@@ -25,6 +52,8 @@ const (
 	settingsNamesPerDay  = "names_per_day"
 	settingsQuizMode     = "quiz_mode"
 	settingsReminders    = "reminders"
+	settingsCalendar     = "calendar"
+	settingsTargetDate   = "target_date"
 )
 
 // Reminder sub-actions.
@@ -33,6 +62,30 @@ const (
 	reminderStartQuiz = "start_quiz"
 	reminderSnooze    = "snooze"
 	reminderDisable   = "disable"
+	reminderDigest    = "digest"
+	reminderStyle     = "style"
+	reminderContent   = "content"
+	reminderOpenToday = "today"
+	reminderAnswer    = "ans"
+	reminderSelf      = "self"
+
+	reminderQuiet        = "quiet"
+	reminderQuietWeekday = "quiet_wd"
+	reminderQuietDate    = "quiet_date"
+	reminderQuietAdd     = "quiet_add"
+
+	reminderPrayer         = "prayer"
+	reminderPrayerToggle   = "prayer_p"
+	reminderPrayerOffset   = "prayer_off"
+	reminderPrayerLocation = "prayer_loc"
+)
+
+// quietDateLayout is the compact date format used in quiet-day callbacks.
+const quietDateLayout = "20060102"
+
+// Progress sub-actions.
+const (
+	progressCalendar = "calendar"
 )
 
 // Quiz sub-actions.
@@ -48,11 +101,64 @@ const (
 	onboardingReminders = "reminders"
 	onboardingCmd       = "cmd"
 	onboardingTimezone  = "timezone"
+	onboardingTZRegion  = "tz_region"
+)
+
+const (
+	todayPage    = "page"
+	todayAudio   = "audio"
+	todayMove    = "move"
+	todayShuffle = "shuffle"
+	todaySort    = "sort"
+	todaySwap    = "swap"
+	todayDone    = "done"
 )
 
+// Group settings sub-actions.
 const (
-	todayPage  = "page"
-	todayAudio = "audio"
+	groupLanguage  = "lang"
+	groupDailyPost = "post"
+	groupDailyQuiz = "quiz"
+	groupPostTime  = "time"
+)
+
+// Favorite sub-actions.
+const (
+	favoriteToggle = "toggle"
+	favoritePage   = "page"
+	favoriteQuiz   = "quiz"
+)
+
+// Note sub-actions.
+const (
+	noteEdit   = "edit"
+	noteDelete = "delete"
+)
+
+// Content report sub-actions.
+const (
+	reportStart   = "start"
+	reportField   = "field"
+	reportCancel  = "cancel"
+	reportAccept  = "accept"
+	reportDismiss = "dismiss"
+)
+
+// Ticket sub-actions.
+const (
+	ticketClose = "close"
+)
+
+// Survey sub-actions.
+const (
+	surveyAnswer = "ans"
+	surveySkip   = "skip"
+)
+
+// What's new sub-actions.
+const (
+	whatsNewSubscribe   = "sub"
+	whatsNewUnsubscribe = "unsub"
 )
 
 const (
@@ -60,6 +166,54 @@ const (
 	resetCancel  = "cancel"
 )
 
+// Delete data sub-actions.
+const (
+	deleteDataProceed = "proceed"
+	deleteDataConfirm = "confirm"
+	deleteDataCancel  = "cancel"
+)
+
+// callbackVersion is the version of the callback data format. Bump it when the params of
+// an existing action change, so that buttons sent before are rejected instead of misparsed.
+// Version 2 passes timezones by ID instead of by name.
+const callbackVersion = "2"
+
+// callbackSigLen is the length of the encoded signature: 6 bytes of HMAC-SHA256 in base64url.
+// With the version it adds 11 bytes to the payload, which must leave it within maxCallbackLen,
+// so params taken from user input are passed by a short ID.
+const callbackSigLen = 8
+
+// maxCallbackLen is the limit Telegram puts on callback data, in bytes.
+const maxCallbackLen = 64
+
+// callbackOverflow replaces callback data over maxCallbackLen. Its version matches no
+// format, so the button is answered as stale instead of Telegram rejecting the message.
+const callbackOverflow = "0.overflow"
+
+var (
+	// errStaleCallback is returned for callback data of another format version.
+	errStaleCallback = errors.New("stale callback data")
+	// errCallbackSignature is returned for callback data with a missing or wrong signature.
+	errCallbackSignature = errors.New("invalid callback signature")
+)
+
+// callbackKey signs callback data if it is set, see SetCallbackSecret.
+var callbackKey []byte
+
+// callbackLogger reports callback data over the limit; NewHandler sets it to the bot's logger.
+var callbackLogger = zap.NewNop()
+
+// SetCallbackSecret makes buttons carry a signature of their callback data made with
+// secret, and rejects callback queries without a valid one. An empty secret turns
+// signing off. It must be called before any button is sent.
+func SetCallbackSecret(secret string) {
+	if secret == "" {
+		callbackKey = nil
+		return
+	}
+	callbackKey = []byte(secret)
+}
+
 // callbackData represents structured callback data.
 type callbackData struct {
 	Action string
@@ -67,17 +221,73 @@ type callbackData struct {
 	Raw    string
 }
 
-// encode creates a callback string representation of callbackData.
+// encode creates a callback string representation of callbackData: the format version,
+// the signature if signing is on, and the action with its params, e.g. "2.name:3" or
+// "2-AbCd12_-.name:3". Data longer than Telegram accepts would make it reject the whole
+// message, so such data is logged and replaced with callbackOverflow, which disables the
+// button. Every builder must keep its data within the limit; callback_data_test.go checks it.
 func (cd callbackData) encode() string {
-	if len(cd.Params) == 0 {
-		return cd.Action
+	payload := cd.Action
+	if len(cd.Params) > 0 {
+		payload += ":" + strings.Join(cd.Params, ":")
+	}
+
+	header := callbackVersion
+	if callbackKey != nil {
+		header += "-" + signCallback(callbackVersion, payload)
+	}
+
+	data := header + "." + payload
+	if len(data) > maxCallbackLen {
+		callbackLogger.Error("callback data over the limit, button disabled",
+			zap.String("data", data),
+			zap.Int("len", len(data)),
+			zap.Int("limit", maxCallbackLen),
+		)
+		return callbackOverflow
+	}
+	return data
+}
+
+// signCallback returns the signature of a callback payload of the given version.
+func signCallback(version, payload string) string {
+	mac := hmac.New(sha256.New, callbackKey)
+	mac.Write([]byte(version + "." + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// splitCallback splits raw callback data into its version, signature and payload.
+// Data from before versioning has no header and an empty version.
+func splitCallback(data string) (version, sig, payload string) {
+	header, payload, ok := strings.Cut(data, ".")
+	if !ok || header == "" || header[0] < '0' || header[0] > '9' {
+		return "", "", data
 	}
-	return cd.Action + ":" + strings.Join(cd.Params, ":")
+	version, sig, _ = strings.Cut(header, "-")
+	return version, sig, payload
 }
 
-// decodeCallback parses a raw callback data string into callbackData.
+// verifyCallback checks that raw callback data has the current format version and,
+// if signing is on, a valid signature.
+func verifyCallback(data string) error {
+	version, sig, payload := splitCallback(data)
+	if version != callbackVersion {
+		return errStaleCallback
+	}
+	if callbackKey == nil {
+		return nil
+	}
+	if len(sig) != callbackSigLen || !hmac.Equal([]byte(sig), []byte(signCallback(version, payload))) {
+		return errCallbackSignature
+	}
+	return nil
+}
+
+// decodeCallback parses a raw callback data string into callbackData. It does not check
+// the version or signature; handleCallback does that once with verifyCallback.
 func decodeCallback(data string) callbackData {
-	parts := strings.Split(data, ":")
+	_, _, payload := splitCallback(data)
+	parts := strings.Split(payload, ":")
 	if len(parts) == 0 {
 		return callbackData{Raw: data}
 	}
@@ -89,6 +299,14 @@ func decodeCallback(data string) callbackData {
 	}
 }
 
+// buildGroupSettingsCallback builds callback data for toggling a group setting.
+func buildGroupSettingsCallback(setting string) string {
+	return callbackData{
+		Action: actionGroup,
+		Params: []string{setting},
+	}.encode()
+}
+
 // buildTodayPageCallback builds callback data for navigating pages in the "today" view.
 func buildTodayPageCallback(page int) string {
 	return callbackData{
@@ -97,6 +315,38 @@ func buildTodayPageCallback(page int) string {
 	}.encode()
 }
 
+// buildTodayMoveCallback builds callback data for moving a name delta positions in today's order.
+func buildTodayMoveCallback(nameNumber, delta int) string {
+	return callbackData{
+		Action: actionToday,
+		Params: []string{todayMove, strconv.Itoa(nameNumber), strconv.Itoa(delta)},
+	}.encode()
+}
+
+// buildTodaySwapCallback builds callback data for replacing a planned name shown on the given page.
+func buildTodaySwapCallback(nameNumber, page int) string {
+	return callbackData{
+		Action: actionToday,
+		Params: []string{todaySwap, strconv.Itoa(nameNumber), strconv.Itoa(page)},
+	}.encode()
+}
+
+// buildTodayDoneCallback builds callback data for ticking off the name shown on the given page.
+func buildTodayDoneCallback(nameNumber, page int) string {
+	return callbackData{
+		Action: actionToday,
+		Params: []string{todayDone, strconv.Itoa(nameNumber), strconv.Itoa(page)},
+	}.encode()
+}
+
+// buildTodayReorderCallback builds callback data for shuffling or sorting today's names.
+func buildTodayReorderCallback(mode string) string {
+	return callbackData{
+		Action: actionToday,
+		Params: []string{mode},
+	}.encode()
+}
+
 // buildTodayAudioCallback builds callback data for requesting audio for a specific name in the "today" view.
 func buildTodayAudioCallback(nameNumber int) string {
 	return callbackData{
@@ -113,6 +363,155 @@ func buildNameCallback(page int) string {
 	}.encode()
 }
 
+// buildNamePagesCallback builds callback data for opening the /all page picker.
+func buildNamePagesCallback(currentPage int) string {
+	return callbackData{
+		Action: actionNamePages,
+		Params: []string{strconv.Itoa(currentPage)},
+	}.encode()
+}
+
+// buildNameIndexCallback builds callback data for opening the compact names index.
+func buildNameIndexCallback() string {
+	return callbackData{Action: actionNameIndex}.encode()
+}
+
+// buildNameStatsCallback builds callback data for opening personal statistics of a name.
+func buildNameStatsCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionNameStats,
+		Params: []string{strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildFindPageCallback builds callback data for navigating search results pages.
+func buildFindPageCallback(page int) string {
+	return callbackData{
+		Action: actionFind,
+		Params: []string{strconv.Itoa(page)},
+	}.encode()
+}
+
+// buildOpenNameCallback builds callback data for opening a single name card.
+func buildOpenNameCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionOpenName,
+		Params: []string{strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildFavoriteToggleCallback builds callback data for adding/removing a name from favorites.
+func buildFavoriteToggleCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionFavorite,
+		Params: []string{favoriteToggle, strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildFavoritesPageCallback builds callback data for navigating favorites list pages.
+func buildFavoritesPageCallback(page int) string {
+	return callbackData{
+		Action: actionFavorite,
+		Params: []string{favoritePage, strconv.Itoa(page)},
+	}.encode()
+}
+
+// buildFavoritesQuizCallback builds callback data for starting a favorites-only quiz.
+func buildFavoritesQuizCallback() string {
+	return callbackData{
+		Action: actionFavorite,
+		Params: []string{favoriteQuiz},
+	}.encode()
+}
+
+// buildNoteEditCallback builds callback data for adding or editing a note on a name.
+func buildNoteEditCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionNote,
+		Params: []string{noteEdit, strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildNoteDeleteCallback builds callback data for deleting a note on a name.
+func buildNoteDeleteCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionNote,
+		Params: []string{noteDelete, strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildReportStartCallback builds callback data for reporting an error on a name card.
+func buildReportStartCallback(nameNumber int) string {
+	return callbackData{
+		Action: actionReport,
+		Params: []string{reportStart, strconv.Itoa(nameNumber)},
+	}.encode()
+}
+
+// buildReportFieldCallback builds callback data for choosing the part of the card a report is about.
+func buildReportFieldCallback(nameNumber int, field entities.ContentReportField) string {
+	return callbackData{
+		Action: actionReport,
+		Params: []string{reportField, strconv.Itoa(nameNumber), string(field)},
+	}.encode()
+}
+
+// buildReportCancelCallback builds callback data for cancelling a report.
+func buildReportCancelCallback() string {
+	return callbackData{
+		Action: actionReport,
+		Params: []string{reportCancel},
+	}.encode()
+}
+
+// buildReportResolveCallback builds callback data for an admin accepting or dismissing a report.
+func buildReportResolveCallback(reportID int64, accept bool) string {
+	sub := reportDismiss
+	if accept {
+		sub = reportAccept
+	}
+	return callbackData{
+		Action: actionReport,
+		Params: []string{sub, strconv.FormatInt(reportID, 10)},
+	}.encode()
+}
+
+// buildTicketCloseCallback builds callback data for an admin closing a ticket.
+func buildTicketCloseCallback(ticketID int64) string {
+	return callbackData{
+		Action: actionTicket,
+		Params: []string{ticketClose, strconv.FormatInt(ticketID, 10)},
+	}.encode()
+}
+
+// buildSurveyAnswerCallback builds callback data for choosing an option of a survey question.
+func buildSurveyAnswerCallback(surveyID int64, question, option int) string {
+	return callbackData{
+		Action: actionSurvey,
+		Params: []string{surveyAnswer, strconv.FormatInt(surveyID, 10), strconv.Itoa(question), strconv.Itoa(option)},
+	}.encode()
+}
+
+// buildSurveySkipCallback builds callback data for skipping the rest of a survey.
+func buildSurveySkipCallback(surveyID int64) string {
+	return callbackData{
+		Action: actionSurvey,
+		Params: []string{surveySkip, strconv.FormatInt(surveyID, 10)},
+	}.encode()
+}
+
+// buildWhatsNewCallback builds callback data for subscribing to or unsubscribing from announcements.
+func buildWhatsNewCallback(subscribe bool) string {
+	sub := whatsNewUnsubscribe
+	if subscribe {
+		sub = whatsNewSubscribe
+	}
+	return callbackData{
+		Action: actionWhatsNew,
+		Params: []string{sub},
+	}.encode()
+}
+
 // buildRangeCallback builds callback data for opening a "range" page.
 func buildRangeCallback(page, from, to int) string {
 	return callbackData{
@@ -157,7 +556,15 @@ func buildQuizStartCallback() string {
 
 // buildProgressCallback builds callback data for opening the progress view.
 func buildProgressCallback() string {
-	return actionProgress
+	return callbackData{Action: actionProgress}.encode()
+}
+
+// buildProgressCalendarCallback builds callback data for opening the activity calendar.
+func buildProgressCalendarCallback() string {
+	return callbackData{
+		Action: actionProgress,
+		Params: []string{progressCalendar},
+	}.encode()
 }
 
 // buildReminderToggleCallback builds callback data for toggling reminders.
@@ -173,11 +580,35 @@ func buildReminderStartQuizCallback() string {
 	}.encode()
 }
 
-// buildReminderSnoozeCallback builds callback data for snoozing reminders.
-func buildReminderSnoozeCallback() string {
+// buildReminderSnoozeCallback builds callback data for snoozing reminders with the given option.
+func buildReminderSnoozeCallback(option entities.SnoozeOption) string {
+	return callbackData{
+		Action: actionReminder,
+		Params: []string{reminderSnooze, string(option)},
+	}.encode()
+}
+
+// buildReminderAnswerCallback builds callback data for answering the question in a reminder message.
+func buildReminderAnswerCallback(nameNumber int, questionType entities.QuestionType, chosenNumber int) string {
+	return callbackData{
+		Action: actionReminder,
+		Params: []string{reminderAnswer, strconv.Itoa(nameNumber), string(questionType), strconv.Itoa(chosenNumber)},
+	}.encode()
+}
+
+// buildReminderSelfReviewCallback builds callback data for a "remember"/"forgot" self-assessment.
+func buildReminderSelfReviewCallback(nameNumber int, remembered bool) string {
+	return callbackData{
+		Action: actionReminder,
+		Params: []string{reminderSelf, strconv.Itoa(nameNumber), strconv.FormatBool(remembered)},
+	}.encode()
+}
+
+// buildReminderOpenTodayCallback builds callback data for opening today's names from a reminder message.
+func buildReminderOpenTodayCallback() string {
 	return callbackData{
 		Action: actionReminder,
-		Params: []string{reminderSnooze},
+		Params: []string{reminderOpenToday},
 	}.encode()
 }
 
@@ -231,13 +662,23 @@ func buildOnboardingCmdCallback(cmd string) string {
 
 // buildOnboardingTimezoneCallback builds callback data for selecting timezone during onboarding.
 func buildOnboardingTimezoneCallback(tz string) string {
-	// tz: "UTC+3", "UTC-7", "UTC+5:30"
+	// tz: zone ID from entities.TimezoneID, or "manual" for city search
 	return callbackData{
 		Action: actionOnboarding,
 		Params: []string{onboardingTimezone, tz},
 	}.encode()
 }
 
+// buildOnboardingTimezoneRegionCallback builds callback data for opening a timezone region
+// during onboarding. An empty key returns to the list of regions.
+func buildOnboardingTimezoneRegionCallback(key string) string {
+	params := []string{onboardingTZRegion}
+	if key != "" {
+		params = append(params, key)
+	}
+	return callbackData{Action: actionOnboarding, Params: params}.encode()
+}
+
 // buildResetConfirmCallback builds callback data for confirming a reset action.
 func buildResetConfirmCallback() string {
 	return callbackData{Action: actionReset, Params: []string{resetConfirm}}.encode()
@@ -247,3 +688,8 @@ func buildResetConfirmCallback() string {
 func buildResetCancelCallback() string {
 	return callbackData{Action: actionReset, Params: []string{resetCancel}}.encode()
 }
+
+// buildDeleteDataCallback builds callback data for a step of the data deletion flow.
+func buildDeleteDataCallback(step string) string {
+	return callbackData{Action: actionDeleteData, Params: []string{step}}.encode()
+}
@@ -0,0 +1,253 @@
+package telegram
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// withCallbackSecret turns callback signing on for the test, since signed data is the longest.
+func withCallbackSecret(t *testing.T) {
+	t.Helper()
+	SetCallbackSecret("test-secret")
+	t.Cleanup(func() { SetCallbackSecret("") })
+}
+
+// longestRegionKey returns the longest key of the timezone catalog regions.
+func longestRegionKey() string {
+	var key string
+	for _, r := range entities.TimezoneRegions {
+		if len(r.Key) > len(key) {
+			key = r.Key
+		}
+	}
+	return key
+}
+
+func TestCallbackBuildersFitLimit(t *testing.T) {
+	withCallbackSecret(t)
+
+	const maxID = math.MaxInt64
+	lastQuietDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC).Format(quietDateLayout)
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"group settings", buildGroupSettingsCallback(groupDailyPost)},
+		{"today page", buildTodayPageCallback(98)},
+		{"today move", buildTodayMoveCallback(99, -1)},
+		{"today swap", buildTodaySwapCallback(99, 98)},
+		{"today done", buildTodayDoneCallback(99, 98)},
+		{"today reorder", buildTodayReorderCallback(todayShuffle)},
+		{"today audio", buildTodayAudioCallback(99)},
+		{"name page", buildNameCallback(98)},
+		{"name pages", buildNamePagesCallback(98)},
+		{"name index", buildNameIndexCallback()},
+		{"name stats", buildNameStatsCallback(99)},
+		{"find page", buildFindPageCallback(98)},
+		{"open name", buildOpenNameCallback(99)},
+		{"favorite toggle", buildFavoriteToggleCallback(99)},
+		{"favorites page", buildFavoritesPageCallback(98)},
+		{"favorites quiz", buildFavoritesQuizCallback()},
+		{"note edit", buildNoteEditCallback(99)},
+		{"note delete", buildNoteDeleteCallback(99)},
+		{"report start", buildReportStartCallback(99)},
+		{"report cancel", buildReportCancelCallback()},
+		{"report dismiss", buildReportResolveCallback(maxID, false)},
+		{"ticket close", buildTicketCloseCallback(maxID)},
+		{"survey answer", buildSurveyAnswerCallback(maxID, 99, 99)},
+		{"survey skip", buildSurveySkipCallback(maxID)},
+		{"whats new", buildWhatsNewCallback(false)},
+		{"range", buildRangeCallback(98, 99, 99)},
+		{"settings menu", buildSettingsCallback(settingsMenu)},
+		{"settings quiet date", buildSettingsCallback(settingsReminders, reminderQuietDate, lastQuietDate, "99")},
+		{"settings time window", buildSettingsCallback(settingsReminders, "time", "08-00-00", "22-00-00")},
+		{"settings prayer offset", buildSettingsCallback(settingsReminders, reminderPrayerOffset, "60")},
+		{"settings timezone", timezoneZoneCallback(tzFlowSettings, "America/Argentina/ComodRivadavia")},
+		{"settings timezone region", timezoneRegionCallback(tzFlowSettings, longestRegionKey())},
+		{"settings timezone search", timezoneSearchCallback(tzFlowSettings)},
+		{"quiz answer", buildQuizAnswerCallback(maxID, 99, 9)},
+		{"quiz start", buildQuizStartCallback()},
+		{"progress", buildProgressCallback()},
+		{"progress calendar", buildProgressCalendarCallback()},
+		{"reminder toggle", buildReminderToggleCallback()},
+		{"reminder start quiz", buildReminderStartQuizCallback()},
+		{"reminder snooze", buildReminderSnoozeCallback(entities.SnoozeMorning)},
+		{"reminder answer", buildReminderAnswerCallback(99, entities.QuestionTypeTransliteration, 99)},
+		{"reminder self review", buildReminderSelfReviewCallback(99, false)},
+		{"reminder open today", buildReminderOpenTodayCallback()},
+		{"reminder disable", buildReminderDisableCallback()},
+		{"onboarding step", buildOnboardingStepCallback(99)},
+		{"onboarding names", buildOnboardingNamesPerDayCallback(99)},
+		{"onboarding mode", buildOnboardingModeCallback("guided")},
+		{"onboarding reminders", buildOnboardingRemindersCallback("yes")},
+		{"onboarding cmd", buildOnboardingCmdCallback("today")},
+		{"onboarding timezone", timezoneZoneCallback(tzFlowOnboarding, "America/Argentina/ComodRivadavia")},
+		{"onboarding timezone region", timezoneRegionCallback(tzFlowOnboarding, longestRegionKey())},
+		{"onboarding timezone search", timezoneSearchCallback(tzFlowOnboarding)},
+		{"reset confirm", buildResetConfirmCallback()},
+		{"reset cancel", buildResetCancelCallback()},
+		{"delete data", buildDeleteDataCallback(deleteDataProceed)},
+	}
+
+	for _, field := range entities.ContentReportFields {
+		tests = append(tests, struct {
+			name string
+			data string
+		}{"report field " + string(field), buildReportFieldCallback(99, field)})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.data == callbackOverflow {
+				t.Fatalf("callback data over %d bytes", maxCallbackLen)
+			}
+			if len(tt.data) > maxCallbackLen {
+				t.Fatalf("len(%q) = %d, want <= %d", tt.data, len(tt.data), maxCallbackLen)
+			}
+			if err := verifyCallback(tt.data); err != nil {
+				t.Fatalf("verifyCallback(%q) = %v", tt.data, err)
+			}
+		})
+	}
+}
+
+func TestEncodeOverflow(t *testing.T) {
+	data := callbackData{Action: actionSettings, Params: []string{strings.Repeat("x", maxCallbackLen)}}.encode()
+	if data != callbackOverflow {
+		t.Fatalf("encode() = %q, want %q", data, callbackOverflow)
+	}
+	if err := verifyCallback(data); err != errStaleCallback {
+		t.Fatalf("verifyCallback(%q) = %v, want %v", data, err, errStaleCallback)
+	}
+}
+
+func TestVerifyCallbackSignature(t *testing.T) {
+	withCallbackSecret(t)
+
+	data := buildOpenNameCallback(7)
+	if err := verifyCallback(data); err != nil {
+		t.Fatalf("verifyCallback(%q) = %v", data, err)
+	}
+
+	tampered := strings.Replace(data, ":7", ":8", 1)
+	if err := verifyCallback(tampered); err != errCallbackSignature {
+		t.Fatalf("verifyCallback(%q) = %v, want %v", tampered, err, errCallbackSignature)
+	}
+
+	unsigned := callbackVersion + "." + actionOpenName + ":7"
+	if err := verifyCallback(unsigned); err != errCallbackSignature {
+		t.Fatalf("verifyCallback(%q) = %v, want %v", unsigned, err, errCallbackSignature)
+	}
+}
+
+func TestKeyboardsPassVerifyCallback(t *testing.T) {
+	withCallbackSecret(t)
+
+	names := make([]*entities.Name, 0, 99)
+	for i := 1; i <= 99; i++ {
+		names = append(names, &entities.Name{Number: i, Transliteration: "Ar-Rahman"})
+	}
+	lat, lon := 55.75, 37.62
+	anchors := entities.PrayerAnchors{Prayers: 1<<entities.Fajr | 1<<entities.Isha, OffsetMinutes: 15, Latitude: &lat, Longitude: &lon}
+	today := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	quiet := entities.QuietDays{Weekdays: 1 << time.Friday, Dates: []time.Time{today.AddDate(0, 0, 3)}}
+	question := &entities.ReminderQuestion{
+		Type:    entities.QuestionTypeTranslation,
+		Options: []entities.Name{{Number: 1}, {Number: 50}, {Number: 99}},
+	}
+	var cities []entities.TimezoneCity
+	for _, r := range entities.TimezoneRegions {
+		cities = append(cities, r.Cities...)
+	}
+	deref := func(kb *tgbotapi.InlineKeyboardMarkup) tgbotapi.InlineKeyboardMarkup {
+		if kb == nil {
+			return tgbotapi.InlineKeyboardMarkup{}
+		}
+		return *kb
+	}
+
+	keyboards := map[string]tgbotapi.InlineKeyboardMarkup{
+		"name":                     deref(buildNameKeyboard(1, 3, buildNameCallback(0), buildNameCallback(2))),
+		"all names":                deref(buildAllNamesKeyboard(5, 12)),
+		"name index":               buildNameIndexKeyboard(names),
+		"name page picker":         buildNamePagePickerKeyboard(len(names), 3),
+		"name list":                nameListKeyboard(names, 1, 3, buildFindPageCallback),
+		"progress":                 buildProgressKeyboard("https://t.me/bot?start=ref_1"),
+		"activity calendar":        buildActivityCalendarKeyboard(),
+		"settings":                 buildSettingsKeyboard(),
+		"calendar":                 buildCalendarKeyboard(),
+		"target date":              buildTargetDateKeyboard(today, true),
+		"learning mode":            buildLearningModeKeyboard(),
+		"quiz result":              buildQuizResultKeyboard(),
+		"quiz answer":              buildQuizAnswerKeyboard(math.MaxInt64, 20, []string{"a", "b", "c", "d"}),
+		"names per day":            buildNamesPerDayKeyboard(),
+		"quiz mode":                buildQuizModeKeyboard(),
+		"reminders off":            buildRemindersKeyboard(nil),
+		"reminders single":         buildRemindersKeyboard(&entities.UserReminders{IsEnabled: true, WeeklyDigest: true}),
+		"reminders digest":         buildRemindersKeyboard(&entities.UserReminders{IsEnabled: true, Style: entities.ReminderStyleDigest}),
+		"reminders prayer":         buildRemindersKeyboard(&entities.UserReminders{IsEnabled: true, Prayer: anchors}),
+		"prayer reminders":         buildPrayerRemindersKeyboard(anchors),
+		"quiet days":               buildQuietDaysKeyboard(quiet),
+		"quiet date picker":        buildQuietDatePickerKeyboard(quiet, today, maxQuietDatePickerPage),
+		"timezone confirm":         buildTimezoneConfirmKeyboard(tzFlowSettings, "Europe/Moscow"),
+		"timezone regions":         buildTimezoneRegionsKeyboard(tzFlowSettings),
+		"timezone cities":          buildTimezoneCitiesKeyboard(tzFlowSettings, cities, today),
+		"onboarding tz confirm":    buildTimezoneConfirmKeyboard(tzFlowOnboarding, "Asia/Yekaterinburg"),
+		"onboarding tz regions":    buildTimezoneRegionsKeyboard(tzFlowOnboarding),
+		"onboarding tz cities":     buildTimezoneCitiesKeyboard(tzFlowOnboarding, cities, today),
+		"streak alert":             buildStreakAlertKeyboard(),
+		"reminder":                 buildReminderKeyboard(99, question, true),
+		"daily digest":             buildDailyDigestKeyboard(),
+		"frequency":                buildFrequencyKeyboard(),
+		"time window":              buildTimeWindowKeyboard(),
+		"reset":                    deref(buildResetKeyboard()),
+		"inactivity warning":       buildInactivityWarningKeyboard(),
+		"delete data":              buildDeleteDataKeyboard(),
+		"delete data final":        buildDeleteDataFinalKeyboard(),
+		"welcome returning":        welcomeReturningKeyboard(),
+		"today cards":              deref(todayCardsKeyboard(1, 3, 99, false)),
+		"name card":                nameCardKeyboard(99, true, true),
+		"with favorite state":      withFavoriteState(nil, 99, false),
+		"report field":             reportFieldKeyboard(99),
+		"ticket admin":             ticketAdminKeyboard(math.MaxInt64),
+		"survey question":          surveyQuestionKeyboard(math.MaxInt64, 9, []string{"yes", "no"}),
+		"whats new":                whatsNewKeyboard(true, langEN),
+		"report review":            reportReviewKeyboard(math.MaxInt64),
+		"favorites":                favoritesKeyboard(names, 1, 3),
+		"group settings":           groupSettingsKeyboard(&entities.GroupChat{LanguageCode: langEN, DailyPost: true, PostTime: "09:00"}),
+		"name stats":               nameStatsKeyboard(),
+		"onboarding step 1":        onboardingStep1Keyboard(),
+		"onboarding step 2":        onboardingStep2Keyboard(),
+		"onboarding step 3":        onboardingStep3Keyboard(),
+		"onboarding step 4":        onboardingStep4Keyboard(),
+		"onboarding step timezone": onboardingStepTimezoneKeyboard(),
+		"onboarding complete":      onboardingCompleteKeyboard(),
+	}
+
+	for name, kb := range keyboards {
+		t.Run(name, func(t *testing.T) {
+			var buttons int
+			for _, row := range kb.InlineKeyboard {
+				for _, btn := range row {
+					if btn.CallbackData == nil {
+						continue
+					}
+					buttons++
+					if err := verifyCallback(*btn.CallbackData); err != nil {
+						t.Errorf("button %q: verifyCallback(%q) = %v", btn.Text, *btn.CallbackData, err)
+					}
+				}
+			}
+			if buttons == 0 {
+				t.Fatal("keyboard has no callback buttons")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// callbackDedupTTL is how long handled callback query IDs are remembered. Telegram
+// delivers a retried update within minutes, and an old query can no longer be answered.
+const callbackDedupTTL = 15 * time.Minute
+
+// seenCallbacks remembers the IDs of handled callback queries, so that a query Telegram
+// delivers twice does not record a quiz answer or a review twice or edit a message again.
+// Store errors are logged and the query is handled, as if it were new.
+type seenCallbacks struct {
+	store  StateStore
+	logger *zap.Logger
+}
+
+func newSeenCallbacks(store StateStore, logger *zap.Logger) seenCallbacks {
+	return seenCallbacks{store: store, logger: logger}
+}
+
+// checkAndMark reports whether the callback query was handled before and marks it as handled.
+func (s seenCallbacks) checkAndMark(ctx context.Context, id string) bool {
+	ctx, cancel := context.WithTimeout(ctx, stateTimeout)
+	defer cancel()
+
+	key := "cb:" + id
+
+	var seen bool
+	ok, err := s.store.Get(ctx, key, &seen)
+	if err != nil {
+		s.logger.Warn("failed to read handled callback", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	if ok {
+		return true
+	}
+
+	if err := s.store.Set(ctx, key, true, callbackDedupTTL); err != nil {
+		s.logger.Warn("failed to mark callback as handled", zap.String("key", key), zap.Error(err))
+	}
+	return false
+}
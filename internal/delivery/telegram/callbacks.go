@@ -6,21 +6,53 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
 )
 
 // handleCallback routes callback queries to appropriate handlers.
 func (h *Handler) handleCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) {
+	if err := verifyCallback(cb.Data); err != nil {
+		h.log(ctx).Warn("callback rejected",
+			zap.Int64("user_id", cb.From.ID),
+			zap.String("data", cb.Data),
+			zap.Error(err),
+		)
+		_ = h.answerCallback(cb.ID, msgStaleButton)
+		return
+	}
+
 	data := decodeCallback(cb.Data)
 
+	if cb.Message != nil && isGroupChat(cb.Message.Chat) &&
+		data.Action != actionGroup && data.Action != actionReport && data.Action != actionTicket {
+		_ = h.answerCallback(cb.ID, groupPrivateOnlyAnswer(h.groupLang(ctx, cb.Message.Chat)))
+		return
+	}
+
 	switch data.Action {
 	case actionName:
 		h.withCallbackErrorHandling(h.handleNameCallback)(ctx, cb)
+	case actionNameIndex:
+		h.withCallbackErrorHandling(h.handleNameIndexCallback)(ctx, cb)
+	case actionNamePages:
+		h.withCallbackErrorHandling(h.handleNamePagesCallback)(ctx, cb)
+	case actionNameStats:
+		h.withCallbackErrorHandling(h.handleNameStatsCallback)(ctx, cb)
+	case actionFind:
+		h.withCallbackErrorHandling(h.handleFindCallback)(ctx, cb)
+	case actionFavorite:
+		h.withCallbackErrorHandling(h.handleFavoriteCallback)(ctx, cb)
+	case actionNote:
+		h.withCallbackErrorHandling(h.handleNoteCallback)(ctx, cb)
+	case actionOpenName:
+		h.withCallbackErrorHandling(h.handleOpenNameCallback)(ctx, cb)
 	case actionToday:
 		h.withCallbackErrorHandling(h.handleTodayCallback)(ctx, cb)
 	case actionRange:
@@ -37,8 +69,20 @@ func (h *Handler) handleCallback(ctx context.Context, cb *tgbotapi.CallbackQuery
 		h.withCallbackErrorHandling(h.handleOnboardingCallback)(ctx, cb)
 	case actionReset:
 		h.withCallbackErrorHandling(h.handleResetCallback)(ctx, cb)
+	case actionDeleteData:
+		h.withCallbackErrorHandling(h.handleDeleteDataCallback)(ctx, cb)
+	case actionGroup:
+		h.withCallbackErrorHandling(h.handleGroupCallback)(ctx, cb)
+	case actionReport:
+		h.withCallbackErrorHandling(h.handleReportCallback)(ctx, cb)
+	case actionTicket:
+		h.withCallbackErrorHandling(h.handleTicketCallback)(ctx, cb)
+	case actionSurvey:
+		h.withCallbackErrorHandling(h.handleSurveyCallback)(ctx, cb)
+	case actionWhatsNew:
+		h.withCallbackErrorHandling(h.handleWhatsNewCallback)(ctx, cb)
 	default:
-		h.logger.Warn("unknown callback action",
+		h.log(ctx).Warn("unknown callback action",
 			zap.String("action", data.Action),
 			zap.String("raw", data.Raw),
 		)
@@ -47,7 +91,7 @@ func (h *Handler) handleCallback(ctx context.Context, cb *tgbotapi.CallbackQuery
 	// Remove the user's "loading clock".
 	answer := tgbotapi.NewCallback(cb.ID, "")
 	if _, err := h.bot.Request(answer); err != nil {
-		h.logger.Error("callback answer error",
+		h.log(ctx).Error("callback answer error",
 			zap.Error(err),
 			zap.String("data", cb.Data),
 		)
@@ -62,13 +106,13 @@ func (h *Handler) handleNameCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 
 	data := decodeCallback(cb.Data)
 	if len(data.Params) != 1 {
-		h.logger.Warn("invalid name callback params", zap.String("raw", data.Raw))
+		h.log(ctx).Warn("invalid name callback params", zap.String("raw", data.Raw))
 		return nil
 	}
 
 	page, err := strconv.Atoi(data.Params[0])
 	if err != nil || page < 0 {
-		h.logger.Warn("invalid page in callback",
+		h.log(ctx).Warn("invalid page in callback",
 			zap.String("data", cb.Data),
 			zap.Error(err),
 		)
@@ -87,16 +131,14 @@ func (h *Handler) handleNameCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 
 	text, totalPages := buildNamesPage(names, page)
 	if totalPages == 0 || page >= totalPages {
-		h.logger.Warn("page out of range",
+		h.log(ctx).Warn("page out of range",
 			zap.Int("page", page),
 			zap.Int("total_pages", totalPages),
 		)
 		return nil
 	}
 
-	prevData := fmt.Sprintf("name:%d", page-1)
-	nextData := fmt.Sprintf("name:%d", page+1)
-	kb := buildNameKeyboard(page, totalPages, prevData, nextData)
+	kb := buildAllNamesKeyboard(page, totalPages)
 
 	edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, text)
 	if kb != nil {
@@ -106,6 +148,277 @@ func (h *Handler) handleNameCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 	return h.send(edit)
 }
 
+// handleNamePagesCallback shows the page picker for the names list.
+func (h *Handler) handleNamePagesCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 1 {
+		h.log(ctx).Warn("invalid name pages callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	currentPage, err := strconv.Atoi(data.Params[0])
+	if err != nil || currentPage < 0 {
+		currentPage = 0
+	}
+
+	names, err := h.getAllNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	kb := buildNamePagePickerKeyboard(len(names), currentPage)
+	edit := tgbotapi.NewEditMessageReplyMarkup(cb.Message.Chat.ID, cb.Message.MessageID, kb)
+
+	return h.send(edit)
+}
+
+// handleNameIndexCallback switches the names list message to the compact index view.
+func (h *Handler) handleNameIndexCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	names, err := h.getAllNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	if names == nil {
+		return h.send(newPlainMessage(cb.Message.Chat.ID, msgNameUnavailable))
+	}
+
+	edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, buildNameIndexText(len(names)))
+	kb := buildNameIndexKeyboard(names)
+	edit.ReplyMarkup = &kb
+
+	return h.send(edit)
+}
+
+// handleFindCallback handles pagination for /find results.
+func (h *Handler) handleFindCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 1 {
+		h.log(ctx).Warn("invalid find callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	page, err := strconv.Atoi(data.Params[0])
+	if err != nil || page < 0 {
+		h.log(ctx).Warn("invalid page in find callback", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	chatID := cb.Message.Chat.ID
+	query, ok := h.findQueries.get(ctx, cb.From.ID)
+	if !ok {
+		return h.send(newPlainMessage(chatID, msgFindExpired))
+	}
+
+	names, err := h.nameService.Search(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	totalPages := (len(names) + findPerPage - 1) / findPerPage
+	if totalPages == 0 || page >= totalPages {
+		h.log(ctx).Warn("find page out of range",
+			zap.Int("page", page),
+			zap.Int("total_pages", totalPages),
+		)
+		return nil
+	}
+
+	edit := newEdit(chatID, cb.Message.MessageID, formatFindResults(query, names, page, totalPages))
+	kb := nameListKeyboard(names, page, totalPages, buildFindPageCallback)
+	edit.ReplyMarkup = &kb
+
+	return h.send(edit)
+}
+
+// handleFavoriteCallback handles favorite toggling, favorites list pagination and favorites quiz.
+func (h *Handler) handleFavoriteCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) == 0 {
+		h.log(ctx).Warn("invalid favorite callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	userID := cb.From.ID
+	chatID := cb.Message.Chat.ID
+
+	switch data.Params[0] {
+	case favoriteToggle:
+		if len(data.Params) != 2 {
+			return nil
+		}
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil || nameNumber < 1 || nameNumber > 99 {
+			h.log(ctx).Warn("invalid name number in favorite callback", zap.String("raw", data.Raw))
+			return nil
+		}
+
+		isFavorite, err := h.favoritesService.Toggle(ctx, userID, nameNumber)
+		if err != nil {
+			return err
+		}
+
+		kb := withFavoriteState(cb.Message.ReplyMarkup, nameNumber, isFavorite)
+		return h.send(tgbotapi.NewEditMessageReplyMarkup(chatID, cb.Message.MessageID, kb))
+
+	case favoritePage:
+		if len(data.Params) != 2 {
+			return nil
+		}
+		page, err := strconv.Atoi(data.Params[1])
+		if err != nil || page < 0 {
+			return nil
+		}
+		return h.editFavoritesPage(ctx, cb, page)
+
+	case favoriteQuiz:
+		return h.handleQuizWithMode(userID, "favorites")(ctx, chatID)
+
+	default:
+		h.log(ctx).Warn("unknown favorite sub-action", zap.String("raw", data.Raw))
+		return nil
+	}
+}
+
+// editFavoritesPage re-renders the favorites list message on the given page.
+func (h *Handler) editFavoritesPage(ctx context.Context, cb *tgbotapi.CallbackQuery, page int) error {
+	names, err := h.favoritesService.List(ctx, cb.From.ID)
+	if err != nil {
+		return err
+	}
+
+	chatID := cb.Message.Chat.ID
+	if len(names) == 0 {
+		return h.send(newEdit(chatID, cb.Message.MessageID, md(msgNoFavorites)))
+	}
+
+	totalPages := (len(names) + findPerPage - 1) / findPerPage
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+
+	edit := newEdit(chatID, cb.Message.MessageID, formatFavoritesList(names, page, totalPages))
+	kb := favoritesKeyboard(names, page, totalPages)
+	edit.ReplyMarkup = &kb
+
+	return h.send(edit)
+}
+
+// handleNoteCallback handles adding, editing and deleting personal notes on name cards.
+func (h *Handler) handleNoteCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 2 {
+		h.log(ctx).Warn("invalid note callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	nameNumber, err := strconv.Atoi(data.Params[1])
+	if err != nil || nameNumber < 1 || nameNumber > 99 {
+		h.log(ctx).Warn("invalid name number in note callback", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	userID := cb.From.ID
+	chatID := cb.Message.Chat.ID
+
+	switch data.Params[0] {
+	case noteEdit:
+		name, err := h.nameService.GetByNumber(ctx, nameNumber)
+		if err != nil {
+			return err
+		}
+
+		prompt := newPlainMessage(chatID, formatNotePrompt(name))
+		prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+
+		sent, err := h.bot.Send(prompt)
+		if err != nil {
+			return err
+		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+		h.setNoteWaitState(ctx, userID, noteWaitState{
+			ChatID:          chatID,
+			NameNumber:      nameNumber,
+			CardMessageID:   cb.Message.MessageID,
+			PromptMessageID: sent.MessageID,
+		})
+		return nil
+
+	case noteDelete:
+		if err := h.notesService.Delete(ctx, userID, nameNumber); err != nil {
+			return err
+		}
+		if err := h.refreshNameCard(ctx, userID, chatID, cb.Message.MessageID, nameNumber); err != nil {
+			return err
+		}
+		return h.send(newPlainMessage(chatID, msgNoteDeleted))
+
+	default:
+		h.log(ctx).Warn("unknown note sub-action", zap.String("raw", data.Raw))
+		return nil
+	}
+}
+
+// handleOpenNameCallback opens a single name card from a list button.
+func (h *Handler) handleOpenNameCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 1 {
+		h.log(ctx).Warn("invalid open name callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	return h.handleNumber(cb.From.ID, data.Params[0])(ctx, cb.Message.Chat.ID)
+}
+
+// handleNameStatsCallback shows personal statistics for a name.
+func (h *Handler) handleNameStatsCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 1 {
+		h.log(ctx).Warn("invalid name stats callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	nameNumber, err := strconv.Atoi(data.Params[0])
+	if err != nil {
+		h.log(ctx).Warn("invalid name number in callback",
+			zap.String("data", cb.Data),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return h.handleNameStats(cb.From.ID, nameNumber)(ctx, cb.Message.Chat.ID)
+}
+
 // handleTodayCallback handles callbacks for the "today" flow.
 func (h *Handler) handleTodayCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 	if cb.Message == nil {
@@ -152,6 +465,110 @@ func (h *Handler) handleTodayCallback(ctx context.Context, cb *tgbotapi.Callback
 
 		return h.answerCallback(cb.ID, "🔊")
 
+	case todayMove:
+		if len(data.Params) < 3 {
+			return nil
+		}
+
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil {
+			return nil
+		}
+		delta, err := strconv.Atoi(data.Params[2])
+		if err != nil {
+			return nil
+		}
+
+		settings, err := h.settingsService.GetOrCreate(ctx, userID)
+		if err != nil {
+			return h.answerCallback(cb.ID, msgSettingsUnavailable)
+		}
+
+		page, err := h.dailyNameService.MoveTodayName(ctx, userID, settings.Timezone, nameNumber, delta)
+		if err != nil {
+			if errors.Is(err, service.ErrNameNotPlanned) {
+				// The plan changed since the card was rendered; show it afresh.
+				return h.handleTodayPage(userID)(ctx, chatID, messageID, 0)
+			}
+			return err
+		}
+		return h.handleTodayPage(userID)(ctx, chatID, messageID, page)
+
+	case todayDone:
+		if len(data.Params) < 3 {
+			return nil
+		}
+
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil {
+			return nil
+		}
+		page, _ := strconv.Atoi(data.Params[2])
+
+		settings, err := h.settingsService.GetOrCreate(ctx, userID)
+		if err != nil {
+			return h.answerCallback(cb.ID, msgSettingsUnavailable)
+		}
+
+		marked, err := h.dailyNameService.MarkTodayStudied(ctx, userID, settings.Timezone, nameNumber)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			return h.answerCallback(cb.ID, "Уже отмечено сегодня")
+		}
+
+		// Move on to the next card so the checklist can be worked through in a row.
+		_ = h.answerCallback(cb.ID, "✅ Отмечено")
+		return h.handleTodayPage(userID)(ctx, chatID, messageID, page+1)
+
+	case todaySwap:
+		if len(data.Params) < 3 {
+			return nil
+		}
+
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil {
+			return nil
+		}
+		page, _ := strconv.Atoi(data.Params[2])
+
+		settings, err := h.settingsService.GetOrCreate(ctx, userID)
+		if err != nil {
+			return h.answerCallback(cb.ID, msgSettingsUnavailable)
+		}
+
+		if _, err := h.dailyNameService.SwapTodayName(ctx, userID, settings.Timezone, nameNumber); err != nil {
+			switch {
+			case errors.Is(err, service.ErrNoNameToSwap):
+				return h.answerCallback(cb.ID, "Все новые имена уже в плане — заменить не на что")
+			case errors.Is(err, service.ErrNameNotPlanned):
+				return h.handleTodayPage(userID)(ctx, chatID, messageID, 0)
+			default:
+				return err
+			}
+		}
+
+		_ = h.answerCallback(cb.ID, "🔁 Имя заменено, прежнее вернётся в план позже")
+		return h.handleTodayPage(userID)(ctx, chatID, messageID, page)
+
+	case todayShuffle, todaySort:
+		settings, err := h.settingsService.GetOrCreate(ctx, userID)
+		if err != nil {
+			return h.answerCallback(cb.ID, msgSettingsUnavailable)
+		}
+
+		reorder, confirm := h.dailyNameService.SortToday, "🔢 По номерам"
+		if data.Params[0] == todayShuffle {
+			reorder, confirm = h.dailyNameService.ShuffleToday, "🔀 Перемешано"
+		}
+		if err := reorder(ctx, userID, settings.Timezone); err != nil {
+			return err
+		}
+
+		_ = h.answerCallback(cb.ID, confirm)
+		return h.handleTodayPage(userID)(ctx, chatID, messageID, 0)
+
 	default:
 		return nil
 	}
@@ -165,7 +582,7 @@ func (h *Handler) handleRangeCallback(ctx context.Context, cb *tgbotapi.Callback
 
 	data := decodeCallback(cb.Data)
 	if len(data.Params) != 3 {
-		h.logger.Warn("invalid range callback params", zap.String("raw", data.Raw))
+		h.log(ctx).Warn("invalid range callback params", zap.String("raw", data.Raw))
 		return nil
 	}
 
@@ -174,7 +591,7 @@ func (h *Handler) handleRangeCallback(ctx context.Context, cb *tgbotapi.Callback
 	to, err3 := strconv.Atoi(data.Params[2])
 
 	if err1 != nil || err2 != nil || err3 != nil || page < 0 || from < 1 || to > 99 || from > to {
-		h.logger.Warn("invalid range callback values",
+		h.log(ctx).Warn("invalid range callback values",
 			zap.String("data", cb.Data),
 			zap.Errors("errors", []error{err1, err2, err3}),
 		)
@@ -194,7 +611,7 @@ func (h *Handler) handleRangeCallback(ctx context.Context, cb *tgbotapi.Callback
 	pages := buildRangePages(names, from, to)
 	totalPages := len(pages)
 	if totalPages == 0 || page >= totalPages {
-		h.logger.Warn("range page out of range",
+		h.log(ctx).Warn("range page out of range",
 			zap.Int("page", page),
 			zap.Int("total_pages", totalPages),
 			zap.Int("from", from),
@@ -224,7 +641,7 @@ func (h *Handler) handleSettingsCallback(ctx context.Context, cb *tgbotapi.Callb
 
 	data := decodeCallback(cb.Data)
 	if len(data.Params) < 1 {
-		h.logger.Warn("invalid settings callback", zap.String("raw", data.Raw))
+		h.log(ctx).Warn("invalid settings callback", zap.String("raw", data.Raw))
 		return nil
 	}
 
@@ -236,6 +653,11 @@ func (h *Handler) handleSettingsCallback(ctx context.Context, cb *tgbotapi.Callb
 
 	value := data.Params[1]
 
+	h.analyticsService.Track(ctx, cb.From.ID, entities.EventSettingChanged, map[string]any{
+		"setting": subAction,
+		"value":   value,
+	})
+
 	if subAction == settingsReminders {
 		return h.applyReminderSetting(ctx, cb, value, data.Params)
 	}
@@ -266,8 +688,16 @@ func (h *Handler) handleSettingsNavigation(ctx context.Context, cb *tgbotapi.Cal
 	case settingsReminders:
 		return h.showReminderSettings(ctx, cb)
 
+	case settingsCalendar:
+		msg := "🌙 " + bold("Календарь") + "\n\n" +
+			md("По какому календарю считать месяцы в /report. Дата по хиджре всегда показывается в /today и в недельной сводке.")
+		return h.showSettingsSubmenu(cb, msg, buildCalendarKeyboard())
+
+	case settingsTargetDate:
+		return h.showTargetDateSettings(ctx, cb)
+
 	default:
-		h.logger.Warn("unknown settings sub-action", zap.String("sub_action", subAction))
+		h.log(ctx).Warn("unknown settings sub-action", zap.String("sub_action", subAction))
 		return nil
 	}
 }
@@ -281,8 +711,12 @@ func (h *Handler) applySettingValue(ctx context.Context, cb *tgbotapi.CallbackQu
 		return h.applyNamesPerDay(ctx, cb, value)
 	case settingsQuizMode:
 		return h.applyQuizMode(ctx, cb, value)
+	case settingsCalendar:
+		return h.applyCalendar(ctx, cb, value)
+	case settingsTargetDate:
+		return h.applyTargetDate(ctx, cb, value)
 	default:
-		h.logger.Warn("unknown settings sub-action with value", zap.String("sub_action", subAction))
+		h.log(ctx).Warn("unknown settings sub-action with value", zap.String("sub_action", subAction))
 		return nil
 	}
 }
@@ -290,7 +724,7 @@ func (h *Handler) applySettingValue(ctx context.Context, cb *tgbotapi.CallbackQu
 // applyLearningMode validates and applies a learning mode change from callback data.
 func (h *Handler) applyLearningMode(ctx context.Context, cb *tgbotapi.CallbackQuery, value string) error {
 	if value != "guided" && value != "free" {
-		h.logger.Warn("invalid learning_mode value", zap.String("value", value))
+		h.log(ctx).Warn("invalid learning_mode value", zap.String("value", value))
 		return nil
 	}
 
@@ -330,13 +764,13 @@ func (h *Handler) showSettingsSubmenu(cb *tgbotapi.CallbackQuery, message string
 func (h *Handler) showReminderSettings(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 	reminder, err := h.reminderService.GetByUserID(ctx, cb.From.ID)
 	if err != nil {
-		msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+		msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 		return h.send(msg)
 	}
 
 	settings, err := h.settingsService.GetOrCreate(ctx, cb.From.ID)
 	if err != nil {
-		msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+		msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 		return h.send(msg)
 	}
 
@@ -352,7 +786,7 @@ func (h *Handler) showReminderSettings(ctx context.Context, cb *tgbotapi.Callbac
 func (h *Handler) applyNamesPerDay(ctx context.Context, cb *tgbotapi.CallbackQuery, value string) error {
 	v, err := strconv.Atoi(value)
 	if err != nil || v < 1 || v > 20 {
-		h.logger.Warn("invalid names_per_day value",
+		h.log(ctx).Warn("invalid names_per_day value",
 			zap.String("value", value),
 			zap.Error(err),
 		)
@@ -367,7 +801,31 @@ func (h *Handler) applyNamesPerDay(ctx context.Context, cb *tgbotapi.CallbackQue
 		return err
 	}
 
-	return h.confirmSettingAndShowMenu(ctx, cb, fmt.Sprintf("Имён в день: %d", v))
+	return h.confirmSettingAndShowMenu(ctx, cb, withReplanNote(fmt.Sprintf("Имён в день: %d", v), h.replanToday(ctx, cb.From.ID)))
+}
+
+// replanToday applies the current names-per-day quota to today's plan and returns
+// a note about the change, or "" if today's plan is unaffected.
+func (h *Handler) replanToday(ctx context.Context, userID int64) string {
+	settings, err := h.settingsService.GetOrCreate(ctx, userID)
+	if err != nil {
+		h.log(ctx).Warn("failed to get settings for replanning",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	replan, err := h.dailyNameService.ReplanToday(ctx, userID, settings.Timezone, settings.NamesPerDay)
+	if err != nil {
+		h.log(ctx).Warn("failed to replan today",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	return formatTodayReplan(replan)
 }
 
 // applyQuizMode updates quiz mode setting.
@@ -380,7 +838,88 @@ func (h *Handler) applyQuizMode(ctx context.Context, cb *tgbotapi.CallbackQuery,
 		return err
 	}
 
-	return h.confirmSettingAndShowMenu(ctx, cb, fmt.Sprintf("Режим квиза: %s", formatQuizMode(value)))
+	return h.confirmSettingAndShowMenu(ctx, cb, fmt.Sprintf("Режим квиза: %s", formatQuizMode(value)))
+}
+
+// applyCalendar updates the calendar setting.
+func (h *Handler) applyCalendar(ctx context.Context, cb *tgbotapi.CallbackQuery, value string) error {
+	calendar := entities.Calendar(value)
+	if !calendar.IsValid() {
+		h.log(ctx).Warn("invalid calendar value", zap.String("value", value))
+		return nil
+	}
+
+	if err := h.settingsService.UpdateCalendar(ctx, cb.From.ID, calendar); err != nil {
+		if errors.Is(err, repository.ErrSettingsNotFound) {
+			msg := newPlainMessage(cb.Message.Chat.ID, msgSettingsUnavailable)
+			return h.send(msg)
+		}
+		return err
+	}
+
+	return h.confirmSettingAndShowMenu(ctx, cb, fmt.Sprintf("Календарь: %s", formatCalendar(calendar)))
+}
+
+// showTargetDateSettings displays the finish-by-date screen.
+func (h *Handler) showTargetDateSettings(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	settings, err := h.settingsService.GetOrCreate(ctx, cb.From.ID)
+	if err != nil {
+		msg := newPlainMessage(cb.Message.Chat.ID, msgSettingsUnavailable)
+		return h.send(msg)
+	}
+
+	text := buildTargetDateMessage(settings)
+	keyboard := buildTargetDateKeyboard(entities.RamadanEnd(userToday(settings.Timezone)), settings.TargetDate != nil)
+	return h.showSettingsSubmenu(cb, text, keyboard)
+}
+
+// applyTargetDate sets or clears the finish-by-date target from a preset.
+func (h *Handler) applyTargetDate(ctx context.Context, cb *tgbotapi.CallbackQuery, value string) error {
+	settings, err := h.settingsService.GetOrCreate(ctx, cb.From.ID)
+	if err != nil {
+		msg := newPlainMessage(cb.Message.Chat.ID, msgSettingsUnavailable)
+		return h.send(msg)
+	}
+	today := userToday(settings.Timezone)
+
+	var date *time.Time
+	switch value {
+	case targetDateOff:
+	case targetDateRamadan:
+		end := entities.RamadanEnd(today)
+		date = &end
+	default:
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 || days > 365 {
+			h.log(ctx).Warn("invalid target_date value", zap.String("value", value))
+			return nil
+		}
+		end := today.AddDate(0, 0, days-1)
+		date = &end
+	}
+
+	if err := h.settingsService.SetTargetDate(ctx, cb.From.ID, date); err != nil {
+		if errors.Is(err, repository.ErrSettingsNotFound) {
+			msg := newPlainMessage(cb.Message.Chat.ID, msgSettingsUnavailable)
+			return h.send(msg)
+		}
+		return err
+	}
+
+	note := h.replanToday(ctx, cb.From.ID)
+	if date == nil {
+		return h.confirmSettingAndShowMenu(ctx, cb, withReplanNote("Дата завершения убрана", note))
+	}
+	return h.confirmSettingAndShowMenu(ctx, cb, withReplanNote("Все имена к "+date.Format("02.01.2006"), note))
+}
+
+// userToday returns the user's current local calendar date, falling back to UTC for an invalid timezone.
+func userToday(tz string) time.Time {
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+	return entities.LocalDay(time.Now(), loc)
 }
 
 // handleReminderCallback handles reminder action callbacks.
@@ -395,37 +934,127 @@ func (h *Handler) handleReminderCallback(ctx context.Context, cb *tgbotapi.Callb
 	userID := cb.From.ID
 	chatID := cb.Message.Chat.ID
 
+	h.analyticsService.Track(ctx, userID, entities.EventReminderClicked, map[string]any{
+		"action": action,
+	})
+
 	switch action {
 	case reminderStartQuiz:
 		answer := tgbotapi.NewCallback(cb.ID, "Запускаю квиз...")
 		if _, err := h.bot.Request(answer); err != nil {
-			h.logger.Error("failed to answer callback", zap.Error(err))
+			h.log(ctx).Error("failed to answer callback", zap.Error(err))
 		}
 
 		deleteMsg := tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID)
 		if _, err := h.bot.Request(deleteMsg); err != nil {
-			h.logger.Error("failed to delete message", zap.Error(err))
+			h.log(ctx).Error("failed to delete message", zap.Error(err))
 		}
 
 		return h.handleQuiz(userID)(ctx, chatID)
 
 	case reminderSnooze:
-		if err := h.reminderService.SnoozeReminder(ctx, userID); err != nil {
+		// Reminder messages sent before snooze options existed carry no option.
+		option := entities.SnoozeOneHour
+		if len(data.Params) > 1 {
+			option = entities.SnoozeOption(data.Params[1])
+		}
+
+		next, err := h.reminderService.SnoozeReminder(ctx, userID, option)
+		if err != nil {
 			return err
 		}
 
-		answer := tgbotapi.NewCallback(cb.ID, "⏰ Напомню позже")
+		answer := tgbotapi.NewCallback(cb.ID, formatSnoozeAnswer(next))
 		if _, err := h.bot.Request(answer); err != nil {
-			h.logger.Error("failed to answer callback", zap.Error(err))
+			h.log(ctx).Error("failed to answer callback", zap.Error(err))
 		}
 
 		deleteMsg := tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID)
 		if _, err := h.bot.Request(deleteMsg); err != nil {
-			h.logger.Error("failed to delete message", zap.Error(err))
+			h.log(ctx).Error("failed to delete message", zap.Error(err))
 		}
 
 		return nil
 
+	case reminderAnswer:
+		// params: ["ans", nameNumber, questionType, chosenNumber]
+		if len(data.Params) < 4 {
+			return fmt.Errorf("invalid reminder answer params: %v", data.Params)
+		}
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil {
+			return fmt.Errorf("invalid name number: %w", err)
+		}
+		chosenNumber, err := strconv.Atoi(data.Params[3])
+		if err != nil {
+			return fmt.Errorf("invalid chosen name number: %w", err)
+		}
+
+		result, err := h.quizService.AnswerReminderQuestion(ctx, userID, nameNumber, entities.QuestionType(data.Params[2]), chosenNumber)
+		if err != nil {
+			return err
+		}
+
+		name, err := h.nameService.GetByNumber(ctx, nameNumber)
+		if err != nil {
+			return err
+		}
+
+		// Reveal the name card under the feedback and keep the regular reminder actions.
+		text := formatAnswerFeedback(result.IsCorrect, result.CorrectAnswer, result.XPGained) +
+			"\n\n" + formatNameMessage(name)
+		keyboard := buildReminderKeyboard(nameNumber, nil, false)
+
+		edit := newEdit(chatID, cb.Message.MessageID, text)
+		edit.ReplyMarkup = &keyboard
+		if err := h.send(edit); err != nil {
+			h.log(ctx).Error("failed to show reminder answer", zap.Error(err))
+		}
+
+		h.sendAnswerRewards(chatID, result)
+
+		return h.answerCallback(cb.ID, "")
+
+	case reminderSelf:
+		// params: ["self", nameNumber, "true"|"false"]
+		if len(data.Params) < 3 {
+			return fmt.Errorf("invalid self review params: %v", data.Params)
+		}
+		nameNumber, err := strconv.Atoi(data.Params[1])
+		if err != nil {
+			return fmt.Errorf("invalid name number: %w", err)
+		}
+		remembered := data.Params[2] == "true"
+
+		progress, err := h.progressService.RecordSelfReview(ctx, userID, nameNumber, remembered)
+		if err != nil {
+			return err
+		}
+
+		name, err := h.nameService.GetByNumber(ctx, nameNumber)
+		if err != nil {
+			return err
+		}
+
+		text := formatSelfReviewFeedback(remembered, progress) + "\n\n" + formatNameMessage(name)
+		keyboard := buildReminderKeyboard(nameNumber, nil, false)
+
+		edit := newEdit(chatID, cb.Message.MessageID, text)
+		edit.ReplyMarkup = &keyboard
+		if err := h.send(edit); err != nil {
+			h.log(ctx).Error("failed to show self review", zap.Error(err))
+		}
+
+		return h.answerCallback(cb.ID, "")
+
+	case reminderOpenToday:
+		answer := tgbotapi.NewCallback(cb.ID, "")
+		if _, err := h.bot.Request(answer); err != nil {
+			h.log(ctx).Error("failed to answer callback", zap.Error(err))
+		}
+
+		return h.handleToday(userID)(ctx, chatID)
+
 	case reminderDisable:
 		if err := h.reminderService.DisableReminder(ctx, userID); err != nil {
 			return err
@@ -433,12 +1062,12 @@ func (h *Handler) handleReminderCallback(ctx context.Context, cb *tgbotapi.Callb
 
 		answer := tgbotapi.NewCallback(cb.ID, "🔕 Напоминания выключены")
 		if _, err := h.bot.Request(answer); err != nil {
-			h.logger.Error("failed to answer callback", zap.Error(err))
+			h.log(ctx).Error("failed to answer callback", zap.Error(err))
 		}
 
 		deleteMsg := tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID)
 		if _, err := h.bot.Request(deleteMsg); err != nil {
-			h.logger.Error("failed to delete message", zap.Error(err))
+			h.log(ctx).Error("failed to delete message", zap.Error(err))
 		}
 
 		return nil
@@ -455,14 +1084,159 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 	switch value {
 	case reminderToggle:
 		if err := h.reminderService.ToggleReminder(ctx, userID); err != nil {
-			msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+		return h.showReminderSettings(ctx, cb)
+
+	case reminderDigest:
+		if err := h.reminderService.ToggleWeeklyDigest(ctx, userID); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 			return h.send(msg)
 		}
 		return h.showReminderSettings(ctx, cb)
 
+	case reminderStyle:
+		// params: [settingsReminders, "style", "digest"]
+		if len(params) < 3 {
+			return nil
+		}
+		style := entities.ReminderStyle(params[2])
+
+		if err := h.reminderService.SetReminderStyle(ctx, userID, style); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+
+		confirmText := "📨 Формат: по одному имени"
+		if style == entities.ReminderStyleDigest {
+			confirmText = "📨 Формат: сводка на день"
+		}
+		return h.confirmSettingAndShowReminderSettings(ctx, cb, confirmText)
+
+	case reminderContent:
+		// params: [settingsReminders, "content", "review"]
+		if len(params) < 3 {
+			return nil
+		}
+		content := entities.ReminderContent(params[2])
+
+		if err := h.reminderService.SetReminderContent(ctx, userID, content); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+
+		return h.confirmSettingAndShowReminderSettings(ctx, cb, "🧩 Содержание: "+formatReminderContent(content))
+
 	case "frequency":
 		return h.showFrequencyMenu(ctx, cb)
 
+	case reminderQuiet:
+		return h.showQuietDays(ctx, cb)
+
+	case reminderQuietWeekday:
+		// params: [settingsReminders, "quiet_wd", "1"]
+		if len(params) < 3 {
+			return nil
+		}
+		wd, err := strconv.Atoi(params[2])
+		if err != nil || wd < 0 || wd > 6 {
+			h.log(ctx).Warn("invalid quiet weekday", zap.Strings("params", params))
+			return nil
+		}
+
+		if err := h.reminderService.ToggleQuietWeekday(ctx, userID, time.Weekday(wd)); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+		return h.showQuietDays(ctx, cb)
+
+	case reminderQuietDate:
+		// params: [settingsReminders, "quiet_date", "20260315"] or with a picker page appended
+		if len(params) < 3 {
+			return nil
+		}
+		date, err := time.Parse(quietDateLayout, params[2])
+		if err != nil {
+			h.log(ctx).Warn("invalid quiet date", zap.Strings("params", params))
+			return nil
+		}
+
+		if err := h.reminderService.ToggleQuietDate(ctx, userID, date); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+
+		if len(params) >= 4 {
+			page, _ := strconv.Atoi(params[3])
+			return h.showQuietDatePicker(ctx, cb, page)
+		}
+		return h.showQuietDays(ctx, cb)
+
+	case reminderQuietAdd:
+		page := 0
+		if len(params) >= 3 {
+			page, _ = strconv.Atoi(params[2])
+		}
+		return h.showQuietDatePicker(ctx, cb, page)
+
+	case reminderPrayer:
+		return h.showPrayerReminders(ctx, cb)
+
+	case reminderPrayerToggle:
+		// params: [settingsReminders, "prayer_p", "3"]
+		if len(params) < 3 {
+			return nil
+		}
+		p, err := strconv.Atoi(params[2])
+		if err != nil || p < int(entities.Fajr) || p > int(entities.Isha) {
+			h.log(ctx).Warn("invalid prayer", zap.Strings("params", params))
+			return nil
+		}
+
+		if err := h.reminderService.TogglePrayerAnchor(ctx, userID, entities.Prayer(p)); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+		return h.showPrayerReminders(ctx, cb)
+
+	case reminderPrayerOffset:
+		// params: [settingsReminders, "prayer_off", "30"]
+		if len(params) < 3 {
+			return nil
+		}
+		minutes, err := strconv.Atoi(params[2])
+		if err != nil {
+			h.log(ctx).Warn("invalid prayer offset", zap.Strings("params", params))
+			return nil
+		}
+
+		if err := h.reminderService.SetPrayerOffset(ctx, userID, minutes); err != nil {
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+			return h.send(msg)
+		}
+		return h.showPrayerReminders(ctx, cb)
+
+	case reminderPrayerLocation:
+		chatID := cb.Message.Chat.ID
+
+		prompt := newPlainMessage(chatID, msgPrayerLocationPrompt)
+		prompt.ReplyMarkup = buildLocationRequestKeyboard()
+
+		sent, err := h.bot.Send(prompt)
+		if err != nil {
+			return err
+		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+		h.setLocationWaitState(ctx, userID, locationWaitState{
+			ChatID:          chatID,
+			OwnerMessageID:  cb.Message.MessageID,
+			PromptMessageID: sent.MessageID,
+		})
+
+		return h.answerCallback(cb.ID, "")
+
 	case "time":
 		if len(params) < 4 {
 			return h.showTimeWindowMenu(ctx, cb)
@@ -472,7 +1246,7 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 		endTime := strings.ReplaceAll(params[3], "-", ":")
 
 		if err := h.reminderService.SetReminderTimeWindow(ctx, userID, startTime, endTime); err != nil {
-			msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 			return h.send(msg)
 		}
 
@@ -481,7 +1255,7 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 
 	case "freq":
 		if len(params) < 3 {
-			h.logger.Warn("invalid frequency params", zap.Strings("params", params))
+			h.log(ctx).Warn("invalid frequency params", zap.Strings("params", params))
 			return nil
 		}
 
@@ -492,7 +1266,7 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 		}
 
 		if err := h.reminderService.SetReminderIntervalHours(ctx, userID, interval); err != nil {
-			msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 			return h.send(msg)
 		}
 
@@ -506,25 +1280,42 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 		}
 
 		text := buildTimezoneMenuMessage(tz)
-		kb := buildTimezoneKeyboard()
+		kb := buildTimezoneRegionsKeyboard(tzFlowSettings)
 
 		edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, text)
 		edit.ReplyMarkup = &kb
 		return h.send(edit)
 
+	case "tz_region":
+		// params: [settingsReminders, "tz_region", "ru"]
+		if len(params) < 3 {
+			return nil
+		}
+		region, ok := entities.FindTimezoneRegion(params[2])
+		if !ok {
+			return nil
+		}
+
+		kb := buildTimezoneCitiesKeyboard(tzFlowSettings, region.Cities, time.Now())
+		return h.send(tgbotapi.NewEditMessageReplyMarkup(cb.Message.Chat.ID, cb.Message.MessageID, kb))
+
 	case "tz":
-		// params: [settingsReminders, "tz", "UTC+3"]
+		// params: [settingsReminders, "tz", <zone ID>]
 		if len(params) < 3 {
 			return nil
 		}
-		tz := params[2]
+		tz, ok := h.resolveTimezoneID(ctx, userID, params[2])
+		if !ok {
+			return h.answerCallback(cb.ID, msgStaleButton)
+		}
 
 		if err := h.settingsService.UpdateTimezone(ctx, userID, tz); err != nil {
-			msg := newPlainMessage(cb.Message.Chat.ID, msgInternalError)
+			msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
 			return h.send(msg)
 		}
+		h.rescheduleReminders(ctx, userID)
 
-		confirmText := fmt.Sprintf("🌍 Часовой пояс: %s", tz)
+		confirmText := fmt.Sprintf("🌍 Часовой пояс: %s", entities.TimezoneLabel(tz, time.Now()))
 		return h.confirmSettingAndShowReminderSettings(ctx, cb, confirmText)
 
 	case "timezone_manual":
@@ -532,7 +1323,7 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 
 		// send prompt first to get its message id
 		prompt := newPlainMessage(chatID,
-			"Введите часовой пояс в формате UTC+3 или UTC+5:30 (можно просто +3).\n\nПример: UTC+3",
+			msgTimezoneSearchPrompt,
 		)
 		prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
 
@@ -540,9 +1331,10 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 		if err != nil {
 			return err
 		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
 
-		h.setTZWaitState(userID, tzWaitState{
-			Flow:            "settings",
+		h.setTZWaitState(ctx, userID, tzWaitState{
+			Flow:            tzFlowSettings,
 			ChatID:          chatID,
 			OwnerMessageID:  cb.Message.MessageID,
 			PromptMessageID: sent.MessageID,
@@ -551,11 +1343,95 @@ func (h *Handler) applyReminderSetting(ctx context.Context, cb *tgbotapi.Callbac
 		return nil
 
 	default:
-		h.logger.Warn("unknown reminder sub-action", zap.String("value", value), zap.Strings("params", params))
+		h.log(ctx).Warn("unknown reminder sub-action", zap.String("value", value), zap.Strings("params", params))
 		return nil
 	}
 }
 
+// showQuietDays displays quiet weekdays and dates.
+func (h *Handler) showQuietDays(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	reminder, err := h.reminderService.GetByUserID(ctx, cb.From.ID)
+	if err != nil {
+		msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+		return h.send(msg)
+	}
+
+	var quiet entities.QuietDays
+	if reminder != nil {
+		quiet = reminder.Quiet
+	}
+
+	text := buildQuietDaysMessage(quiet)
+	keyboard := buildQuietDaysKeyboard(quiet)
+
+	edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	edit.ReplyMarkup = &keyboard
+	return h.send(edit)
+}
+
+// showPrayerReminders displays the prayer-anchored reminder settings.
+func (h *Handler) showPrayerReminders(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	return h.renderPrayerReminders(ctx, cb.From.ID, cb.Message.Chat.ID, cb.Message.MessageID)
+}
+
+// renderPrayerReminders edits the message into the prayer settings screen with today's prayer times.
+func (h *Handler) renderPrayerReminders(ctx context.Context, userID, chatID int64, messageID int) error {
+	reminder, err := h.reminderService.GetOrCreate(ctx, userID)
+	if err != nil {
+		return h.send(h.internalErrorMessage(ctx, chatID))
+	}
+
+	loc := time.UTC
+	if st, err := h.settingsService.GetOrCreate(ctx, userID); err == nil && st != nil {
+		if l, err := entities.ParseTimezoneLocation(st.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	var times *entities.PrayerTimes
+	if t, err := reminder.Prayer.TimesOn(time.Now(), loc); err == nil {
+		times = &t
+	}
+
+	text := buildPrayerRemindersMessage(reminder, times)
+	keyboard := buildPrayerRemindersKeyboard(reminder.Prayer)
+
+	edit := newEdit(chatID, messageID, text)
+	edit.ReplyMarkup = &keyboard
+	return h.send(edit)
+}
+
+// showQuietDatePicker displays a page of upcoming dates to mute.
+func (h *Handler) showQuietDatePicker(ctx context.Context, cb *tgbotapi.CallbackQuery, page int) error {
+	page = max(0, min(page, maxQuietDatePickerPage))
+
+	reminder, err := h.reminderService.GetByUserID(ctx, cb.From.ID)
+	if err != nil {
+		msg := h.internalErrorMessage(ctx, cb.Message.Chat.ID)
+		return h.send(msg)
+	}
+
+	var quiet entities.QuietDays
+	if reminder != nil {
+		quiet = reminder.Quiet
+	}
+
+	loc := time.UTC
+	if st, err := h.settingsService.GetOrCreate(ctx, cb.From.ID); err == nil && st != nil {
+		if l, err := entities.ParseTimezoneLocation(st.Timezone); err == nil {
+			loc = l
+		}
+	}
+	today := entities.LocalDay(time.Now(), loc)
+
+	text := md("🌙 Выберите даты, в которые напоминания не нужны:")
+	keyboard := buildQuietDatePickerKeyboard(quiet, today, page)
+
+	edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, text)
+	edit.ReplyMarkup = &keyboard
+	return h.send(edit)
+}
+
 // showFrequencyMenu displays frequency selection menu.
 func (h *Handler) showFrequencyMenu(_ context.Context, cb *tgbotapi.CallbackQuery) error {
 	text := "📅 " + bold("Как часто отправлять напоминания?") + "\n\n" +
@@ -584,7 +1460,7 @@ func (h *Handler) showTimeWindowMenu(_ context.Context, cb *tgbotapi.CallbackQue
 func (h *Handler) confirmSettingAndShowMenu(ctx context.Context, cb *tgbotapi.CallbackQuery, confirmText string) error {
 	confirm := tgbotapi.NewCallback(cb.ID, confirmText)
 	if _, err := h.bot.Request(confirm); err != nil {
-		h.logger.Error("failed to send confirmation", zap.Error(err))
+		h.log(ctx).Error("failed to send confirmation", zap.Error(err))
 	}
 	return h.showSettingsMenu(ctx, cb)
 }
@@ -593,7 +1469,7 @@ func (h *Handler) confirmSettingAndShowMenu(ctx context.Context, cb *tgbotapi.Ca
 func (h *Handler) confirmSettingAndShowReminderSettings(ctx context.Context, cb *tgbotapi.CallbackQuery, confirmText string) error {
 	confirm := tgbotapi.NewCallback(cb.ID, confirmText)
 	if _, err := h.bot.Request(confirm); err != nil {
-		h.logger.Error("failed to send confirmation", zap.Error(err))
+		h.log(ctx).Error("failed to send confirmation", zap.Error(err))
 	}
 
 	return h.showReminderSettings(ctx, cb)
@@ -610,7 +1486,7 @@ func (h *Handler) handleQuizCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 
 	// Handle quiz answer: quiz:sessionID:questionNum:answerIndex.
 	if len(data.Params) < 3 {
-		h.logger.Warn("invalid quiz callback params", zap.String("raw", data.Raw))
+		h.log(ctx).Warn("invalid quiz callback params", zap.String("raw", data.Raw))
 		return nil
 	}
 
@@ -638,7 +1514,7 @@ func (h *Handler) handleQuizCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 		if strings.Contains(err.Error(), "already submitted") {
 			return h.answerCallback(cb.ID, "Ответ уже отправлен")
 		}
-		h.logger.Error("failed to submit answer",
+		h.log(ctx).Error("failed to submit answer",
 			zap.Error(err),
 			zap.Int64("session_id", sessionID),
 			zap.Int("question_num", questionNum),
@@ -652,18 +1528,26 @@ func (h *Handler) handleQuizCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 	_ = h.send(deleteMsg)
 
 	// Send feedback.
-	feedbackText := formatAnswerFeedback(result.IsCorrect, result.CorrectAnswer)
+	feedbackText := formatAnswerFeedback(result.IsCorrect, result.CorrectAnswer, result.XPGained)
 	feedbackMsg := newMessage(chatID, feedbackText)
 	_, err = h.bot.Send(feedbackMsg)
 	if err != nil {
-		h.logger.Error("failed to send feedback", zap.Error(err))
+		h.log(ctx).Error("failed to send feedback", zap.Error(err))
 	}
 
+	h.sendAnswerRewards(chatID, result)
+
 	// Check if quiz is completed.
 	if result.IsSessionComplete {
 		// Clear storage.
 		h.quizStorage.Delete(sessionID)
 
+		h.analyticsService.Track(ctx, userID, entities.EventQuizFinished, map[string]any{
+			"session_id": sessionID,
+			"score":      result.Score,
+			"total":      result.Total,
+		})
+
 		// Build session summary.
 		completedSession := &entities.QuizSession{
 			ID:             sessionID,
@@ -671,14 +1555,14 @@ func (h *Handler) handleQuizCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 			TotalQuestions: result.Total,
 			SessionStatus:  "completed",
 		}
-		return h.sendQuizResults(chatID, completedSession)
+		return h.sendQuizResults(chatID, completedSession, result.Level, result.TotalXP)
 	}
 
 	// Send next question.
 	nextQuestionNum := questionNum + 1
 	question, nextName, err := h.quizService.GetCurrentQuestion(ctx, sessionID, nextQuestionNum)
 	if err != nil {
-		h.logger.Error("failed to get next question",
+		h.log(ctx).Error("failed to get next question",
 			zap.Error(err),
 			zap.Int64("session_id", sessionID),
 			zap.Int("next_question_num", nextQuestionNum),
@@ -689,27 +1573,47 @@ func (h *Handler) handleQuizCallback(ctx context.Context, cb *tgbotapi.CallbackQ
 	// Get active session to pass correct data.
 	session, err := h.quizService.GetActiveSession(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get active session",
+		h.log(ctx).Error("failed to get active session",
 			zap.Error(err),
 			zap.Int64("user_id", userID),
 		)
 		return nil
 	}
 
-	err = h.sendQuizQuestionFromDB(chatID, session, question, nextName, nextQuestionNum, false)
+	err = h.sendQuizQuestionFromDB(ctx, chatID, session, question, nextName, nextQuestionNum, false)
 	if err != nil {
-		h.logger.Error("failed to send next question", zap.Error(err))
+		h.log(ctx).Error("failed to send next question", zap.Error(err))
 	}
 
 	return h.answerCallback(cb.ID, "")
 }
 
+// sendAnswerRewards announces a level-up or streak milestone reached by an answer.
+func (h *Handler) sendAnswerRewards(chatID int64, result *service.AnswerResult) {
+	if result.LeveledUp {
+		if err := h.send(newMessage(chatID, formatLevelUpMessage(result.Level.Level))); err != nil {
+			h.logger.Error("failed to send level up", zap.Error(err))
+		}
+	}
+
+	if result.StreakMilestone > 0 {
+		if err := h.send(newMessage(chatID, formatStreakMilestoneMessage(result.StreakMilestone))); err != nil {
+			h.logger.Error("failed to send streak milestone", zap.Error(err))
+		}
+	}
+}
+
 // handleProgressCallback shows user progress.
 func (h *Handler) handleProgressCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 	if cb.Message == nil {
 		return nil
 	}
 
+	data := decodeCallback(cb.Data)
+	if len(data.Params) > 0 && data.Params[0] == progressCalendar {
+		return h.showActivityCalendar(ctx, cb)
+	}
+
 	text, keyboard, err := h.RenderProgress(ctx, cb.From.ID, true)
 	if err != nil {
 		msg := newPlainMessage(cb.Message.Chat.ID, msgProgressUnavailable)
@@ -724,6 +1628,24 @@ func (h *Handler) handleProgressCallback(ctx context.Context, cb *tgbotapi.Callb
 	return h.send(edit)
 }
 
+// showActivityCalendar shows the activity calendar of the current month.
+func (h *Handler) showActivityCalendar(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	calendar, err := h.progressService.GetActivityCalendar(ctx, cb.From.ID)
+	if err != nil {
+		h.log(ctx).Error("failed to get activity calendar",
+			zap.Int64("user_id", cb.From.ID),
+			zap.Error(err),
+		)
+		msg := newPlainMessage(cb.Message.Chat.ID, msgProgressUnavailable)
+		return h.send(msg)
+	}
+
+	kb := buildActivityCalendarKeyboard()
+	edit := newEdit(cb.Message.Chat.ID, cb.Message.MessageID, formatActivityCalendar(calendar))
+	edit.ReplyMarkup = &kb
+	return h.send(edit)
+}
+
 // handleOnboardingCallback handles onboarding-related callbacks.
 func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 	if cb.Message == nil {
@@ -758,6 +1680,8 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 			text = onboardingStep2Message()
 			k := onboardingStep2Keyboard()
 			kb = &k
+			h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepWelcome, "")
+			h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepNamesPerDay, "")
 		case 3:
 			text = onboardingStep3Message()
 			k := onboardingStep3Keyboard()
@@ -794,6 +1718,8 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 		if err := h.settingsService.UpdateNamesPerDay(ctx, userID, n); err != nil {
 			return err
 		}
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepNamesPerDay, data.Params[1])
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepLearningMode, "")
 
 		edit := newEdit(chatID, cb.Message.MessageID, onboardingStep3Message())
 		kb := onboardingStep3Keyboard()
@@ -809,6 +1735,8 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 		if err := h.settingsService.UpdateLearningMode(ctx, userID, mode); err != nil {
 			return err
 		}
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepLearningMode, mode)
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepReminders, "")
 
 		edit := newEdit(chatID, cb.Message.MessageID, onboardingStep4Message())
 		kb := onboardingStep4Keyboard()
@@ -831,6 +1759,8 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 					return err
 				}
 			}
+			h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepReminders, choice)
+			h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepTimezone, "")
 
 			edit := newEdit(chatID, cb.Message.MessageID, onboardingStepTimezoneMessage())
 			kb := onboardingStepTimezoneKeyboard()
@@ -838,31 +1768,48 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 			return h.send(edit)
 		}
 
-		if old, ok := h.tzInputWait[userID]; ok && old.PromptMessageID != 0 {
+		if old, ok := h.tzInputWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
 			_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
 		}
-		delete(h.tzInputWait, userID)
+		h.tzInputWait.delete(ctx, userID)
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepReminders, choice)
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepComplete, "")
 
 		edit := newEdit(chatID, cb.Message.MessageID, onboardingCompleteMessage())
 		kb := onboardingCompleteKeyboard()
 		edit.ReplyMarkup = &kb
 		return h.send(edit)
 
+	case onboardingTZRegion:
+		if len(data.Params) != 2 {
+			edit := newEdit(chatID, cb.Message.MessageID, onboardingStepTimezoneMessage())
+			kb := onboardingStepTimezoneKeyboard()
+			edit.ReplyMarkup = &kb
+			return h.send(edit)
+		}
+
+		region, ok := entities.FindTimezoneRegion(data.Params[1])
+		if !ok {
+			return nil
+		}
+		kb := buildTimezoneCitiesKeyboard(tzFlowOnboarding, region.Cities, time.Now())
+		return h.send(tgbotapi.NewEditMessageReplyMarkup(chatID, cb.Message.MessageID, kb))
+
 	case onboardingTimezone:
 		if len(data.Params) != 2 {
 			return nil
 		}
-		tz := data.Params[1]
+		param := data.Params[1]
 
 		// If there is any previous pending timezone input, cleanup it.
-		if old, ok := h.tzInputWait[userID]; ok && old.PromptMessageID != 0 {
+		if old, ok := h.tzInputWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
 			_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
 		}
-		delete(h.tzInputWait, userID)
+		h.tzInputWait.delete(ctx, userID)
 
-		if tz == "manual" {
+		if param == "manual" {
 			prompt := newPlainMessage(chatID,
-				"Введите часовой пояс в формате UTC+3 или UTC+5:30 (можно просто +3).\n\nПример: UTC+3",
+				msgTimezoneSearchPrompt,
 			)
 			prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
 
@@ -870,9 +1817,10 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 			if err != nil {
 				return err
 			}
+			h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
 
-			h.setTZWaitState(userID, tzWaitState{
-				Flow:            "onboarding",
+			h.setTZWaitState(ctx, userID, tzWaitState{
+				Flow:            tzFlowOnboarding,
 				ChatID:          chatID,
 				OwnerMessageID:  cb.Message.MessageID,
 				PromptMessageID: sent.MessageID,
@@ -881,9 +1829,17 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 			return nil
 		}
 
+		tz, ok := h.resolveTimezoneID(ctx, userID, param)
+		if !ok {
+			return h.answerCallback(cb.ID, msgStaleButton)
+		}
+
 		if err := h.settingsService.UpdateTimezone(ctx, userID, tz); err != nil {
 			return err
 		}
+		h.rescheduleReminders(ctx, userID)
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepCompleted, StepTimezone, tz)
+		h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepComplete, "")
 
 		edit := newEdit(chatID, cb.Message.MessageID, onboardingCompleteMessage())
 		kb := onboardingCompleteKeyboard()
@@ -901,7 +1857,7 @@ func (h *Handler) handleOnboardingCallback(ctx context.Context, cb *tgbotapi.Cal
 		case "today":
 			return h.handleToday(userID)(ctx, chatID)
 		case "all":
-			return h.handleAll()(ctx, chatID)
+			return h.handleAll("")(ctx, chatID)
 		default:
 			return nil
 		}
@@ -930,7 +1886,7 @@ func (h *Handler) handleResetCallback(ctx context.Context, cb *tgbotapi.Callback
 		_ = h.answerCallback(cb.ID, "Сбрасываю прогресс...")
 
 		if err := h.resetService.ResetUser(ctx, userID); err != nil {
-			h.logger.Error("failed to reset progress", zap.Error(err), zap.Int64("user_id", userID))
+			h.log(ctx).Error("failed to reset progress", zap.Error(err), zap.Int64("user_id", userID))
 			_, _ = h.bot.Send(tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID))
 			return h.send(newPlainMessage(chatID, "❌ Не удалось сбросить прогресс"))
 		}
@@ -945,6 +1901,56 @@ func (h *Handler) handleResetCallback(ctx context.Context, cb *tgbotapi.Callback
 	}
 }
 
+// handleDeleteDataCallback handles the two-step confirmation of /deletemydata.
+func (h *Handler) handleDeleteDataCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	data := decodeCallback(cb.Data)
+	userID := cb.From.ID
+	chatID := cb.Message.Chat.ID
+
+	if len(data.Params) == 0 {
+		return fmt.Errorf("missing delete data action")
+	}
+
+	switch data.Params[0] {
+	case deleteDataCancel:
+		_ = h.answerCallback(cb.ID, "Ок, отменено")
+		_, _ = h.bot.Send(tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID))
+		return nil
+
+	case deleteDataProceed:
+		_ = h.answerCallback(cb.ID, "")
+		edit := newEdit(chatID, cb.Message.MessageID, formatDeleteDataFinal())
+		kb := buildDeleteDataFinalKeyboard()
+		edit.ReplyMarkup = &kb
+		return h.send(edit)
+
+	case deleteDataConfirm:
+		_ = h.answerCallback(cb.ID, "Удаляю данные...")
+		_, _ = h.bot.Send(tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID))
+
+		if err := h.resetService.DeleteUser(ctx, userID); err != nil {
+			h.log(ctx).Error("failed to delete user data", zap.Error(err), zap.Int64("user_id", userID))
+			return h.send(newPlainMessage(chatID, "❌ Не удалось удалить данные. Попробуйте позже."))
+		}
+
+		h.forgetUser(ctx, userID)
+		return h.send(newPlainMessage(chatID, msgDataDeleted))
+
+	default:
+		return fmt.Errorf("unknown delete data action: %q", data.Params[0])
+	}
+}
+
+// rescheduleReminders recalculates the next reminder after a timezone change (best-effort).
+func (h *Handler) rescheduleReminders(ctx context.Context, userID int64) {
+	if err := h.reminderService.Reschedule(ctx, userID); err != nil {
+		h.log(ctx).Warn("failed to reschedule reminders",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+	}
+}
+
 // answerCallback sends a callback answer and removes the loading indicator.
 func (h *Handler) answerCallback(callbackID, text string) error {
 	callback := tgbotapi.NewCallback(callbackID, text)
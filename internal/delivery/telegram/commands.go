@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
@@ -15,17 +17,46 @@ import (
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
 )
 
+const (
+	// deepLinkNamePrefix is the /start payload prefix for opening a specific name (e.g. "name_42").
+	deepLinkNamePrefix = "name_"
+	// deepLinkReferralPrefix is the /start payload prefix of personal referral links (e.g. "ref_12345").
+	deepLinkReferralPrefix = "ref_"
+)
+
 // handleStart handles /start and sends either onboarding or returning-user welcome message.
-func (h *Handler) handleStart(userID int64) HandlerFunc {
+// A "name_N" deep-link payload additionally opens the requested name card;
+// a "ref_ID" payload attributes a new user to the referrer.
+func (h *Handler) handleStart(userID int64, payload string) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
 		isNewUser, err := h.userService.EnsureUser(ctx, userID, chatID)
 		if err != nil {
-			return h.send(newPlainMessage(chatID, msgInternalError))
+			return h.send(h.internalErrorMessage(ctx, chatID))
+		}
+
+		if referrerID, ok := parseReferralDeepLink(payload); ok && isNewUser {
+			if _, err := h.userService.AttributeReferral(ctx, userID, referrerID); err != nil {
+				h.log(ctx).Warn("failed to attribute referral",
+					zap.Int64("user_id", userID),
+					zap.Int64("referrer_id", referrerID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		if nameNumber, ok := parseNameDeepLink(payload); ok {
+			if err := h.sendNameCard(ctx, userID, chatID, nameNumber, true); err != nil {
+				h.log(ctx).Warn("failed to open deep-linked name",
+					zap.Int64("user_id", userID),
+					zap.Int("name_number", nameNumber),
+					zap.Error(err),
+				)
+			}
 		}
 
 		stats, err := h.progressService.GetProgressSummary(ctx, userID)
 		if err != nil {
-			msg := newPlainMessage(chatID, msgInternalError)
+			msg := h.internalErrorMessage(ctx, chatID)
 			return h.send(msg)
 		}
 
@@ -34,6 +65,7 @@ func (h *Handler) handleStart(userID int64) HandlerFunc {
 		if isNewUser {
 			kb := onboardingStep1Keyboard()
 			msg.ReplyMarkup = kb
+			h.trackOnboarding(ctx, userID, entities.EventOnboardingStepShown, StepWelcome, "")
 		} else {
 			kb := welcomeReturningKeyboard()
 			msg.ReplyMarkup = kb
@@ -43,8 +75,48 @@ func (h *Handler) handleStart(userID int64) HandlerFunc {
 	}
 }
 
+// parseNameDeepLink extracts a name number from a "name_N" /start payload.
+func parseNameDeepLink(payload string) (int, bool) {
+	payload = strings.TrimSpace(payload)
+	if !strings.HasPrefix(payload, deepLinkNamePrefix) {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(payload, deepLinkNamePrefix))
+	if err != nil || n < 1 || n > 99 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// buildNameDeepLink returns a t.me link that opens the given name card in the bot.
+func buildNameDeepLink(botUsername string, nameNumber int) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s%d", botUsername, deepLinkNamePrefix, nameNumber)
+}
+
+// parseReferralDeepLink extracts the referrer ID from a "ref_ID" /start payload.
+func parseReferralDeepLink(payload string) (int64, bool) {
+	payload = strings.TrimSpace(payload)
+	if !strings.HasPrefix(payload, deepLinkReferralPrefix) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(payload, deepLinkReferralPrefix), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// buildReferralLink returns the user's personal t.me link for inviting friends.
+func buildReferralLink(botUsername string, userID int64) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s%d", botUsername, deepLinkReferralPrefix, userID)
+}
+
 // handleNumber processes numeric input and displays the corresponding name.
-func (h *Handler) handleNumber(numStr string) HandlerFunc {
+func (h *Handler) handleNumber(userID int64, numStr string) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
 		n, err := strconv.Atoi(numStr)
 		if err != nil {
@@ -63,6 +135,7 @@ func (h *Handler) handleNumber(numStr string) HandlerFunc {
 		if err != nil {
 			return err
 		}
+		h.personalizeNameCard(ctx, userID, &msg, n)
 
 		if err = h.send(msg); err != nil {
 			return err
@@ -75,117 +148,184 @@ func (h *Handler) handleNumber(numStr string) HandlerFunc {
 	}
 }
 
-// handleTimezoneText consumes timezone text input for both onboarding and settings flows.
-func (h *Handler) handleTimezoneText(text string, userID int64, userMsgID int) HandlerFunc {
+// handleNoteText consumes personal note text input for a name card.
+func (h *Handler) handleNoteText(text string, userID int64, userMsgID int) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
-		st, ok := h.tzInputWait[userID]
+		st, ok := h.noteInputWait.get(ctx, userID)
 		if !ok {
 			return nil
 		}
 
-		tz, ok := normalizeUTCOffset(text)
-		if !ok {
-			msg := newPlainMessage(chatID, "Не понял формат. Пример: UTC+3 или UTC+5:30")
-			msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
-			return h.send(msg)
+		if isCancelText(text) {
+			if st.PromptMessageID != 0 {
+				_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+			}
+			h.noteInputWait.delete(ctx, userID)
+			return h.send(newPlainMessage(chatID, msgNoteCancelled))
 		}
 
-		if err := h.settingsService.UpdateTimezone(ctx, userID, tz); err != nil {
-			return h.send(newPlainMessage(chatID, msgInternalError))
+		if err := h.notesService.Save(ctx, userID, st.NameNumber, text); err != nil {
+			switch {
+			case errors.Is(err, service.ErrNoteEmpty):
+				return h.sendNoteRetry(chatID, msgNoteEmpty)
+			case errors.Is(err, service.ErrNoteTooLong):
+				return h.sendNoteRetry(chatID, fmt.Sprintf(
+					"Заметка слишком длинная. Максимум — %d символов.", entities.MaxNoteLength,
+				))
+			default:
+				return err
+			}
 		}
 
-		// Cleanup messages (best-effort).
+		// Cleanup prompt (best-effort); the user's message is kept as it holds their text.
 		if st.PromptMessageID != 0 {
 			_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
 		}
-		if userMsgID != 0 {
-			_ = h.send(tgbotapi.NewDeleteMessage(chatID, userMsgID))
+		h.noteInputWait.delete(ctx, userID)
+
+		if err := h.refreshNameCard(ctx, userID, st.ChatID, st.CardMessageID, st.NameNumber); err != nil {
+			h.log(ctx).Warn("failed to refresh name card after note save",
+				zap.Error(err),
+				zap.Int64("user_id", userID),
+				zap.Int("name_number", st.NameNumber),
+			)
+			return h.handleNumber(userID, strconv.Itoa(st.NameNumber))(ctx, chatID)
 		}
 
-		delete(h.tzInputWait, userID)
+		return h.send(newPlainMessage(chatID, msgNoteSaved))
+	}
+}
 
-		switch st.Flow {
-		case "onboarding":
-			edit := newEdit(st.ChatID, st.OwnerMessageID, onboardingCompleteMessage())
-			kb := onboardingCompleteKeyboard()
-			edit.ReplyMarkup = &kb
-			return h.send(edit)
+// sendNoteRetry asks the user to re-enter the note.
+func (h *Handler) sendNoteRetry(chatID int64, text string) error {
+	msg := newPlainMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+	return h.send(msg)
+}
 
-		case "settings":
-			settings, err := h.settingsService.GetOrCreate(ctx, userID)
+// isCancelText reports whether the text input cancels the current flow.
+func isCancelText(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "отмена", "cancel":
+		return true
+	default:
+		return false
+	}
+}
+
+// handleTimezoneText consumes timezone text input for both onboarding and settings flows.
+func (h *Handler) handleTimezoneText(text string, userID int64, userMsgID int) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		st, ok := h.tzInputWait.get(ctx, userID)
+		if !ok {
+			return nil
+		}
+
+		cities := entities.SearchTimezones(text)
+		if len(cities) == 0 {
+			tz, err := entities.NormalizeTimezone(text)
 			if err != nil {
-				msg := newPlainMessage(chatID, msgInternalError)
+				msg := newPlainMessage(chatID, msgTimezoneNotFound)
+				msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
 				return h.send(msg)
 			}
+			cities = []entities.TimezoneCity{{Name: tz, Zone: tz}}
+		}
 
-			// Return to reminders settings (edit the settings message, not onboarding).
-			rem, err := h.reminderService.GetByUserID(ctx, userID)
-			if err != nil {
-				return h.send(newPlainMessage(chatID, fmt.Sprintf("🌍 Часовой пояс сохранён: %s", tz)))
+		now := time.Now()
+
+		var reply string
+		var kb tgbotapi.InlineKeyboardMarkup
+		if len(cities) == 1 {
+			// Nothing is saved until the user confirms the resolved timezone.
+			reply = formatTimezoneConfirmMessage(cities[0], now)
+			kb = buildTimezoneConfirmKeyboard(st.Flow, cities[0].Zone)
+			// A typed zone may be missing from the catalog that zone IDs are resolved against.
+			h.tzPending.set(ctx, userID, cities[0].Zone)
+		} else {
+			const maxResults = 10
+			if len(cities) > maxResults {
+				cities = cities[:maxResults]
 			}
+			reply = md("🔎 Найдено несколько городов, выберите нужный:")
+			kb = buildTimezoneCitiesKeyboard(st.Flow, cities, now)
+		}
 
-			edit := newEdit(st.ChatID, st.OwnerMessageID, buildReminderSettingsMessage(settings.Timezone, rem))
-			kb := buildRemindersKeyboard(rem)
-			edit.ReplyMarkup = &kb
+		// Cleanup messages (best-effort).
+		if st.PromptMessageID != 0 {
+			_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+		}
+		if userMsgID != 0 {
+			_ = h.send(tgbotapi.NewDeleteMessage(chatID, userMsgID))
+		}
 
-			// Optional: show toast via callback isn't possible here; send a short message if needed.
-			_ = h.send(newPlainMessage(chatID, fmt.Sprintf("🌍 Часовой пояс: %s", tz)))
+		h.tzInputWait.delete(ctx, userID)
 
-			return h.send(edit)
+		edit := newEdit(st.ChatID, st.OwnerMessageID, reply)
+		edit.ReplyMarkup = &kb
+		return h.send(edit)
+	}
+}
 
-		default:
-			return nil
-		}
+// resolveTimezoneID returns the zone of a timezone button: a catalog zone, or the zone
+// the user typed and was asked to confirm.
+func (h *Handler) resolveTimezoneID(ctx context.Context, userID int64, id string) (string, bool) {
+	if zone, ok := entities.TimezoneByID(id); ok {
+		return zone, true
 	}
+	if zone, ok := h.tzPending.get(ctx, userID); ok && entities.TimezoneID(zone) == id {
+		return zone, true
+	}
+	return "", false
 }
 
-// normalizeUTCOffset normalizes a user-entered UTC offset into "UTC±H:MM" format.
-func normalizeUTCOffset(input string) (string, bool) {
-	s := strings.TrimSpace(input)
-	s = strings.ReplaceAll(s, " ", "")
-	s = strings.ToUpper(s)
+// handleLocationMessage saves a shared location for prayer-anchored reminders.
+func (h *Handler) handleLocationMessage(msg *tgbotapi.Message) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		userID := msg.From.ID
 
-	if strings.HasPrefix(s, "UTC") {
-		s = s[3:]
-		if s == "" {
-			return "UTC+0", true
+		st, ok := h.locationWait.get(ctx, userID)
+		if !ok {
+			return nil
 		}
-	}
 
-	if !(strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")) {
-		return "", false
-	}
+		if msg.Location == nil {
+			if isCancelText(msg.Text) {
+				if st.PromptMessageID != 0 {
+					_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+				}
+				h.locationWait.delete(ctx, userID)
 
-	sign := s[:1]
-	rest := s[1:]
+				reply := newPlainMessage(chatID, msgPrayerLocationKept)
+				reply.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+				return h.send(reply)
+			}
 
-	hh := rest
-	mm := "00"
-	if strings.Contains(rest, ":") {
-		parts := strings.Split(rest, ":")
-		if len(parts) != 2 {
-			return "", false
+			reply := newPlainMessage(chatID, msgPrayerLocationNeeded)
+			reply.ReplyMarkup = buildLocationRequestKeyboard()
+			return h.send(reply)
 		}
-		hh, mm = parts[0], parts[1]
-	}
 
-	h, err := strconv.Atoi(hh)
-	if err != nil {
-		return "", false
-	}
-	m, err := strconv.Atoi(mm)
-	if err != nil {
-		return "", false
-	}
+		if err := h.reminderService.SetPrayerLocation(ctx, userID, msg.Location.Latitude, msg.Location.Longitude); err != nil {
+			return err
+		}
 
-	if h < 0 || h > 14 || m < 0 || m >= 60 {
-		return "", false
-	}
-	if m%15 != 0 {
-		return "", false
-	}
+		// Cleanup messages (best-effort); the location itself is not kept in the chat.
+		if st.PromptMessageID != 0 {
+			_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+		}
+		_ = h.send(tgbotapi.NewDeleteMessage(chatID, msg.MessageID))
+
+		h.locationWait.delete(ctx, userID)
+
+		reply := newPlainMessage(chatID, msgPrayerLocationSaved)
+		reply.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+		if err := h.send(reply); err != nil {
+			return err
+		}
 
-	return fmt.Sprintf("UTC%s%d:%02d", sign, h, m), true
+		return h.renderPrayerReminders(ctx, userID, st.ChatID, st.OwnerMessageID)
+	}
 }
 
 // handleToday starts the "today" flow at the first page.
@@ -215,12 +355,12 @@ func (h *Handler) handleTodayPage(userID int64) func(ctx context.Context, chatID
 			namesPerDay,
 		)
 		if err != nil {
-			return h.send(newPlainMessage(chatID, msgInternalError))
+			return h.send(h.internalErrorMessage(ctx, chatID))
 		}
 
 		todayNames, err := h.dailyNameService.GetTodayNamesTZ(ctx, userID, settings.Timezone)
 		if err != nil {
-			return h.send(newPlainMessage(chatID, msgInternalError))
+			return h.send(h.internalErrorMessage(ctx, chatID))
 		}
 		if len(todayNames) == 0 {
 			return h.send(newPlainMessage(chatID, "📚 На сегодня пока нет имён.\n\nНажмите /new, чтобы открыть новое имя."))
@@ -237,12 +377,27 @@ func (h *Handler) handleTodayPage(userID int64) func(ctx context.Context, chatID
 
 		// Status indicates whether the name is mastered or still in progress.
 		status := "⏳"
-		pMap, _ := h.progressService.GetByNumbers(ctx, userID, []int{nameNumber})
-		if p := pMap[nameNumber]; p != nil && p.Phase == entities.PhaseMastered {
+		phases, _ := h.progressService.GetPhases(ctx, userID, []int{nameNumber})
+		if phases[nameNumber] == entities.PhaseMastered {
 			status = "✅"
 		}
 
-		prefix := md(fmt.Sprintf("📅 Сегодня: %s %d/%d\n\n", status, page+1, len(todayNames)))
+		loc := time.UTC
+		if l, err := entities.ParseTimezoneLocation(settings.Timezone); err == nil {
+			loc = l
+		}
+		studied, err := h.dailyNameService.GetTodayStudied(ctx, userID, settings.Timezone)
+		if err != nil {
+			h.log(ctx).Warn("failed to get studied names",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+		}
+
+		prefix := md(fmt.Sprintf("📅 Сегодня: %s %d/%d\n%s\n%s\n\n",
+			status, page+1, len(todayNames),
+			formatTodayChecklist(len(studied), len(todayNames)),
+			formatHijriDate(time.Now().In(loc), settings.LanguageCode)))
 
 		name, err := h.nameService.GetByNumber(ctx, nameNumber)
 		if err != nil {
@@ -251,7 +406,7 @@ func (h *Handler) handleTodayPage(userID int64) func(ctx context.Context, chatID
 
 		text := prefix + buildNameCardText(name)
 
-		kb := todayCardsKeyboard(page, len(todayNames), name.Number)
+		kb := todayCardsKeyboard(page, len(todayNames), name.Number, slices.Contains(studied, name.Number))
 
 		if messageID != 0 {
 			edit := newEdit(chatID, messageID, text)
@@ -291,7 +446,7 @@ func (h *Handler) handleRandom(userID int64) HandlerFunc {
 			// Free: truly random from all 99.
 			name, err := h.nameService.GetRandom(ctx)
 			if err != nil {
-				h.logger.Error("failed to get random name", zap.Error(err))
+				h.log(ctx).Error("failed to get random name", zap.Error(err))
 				msg := newPlainMessage(chatID, msgNameUnavailable)
 				return h.send(msg)
 			}
@@ -302,6 +457,7 @@ func (h *Handler) handleRandom(userID int64) HandlerFunc {
 			if err != nil {
 				return err
 			}
+			h.personalizeNameCard(ctx, userID, &msg, name.Number)
 
 			if err = h.send(msg); err != nil {
 				return err
@@ -322,6 +478,7 @@ func (h *Handler) handleRandom(userID int64) HandlerFunc {
 		if err != nil {
 			return err
 		}
+		h.personalizeNameCard(ctx, userID, &msg, nameNumber)
 
 		if err = h.send(msg); err != nil {
 			return err
@@ -335,7 +492,8 @@ func (h *Handler) handleRandom(userID int64) HandlerFunc {
 }
 
 // handleAll sends a paginated list of all names.
-func (h *Handler) handleAll() HandlerFunc {
+// The "index" argument sends the compact index view instead.
+func (h *Handler) handleAll(args string) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
 		names, err := h.getAllNames(ctx)
 		if err != nil {
@@ -347,13 +505,17 @@ func (h *Handler) handleAll() HandlerFunc {
 			return h.send(msg)
 		}
 
+		if isIndexArg(args) {
+			msg := newMessage(chatID, buildNameIndexText(len(names)))
+			msg.ReplyMarkup = buildNameIndexKeyboard(names)
+			return h.send(msg)
+		}
+
 		page := 0
 		text, totalPages := buildNamesPage(names, page)
-		prevData := buildNameCallback(page - 1)
-		nextData := buildNameCallback(page + 1)
 
 		msg := newMessage(chatID, text)
-		kb := buildNameKeyboard(page, totalPages, prevData, nextData)
+		kb := buildAllNamesKeyboard(page, totalPages)
 		if kb != nil {
 			msg.ReplyMarkup = *kb
 		}
@@ -400,11 +562,11 @@ func (h *Handler) handleRangeNumbers(from, to int) HandlerFunc {
 // handleProgress displays user progress.
 func (h *Handler) handleProgress(userID int64) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
-		h.logger.Debug("rendering progress", zap.Int64("user_id", userID))
+		h.log(ctx).Debug("rendering progress", zap.Int64("user_id", userID))
 
 		text, keyboard, err := h.RenderProgress(ctx, userID, true)
 		if err != nil {
-			h.logger.Error("failed to render progress",
+			h.log(ctx).Error("failed to render progress",
 				zap.Int64("user_id", userID),
 				zap.Error(err),
 			)
@@ -421,14 +583,328 @@ func (h *Handler) handleProgress(userID int64) HandlerFunc {
 	}
 }
 
+// handleSchedule displays the review forecast for the upcoming days.
+func (h *Handler) handleSchedule(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		forecast, err := h.progressService.GetDueForecast(ctx, userID, scheduleDays)
+		if err != nil {
+			h.log(ctx).Error("failed to get due forecast",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgProgressUnavailable))
+		}
+
+		msg := newMessage(chatID, formatScheduleMessage(forecast))
+		return h.send(msg)
+	}
+}
+
+// handleReport displays the monthly recap compared with the previous month.
+func (h *Handler) handleReport(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		report, err := h.progressService.GetMonthlyReport(ctx, userID)
+		if err != nil {
+			h.log(ctx).Error("failed to get monthly report",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgProgressUnavailable))
+		}
+
+		msg := newMessage(chatID, formatMonthlyReport(report))
+		return h.send(msg)
+	}
+}
+
+// goalDateLayouts are the accepted /goal date formats.
+var goalDateLayouts = []string{time.DateOnly, "02.01.2006"}
+
+// handleGoal shows, sets or clears the date by which all names should be started.
+func (h *Handler) handleGoal(userID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		args = strings.ToLower(strings.TrimSpace(args))
+
+		var note string
+		switch {
+		case args == "":
+		case isGoalOffArg(args):
+			if err := h.settingsService.SetTargetDate(ctx, userID, nil); err != nil {
+				return err
+			}
+			return h.send(newPlainMessage(chatID, withReplanNote(msgGoalCleared, h.replanToday(ctx, userID))))
+		default:
+			date, ok := parseGoalDate(args)
+			if !ok {
+				return h.send(newPlainMessage(chatID, msgGoalUsage))
+			}
+			if err := h.settingsService.SetTargetDate(ctx, userID, &date); err != nil {
+				if errors.Is(err, service.ErrTargetDateInPast) {
+					return h.send(newPlainMessage(chatID, msgGoalInPast))
+				}
+				return err
+			}
+			note = h.replanToday(ctx, userID)
+		}
+
+		summary, err := h.progressService.GetProgressSummary(ctx, userID)
+		if err != nil {
+			h.log(ctx).Error("failed to get progress summary",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgProgressUnavailable))
+		}
+
+		if summary.Target == nil {
+			return h.send(newPlainMessage(chatID, msgGoalUsage))
+		}
+		text := formatGoalMessage(summary.Target)
+		if note != "" {
+			text += "\n\n" + md("📚 "+note)
+		}
+		return h.send(newMessage(chatID, text))
+	}
+}
+
+// isGoalOffArg reports whether the /goal argument clears the target date.
+func isGoalOffArg(args string) bool {
+	switch args {
+	case "off", "нет", "сброс", "отмена":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseGoalDate parses a /goal date in one of goalDateLayouts.
+func parseGoalDate(s string) (time.Time, bool) {
+	for _, layout := range goalDateLayouts {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// handleExport sends the user's personal data as JSON and CSV files.
+// With the "anki" argument it sends an Anki-compatible deck instead.
+func (h *Handler) handleExport(userID int64, args string) HandlerFunc {
+	if isAnkiArg(args) {
+		return h.handleAnkiExport(userID)
+	}
+
+	return func(ctx context.Context, chatID int64) error {
+		export, err := h.exportService.Export(ctx, userID)
+		if err != nil {
+			h.log(ctx).Error("failed to export user data",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgExportUnavailable))
+		}
+
+		jsonData, err := export.JSON()
+		if err != nil {
+			return err
+		}
+		progressCSV, err := export.ProgressCSV()
+		if err != nil {
+			return err
+		}
+		historyCSV, err := export.QuizHistoryCSV()
+		if err != nil {
+			return err
+		}
+
+		prefix := "asma-ul-husna-" + export.ExportedAt.Format("2006-01-02")
+		history := tgbotapi.NewInputMediaDocument(tgbotapi.FileBytes{Name: prefix + "-quiz-history.csv", Bytes: historyCSV})
+		history.Caption = formatExportCaption(export)
+
+		group := tgbotapi.NewMediaGroup(chatID, []interface{}{
+			tgbotapi.NewInputMediaDocument(tgbotapi.FileBytes{Name: prefix + ".json", Bytes: jsonData}),
+			tgbotapi.NewInputMediaDocument(tgbotapi.FileBytes{Name: prefix + "-progress.csv", Bytes: progressCSV}),
+			history,
+		})
+
+		if _, err := h.bot.SendMediaGroup(group); err != nil {
+			return fmt.Errorf("send export files: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// handleAnkiExport sends all names with the user's SRS state as an Anki text import file.
+func (h *Handler) handleAnkiExport(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		deck, err := h.exportService.AnkiDeck(ctx, userID)
+		if err != nil {
+			h.log(ctx).Error("failed to build anki deck",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgExportUnavailable))
+		}
+
+		doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: "asma-ul-husna-anki.txt", Bytes: deck})
+		doc.Caption = msgAnkiExportCaption
+		return h.send(doc)
+	}
+}
+
+// isAnkiArg reports whether the /export argument requests an Anki deck.
+func isAnkiArg(args string) bool {
+	switch strings.ToLower(strings.TrimSpace(args)) {
+	case "anki", "анки":
+		return true
+	default:
+		return false
+	}
+}
+
+// handleNameStats displays personal statistics for a single name.
+func (h *Handler) handleNameStats(userID int64, nameNumber int) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if nameNumber < 1 || nameNumber > 99 {
+			return h.send(newPlainMessage(chatID, msgOutOfRangeNumber))
+		}
+
+		name, err := h.nameService.GetByNumber(ctx, nameNumber)
+		if err != nil {
+			return h.send(newPlainMessage(chatID, msgNameUnavailable))
+		}
+
+		stats, err := h.progressService.GetNameStats(ctx, userID, nameNumber)
+		if err != nil {
+			h.log(ctx).Error("failed to get name stats",
+				zap.Int64("user_id", userID),
+				zap.Int("name_number", nameNumber),
+				zap.Error(err),
+			)
+			return h.send(newPlainMessage(chatID, msgProgressUnavailable))
+		}
+
+		loc := time.UTC
+		if settings, err := h.settingsService.GetOrCreate(ctx, userID); err == nil && settings != nil {
+			if l, err := entities.ParseTimezoneLocation(settings.Timezone); err == nil {
+				loc = l
+			}
+		}
+
+		msg := newMessage(chatID, formatNameStatsMessage(name, stats, loc))
+		msg.ReplyMarkup = nameStatsKeyboard()
+		return h.send(msg)
+	}
+}
+
+// handleNameCommand handles the /name command with its arguments.
+// Supported forms: "/name N" opens the name card, "/name N stats" shows personal statistics.
+func (h *Handler) handleNameCommand(userID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		fields := strings.Fields(args)
+
+		switch {
+		case len(fields) == 1:
+			return h.handleNumber(userID, strings.TrimPrefix(fields[0], "#"))(ctx, chatID)
+
+		case len(fields) == 2 && isStatsArg(fields[1]):
+			n, err := strconv.Atoi(strings.TrimPrefix(fields[0], "#"))
+			if err != nil {
+				return h.send(newPlainMessage(chatID, msgIncorrectNameNumber))
+			}
+			return h.handleNameStats(userID, n)(ctx, chatID)
+
+		default:
+			return h.send(newPlainMessage(chatID, msgNameCommandUsage))
+		}
+	}
+}
+
+// handleFind searches names by Arabic script, translation, transliteration and meaning.
+// A single match opens the name card directly; otherwise the first page of results is sent.
+func (h *Handler) handleFind(userID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		query := strings.TrimSpace(args)
+		if query == "" {
+			return h.send(newPlainMessage(chatID, msgFindUsage))
+		}
+
+		names, err := h.nameService.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if len(names) == 0 {
+			return h.send(newPlainMessage(chatID, msgFindNothing))
+		}
+
+		if len(names) == 1 {
+			return h.handleNumber(userID, strconv.Itoa(names[0].Number))(ctx, chatID)
+		}
+
+		h.findQueries.set(ctx, userID, query)
+
+		page := 0
+		totalPages := (len(names) + findPerPage - 1) / findPerPage
+
+		msg := newMessage(chatID, formatFindResults(query, names, page, totalPages))
+		msg.ReplyMarkup = nameListKeyboard(names, page, totalPages, buildFindPageCallback)
+
+		return h.send(msg)
+	}
+}
+
+// handleFavorites sends the first page of user's favorite names.
+func (h *Handler) handleFavorites(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		names, err := h.favoritesService.List(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if len(names) == 0 {
+			return h.send(newPlainMessage(chatID, msgNoFavorites))
+		}
+
+		page := 0
+		totalPages := (len(names) + findPerPage - 1) / findPerPage
+
+		msg := newMessage(chatID, formatFavoritesList(names, page, totalPages))
+		msg.ReplyMarkup = favoritesKeyboard(names, page, totalPages)
+
+		return h.send(msg)
+	}
+}
+
+// isIndexArg reports whether the /all argument requests the compact index view.
+func isIndexArg(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "index", "compact", "индекс", "список":
+		return true
+	default:
+		return false
+	}
+}
+
+// isStatsArg reports whether the argument requests name statistics.
+func isStatsArg(s string) bool {
+	switch strings.ToLower(s) {
+	case "stats", "статистика":
+		return true
+	default:
+		return false
+	}
+}
+
 // handleSettings displays user settings.
 func (h *Handler) handleSettings(userID int64) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
-		h.logger.Debug("rendering settings", zap.Int64("user_id", userID))
+		h.log(ctx).Debug("rendering settings", zap.Int64("user_id", userID))
 
 		text, keyboard, err := h.RenderSettings(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to render settings",
+			h.log(ctx).Error("failed to render settings",
 				zap.Int64("user_id", userID),
 				zap.Error(err),
 			)
@@ -444,6 +920,11 @@ func (h *Handler) handleSettings(userID int64) HandlerFunc {
 
 // handleQuiz starts or resumes a quiz for the user.
 func (h *Handler) handleQuiz(userID int64) HandlerFunc {
+	return h.handleQuizWithMode(userID, "")
+}
+
+// handleQuizWithMode starts or resumes a quiz. An empty quizMode uses the mode from settings.
+func (h *Handler) handleQuizWithMode(userID int64, quizMode string) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
 		isFirstQuiz, err := h.quizService.IsFirstQuiz(ctx, userID)
 		if err != nil {
@@ -452,7 +933,7 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 
 		settings, err := h.settingsService.GetOrCreate(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to get settings for quiz",
+			h.log(ctx).Error("failed to get settings for quiz",
 				zap.Int64("user_id", userID),
 				zap.Error(err),
 			)
@@ -460,10 +941,14 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 			return h.send(msg)
 		}
 
+		if quizMode == "" {
+			quizMode = settings.QuizMode
+		}
+
 		// Check for active session.
 		activeSession, err := h.quizService.GetActiveSession(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to get active session",
+			h.log(ctx).Error("failed to get active session",
 				zap.Int64("user_id", userID),
 				zap.Error(err),
 			)
@@ -479,7 +964,7 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 
 			q, name, err := h.quizService.GetCurrentQuestion(ctx, activeSession.ID, activeSession.CurrentQuestionNum)
 			if err != nil {
-				h.logger.Error("failed to get current question for resume",
+				h.log(ctx).Error("failed to get current question for resume",
 					zap.Int64("session_id", activeSession.ID),
 					zap.Int("question_num", activeSession.CurrentQuestionNum),
 					zap.Error(err),
@@ -488,22 +973,22 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 			}
 
 			_ = h.send(newMessage(chatID, md("📝 Продолжаем квиз...")))
-			return h.sendQuizQuestionFromDB(chatID, activeSession, q, name, activeSession.CurrentQuestionNum, isFirstQuiz)
+			return h.sendQuizQuestionFromDB(ctx, chatID, activeSession, q, name, activeSession.CurrentQuestionNum, isFirstQuiz)
 		}
 
 		// Start new quiz session.
 		totalQuestions := 5 // Default number of questions.
-		h.logger.Debug("starting new quiz session",
+		h.log(ctx).Debug("starting new quiz session",
 			zap.Int64("user_id", userID),
 			zap.Int("total_questions", totalQuestions),
-			zap.String("quiz_mode", settings.QuizMode),
+			zap.String("quiz_mode", quizMode),
 		)
 
-		session, names, err := h.quizService.StartQuizSession(ctx, userID, totalQuestions)
+		session, names, err := h.quizService.StartQuizSessionWithMode(ctx, userID, totalQuestions, quizMode)
 		if err != nil {
-			h.logger.Error("failed to start quiz session",
+			h.log(ctx).Error("failed to start quiz session",
 				zap.Int64("user_id", userID),
-				zap.String("quiz_mode", settings.QuizMode),
+				zap.String("quiz_mode", quizMode),
 				zap.Error(err),
 			)
 
@@ -513,7 +998,7 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 					return h.send(newMessage(chatID, msgNoNewNames()))
 				}
 
-				if settings.LearningMode == string(entities.ModeGuided) && settings.QuizMode == "new" {
+				if settings.LearningMode == string(entities.ModeGuided) && quizMode == "new" {
 					return h.send(newMessage(chatID,
 						md("🆕 Новых вопросов нет.\n\n")+
 							md("В Guided режиме «Новые» — это только незавершённые имена из /today.\n")+
@@ -521,7 +1006,9 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 					))
 				}
 
-				switch settings.QuizMode {
+				switch quizMode {
+				case "favorites":
+					return h.send(newPlainMessage(chatID, msgNoFavorites))
 				case "review":
 					return h.send(newMessage(chatID, msgNoReviews()))
 				case "new":
@@ -533,7 +1020,7 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 			return h.send(newPlainMessage(chatID, msgQuizUnavailable))
 		}
 
-		h.logger.Debug("quiz session created",
+		h.log(ctx).Debug("quiz session created",
 			zap.Int64("session_id", session.ID),
 			zap.Int("names_count", len(names)),
 		)
@@ -541,17 +1028,66 @@ func (h *Handler) handleQuiz(userID int64) HandlerFunc {
 		// Store names for quick access during quiz.
 		h.quizStorage.Store(session.ID, names)
 
-		if err := h.send(newMessage(chatID, buildQuizStartMessage(settings.QuizMode))); err != nil {
+		h.analyticsService.Track(ctx, userID, entities.EventQuizStarted, map[string]any{
+			"session_id": session.ID,
+			"mode":       quizMode,
+			"questions":  len(names),
+		})
+
+		if err := h.send(newMessage(chatID, buildQuizStartMessage(quizMode))); err != nil {
 			return err
 		}
 
 		q, name, err := h.quizService.GetCurrentQuestion(ctx, session.ID, 1)
 		if err != nil {
-			h.logger.Error("failed to get first question", zap.Int64("session_id", session.ID), zap.Error(err))
+			h.log(ctx).Error("failed to get first question", zap.Int64("session_id", session.ID), zap.Error(err))
 			return h.send(newPlainMessage(chatID, msgQuizUnavailable))
 		}
 
-		return h.sendQuizQuestionFromDB(chatID, session, q, name, 1, isFirstQuiz)
+		return h.sendQuizQuestionFromDB(ctx, chatID, session, q, name, 1, isFirstQuiz)
+	}
+}
+
+// handleDeleteMyData shows the first confirmation of a full data deletion.
+func (h *Handler) handleDeleteMyData() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		msg := newMessage(chatID, formatDeleteDataWarning())
+		msg.ReplyMarkup = buildDeleteDataKeyboard()
+		return h.send(msg)
+	}
+}
+
+// handleRemindTest sends the reminder the scheduler would produce right now,
+// preceded by a summary of the reminder schedule. The schedule itself is not changed.
+func (h *Handler) handleRemindTest(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		reminder, err := h.reminderService.GetOrCreate(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		preview, err := h.reminderService.PreviewReminder(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		loc := time.UTC
+		if l, err := entities.ParseTimezoneLocation(preview.Timezone); err == nil {
+			loc = l
+		}
+
+		if err := h.send(newMessage(chatID, buildReminderPreviewMessage(reminder, preview, time.Now().In(loc)))); err != nil {
+			return err
+		}
+
+		switch {
+		case preview.Reminder != nil:
+			return h.SendReminder(ctx, userID, chatID, *preview.Reminder)
+		case preview.Digest != nil:
+			return h.SendDailyDigest(ctx, userID, chatID, *preview.Digest)
+		default:
+			return nil
+		}
 	}
 }
 
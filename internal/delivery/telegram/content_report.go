@@ -0,0 +1,308 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// adminReportsLimit is the number of open reports /admin_reports shows.
+const adminReportsLimit = 10
+
+// handleReportCallback handles the content error report flow on name cards
+// and the accept/dismiss buttons of reports sent to admins.
+func (h *Handler) handleReportCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) < 1 {
+		h.log(ctx).Warn("invalid report callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	chatID := cb.Message.Chat.ID
+
+	switch data.Params[0] {
+	case reportStart:
+		if len(data.Params) != 2 {
+			return nil
+		}
+		name, err := h.reportName(ctx, data.Params[1])
+		if err != nil || name == nil {
+			return err
+		}
+
+		msg := newPlainMessage(chatID, fmt.Sprintf(msgReportChooseField, name.Number, name.Transliteration))
+		msg.ReplyMarkup = reportFieldKeyboard(name.Number)
+		return h.send(msg)
+
+	case reportField:
+		if len(data.Params) != 3 {
+			return nil
+		}
+		name, err := h.reportName(ctx, data.Params[1])
+		if err != nil || name == nil {
+			return err
+		}
+		field, ok := entities.ParseContentReportField(data.Params[2])
+		if !ok {
+			h.log(ctx).Warn("invalid report field", zap.String("raw", data.Raw))
+			return nil
+		}
+
+		_ = h.send(tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID))
+
+		prompt := newPlainMessage(chatID, formatReportPrompt(name, field))
+		prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+
+		sent, err := h.bot.Send(prompt)
+		if err != nil {
+			return err
+		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+		h.setReportWaitState(ctx, cb.From.ID, reportWaitState{
+			ChatID:          chatID,
+			NameNumber:      name.Number,
+			Field:           field,
+			PromptMessageID: sent.MessageID,
+		})
+		return nil
+
+	case reportCancel:
+		return h.send(tgbotapi.NewDeleteMessage(chatID, cb.Message.MessageID))
+
+	case reportAccept, reportDismiss:
+		if len(data.Params) != 2 {
+			return nil
+		}
+		reportID, err := strconv.ParseInt(data.Params[1], 10, 64)
+		if err != nil {
+			h.log(ctx).Warn("invalid report id in callback", zap.String("raw", data.Raw))
+			return nil
+		}
+		return h.resolveReport(ctx, cb, reportID, data.Params[0] == reportAccept)
+
+	default:
+		h.log(ctx).Warn("unknown report sub-action", zap.String("raw", data.Raw))
+		return nil
+	}
+}
+
+// reportName returns the name a report callback refers to, or nil if the number is invalid.
+func (h *Handler) reportName(ctx context.Context, param string) (*entities.Name, error) {
+	nameNumber, err := strconv.Atoi(param)
+	if err != nil || nameNumber < 1 || nameNumber > 99 {
+		h.log(ctx).Warn("invalid name number in report callback", zap.String("param", param))
+		return nil, nil
+	}
+	return h.nameService.GetByNumber(ctx, nameNumber)
+}
+
+// handleReportText consumes the error description after the report prompt.
+func (h *Handler) handleReportText(from *tgbotapi.User, text string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		st, ok := h.reportWait.get(ctx, from.ID)
+		if !ok {
+			return nil
+		}
+
+		if isCancelText(text) {
+			h.clearReportWait(ctx, from.ID)
+			return h.send(newPlainMessage(chatID, msgReportCancelled))
+		}
+
+		report, err := h.reportService.Submit(ctx, from.ID, chatID, st.NameNumber, st.Field, text)
+		switch {
+		case errors.Is(err, service.ErrContentReportEmpty):
+			return h.sendFeedbackRetry(chatID, msgReportEmpty)
+		case errors.Is(err, service.ErrContentReportTooLong):
+			return h.sendFeedbackRetry(chatID, fmt.Sprintf(
+				"Описание слишком длинное. Максимум — %d символов.", entities.MaxContentReportLength,
+			))
+		case err != nil:
+			return err
+		}
+
+		h.clearReportWait(ctx, from.ID)
+
+		name, err := h.nameService.GetByNumber(ctx, report.NameNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, recipient := range h.reportService.Recipients() {
+			msg := newPlainMessage(recipient, formatReportForAdmins(report, name, from))
+			msg.ReplyMarkup = reportReviewKeyboard(report.ID)
+			if err := h.send(msg); err != nil {
+				h.log(ctx).Warn("failed to deliver content report to admins",
+					zap.Int64("report_id", report.ID),
+					zap.Int64("chat_id", recipient),
+					zap.Error(err),
+				)
+			}
+		}
+
+		return h.send(newPlainMessage(chatID, msgReportSent))
+	}
+}
+
+// resolveReport accepts or dismisses a report from its admin message and notifies the user on accept.
+func (h *Handler) resolveReport(ctx context.Context, cb *tgbotapi.CallbackQuery, reportID int64, accept bool) error {
+	allowed, err := h.adminService.Can(ctx, cb.From.ID, entities.AdminPermContent)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return h.answerCallback(cb.ID, msgAdminNoRights)
+	}
+
+	report, err := h.reportService.Resolve(ctx, reportID, accept, cb.From.ID)
+	switch {
+	case errors.Is(err, repository.ErrContentReportNotFound):
+		return h.answerCallback(cb.ID, msgReportResolved)
+	case errors.Is(err, service.ErrContentReportResolved):
+		_ = h.answerCallback(cb.ID, msgReportResolved)
+		return h.send(tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID,
+			cb.Message.Text+formatReportResolution(report)))
+	case err != nil:
+		return err
+	}
+
+	action := "report_dismiss"
+	if accept {
+		action = "report_accept"
+	}
+	h.adminService.LogAction(ctx, &entities.AdminAction{
+		AdminID:      cb.From.ID,
+		Action:       action,
+		TargetUserID: report.UserID,
+		Details:      fmt.Sprintf("report #%d, name %d, %s", report.ID, report.NameNumber, report.Field),
+	})
+
+	if err := h.send(tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID,
+		cb.Message.Text+formatReportResolution(report))); err != nil {
+		return err
+	}
+
+	if !accept {
+		return nil
+	}
+
+	_, err = h.sendNotification(ctx, newPlainMessage(report.ChatID, fmt.Sprintf(
+		"✅ Спасибо! Ошибку в карточке имени %d подтвердили, мы её исправим.", report.NameNumber,
+	)))
+	if err != nil && !errors.Is(err, service.ErrNotificationUndeliverable) {
+		h.log(ctx).Warn("failed to notify user about accepted report",
+			zap.Int64("report_id", report.ID),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// handleAdminReports re-sends the oldest open reports with review buttons: /admin_reports.
+func (h *Handler) handleAdminReports() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		reports, err := h.reportService.ListOpen(ctx, adminReportsLimit)
+		if err != nil {
+			return err
+		}
+		if len(reports) == 0 {
+			return h.send(newPlainMessage(chatID, msgReportNoneOpen))
+		}
+
+		for _, report := range reports {
+			name, err := h.nameService.GetByNumber(ctx, report.NameNumber)
+			if err != nil {
+				return err
+			}
+
+			msg := newPlainMessage(chatID, formatReportForAdmins(&report, name, nil))
+			msg.ReplyMarkup = reportReviewKeyboard(report.ID)
+			if err := h.send(msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// setReportWaitState sets the current report wait state and replaces any previous prompt.
+func (h *Handler) setReportWaitState(ctx context.Context, userID int64, st reportWaitState) {
+	if old, ok := h.reportWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
+	}
+	h.reportWait.set(ctx, userID, st)
+}
+
+// clearReportWait removes the report wait state and its prompt.
+func (h *Handler) clearReportWait(ctx context.Context, userID int64) {
+	if st, ok := h.reportWait.get(ctx, userID); ok && st.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+	}
+	h.reportWait.delete(ctx, userID)
+}
+
+// reportFieldTitle returns the button title of a report field.
+func reportFieldTitle(field entities.ContentReportField) string {
+	switch field {
+	case entities.ContentReportArabic:
+		return "Арабское написание"
+	case entities.ContentReportTransliteration:
+		return "Транслитерация"
+	case entities.ContentReportTranslation:
+		return "Перевод"
+	case entities.ContentReportMeaning:
+		return "Значение"
+	case entities.ContentReportAudio:
+		return "Аудио"
+	default:
+		return "Другое"
+	}
+}
+
+// formatReportPrompt renders the request to describe the error.
+func formatReportPrompt(name *entities.Name, field entities.ContentReportField) string {
+	return fmt.Sprintf(
+		"⚠️ Имя %d. %s · %s\n\nОпишите ошибку (до %d символов): что не так и как правильно.\nДля отмены напишите «отмена».",
+		name.Number, name.Transliteration, reportFieldTitle(field), entities.MaxContentReportLength,
+	)
+}
+
+// formatReportForAdmins renders a report for review. from is nil when the author is not at hand.
+func formatReportForAdmins(r *entities.ContentReport, name *entities.Name, from *tgbotapi.User) string {
+	author := fmt.Sprintf("id %d", r.UserID)
+	if from != nil {
+		author = strings.TrimSpace(from.FirstName+" "+from.LastName) + " (" + author + ")"
+		if from.UserName != "" {
+			author = "@" + from.UserName + " " + author
+		}
+	}
+
+	return fmt.Sprintf("⚠️ Ошибка в карточке #%d от %s\n\nИмя %d. %s · %s\n\n%s",
+		r.ID, author, name.Number, name.Transliteration, reportFieldTitle(r.Field), r.Text)
+}
+
+// formatReportResolution renders the line appended to a reviewed report.
+func formatReportResolution(r *entities.ContentReport) string {
+	status := "🚫 Отклонено"
+	if r.Status == entities.ContentReportAccepted {
+		status = "✅ Принято"
+	}
+	if r.ResolvedBy != nil {
+		return fmt.Sprintf("\n\n%s (%d)", status, *r.ResolvedBy)
+	}
+	return "\n\n" + status
+}
@@ -2,8 +2,10 @@ package telegram
 
 import (
 	"context"
+	"time"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/storage"
 )
@@ -12,6 +14,9 @@ import (
 type UserService interface {
 	EnsureUser(ctx context.Context, userID, chatID int64) (bool, error)
 	Exists(ctx context.Context, userID int64) (bool, error)
+	TouchActivity(ctx context.Context, userID int64) error
+	AttributeReferral(ctx context.Context, userID, referrerID int64) (bool, error)
+	GetReferralStats(ctx context.Context, userID int64) (*entities.ReferralStats, error)
 }
 
 // NameService interface for name-related operations.
@@ -19,14 +24,167 @@ type NameService interface {
 	GetByNumber(ctx context.Context, number int) (*entities.Name, error)
 	GetRandom(ctx context.Context) (*entities.Name, error)
 	GetAll(ctx context.Context) ([]*entities.Name, error)
+	Search(ctx context.Context, query string) ([]*entities.Name, error)
 }
 
 // ProgressService interface for progress-related operations.
 type ProgressService interface {
 	GetProgressSummary(ctx context.Context, userID int64) (*service.ProgressSummary, error)
 	GetNewNames(ctx context.Context, userID int64, limit int) ([]int, error)
-	GetStreak(ctx context.Context, userID int64, nameNumber int) (int, error)
+	GetStreaks(ctx context.Context, userID int64, nums []int) (map[int]int, error)
+	GetPhases(ctx context.Context, userID int64, nums []int) (map[int]entities.Phase, error)
 	GetByNumbers(ctx context.Context, userID int64, nums []int) (map[int]*entities.UserProgress, error)
+	GetDueForecast(ctx context.Context, userID int64, days int) ([]repository.DueForecastDay, error)
+	GetNameStats(ctx context.Context, userID int64, nameNumber int) (*service.NameStats, error)
+	GetMonthlyReport(ctx context.Context, userID int64) (*service.MonthlyReport, error)
+	GetActivityCalendar(ctx context.Context, userID int64) (*service.ActivityCalendar, error)
+	RecordSelfReview(ctx context.Context, userID int64, nameNumber int, remembered bool) (*entities.UserProgress, error)
+}
+
+// FavoritesService interface for bookmarked names.
+type FavoritesService interface {
+	Toggle(ctx context.Context, userID int64, nameNumber int) (bool, error)
+	IsFavorite(ctx context.Context, userID int64, nameNumber int) (bool, error)
+	List(ctx context.Context, userID int64) ([]*entities.Name, error)
+}
+
+// NotesService interface for personal notes on names.
+type NotesService interface {
+	Get(ctx context.Context, userID int64, nameNumber int) (*entities.NameNote, error)
+	Save(ctx context.Context, userID int64, nameNumber int, text string) error
+	Delete(ctx context.Context, userID int64, nameNumber int) error
+}
+
+// ExportService interface for personal data export.
+type ExportService interface {
+	Export(ctx context.Context, userID int64) (*service.UserExport, error)
+	AnkiDeck(ctx context.Context, userID int64) ([]byte, error)
+}
+
+// ImportService interface for restoring data from an export.
+type ImportService interface {
+	Import(ctx context.Context, userID int64, data []byte) (*service.ImportResult, error)
+	Restore(ctx context.Context, userID int64, data []byte) (*service.ImportResult, error)
+}
+
+// AdminService interface for admin roles and the audit log.
+type AdminService interface {
+	Can(ctx context.Context, userID int64, perm entities.AdminPermission) (bool, error)
+	Grant(ctx context.Context, userID int64, role string, grantedBy int64) error
+	Revoke(ctx context.Context, userID int64) error
+	List(ctx context.Context) ([]entities.Admin, error)
+	LogAction(ctx context.Context, action *entities.AdminAction)
+	RecentActions(ctx context.Context, limit int) ([]entities.AdminAction, error)
+}
+
+// UserAuditService reads the audit log of changes users made to their own state.
+type UserAuditService interface {
+	History(ctx context.Context, userID int64, limit int) ([]entities.UserAuditEntry, error)
+}
+
+// MessageTracker schedules bot messages that are only useful for a while for deletion.
+type MessageTracker interface {
+	Track(ctx context.Context, chatID int64, messageID int, kind entities.EphemeralKind)
+	Forget(ctx context.Context, chatID int64, messageID int)
+}
+
+// BlockListService keeps the users blocked by admins.
+type BlockListService interface {
+	IsBlocked(userID int64) bool
+	Block(ctx context.Context, userID int64, reason string, blockedBy int64) error
+	Unblock(ctx context.Context, userID int64) (bool, error)
+	List(ctx context.Context) ([]entities.BlockedUser, error)
+}
+
+// ConfigReloader reloads the configuration and applies the settings that can change without a restart.
+type ConfigReloader interface {
+	// Reload returns the applied changes and the changed keys that need a restart.
+	Reload() (applied, restart []string, err error)
+}
+
+// LogControls switch the log level and sampling while the bot is running.
+type LogControls interface {
+	Level() string
+	SetLevel(level string) error
+	Sampling() bool
+	SetSampling(on bool)
+}
+
+// TicketService interface for support tickets sent with /feedback.
+type TicketService interface {
+	Recipients() []int64
+	Submit(ctx context.Context, userID, chatID int64, text string) (*entities.Ticket, error)
+	TrackMessage(ctx context.Context, ticketID, chatID int64, messageID int) error
+	FindByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Ticket, error)
+	MarkAnswered(ctx context.Context, ticketID, adminID int64) error
+	Close(ctx context.Context, ticketID, adminID int64) (*entities.Ticket, error)
+	List(ctx context.Context, status entities.TicketStatus, limit int) ([]entities.Ticket, error)
+}
+
+// ContentReportService interface for reports about errors on name cards.
+type ContentReportService interface {
+	Recipients() []int64
+	Submit(
+		ctx context.Context,
+		userID, chatID int64,
+		nameNumber int,
+		field entities.ContentReportField,
+		text string,
+	) (*entities.ContentReport, error)
+	Resolve(ctx context.Context, id int64, accept bool, adminID int64) (*entities.ContentReport, error)
+	ListOpen(ctx context.Context, limit int) ([]entities.ContentReport, error)
+}
+
+// SurveyService interface for user surveys.
+type SurveyService interface {
+	Create(ctx context.Context, adminID int64, segment, definition string) (*entities.Survey, error)
+	Send(ctx context.Context, surveyID int64) (int, error)
+	Answer(ctx context.Context, userID, surveyID int64, question, option int) (*entities.Survey, bool, error)
+	Results(ctx context.Context, surveyID int64) (*entities.SurveyResults, error)
+	List(ctx context.Context, limit int) ([]entities.Survey, error)
+}
+
+// ChangelogService interface for release notes and their announcements.
+type ChangelogService interface {
+	Latest(limit int) []entities.Release
+	IsSubscribed(ctx context.Context, userID int64) (bool, error)
+	SetSubscribed(ctx context.Context, userID int64, subscribed bool) error
+}
+
+// AnalyticsService interface for recording product analytics events.
+type AnalyticsService interface {
+	Track(ctx context.Context, userID int64, name entities.EventName, properties map[string]any)
+	OnboardingFunnel(ctx context.Context, period time.Duration) (*entities.OnboardingFunnel, error)
+}
+
+// APITokenService interface for personal HTTP API tokens.
+type APITokenService interface {
+	Issue(ctx context.Context, userID int64) (string, error)
+	Revoke(ctx context.Context, userID int64) (bool, error)
+}
+
+// AdminSessionService interface for web admin sign-in links.
+type AdminSessionService interface {
+	LoginLink(adminID int64) (string, error)
+}
+
+// GroupChatService interface for group chat settings.
+type GroupChatService interface {
+	GetOrCreate(ctx context.Context, chatID int64, title string) (*entities.GroupChat, error)
+	Remove(ctx context.Context, chatID int64) error
+	SetLanguage(ctx context.Context, chatID int64, lang string) error
+	SetDailyPost(ctx context.Context, chatID int64, enabled bool) error
+	SetDailyQuiz(ctx context.Context, chatID int64, enabled bool) error
+	SetPostTime(ctx context.Context, chatID int64, at string) error
+	SetTimezone(ctx context.Context, chatID int64, tz string) error
+	RecordQuizResults(ctx context.Context, pollID string, voters, correct int) error
+	GetQuizStats(ctx context.Context, chatID int64) (entities.ChannelQuizStats, error)
+}
+
+// DeliveryStatsService interface for usage and notification delivery statistics.
+type DeliveryStatsService interface {
+	GetDeliveryStats(ctx context.Context, period time.Duration) (*entities.DeliveryStats, error)
+	GetOverview(ctx context.Context) (*entities.BotOverview, error)
 }
 
 // SettingsService interface for settings-related operations.
@@ -36,6 +194,8 @@ type SettingsService interface {
 	UpdateQuizMode(ctx context.Context, userID int64, quizMode string) error
 	UpdateLearningMode(ctx context.Context, userID int64, learningMode string) error
 	UpdateTimezone(ctx context.Context, userID int64, timezone string) error
+	UpdateCalendar(ctx context.Context, userID int64, calendar entities.Calendar) error
+	SetTargetDate(ctx context.Context, userID int64, date *time.Time) error
 }
 
 // QuizService interface for quiz-related operations.
@@ -43,8 +203,10 @@ type QuizService interface {
 	GetActiveSession(ctx context.Context, userID int64) (*entities.QuizSession, error)
 	GetCurrentQuestion(ctx context.Context, sessionID int64, questionNum int) (*entities.QuizQuestion, *entities.Name, error)
 	StartQuizSession(ctx context.Context, userID int64, totalQuestions int) (*entities.QuizSession, []entities.Name, error)
+	StartQuizSessionWithMode(ctx context.Context, userID int64, totalQuestions int, quizMode string) (*entities.QuizSession, []entities.Name, error)
 	SubmitAnswer(ctx context.Context, sessionID int64, userID int64, selectedOption string) (*service.AnswerResult, error)
 	IsFirstQuiz(ctx context.Context, userID int64) (bool, error)
+	AnswerReminderQuestion(ctx context.Context, userID int64, nameNumber int, questionType entities.QuestionType, chosenNumber int) (*service.AnswerResult, error)
 }
 
 // ReminderService interface for reminder-related operations.
@@ -54,8 +216,18 @@ type ReminderService interface {
 	ToggleReminder(ctx context.Context, userID int64) error
 	SetReminderIntervalHours(ctx context.Context, userID int64, intervalHours int) error
 	SetReminderTimeWindow(ctx context.Context, userID int64, startTime, endTime string) error
-	SnoozeReminder(ctx context.Context, userID int64) error
+	SnoozeReminder(ctx context.Context, userID int64, option entities.SnoozeOption) (time.Time, error)
 	DisableReminder(ctx context.Context, userID int64) error
+	ToggleWeeklyDigest(ctx context.Context, userID int64) error
+	SetReminderStyle(ctx context.Context, userID int64, style entities.ReminderStyle) error
+	Reschedule(ctx context.Context, userID int64) error
+	ToggleQuietWeekday(ctx context.Context, userID int64, weekday time.Weekday) error
+	ToggleQuietDate(ctx context.Context, userID int64, date time.Time) error
+	SetPrayerLocation(ctx context.Context, userID int64, latitude, longitude float64) error
+	TogglePrayerAnchor(ctx context.Context, userID int64, prayer entities.Prayer) error
+	SetPrayerOffset(ctx context.Context, userID int64, minutes int) error
+	PreviewReminder(ctx context.Context, userID int64) (*entities.ReminderPreview, error)
+	SetReminderContent(ctx context.Context, userID int64, content entities.ReminderContent) error
 }
 
 // DailyNameService provides daily plan operations for selecting and tracking names.
@@ -66,8 +238,15 @@ type DailyNameService interface {
 	GetOldestUnfinishedName(ctx context.Context, userID int64) (int, error)
 	HasUnfinishedDays(ctx context.Context, userID int64) (bool, error)
 	EnsureTodayPlan(ctx context.Context, userID int64, tz string, namesPerDay int) error
+	ReplanToday(ctx context.Context, userID int64, tz string, namesPerDay int) (*service.TodayReplan, error)
 	GetTodayNamesTZ(ctx context.Context, userID int64, tz string) ([]int, error)
 	AddTodayNameTZ(ctx context.Context, userID int64, tz string, nameNumber int) error
+	ShuffleToday(ctx context.Context, userID int64, tz string) error
+	SortToday(ctx context.Context, userID int64, tz string) error
+	MoveTodayName(ctx context.Context, userID int64, tz string, nameNumber, delta int) (int, error)
+	SwapTodayName(ctx context.Context, userID int64, tz string, nameNumber int) (int, error)
+	MarkTodayStudied(ctx context.Context, userID int64, tz string, nameNumber int) (bool, error)
+	GetTodayStudied(ctx context.Context, userID int64, tz string) ([]int, error)
 }
 
 // QuizStorage interface for quiz session storage.
@@ -87,7 +266,16 @@ type ReminderStorage interface {
 	Delete(userID int64)
 }
 
-// ResetService resets user progress and settings.
+// StateStore keeps JSON-encoded conversation state, in memory or shared between replicas.
+type StateStore interface {
+	Get(ctx context.Context, key string, v any) (bool, error)
+	GetMany(ctx context.Context, keys []string, vs []any) ([]bool, error)
+	Set(ctx context.Context, key string, v any, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// ResetService resets user progress and settings or erases the user completely.
 type ResetService interface {
 	ResetUser(ctx context.Context, userID int64) error
+	DeleteUser(ctx context.Context, userID int64) error
 }
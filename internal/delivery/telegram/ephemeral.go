@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// SetMessageTracker sets the tracker that schedules quiz questions, prompts and
+// reminders for deletion. Without it these messages stay in the chat.
+func (h *Handler) SetMessageTracker(tracker MessageTracker) {
+	h.messageTracker = tracker
+}
+
+// SetEditReminders makes a reminder replaced by the next one edited out instead of deleted.
+func (h *Handler) SetEditReminders(edit bool) {
+	h.editReminders = edit
+}
+
+// trackEphemeral schedules a sent message for deletion if a tracker is set.
+func (h *Handler) trackEphemeral(ctx context.Context, chatID int64, messageID int, kind entities.EphemeralKind) {
+	if h.messageTracker == nil {
+		return
+	}
+	h.messageTracker.Track(ctx, chatID, messageID, kind)
+}
+
+// expirePreviousReminder removes the last reminder sent to the user, if any, before
+// the next one is sent, so that only the latest reminder stays in the chat.
+func (h *Handler) expirePreviousReminder(userID int64) {
+	prev, ok := h.reminderStorage.Get(userID)
+	if !ok || prev.MessageID == 0 {
+		return
+	}
+
+	if h.editReminders {
+		_ = h.EditOutMessage(prev.ChatID, prev.MessageID)
+	} else {
+		_ = h.DeleteMessage(prev.ChatID, prev.MessageID)
+	}
+	h.reminderStorage.Delete(userID)
+
+	if h.messageTracker != nil {
+		h.messageTracker.Forget(context.Background(), prev.ChatID, prev.MessageID)
+	}
+}
+
+// DeleteMessage deletes a bot message from a chat.
+func (h *Handler) DeleteMessage(chatID int64, messageID int) error {
+	_, err := h.bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
+	return err
+}
+
+// EditOutMessage replaces the text of a bot message with a note that it is outdated,
+// which also removes its buttons. Unlike deletion this works on messages of any age.
+func (h *Handler) EditOutMessage(chatID int64, messageID int) error {
+	return h.send(tgbotapi.NewEditMessageText(chatID, messageID, msgReminderExpired))
+}
@@ -0,0 +1,318 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+const (
+	// feedbackQuoteLength is how much of the user's message is quoted above an admin answer.
+	feedbackQuoteLength = 200
+	// ticketListQuoteLength is how much of each ticket /admin_tickets shows.
+	ticketListQuoteLength = 60
+	// ticketListDefaultLimit is the number of tickets /admin_tickets shows.
+	ticketListDefaultLimit = 20
+)
+
+// handleFeedback sends feedback given as command arguments or asks the user to write it.
+func (h *Handler) handleFeedback(from *tgbotapi.User, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if strings.TrimSpace(args) != "" {
+			return h.submitFeedback(ctx, chatID, from, args)
+		}
+
+		prompt := newPlainMessage(chatID, msgFeedbackPrompt)
+		prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+
+		sent, err := h.bot.Send(prompt)
+		if err != nil {
+			return err
+		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+		h.setFeedbackWaitState(ctx, from.ID, feedbackWaitState{
+			ChatID:          chatID,
+			PromptMessageID: sent.MessageID,
+		})
+		return nil
+	}
+}
+
+// handleFeedbackText consumes the feedback text after the /feedback prompt.
+func (h *Handler) handleFeedbackText(from *tgbotapi.User, text string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if isCancelText(text) {
+			h.clearFeedbackWait(ctx, from.ID)
+			return h.send(newPlainMessage(chatID, msgFeedbackCancelled))
+		}
+		return h.submitFeedback(ctx, chatID, from, text)
+	}
+}
+
+// submitFeedback opens a ticket and sends a copy to every recipient chat.
+func (h *Handler) submitFeedback(ctx context.Context, chatID int64, from *tgbotapi.User, text string) error {
+	ticket, err := h.ticketService.Submit(ctx, from.ID, chatID, text)
+	switch {
+	case errors.Is(err, service.ErrTicketEmpty):
+		return h.sendFeedbackRetry(chatID, msgFeedbackEmpty)
+	case errors.Is(err, service.ErrTicketTooLong):
+		return h.sendFeedbackRetry(chatID, fmt.Sprintf(
+			"Сообщение слишком длинное. Максимум — %d символов.", entities.MaxTicketLength,
+		))
+	case err != nil:
+		return err
+	}
+
+	h.clearFeedbackWait(ctx, from.ID)
+
+	for _, recipient := range h.ticketService.Recipients() {
+		msg := newPlainMessage(recipient, formatTicketForAdmins(ticket, from))
+		msg.ReplyMarkup = ticketAdminKeyboard(ticket.ID)
+
+		sent, err := h.bot.Send(msg)
+		if err != nil {
+			h.log(ctx).Warn("failed to deliver ticket to admins",
+				zap.Int64("ticket_id", ticket.ID),
+				zap.Int64("chat_id", recipient),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := h.ticketService.TrackMessage(ctx, ticket.ID, recipient, sent.MessageID); err != nil {
+			h.log(ctx).Warn("failed to track ticket message",
+				zap.Int64("ticket_id", ticket.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return h.send(newPlainMessage(chatID, fmt.Sprintf(msgFeedbackSent, ticket.ID)))
+}
+
+// handleFeedbackReply relays an admin's reply to a ticket copy back to the user and marks the ticket answered.
+// It reports false if the message is not such a reply, so it is processed as usual.
+func (h *Handler) handleFeedbackReply(ctx context.Context, msg *tgbotapi.Message) bool {
+	reply := msg.ReplyToMessage
+	if reply == nil || reply.From == nil || reply.From.ID != h.bot.Self.ID || msg.From == nil || msg.IsCommand() {
+		return false
+	}
+
+	ticket, err := h.ticketService.FindByMessage(ctx, msg.Chat.ID, reply.MessageID)
+	if err != nil {
+		h.log(ctx).Error("failed to find ticket by message",
+			zap.Int64("chat_id", msg.Chat.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+	if ticket == nil {
+		return false
+	}
+
+	allowed, err := h.adminService.Can(ctx, msg.From.ID, entities.AdminPermSupport)
+	if err != nil || !allowed {
+		return false
+	}
+
+	answer := strings.TrimSpace(msg.Text)
+	if answer == "" {
+		_ = h.send(newPlainMessage(msg.Chat.ID, msgFeedbackNotText))
+		return true
+	}
+
+	_, err = h.sendNotification(ctx, newPlainMessage(ticket.ChatID, formatTicketAnswer(ticket, answer)))
+	switch {
+	case errors.Is(err, service.ErrNotificationUndeliverable):
+		_ = h.send(newPlainMessage(msg.Chat.ID, msgFeedbackBlocked))
+		return true
+	case err != nil:
+		h.log(ctx).Error("failed to relay ticket answer",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+		_ = h.send(h.internalErrorMessage(ctx, msg.Chat.ID))
+		return true
+	}
+
+	if err := h.ticketService.MarkAnswered(ctx, ticket.ID, msg.From.ID); err != nil {
+		h.log(ctx).Warn("failed to mark ticket answered",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+	}
+	h.adminService.LogAction(ctx, &entities.AdminAction{
+		AdminID:      msg.From.ID,
+		Action:       "ticket_reply",
+		TargetUserID: ticket.UserID,
+		Details:      fmt.Sprintf("ticket #%d", ticket.ID),
+	})
+
+	_ = h.send(newPlainMessage(msg.Chat.ID, msgFeedbackAnswered))
+	return true
+}
+
+// handleTicketCallback closes a ticket from the button on its admin copy.
+func (h *Handler) handleTicketCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 2 || data.Params[0] != ticketClose {
+		h.log(ctx).Warn("invalid ticket callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	ticketID, err := strconv.ParseInt(data.Params[1], 10, 64)
+	if err != nil {
+		h.log(ctx).Warn("invalid ticket id in callback", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	allowed, err := h.adminService.Can(ctx, cb.From.ID, entities.AdminPermSupport)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return h.answerCallback(cb.ID, msgAdminNoRights)
+	}
+
+	ticket, err := h.ticketService.Close(ctx, ticketID, cb.From.ID)
+	switch {
+	case errors.Is(err, repository.ErrTicketNotFound):
+		return h.answerCallback(cb.ID, msgTicketClosed)
+	case errors.Is(err, service.ErrTicketClosed):
+		_ = h.answerCallback(cb.ID, msgTicketClosed)
+	case err != nil:
+		return err
+	default:
+		h.adminService.LogAction(ctx, &entities.AdminAction{
+			AdminID:      cb.From.ID,
+			Action:       "ticket_close",
+			TargetUserID: ticket.UserID,
+			Details:      fmt.Sprintf("ticket #%d", ticket.ID),
+		})
+	}
+
+	closed := "\n\n🔒 Закрыто"
+	if ticket.ClosedBy != nil {
+		closed += fmt.Sprintf(" (%d)", *ticket.ClosedBy)
+	}
+	return h.send(tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, cb.Message.Text+closed))
+}
+
+// handleAdminTickets lists the latest tickets: /admin_tickets [open|answered|closed|all].
+func (h *Handler) handleAdminTickets(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		status := entities.TicketOpen
+		switch arg := strings.ToLower(strings.TrimSpace(args)); arg {
+		case "":
+		case "all":
+			status = ""
+		default:
+			st, ok := entities.ParseTicketStatus(arg)
+			if !ok {
+				return h.send(newPlainMessage(chatID, msgTicketsUsage))
+			}
+			status = st
+		}
+
+		tickets, err := h.ticketService.List(ctx, status, ticketListDefaultLimit)
+		if err != nil {
+			return err
+		}
+		if len(tickets) == 0 {
+			return h.send(newPlainMessage(chatID, "Обращений нет."))
+		}
+
+		return h.send(newPlainMessage(chatID, formatTicketList(tickets)))
+	}
+}
+
+// sendFeedbackRetry asks the user to re-enter the feedback.
+func (h *Handler) sendFeedbackRetry(chatID int64, text string) error {
+	msg := newPlainMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+	return h.send(msg)
+}
+
+// setFeedbackWaitState sets the current feedback wait state and replaces any previous prompt.
+func (h *Handler) setFeedbackWaitState(ctx context.Context, userID int64, st feedbackWaitState) {
+	if old, ok := h.feedbackWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
+	}
+	h.feedbackWait.set(ctx, userID, st)
+}
+
+// clearFeedbackWait removes the feedback wait state and its prompt.
+func (h *Handler) clearFeedbackWait(ctx context.Context, userID int64) {
+	if st, ok := h.feedbackWait.get(ctx, userID); ok && st.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+	}
+	h.feedbackWait.delete(ctx, userID)
+}
+
+// formatTicketForAdmins renders the copy of a ticket sent to admins.
+func formatTicketForAdmins(t *entities.Ticket, from *tgbotapi.User) string {
+	author := strings.TrimSpace(from.FirstName + " " + from.LastName)
+	if from.UserName != "" {
+		author += " @" + from.UserName
+	}
+
+	return fmt.Sprintf("📨 Обращение #%d от %s (id %d)\n\n%s\n\n↩️ Ответьте на это сообщение, чтобы ответить пользователю.",
+		t.ID, author, t.UserID, t.Text)
+}
+
+// formatTicketAnswer renders an admin answer for the user, quoting the start of their message.
+func formatTicketAnswer(t *entities.Ticket, answer string) string {
+	return fmt.Sprintf("💬 Ответ на обращение #%d «%s»:\n\n%s\n\nНаписать ещё: /feedback",
+		t.ID, truncateRunes(t.Text, feedbackQuoteLength), answer)
+}
+
+// formatTicketList renders the /admin_tickets list, newest first.
+func formatTicketList(tickets []entities.Ticket) string {
+	var sb strings.Builder
+	sb.WriteString("📨 Обращения (UTC)\n")
+	for _, t := range tickets {
+		fmt.Fprintf(&sb, "\n#%d · %s · %s · %d\n%s\n",
+			t.ID,
+			ticketStatusTitle(t.Status),
+			t.CreatedAt.UTC().Format("2006-01-02 15:04"),
+			t.UserID,
+			truncateRunes(t.Text, ticketListQuoteLength),
+		)
+	}
+	return sb.String()
+}
+
+// ticketStatusTitle returns the label of a ticket status.
+func ticketStatusTitle(status entities.TicketStatus) string {
+	switch status {
+	case entities.TicketAnswered:
+		return "💬 отвечено"
+	case entities.TicketClosed:
+		return "🔒 закрыто"
+	default:
+		return "🆕 открыто"
+	}
+}
+
+// truncateRunes shortens s to n characters, marking the cut with an ellipsis.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// updateLimit is the per-user limit an update was dropped by.
+type updateLimit int
+
+const (
+	limitNone      updateLimit = iota
+	limitBurst                 // too many updates in a quick row, see RuntimeSettings.UserBurst
+	limitSustained             // too many updates in a minute, see RuntimeSettings.UserUpdatesPerMinute
+)
+
+// userLimiter holds the rate limiters of a user; a nil limiter means no limit.
+type userLimiter struct {
+	burst     *rate.Limiter // UserBurst updates in a row, one more every UserRefill
+	sustained *rate.Limiter // UserUpdatesPerMinute updates a minute
+	warned    bool          // the user was told to slow down since the last allowed update
+}
+
+// newUserLimiter builds the limiters of a user from the settings.
+func newUserLimiter(settings RuntimeSettings) *userLimiter {
+	l := &userLimiter{}
+	if settings.UserBurst > 0 && settings.UserRefill > 0 {
+		l.burst = rate.NewLimiter(rate.Every(settings.UserRefill), settings.UserBurst)
+	}
+	if n := settings.UserUpdatesPerMinute; n > 0 {
+		l.sustained = rate.NewLimiter(rate.Every(time.Minute/time.Duration(n)), n)
+	}
+	return l
+}
+
+// idle reports whether the limiters are full again, so that dropping them changes nothing.
+func (l *userLimiter) idle(now time.Time) bool {
+	return full(l.burst, now) && full(l.sustained, now)
+}
+
+// full reports whether a limiter has all of its tokens; no limiter is always full.
+func full(lim *rate.Limiter, now time.Time) bool {
+	return lim == nil || lim.TokensAt(now) >= float64(lim.Burst())
+}
+
+// hasToken reports whether a limiter allows an event now without taking a token.
+func hasToken(lim *rate.Limiter, now time.Time) bool {
+	return lim == nil || lim.TokensAt(now) >= 1
+}
+
+// allowUpdate checks an update of a user against the burst and the sustained limit and
+// returns the limit that drops it, limitNone if it is allowed. A dropped update takes no
+// token from either limit. warn is true only for the first update in a row dropped by
+// the burst limit, so that a flood gets a single reply.
+func (h *Handler) allowUpdate(userID int64, now time.Time) (limit updateLimit, warn bool) {
+	h.runtime.mu.Lock()
+	defer h.runtime.mu.Unlock()
+
+	settings := h.runtime.settings
+	if settings.UserUpdatesPerMinute <= 0 && (settings.UserBurst <= 0 || settings.UserRefill <= 0) {
+		return limitNone, false
+	}
+
+	if h.runtime.limiters == nil {
+		h.runtime.limiters = make(map[int64]*userLimiter)
+	}
+	if len(h.runtime.limiters) >= rateLimitSweepSize {
+		for id, l := range h.runtime.limiters {
+			if l.idle(now) {
+				delete(h.runtime.limiters, id)
+			}
+		}
+	}
+
+	l, ok := h.runtime.limiters[userID]
+	if !ok {
+		l = newUserLimiter(settings)
+		h.runtime.limiters[userID] = l
+	}
+
+	switch {
+	case !hasToken(l.burst, now):
+		warn = !l.warned
+		l.warned = true
+		return limitBurst, warn
+	case !hasToken(l.sustained, now):
+		return limitSustained, false
+	}
+
+	if l.burst != nil {
+		l.burst.AllowN(now, 1)
+	}
+	if l.sustained != nil {
+		l.sustained.AllowN(now, 1)
+	}
+	l.warned = false
+	return limitNone, false
+}
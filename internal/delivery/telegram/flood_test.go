@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllowUpdateBurst(t *testing.T) {
+	h := &Handler{}
+	h.ApplyRuntime(RuntimeSettings{UserBurst: 3, UserRefill: time.Second})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if limit, _ := h.allowUpdate(1, now); limit != limitNone {
+			t.Fatalf("update %d dropped by %d, want allowed", i+1, limit)
+		}
+	}
+
+	if limit, warn := h.allowUpdate(1, now); limit != limitBurst || !warn {
+		t.Fatalf("4th update = %d, warn %v; want burst limit with a warning", limit, warn)
+	}
+	if limit, warn := h.allowUpdate(1, now.Add(100*time.Millisecond)); limit != limitBurst || warn {
+		t.Fatalf("5th update = %d, warn %v; want burst limit without a warning", limit, warn)
+	}
+
+	// Another user has a bucket of their own.
+	if limit, _ := h.allowUpdate(2, now); limit != limitNone {
+		t.Fatalf("other user dropped by %d, want allowed", limit)
+	}
+
+	// A token comes back after UserRefill, and an allowed update re-arms the warning.
+	now = now.Add(time.Second)
+	if limit, _ := h.allowUpdate(1, now); limit != limitNone {
+		t.Fatalf("update after refill dropped by %d, want allowed", limit)
+	}
+	if limit, warn := h.allowUpdate(1, now); limit != limitBurst || !warn {
+		t.Fatalf("update after refill = %d, warn %v; want burst limit with a warning", limit, warn)
+	}
+}
+
+func TestAllowUpdateSustained(t *testing.T) {
+	h := &Handler{}
+	h.ApplyRuntime(RuntimeSettings{UserUpdatesPerMinute: 6, UserBurst: 3, UserRefill: time.Second})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Three quick updates every few seconds stay within the burst limit, but the sixth
+	// update within a minute uses up the sustained one.
+	for i := range 6 {
+		at := now.Add(time.Duration(i/3) * 5 * time.Second)
+		if limit, _ := h.allowUpdate(1, at); limit != limitNone {
+			t.Fatalf("update %d dropped by %d, want allowed", i+1, limit)
+		}
+	}
+
+	now = now.Add(8 * time.Second)
+	if limit, warn := h.allowUpdate(1, now); limit != limitSustained || warn {
+		t.Fatalf("7th update = %d, warn %v; want sustained limit without a warning", limit, warn)
+	}
+
+	// A dropped update takes no burst token: the burst bucket is still full.
+	if limit, _ := h.allowUpdate(1, now.Add(2*time.Second)); limit != limitNone {
+		t.Fatalf("update after 10s dropped by %d, want allowed", limit)
+	}
+}
+
+func TestAllowUpdateNoLimits(t *testing.T) {
+	h := &Handler{}
+	now := time.Now()
+	for range 100 {
+		if limit, _ := h.allowUpdate(1, now); limit != limitNone {
+			t.Fatalf("update dropped by %d with no limits set", limit)
+		}
+	}
+}
+
+func TestApplyRuntimeResetsLimiters(t *testing.T) {
+	h := &Handler{}
+	h.ApplyRuntime(RuntimeSettings{UserBurst: 1, UserRefill: time.Hour})
+	now := time.Now()
+
+	h.allowUpdate(1, now)
+	if limit, _ := h.allowUpdate(1, now); limit != limitBurst {
+		t.Fatalf("second update = %d, want burst limit", limit)
+	}
+
+	h.ApplyRuntime(RuntimeSettings{UserBurst: 5, UserRefill: time.Second})
+	if limit, _ := h.allowUpdate(1, now); limit != limitNone {
+		t.Fatalf("update after reload dropped by %d, want allowed", limit)
+	}
+}
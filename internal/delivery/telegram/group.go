@@ -0,0 +1,431 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// groupPrivateCommands are personal commands that only work in the private chat with the bot.
+var groupPrivateCommands = map[string]struct{}{
+	"today":        {},
+	"all":          {},
+	"progress":     {},
+	"favorites":    {},
+	"find":         {},
+	"schedule":     {},
+	"report":       {},
+	"goal":         {},
+	"quiz":         {},
+	"export":       {},
+	"import":       {},
+	"remindtest":   {},
+	"reset":        {},
+	"deletemydata": {},
+	"feedback":     {},
+	"whatsnew":     {},
+	"apitoken":     {},
+	"app":          {},
+}
+
+// isGroupChat reports whether the chat is a group or a supergroup.
+func isGroupChat(chat *tgbotapi.Chat) bool {
+	return chat != nil && (chat.IsGroup() || chat.IsSuperGroup())
+}
+
+// handleMyChatMember registers a group when the bot is added to it and forgets it when the bot is removed.
+func (h *Handler) handleMyChatMember(ctx context.Context, upd *tgbotapi.ChatMemberUpdated) {
+	if !isGroupChat(&upd.Chat) {
+		return
+	}
+
+	wasMember := isChatMemberPresent(upd.OldChatMember)
+	isMember := isChatMemberPresent(upd.NewChatMember)
+
+	switch {
+	case isMember && !wasMember:
+		group, err := h.groupService.GetOrCreate(ctx, upd.Chat.ID, upd.Chat.Title)
+		if err != nil {
+			h.log(ctx).Error("failed to register group",
+				zap.Int64("chat_id", upd.Chat.ID),
+				zap.Error(err),
+			)
+			return
+		}
+		h.sendGroupHelp(group)
+
+	case !isMember && wasMember:
+		if err := h.groupService.Remove(ctx, upd.Chat.ID); err != nil {
+			h.log(ctx).Error("failed to remove group",
+				zap.Int64("chat_id", upd.Chat.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// isChatMemberPresent reports whether the member status means being in the chat.
+func isChatMemberPresent(m tgbotapi.ChatMember) bool {
+	return !m.HasLeft() && !m.WasKicked()
+}
+
+// handleGroupMessage processes commands sent in a group. Only commands that make sense
+// for the whole chat are served; personal ones point to the private chat.
+func (h *Handler) handleGroupMessage(ctx context.Context, msg *tgbotapi.Message) {
+	if !msg.IsCommand() || !h.isAddressedToBot(msg) {
+		return
+	}
+
+	chatID := msg.Chat.ID
+	group, err := h.groupService.GetOrCreate(ctx, chatID, msg.Chat.Title)
+	if err != nil {
+		h.log(ctx).Error("failed to get group settings",
+			zap.Int64("chat_id", chatID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	command := msg.Command()
+	switch command {
+	case "start", "help":
+		h.sendGroupHelp(group)
+
+	case "name":
+		_ = h.withErrorHandling(h.handleGroupName(group, msg.CommandArguments()))(ctx, chatID)
+
+	case "random":
+		_ = h.withErrorHandling(h.handleGroupRandom(group))(ctx, chatID)
+
+	case "settings":
+		if !h.isGroupAdmin(chatID, msg.From, msg.SenderChat) {
+			_ = h.send(newPlainMessage(chatID, groupAdminOnlyText(group.LanguageCode)))
+			return
+		}
+		_ = h.withErrorHandling(h.handleGroupSettings(group, msg.CommandArguments()))(ctx, chatID)
+
+	default:
+		if _, ok := groupPrivateCommands[command]; !ok {
+			return // unknown commands may belong to other bots in the group
+		}
+		reply := newPlainMessage(chatID, groupPrivateOnlyText(group.LanguageCode))
+		reply.ReplyToMessageID = msg.MessageID
+		if h.bot.Self.UserName != "" {
+			reply.ReplyMarkup = privateChatKeyboard(h.bot.Self.UserName, group.LanguageCode)
+		}
+		_ = h.send(reply)
+	}
+}
+
+// isAddressedToBot reports whether a command has no bot mention or mentions this bot.
+func (h *Handler) isAddressedToBot(msg *tgbotapi.Message) bool {
+	_, mention, ok := strings.Cut(msg.CommandWithAt(), "@")
+	return !ok || strings.EqualFold(mention, h.bot.Self.UserName)
+}
+
+// isGroupAdmin reports whether the sender may change group settings.
+// Anonymous administrators send messages on behalf of the group itself.
+func (h *Handler) isGroupAdmin(chatID int64, from *tgbotapi.User, senderChat *tgbotapi.Chat) bool {
+	if senderChat != nil && senderChat.ID == chatID {
+		return true
+	}
+	if from == nil {
+		return false
+	}
+
+	member, err := h.bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: from.ID},
+	})
+	if err != nil {
+		h.logger.Warn("failed to get chat member",
+			zap.Int64("chat_id", chatID),
+			zap.Int64("user_id", from.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+// groupLang returns the language of a group, Russian if its settings cannot be loaded.
+func (h *Handler) groupLang(ctx context.Context, chat *tgbotapi.Chat) string {
+	group, err := h.groupService.GetOrCreate(ctx, chat.ID, chat.Title)
+	if err != nil {
+		return langRU
+	}
+	return group.LanguageCode
+}
+
+// sendGroupHelp sends the list of commands available in groups.
+func (h *Handler) sendGroupHelp(group *entities.GroupChat) {
+	msg := newMessage(group.ChatID, groupHelpMessage(group.LanguageCode))
+	if h.bot.Self.UserName != "" {
+		msg.ReplyMarkup = privateChatKeyboard(h.bot.Self.UserName, group.LanguageCode)
+	}
+	if err := h.send(msg); err != nil {
+		h.logger.Error("failed to send group help message",
+			zap.Int64("chat_id", group.ChatID),
+			zap.Error(err),
+		)
+	}
+}
+
+// handleGroupName sends the card of a name by number in the group language.
+func (h *Handler) handleGroupName(group *entities.GroupChat, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(args), "#"))
+		if err != nil || n < 1 || n > 99 {
+			return h.send(newPlainMessage(chatID, groupNameUsageText(group.LanguageCode)))
+		}
+
+		name, err := h.nameService.GetByNumber(ctx, n)
+		if err != nil {
+			return err
+		}
+		return h.sendGroupNameCard(group, name)
+	}
+}
+
+// handleGroupRandom sends a random name card in the group language.
+func (h *Handler) handleGroupRandom(group *entities.GroupChat) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		name, err := h.nameService.GetRandom(ctx)
+		if err != nil {
+			return err
+		}
+		return h.sendGroupNameCard(group, name)
+	}
+}
+
+// sendGroupNameCard sends a name card with a link to study it in the private chat, followed by its audio.
+func (h *Handler) sendGroupNameCard(group *entities.GroupChat, name *entities.Name) error {
+	msg := newMessage(group.ChatID, formatLocalizedNameMessage(name, group.LanguageCode))
+	if h.bot.Self.UserName != "" {
+		msg.ReplyMarkup = inlineNameKeyboard(buildNameDeepLink(h.bot.Self.UserName, name.Number), group.LanguageCode)
+	}
+	if err := h.send(msg); err != nil {
+		return err
+	}
+
+	if name.Audio != "" {
+		_ = h.send(*buildNameAudio(name, group.ChatID))
+	}
+	return nil
+}
+
+// handleGroupSettings shows group settings; "/settings tz <zone>" sets the timezone of the daily post.
+func (h *Handler) handleGroupSettings(group *entities.GroupChat, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		fields := strings.Fields(args)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "tz") {
+			err := h.groupService.SetTimezone(ctx, chatID, fields[1])
+			if errors.Is(err, service.ErrInvalidGroupSetting) {
+				return h.send(newPlainMessage(chatID, groupInvalidTimezoneText(group.LanguageCode)))
+			}
+			if err != nil {
+				return err
+			}
+
+			group, err = h.groupService.GetOrCreate(ctx, chatID, group.Title)
+			if err != nil {
+				return err
+			}
+		}
+
+		msg := newMessage(chatID, formatGroupSettings(group, h.groupQuizStats(ctx, chatID)))
+		msg.ReplyMarkup = groupSettingsKeyboard(group)
+		return h.send(msg)
+	}
+}
+
+// handleGroupCallback changes a group setting from the settings keyboard. Only group admins may do it.
+func (h *Handler) handleGroupCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil || !isGroupChat(cb.Message.Chat) {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) == 0 {
+		return nil
+	}
+
+	chat := cb.Message.Chat
+	group, err := h.groupService.GetOrCreate(ctx, chat.ID, chat.Title)
+	if err != nil {
+		return err
+	}
+
+	if !h.isGroupAdmin(chat.ID, cb.From, nil) {
+		return h.answerCallback(cb.ID, groupAdminOnlyText(group.LanguageCode))
+	}
+
+	switch data.Params[0] {
+	case groupLanguage:
+		lang := langEN
+		if group.LanguageCode == langEN {
+			lang = langRU
+		}
+		err = h.groupService.SetLanguage(ctx, chat.ID, lang)
+	case groupDailyPost:
+		err = h.groupService.SetDailyPost(ctx, chat.ID, !group.DailyPost)
+	case groupDailyQuiz:
+		err = h.groupService.SetDailyQuiz(ctx, chat.ID, !group.DailyQuiz)
+	case groupPostTime:
+		err = h.groupService.SetPostTime(ctx, chat.ID, entities.NextGroupPostTime(group.PostTime))
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	group, err = h.groupService.GetOrCreate(ctx, chat.ID, chat.Title)
+	if err != nil {
+		return err
+	}
+
+	edit := newEdit(chat.ID, cb.Message.MessageID, formatGroupSettings(group, h.groupQuizStats(ctx, chat.ID)))
+	kb := groupSettingsKeyboard(group)
+	edit.ReplyMarkup = &kb
+	return h.send(edit)
+}
+
+// groupQuizStats returns mini-quiz totals of a group; they are informational, so errors only get logged.
+func (h *Handler) groupQuizStats(ctx context.Context, chatID int64) entities.ChannelQuizStats {
+	stats, err := h.groupService.GetQuizStats(ctx, chatID)
+	if err != nil {
+		h.log(ctx).Warn("failed to get group quiz stats",
+			zap.Int64("chat_id", chatID),
+			zap.Error(err),
+		)
+	}
+	return stats
+}
+
+// handlePollUpdate records answer counts of a mini-quiz. Telegram sends the new state
+// of every poll the bot has sent whenever somebody answers it.
+func (h *Handler) handlePollUpdate(ctx context.Context, poll *tgbotapi.Poll) {
+	if poll.Type != "quiz" || poll.CorrectOptionID < 0 || poll.CorrectOptionID >= len(poll.Options) {
+		return
+	}
+
+	correct := poll.Options[poll.CorrectOptionID].VoterCount
+	if err := h.groupService.RecordQuizResults(ctx, poll.ID, poll.TotalVoterCount, correct); err != nil {
+		h.log(ctx).Error("failed to record group quiz results",
+			zap.String("poll_id", poll.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// groupHelpMessage lists commands available in groups (MarkdownV2 safe).
+func groupHelpMessage(lang string) string {
+	if lang == langEN {
+		return "🤲 " + bold("Asma ul-Husna in this group") + "\n\n" +
+			"/name N — " + md("card of name N (1–99)") + "\n" +
+			"/random — " + md("a random name") + "\n" +
+			"/settings — " + md("group settings: language, the daily name of the day and a mini-quiz after it (admins only)") + "\n\n" +
+			md("Personal learning, quizzes and progress are available in the private chat with the bot.")
+	}
+	return "🤲 " + bold("Асма уль-Хусна в группе") + "\n\n" +
+		"/name N — " + md("карточка имени N (1–99)") + "\n" +
+		"/random — " + md("случайное имя") + "\n" +
+		"/settings — " + md("настройки группы: язык, ежедневное «Имя дня» и мини-квиз после него (для администраторов)") + "\n\n" +
+		md("Личное изучение, квизы и прогресс — в личном чате с ботом.")
+}
+
+// formatGroupSettings formats group settings and mini-quiz totals in the group language (MarkdownV2 safe).
+func formatGroupSettings(group *entities.GroupChat, stats entities.ChannelQuizStats) string {
+	if group.LanguageCode == langEN {
+		post, quiz := "off", "off"
+		if group.DailyPost {
+			post = "on, at " + group.PostTime
+		}
+		if group.DailyQuiz {
+			quiz = "after the name of the day"
+		}
+		text := "⚙️ " + bold("Group settings") + "\n\n" +
+			md("🌐 Language: English") + "\n" +
+			md("📬 Name of the day: "+post) + "\n" +
+			md("🧩 Mini-quiz: "+quiz) + "\n" +
+			md("🕰 Timezone: "+group.Timezone) + "\n\n"
+		if stats.Quizzes > 0 {
+			text += md(fmt.Sprintf("📊 Mini-quizzes: %d, answers: %d, correct: %d%%", stats.Quizzes, stats.Answers, stats.CorrectPercent())) + "\n\n"
+		}
+		return text + md("Change the timezone with /settings tz Europe/London or /settings tz +3.")
+	}
+
+	post, quiz := "выключено", "выключен"
+	if group.DailyPost {
+		post = "включено, в " + group.PostTime
+	}
+	if group.DailyQuiz {
+		quiz = "после имени дня"
+	}
+	text := "⚙️ " + bold("Настройки группы") + "\n\n" +
+		md("🌐 Язык: русский") + "\n" +
+		md("📬 Имя дня: "+post) + "\n" +
+		md("🧩 Мини-квиз: "+quiz) + "\n" +
+		md("🕰 Часовой пояс: "+group.Timezone) + "\n\n"
+	if stats.Quizzes > 0 {
+		text += md(fmt.Sprintf("📊 Мини-квизов: %d, ответов: %d, верных: %d%%", stats.Quizzes, stats.Answers, stats.CorrectPercent())) + "\n\n"
+	}
+	return text + md("Сменить часовой пояс: /settings tz Europe/Moscow или /settings tz +3.")
+}
+
+// formatGroupQuizQuestion builds the mini-quiz question about a name; answers are Russian translations.
+func formatGroupQuizQuestion(name *entities.Name, lang string) string {
+	if lang == langEN {
+		return "🧩 What does " + name.ArabicName + " (" + name.Transliteration + ") mean? (ru)"
+	}
+	return "🧩 Что означает имя " + name.ArabicName + " (" + name.Transliteration + ")?"
+}
+
+// groupAdminOnlyText is shown when a non-admin tries to change group settings.
+func groupAdminOnlyText(lang string) string {
+	if lang == langEN {
+		return "⛔ Only group admins can change the settings."
+	}
+	return "⛔ Менять настройки могут только администраторы группы."
+}
+
+// groupPrivateOnlyText is shown for personal commands sent in a group.
+func groupPrivateOnlyText(lang string) string {
+	if lang == langEN {
+		return "This command works only in the private chat with the bot."
+	}
+	return "Эта команда работает только в личном чате с ботом."
+}
+
+// groupPrivateOnlyAnswer is the callback answer for personal buttons pressed in a group.
+func groupPrivateOnlyAnswer(lang string) string {
+	if lang == langEN {
+		return "Available in the private chat with the bot"
+	}
+	return "Доступно в личном чате с ботом"
+}
+
+// groupNameUsageText explains /name in a group.
+func groupNameUsageText(lang string) string {
+	if lang == langEN {
+		return "Usage: /name N, where N is a number from 1 to 99."
+	}
+	return "Использование: /name N, где N — число от 1 до 99."
+}
+
+// groupInvalidTimezoneText is shown for an unrecognized timezone in /settings tz.
+func groupInvalidTimezoneText(lang string) string {
+	if lang == langEN {
+		return "Unknown timezone. Examples: /settings tz Europe/London, /settings tz +3."
+	}
+	return "Не удалось распознать часовой пояс. Примеры: /settings tz Europe/Moscow, /settings tz +3."
+}
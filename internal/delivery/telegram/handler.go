@@ -7,37 +7,108 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
 )
 
 // tzWaitState stores state for awaiting a timezone input via ForceReply.
 type tzWaitState struct {
-	Flow            string // "onboarding" | "settings"
+	Flow            string // tzFlowOnboarding | tzFlowSettings
 	ChatID          int64
 	OwnerMessageID  int
 	PromptMessageID int
 }
 
+// noteWaitState stores state for awaiting a personal note text via ForceReply.
+type noteWaitState struct {
+	ChatID          int64
+	NameNumber      int
+	CardMessageID   int
+	PromptMessageID int
+}
+
+// importWaitState stores state for awaiting an export file to import.
+type importWaitState struct {
+	ChatID          int64
+	PromptMessageID int
+	RestoreUserID   int64 // non-zero when an admin restores a snapshot of this user
+}
+
+// locationWaitState stores state for awaiting a shared location for prayer times.
+type locationWaitState struct {
+	ChatID          int64
+	OwnerMessageID  int // prayer settings message to refresh
+	PromptMessageID int
+}
+
+// feedbackWaitState stores state for awaiting a /feedback message via ForceReply.
+type feedbackWaitState struct {
+	ChatID          int64
+	PromptMessageID int
+}
+
+// reportWaitState stores state for awaiting a content error description via ForceReply.
+type reportWaitState struct {
+	ChatID          int64
+	NameNumber      int
+	Field           entities.ContentReportField
+	PromptMessageID int
+}
+
 // Handler is responsible for processing Telegram updates and callbacks.
 type Handler struct {
-	bot              *tgbotapi.BotAPI
-	logger           *zap.Logger
-	nameService      NameService
-	userService      UserService
-	progressService  ProgressService
-	settingsService  SettingsService
-	quizService      QuizService
-	quizStorage      QuizStorage
-	reminderService  ReminderService
-	dailyNameService DailyNameService
-	reminderStorage  ReminderStorage
-	resetService     ResetService
-
-	tzInputWait map[int64]tzWaitState
+	bot                 *tgbotapi.BotAPI
+	logger              *zap.Logger
+	nameService         NameService
+	userService         UserService
+	progressService     ProgressService
+	settingsService     SettingsService
+	quizService         QuizService
+	quizStorage         QuizStorage
+	reminderService     ReminderService
+	dailyNameService    DailyNameService
+	reminderStorage     ReminderStorage
+	resetService        ResetService
+	favoritesService    FavoritesService
+	notesService        NotesService
+	exportService       ExportService
+	importService       ImportService
+	groupService        GroupChatService
+	statsService        DeliveryStatsService
+	adminService        AdminService
+	userAuditService    UserAuditService
+	blockList           BlockListService
+	ticketService       TicketService
+	reportService       ContentReportService
+	surveyService       SurveyService
+	changelogService    ChangelogService
+	analyticsService    AnalyticsService
+	apiTokenService     APITokenService
+	webAppURL           string // Mini App URL, empty if it is not served
+	adminSessionService AdminSessionService
+	reloader            ConfigReloader // set by SetReloader, nil disables /admin_reload
+	logControls         LogControls    // set by SetLogControls, nil disables /admin_loglevel
+	runtime             runtimeState
+	backlog             backlog        // set by SetBacklog
+	handlerTimeout      time.Duration  // set by SetHandlerTimeout, 0 for no limit
+	messageTracker      MessageTracker // set by SetMessageTracker, nil keeps messages
+	editReminders       bool           // set by SetEditReminders
+
+	tzInputWait   userStates[tzWaitState]
+	noteInputWait userStates[noteWaitState]
+	importWait    userStates[importWaitState]
+	locationWait  userStates[locationWaitState]
+	feedbackWait  userStates[feedbackWaitState]
+	reportWait    userStates[reportWaitState]
+	findQueries   userStates[string]
+	tzPending     userStates[string] // typed zone awaiting confirmation, see resolveTimezoneID
+	callbacksSeen seenCallbacks
 }
 
 // NewHandler creates a new Telegram handler with dependencies.
@@ -53,36 +124,85 @@ func NewHandler(
 	reminderService ReminderService,
 	dailyNameService DailyNameService,
 	reminderStorage ReminderStorage,
+	stateStore StateStore,
 	resetService ResetService,
+	favoritesService FavoritesService,
+	notesService NotesService,
+	exportService ExportService,
+	importService ImportService,
+	groupService GroupChatService,
+	statsService DeliveryStatsService,
+	adminService AdminService,
+	userAuditService UserAuditService,
+	blockList BlockListService,
+	ticketService TicketService,
+	reportService ContentReportService,
+	surveyService SurveyService,
+	changelogService ChangelogService,
+	analyticsService AnalyticsService,
+	apiTokenService APITokenService,
+	webAppURL string,
+	adminSessionService AdminSessionService,
 ) *Handler {
-	return &Handler{
-		bot:              bot,
-		logger:           logger,
-		nameService:      nameService,
-		userService:      userService,
-		progressService:  progressService,
-		settingsService:  settingsService,
-		quizService:      quizService,
-		quizStorage:      quizStorage,
-		reminderService:  reminderService,
-		dailyNameService: dailyNameService,
-		reminderStorage:  reminderStorage,
-		resetService:     resetService,
+	callbackLogger = logger
 
-		tzInputWait: make(map[int64]tzWaitState),
+	return &Handler{
+		bot:                 bot,
+		logger:              logger,
+		nameService:         nameService,
+		userService:         userService,
+		progressService:     progressService,
+		settingsService:     settingsService,
+		quizService:         quizService,
+		quizStorage:         quizStorage,
+		reminderService:     reminderService,
+		dailyNameService:    dailyNameService,
+		reminderStorage:     reminderStorage,
+		resetService:        resetService,
+		favoritesService:    favoritesService,
+		notesService:        notesService,
+		exportService:       exportService,
+		importService:       importService,
+		groupService:        groupService,
+		statsService:        statsService,
+		adminService:        adminService,
+		userAuditService:    userAuditService,
+		blockList:           blockList,
+		ticketService:       ticketService,
+		reportService:       reportService,
+		surveyService:       surveyService,
+		changelogService:    changelogService,
+		analyticsService:    analyticsService,
+		apiTokenService:     apiTokenService,
+		webAppURL:           webAppURL,
+		adminSessionService: adminSessionService,
+
+		tzInputWait:   newUserStates[tzWaitState](stateStore, "wait:tz", logger),
+		noteInputWait: newUserStates[noteWaitState](stateStore, "wait:note", logger),
+		importWait:    newUserStates[importWaitState](stateStore, "wait:import", logger),
+		locationWait:  newUserStates[locationWaitState](stateStore, "wait:location", logger),
+		feedbackWait:  newUserStates[feedbackWaitState](stateStore, "wait:feedback", logger),
+		reportWait:    newUserStates[reportWaitState](stateStore, "wait:report", logger),
+		findQueries:   newUserStates[string](stateStore, "find", logger),
+		tzPending:     newUserStates[string](stateStore, "pick:tz", logger),
+		callbacksSeen: newSeenCallbacks(stateStore, logger),
 	}
 }
 
 // Run starts the handler loop for processing Telegram updates.
 func (h *Handler) Run(ctx context.Context) error {
-	h.logger.Info("telegram handler started")
-	defer h.logger.Info("telegram handler stopped")
+	h.log(ctx).Info("telegram handler started")
+	defer h.log(ctx).Info("telegram handler stopped")
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := h.bot.GetUpdatesChan(u)
 
+	if h.backlog.limit > 0 {
+		return h.runBacklog(ctx, updates)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -93,82 +213,145 @@ func (h *Handler) Run(ctx context.Context) error {
 	}
 }
 
-// handleUpdate processes incoming Telegram update.
+// handleUpdate processes incoming Telegram update. Each update gets a request ID that is
+// logged with everything done for it and shown to the user in error messages.
+// The handling is bounded by the handler timeout, see SetHandlerTimeout.
 func (h *Handler) handleUpdate(ctx context.Context, update tgbotapi.Update) {
+	ctx = requestid.With(ctx, requestid.New())
+	ctx, cancel := h.withTimeout(ctx)
+	defer cancel()
+
+	if from := update.SentFrom(); from != nil && h.blockList.IsBlocked(from.ID) {
+		h.log(ctx).Debug("update from blocked user ignored", zap.Int64("user_id", from.ID))
+		return
+	}
+
+	if update.MyChatMember != nil {
+		h.handleMyChatMember(ctx, update.MyChatMember)
+		return
+	}
+
+	if update.Poll != nil {
+		h.handlePollUpdate(ctx, update.Poll)
+		return
+	}
+
 	if update.CallbackQuery != nil {
-		h.logger.Debug("callback received",
+		h.log(ctx).Debug("callback received",
 			zap.Int64("user_id", update.CallbackQuery.From.ID),
 			zap.String("data", update.CallbackQuery.Data),
 		)
+		if h.callbacksSeen.checkAndMark(ctx, update.CallbackQuery.ID) {
+			h.log(ctx).Info("duplicate callback ignored",
+				zap.Int64("user_id", update.CallbackQuery.From.ID),
+				zap.String("callback_id", update.CallbackQuery.ID),
+			)
+			return
+		}
+		switch limit, _ := h.allowUpdate(update.CallbackQuery.From.ID, time.Now()); limit {
+		case limitBurst:
+			_ = h.answerCallback(update.CallbackQuery.ID, msgSlowDown)
+			return
+		case limitSustained:
+			_ = h.answerCallback(update.CallbackQuery.ID, msgTooManyUpdates)
+			return
+		}
+		h.touchActivity(ctx, update.CallbackQuery.From.ID)
 		h.handleCallback(ctx, update.CallbackQuery)
 		return
 	}
 
+	if update.InlineQuery != nil && h.featureEnabled(FeatureInlineQueries) {
+		h.log(ctx).Debug("inline query received",
+			zap.Int64("user_id", update.InlineQuery.From.ID),
+			zap.String("query", update.InlineQuery.Query),
+		)
+		h.handleInlineQuery(ctx, update.InlineQuery)
+		return
+	}
+
 	if update.Message == nil {
-		h.logger.Debug("update without message and callback")
+		h.log(ctx).Debug("update without message, callback and inline query")
 		return
 	}
 
-	h.logger.Debug("update received",
+	h.log(ctx).Debug("update received",
 		zap.Int64("chat_id", update.Message.Chat.ID),
 		zap.String("text", update.Message.Text),
 	)
 
+	if h.handleFeedbackReply(ctx, update.Message) {
+		return
+	}
+
+	if isGroupChat(update.Message.Chat) {
+		if h.featureEnabled(FeatureGroupChats) {
+			h.handleGroupMessage(ctx, update.Message)
+		}
+		return
+	}
+
 	from := update.Message.From
+	switch limit, warn := h.allowUpdate(from.ID, time.Now()); limit {
+	case limitBurst:
+		h.log(ctx).Debug("update dropped by flood limit", zap.Int64("user_id", from.ID))
+		if warn {
+			_ = h.send(newPlainMessage(update.Message.Chat.ID, msgSlowDown))
+		}
+		return
+	case limitSustained:
+		h.log(ctx).Debug("update dropped by rate limit", zap.Int64("user_id", from.ID))
+		return
+	}
+	h.touchActivity(ctx, from.ID)
 
 	chatID := update.Message.Chat.ID
 
 	if update.Message.IsCommand() {
-		switch update.Message.Command() {
-		case "start":
-			_ = h.withErrorHandling(h.handleStart(from.ID))(ctx, chatID)
-
-		case "today":
-			_ = h.withErrorHandling(h.handleToday(from.ID))(ctx, chatID)
-
-		case "random":
-			_ = h.withErrorHandling(h.handleRandom(from.ID))(ctx, chatID)
-
-		case "all":
-			_ = h.withErrorHandling(h.handleAll())(ctx, chatID)
-
-		case "progress":
-			_ = h.withErrorHandling(h.handleProgress(from.ID))(ctx, chatID)
-
-		case "quiz":
-			_ = h.withErrorHandling(h.handleQuiz(from.ID))(ctx, chatID)
-
-		case "settings":
-			_ = h.withErrorHandling(h.handleSettings(from.ID))(ctx, chatID)
-
-		case "help":
-			msg := newMessage(chatID, helpMessage())
-			if err := h.send(msg); err != nil {
-				h.logger.Error("failed to send help message",
-					zap.Error(err),
-				)
-			}
-
-		case "reset":
-			_ = h.withErrorHandling(h.handleReset())(ctx, chatID)
-
-		default:
-			msg := newPlainMessage(chatID, msgUnknownCommand)
-			if err := h.send(msg); err != nil {
-				h.logger.Error("failed to send unknown command message",
-					zap.Error(err),
-				)
-			}
+		command := resolveCommand(update.Message.Command())
+		h.analyticsService.Track(ctx, from.ID, entities.EventCommand, map[string]any{
+			"command": command,
+		})
+
+		if _, ok := adminCommandPermissions[update.Message.Command()]; ok {
+			h.handleAdminMessage(ctx, update.Message)
+			return
 		}
 
+		h.handleCommand(ctx, from, chatID, command, update.Message.CommandArguments())
 		return
 	}
 
 	text := strings.TrimSpace(update.Message.Text)
 
-	if _, ok := h.tzInputWait[from.ID]; ok {
+	switch h.pendingWait(ctx, from.ID) {
+	case waitImport:
+		_ = h.withErrorHandling(h.handleImportMessage(update.Message))(ctx, chatID)
+		return
+	case waitFeedback:
+		_ = h.withErrorHandling(h.handleFeedbackText(from, text))(ctx, chatID)
+		return
+	case waitReport:
+		_ = h.withErrorHandling(h.handleReportText(from, text))(ctx, chatID)
+		return
+	case waitNote:
+		_ = h.withErrorHandling(h.handleNoteText(text, from.ID, update.Message.MessageID))(ctx, chatID)
+		return
+	case waitTimezone:
 		_ = h.withErrorHandling(h.handleTimezoneText(text, from.ID, update.Message.MessageID))(ctx, chatID)
 		return
+	case waitLocation:
+		_ = h.withErrorHandling(h.handleLocationMessage(update.Message))(ctx, chatID)
+		return
+	}
+
+	if command, ok := textShortcut(text); ok {
+		h.analyticsService.Track(ctx, from.ID, entities.EventCommand, map[string]any{
+			"command":  command,
+			"shortcut": true,
+		})
+		h.handleCommand(ctx, from, chatID, command, "")
+		return
 	}
 
 	fields := strings.Fields(text)
@@ -181,7 +364,110 @@ func (h *Handler) handleUpdate(ctx context.Context, update tgbotapi.Update) {
 		}
 	}
 
-	_ = h.withErrorHandling(h.handleNumber(update.Message.Text))(ctx, chatID)
+	if containsArabic(text) {
+		_ = h.withErrorHandling(h.handleFind(from.ID, text))(ctx, chatID)
+		return
+	}
+
+	_ = h.withErrorHandling(h.handleNumber(from.ID, update.Message.Text))(ctx, chatID)
+}
+
+// handleCommand runs a user command with its arguments. Aliases and text shortcuts
+// are resolved by the caller.
+func (h *Handler) handleCommand(ctx context.Context, from *tgbotapi.User, chatID int64, command, args string) {
+	switch command {
+	case "start":
+		_ = h.withErrorHandling(h.handleStart(from.ID, args))(ctx, chatID)
+
+	case "today":
+		_ = h.withErrorHandling(h.handleToday(from.ID))(ctx, chatID)
+
+	case "random":
+		_ = h.withErrorHandling(h.handleRandom(from.ID))(ctx, chatID)
+
+	case "all":
+		_ = h.withErrorHandling(h.handleAll(args))(ctx, chatID)
+
+	case "progress":
+		_ = h.withErrorHandling(h.handleProgress(from.ID))(ctx, chatID)
+
+	case "name":
+		_ = h.withErrorHandling(h.handleNameCommand(from.ID, args))(ctx, chatID)
+
+	case "favorites":
+		_ = h.withErrorHandling(h.handleFavorites(from.ID))(ctx, chatID)
+
+	case "find":
+		_ = h.withErrorHandling(h.handleFind(from.ID, args))(ctx, chatID)
+
+	case "schedule":
+		_ = h.withErrorHandling(h.handleSchedule(from.ID))(ctx, chatID)
+
+	case "report":
+		_ = h.withErrorHandling(h.handleReport(from.ID))(ctx, chatID)
+
+	case "goal":
+		_ = h.withErrorHandling(h.handleGoal(from.ID, args))(ctx, chatID)
+
+	case "quiz":
+		_ = h.withErrorHandling(h.handleQuiz(from.ID))(ctx, chatID)
+
+	case "settings":
+		_ = h.withErrorHandling(h.handleSettings(from.ID))(ctx, chatID)
+
+	case "export":
+		_ = h.withErrorHandling(h.handleExport(from.ID, args))(ctx, chatID)
+
+	case "import":
+		_ = h.withErrorHandling(h.handleImport(from.ID))(ctx, chatID)
+
+	case "feedback":
+		_ = h.withErrorHandling(h.handleFeedback(from, args))(ctx, chatID)
+
+	case "whatsnew":
+		_ = h.withErrorHandling(h.handleWhatsNew(from.ID))(ctx, chatID)
+
+	case "apitoken":
+		_ = h.withErrorHandling(h.handleAPIToken(from.ID, args))(ctx, chatID)
+
+	case "app":
+		_ = h.withErrorHandling(h.handleApp())(ctx, chatID)
+
+	case "help":
+		msg := newMessage(chatID, helpMessage())
+		if err := h.send(msg); err != nil {
+			h.log(ctx).Error("failed to send help message",
+				zap.Error(err),
+			)
+		}
+
+	case "remindtest":
+		_ = h.withErrorHandling(h.handleRemindTest(from.ID))(ctx, chatID)
+
+	case "reset":
+		_ = h.withErrorHandling(h.handleReset())(ctx, chatID)
+
+	case "deletemydata":
+		_ = h.withErrorHandling(h.handleDeleteMyData())(ctx, chatID)
+
+	default:
+		msg := newPlainMessage(chatID, msgUnknownCommand)
+		if err := h.send(msg); err != nil {
+			h.log(ctx).Error("failed to send unknown command message",
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// containsArabic reports whether s contains Arabic-script letters.
+func containsArabic(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Arabic, r) && unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
 }
 
 // send sends a Telegram message and ignores "message is not modified" errors.
@@ -197,8 +483,8 @@ func (h *Handler) send(c tgbotapi.Chattable) error {
 }
 
 // sendQuizResults sends quiz results with a keyboard.
-func (h *Handler) sendQuizResults(chatID int64, session *entities.QuizSession) error {
-	resultText := formatQuizResult(session)
+func (h *Handler) sendQuizResults(chatID int64, session *entities.QuizSession, level entities.Level, totalXP int) error {
+	resultText := formatQuizResult(session, level, totalXP)
 	keyboard := buildQuizResultKeyboard()
 
 	msg := newMessage(chatID, resultText)
@@ -210,6 +496,7 @@ func (h *Handler) sendQuizResults(chatID int64, session *entities.QuizSession) e
 
 // sendQuizQuestionFromDB sends a quiz question from database with answer buttons.
 func (h *Handler) sendQuizQuestionFromDB(
+	ctx context.Context,
 	chatID int64,
 	session *entities.QuizSession,
 	question *entities.QuizQuestion,
@@ -238,18 +525,20 @@ func (h *Handler) sendQuizQuestionFromDB(
 	}
 
 	h.quizStorage.StoreMessageID(session.ID, sentMsg.MessageID)
+	h.trackEphemeral(ctx, chatID, sentMsg.MessageID, entities.EphemeralQuiz)
 
 	return nil
 }
 
 // sendNameCard sends a name card message (and optional audio) to the specified chat.
-func (h *Handler) sendNameCard(ctx context.Context, chatID int64, nameNumber int, audioEnabled bool) error {
+func (h *Handler) sendNameCard(ctx context.Context, userID, chatID int64, nameNumber int, audioEnabled bool) error {
 	msg, audio, err := buildNameResponse(ctx, func(ctx context.Context) (*entities.Name, error) {
 		return h.nameService.GetByNumber(ctx, nameNumber)
 	}, chatID)
 	if err != nil {
 		return err
 	}
+	h.personalizeNameCard(ctx, userID, &msg, nameNumber)
 
 	if !audioEnabled {
 		audio = nil
@@ -264,6 +553,91 @@ func (h *Handler) sendNameCard(ctx context.Context, chatID int64, nameNumber int
 	return nil
 }
 
+// personalizeNameCard adds user's note and favorite state to a name card message.
+// Messages without a keyboard (e.g. errors) are left untouched.
+func (h *Handler) personalizeNameCard(ctx context.Context, userID int64, msg *tgbotapi.MessageConfig, nameNumber int) {
+	if msg.ReplyMarkup == nil {
+		return
+	}
+
+	isFavorite, err := h.favoritesService.IsFavorite(ctx, userID, nameNumber)
+	if err != nil {
+		h.log(ctx).Warn("failed to check favorite",
+			zap.Error(err),
+			zap.Int64("user_id", userID),
+			zap.Int("name_number", nameNumber),
+		)
+	}
+
+	note, err := h.notesService.Get(ctx, userID, nameNumber)
+	if err != nil {
+		h.log(ctx).Warn("failed to get note",
+			zap.Error(err),
+			zap.Int64("user_id", userID),
+			zap.Int("name_number", nameNumber),
+		)
+	}
+	if note != nil {
+		msg.Text += formatNoteBlock(note.Text)
+	}
+
+	msg.ReplyMarkup = nameCardKeyboard(nameNumber, isFavorite, note != nil)
+}
+
+// refreshNameCard re-renders an existing name card message with user's current note and favorite state.
+func (h *Handler) refreshNameCard(ctx context.Context, userID, chatID int64, messageID, nameNumber int) error {
+	msg, _, err := buildNameResponse(ctx, func(ctx context.Context) (*entities.Name, error) {
+		return h.nameService.GetByNumber(ctx, nameNumber)
+	}, chatID)
+	if err != nil {
+		return err
+	}
+	h.personalizeNameCard(ctx, userID, &msg, nameNumber)
+
+	edit := newEdit(chatID, messageID, msg.Text)
+	if kb, ok := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); ok {
+		edit.ReplyMarkup = &kb
+	}
+
+	return h.send(edit)
+}
+
+// setNoteWaitState sets the current note input wait state and replaces any previous prompt.
+func (h *Handler) setNoteWaitState(ctx context.Context, userID int64, st noteWaitState) {
+	if old, ok := h.noteInputWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
+	}
+	h.noteInputWait.set(ctx, userID, st)
+}
+
+// forgetUser drops conversation state kept for the user.
+func (h *Handler) forgetUser(ctx context.Context, userID int64) {
+	h.tzInputWait.delete(ctx, userID)
+	h.noteInputWait.delete(ctx, userID)
+	h.importWait.delete(ctx, userID)
+	h.locationWait.delete(ctx, userID)
+	h.feedbackWait.delete(ctx, userID)
+	h.reportWait.delete(ctx, userID)
+	h.findQueries.delete(ctx, userID)
+	h.tzPending.delete(ctx, userID)
+}
+
+// setLocationWaitState sets the current location wait state and replaces any previous prompt.
+func (h *Handler) setLocationWaitState(ctx context.Context, userID int64, st locationWaitState) {
+	if old, ok := h.locationWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
+	}
+	h.locationWait.set(ctx, userID, st)
+}
+
+// setImportWaitState sets the current import wait state and replaces any previous prompt.
+func (h *Handler) setImportWaitState(ctx context.Context, userID int64, st importWaitState) {
+	if old, ok := h.importWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
+	}
+	h.importWait.set(ctx, userID, st)
+}
+
 // sendTodayList sends a formatted list of today's names with their learning status.
 func (h *Handler) sendTodayList(ctx context.Context, chatID int64, userID int64, settings *entities.UserSettings, todayNames []int) error {
 	namesPerDay := settings.NamesPerDay
@@ -273,21 +647,29 @@ func (h *Handler) sendTodayList(ctx context.Context, chatID int64, userID int64,
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("📚 *Сегодня изучаете \\(%d/%d\\):*\n\n",
-		len(todayNames), namesPerDay))
+		len(todayNames), max(namesPerDay, len(todayNames))))
+	if len(todayNames) > namesPerDay {
+		// The quota was lowered after today's plan was built.
+		sb.WriteString(md(fmt.Sprintf("Новая норма — %d в день, начнёт действовать завтра.", namesPerDay)) + "\n\n")
+	}
+
+	streaks, err := h.progressService.GetStreaks(ctx, userID, todayNames)
+	if err != nil {
+		h.log(ctx).Warn("failed to get streaks", zap.Error(err))
+	}
 
 	learnedCount := 0
 	for i, nameNumber := range todayNames {
 		name, err := h.nameService.GetByNumber(ctx, nameNumber)
 		if err != nil {
-			h.logger.Warn("failed to get name by number",
+			h.log(ctx).Warn("failed to get name by number",
 				zap.Error(err),
 				zap.Int("name_number", nameNumber))
 			continue
 		}
 
 		// Check if learned
-		streak, err := h.progressService.GetStreak(ctx, userID, nameNumber)
-		if err == nil && streak >= 7 {
+		if streaks[nameNumber] >= entities.MinStreakForMastery {
 			learnedCount++
 			sb.WriteString(fmt.Sprintf("✅ %d\\. %s\n", i+1, bold(name.Translation)))
 		} else {
@@ -314,28 +696,123 @@ func (h *Handler) sendTodayList(ctx context.Context, chatID int64, userID int64,
 }
 
 // SendReminder sends a reminder notification to user
-func (h *Handler) SendReminder(userID, chatID int64, payload entities.ReminderPayload) error {
+func (h *Handler) SendReminder(ctx context.Context, userID, chatID int64, payload entities.ReminderPayload) error {
 	text := buildReminderNotification(payload)
-	keyboard := buildReminderKeyboard()
+	selfReview := payload.Kind == entities.ReminderKindStudy || payload.Kind == entities.ReminderKindReview
+	keyboard := buildReminderKeyboard(payload.Name.Number, payload.Question, selfReview)
 
-	if prev, ok := h.reminderStorage.Get(userID); ok && prev.MessageID != 0 {
-		_ = h.send(tgbotapi.NewDeleteMessage(prev.ChatID, prev.MessageID))
-		h.reminderStorage.Delete(userID)
-	}
+	h.expirePreviousReminder(userID)
 
 	msg := newMessage(chatID, text)
 	msg.ReplyMarkup = keyboard
 
-	sent, err := h.bot.Send(msg)
+	sent, err := h.sendNotification(ctx, msg)
 	if err != nil {
 		return err
 	}
 
 	h.reminderStorage.Store(userID, chatID, sent.MessageID)
+	h.trackEphemeral(context.Background(), chatID, sent.MessageID, entities.EphemeralReminder)
 
 	return nil
 }
 
+// SendDailyDigest sends the daily plan reminder, replacing the previous reminder message.
+func (h *Handler) SendDailyDigest(ctx context.Context, userID, chatID int64, digest entities.DailyDigest) error {
+	h.expirePreviousReminder(userID)
+
+	msg := newMessage(chatID, buildDailyDigestMessage(digest))
+	msg.ReplyMarkup = buildDailyDigestKeyboard()
+
+	sent, err := h.sendNotification(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	h.reminderStorage.Store(userID, chatID, sent.MessageID)
+	h.trackEphemeral(context.Background(), chatID, sent.MessageID, entities.EphemeralReminder)
+
+	return nil
+}
+
+// SendWeeklyDigest sends the weekly progress digest to user.
+func (h *Handler) SendWeeklyDigest(ctx context.Context, userID, chatID int64, digest entities.WeeklyDigest) error {
+	msg := newMessage(chatID, buildWeeklyDigestMessage(digest))
+	_, err := h.sendNotification(ctx, msg)
+	return err
+}
+
+// PostNameOfTheDay posts the name card for day to a channel or group in lang, followed
+// by its audio, and returns the ID of the card message.
+func (h *Handler) PostNameOfTheDay(ctx context.Context, chatID int64, name *entities.Name, day time.Time, lang string) (int, error) {
+	lang = normalizeLang(lang)
+	msg := newMessage(chatID, buildChannelPostText(name, day, lang))
+	if h.bot.Self.UserName != "" {
+		msg.ReplyMarkup = inlineNameKeyboard(buildNameDeepLink(h.bot.Self.UserName, name.Number), lang)
+	}
+
+	sent, err := h.sendNotification(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	if name.Audio != "" {
+		if _, err := h.sendNotification(ctx, *buildNameAudio(name, chatID)); err != nil {
+			h.logger.Warn("failed to post name audio",
+				zap.Int64("chat_id", chatID),
+				zap.Int("name_number", name.Number),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return sent.MessageID, nil
+}
+
+// PostQuiz sends a mini-quiz about a name to a group as an anonymous Telegram quiz
+// and returns the poll ID.
+func (h *Handler) PostQuiz(ctx context.Context, chatID int64, quiz *entities.ChannelQuiz, lang string) (string, error) {
+	poll := tgbotapi.NewPoll(chatID, formatGroupQuizQuestion(quiz.Name, lang), quiz.Options...)
+	poll.Type = "quiz"
+	poll.CorrectOptionID = int64(quiz.CorrectIndex)
+	poll.Explanation = quiz.Name.Transliteration + " — " + quiz.Name.Translation
+
+	sent, err := h.sendNotification(ctx, poll)
+	if err != nil {
+		return "", err
+	}
+	if sent.Poll == nil {
+		return "", fmt.Errorf("telegram returned no poll")
+	}
+
+	return sent.Poll.ID, nil
+}
+
+// SendStreakAlert warns user that their daily streak breaks at midnight.
+func (h *Handler) SendStreakAlert(ctx context.Context, userID, chatID int64, streak int, hoursLeft int) error {
+	msg := newMessage(chatID, formatStreakAlertMessage(streak, hoursLeft))
+	msg.ReplyMarkup = buildStreakAlertKeyboard()
+	_, err := h.sendNotification(ctx, msg)
+	return err
+}
+
+// SendInactivityWarning warns user that their data will be deleted due to inactivity.
+func (h *Handler) SendInactivityWarning(userID, chatID int64, deleteAt time.Time) error {
+	msg := newMessage(chatID, formatInactivityWarning(deleteAt))
+	msg.ReplyMarkup = buildInactivityWarningKeyboard()
+	return h.send(msg)
+}
+
+// touchActivity records user activity, which also cancels a pending deletion warning.
+func (h *Handler) touchActivity(ctx context.Context, userID int64) {
+	if err := h.userService.TouchActivity(ctx, userID); err != nil {
+		h.log(ctx).Warn("failed to record user activity",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+	}
+}
+
 // removeInlineKeyboard clears the inline keyboard for an existing message.
 func (h *Handler) removeInlineKeyboard(chatID int64, messageID int) {
 	edit := tgbotapi.NewEditMessageReplyMarkup(
@@ -346,9 +823,9 @@ func (h *Handler) removeInlineKeyboard(chatID int64, messageID int) {
 }
 
 // setTZWaitState sets the current timezone input wait state and replaces any previous prompt.
-func (h *Handler) setTZWaitState(userID int64, st tzWaitState) {
-	if old, ok := h.tzInputWait[userID]; ok && old.PromptMessageID != 0 {
+func (h *Handler) setTZWaitState(ctx context.Context, userID int64, st tzWaitState) {
+	if old, ok := h.tzInputWait.get(ctx, userID); ok && old.PromptMessageID != 0 {
 		_ = h.send(tgbotapi.NewDeleteMessage(old.ChatID, old.PromptMessageID))
 	}
-	h.tzInputWait[userID] = st
+	h.tzInputWait.set(ctx, userID, st)
 }
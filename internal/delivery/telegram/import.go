@@ -0,0 +1,119 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// maxImportFileSize limits the size of an uploaded export file.
+const maxImportFileSize = 5 << 20 // 5 MB
+
+// handleImport asks the user to send a JSON file produced by /export.
+func (h *Handler) handleImport(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		prompt := newPlainMessage(chatID, msgImportPrompt)
+		prompt.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+
+		sent, err := h.bot.Send(prompt)
+		if err != nil {
+			return err
+		}
+		h.trackEphemeral(ctx, chatID, sent.MessageID, entities.EphemeralPrompt)
+
+		h.setImportWaitState(ctx, userID, importWaitState{
+			ChatID:          chatID,
+			PromptMessageID: sent.MessageID,
+		})
+		return nil
+	}
+}
+
+// handleImportMessage processes a message received while waiting for an export file.
+func (h *Handler) handleImportMessage(m *tgbotapi.Message) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		userID := m.From.ID
+
+		if m.Document == nil {
+			if isCancelText(m.Text) {
+				h.clearImportWait(ctx, userID)
+				return h.send(newPlainMessage(chatID, msgImportCancelled))
+			}
+			return h.send(newPlainMessage(chatID, msgImportNeedFile))
+		}
+
+		if m.Document.FileSize > maxImportFileSize {
+			return h.send(newPlainMessage(chatID, msgImportTooLarge))
+		}
+
+		data, err := h.downloadFile(ctx, m.Document.FileID)
+		if err != nil {
+			return fmt.Errorf("download import file: %w", err)
+		}
+
+		if st, _ := h.importWait.get(ctx, userID); st.RestoreUserID != 0 {
+			return h.restoreUser(ctx, chatID, userID, st.RestoreUserID, data)
+		}
+
+		result, err := h.importService.Import(ctx, userID, data)
+		switch {
+		case errors.Is(err, service.ErrImportInvalid):
+			return h.send(newPlainMessage(chatID, msgImportInvalid))
+		case errors.Is(err, service.ErrImportForeignUser):
+			return h.send(newPlainMessage(chatID, msgImportForeignUser))
+		case err != nil:
+			h.log(ctx).Error("failed to import user data",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			h.clearImportWait(ctx, userID)
+			return h.send(h.internalErrorMessage(ctx, chatID))
+		}
+
+		h.clearImportWait(ctx, userID)
+		return h.send(newMessage(chatID, formatImportResult(result)))
+	}
+}
+
+// clearImportWait removes the import wait state and its prompt.
+func (h *Handler) clearImportWait(ctx context.Context, userID int64) {
+	if st, ok := h.importWait.get(ctx, userID); ok && st.PromptMessageID != 0 {
+		_ = h.send(tgbotapi.NewDeleteMessage(st.ChatID, st.PromptMessageID))
+	}
+	h.importWait.delete(ctx, userID)
+}
+
+// downloadFile downloads a Telegram file into memory.
+func (h *Handler) downloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	url, err := h.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("get file url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get file: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get file: unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+}
@@ -0,0 +1,97 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+const (
+	// inlineResultsPerPage is the number of name cards returned per inline answer.
+	inlineResultsPerPage = 20
+	// inlineCacheTimeSec is how long Telegram may cache inline results.
+	inlineCacheTimeSec = 300
+)
+
+// handleInlineQuery answers "@bot <query>" with name cards that can be inserted into any chat.
+// Results are paginated via the inline query offset.
+func (h *Handler) handleInlineQuery(ctx context.Context, q *tgbotapi.InlineQuery) {
+	lang := normalizeLang(q.From.LanguageCode)
+
+	names, err := h.nameService.Search(ctx, q.Query)
+	if err != nil {
+		h.log(ctx).Error("failed to search names for inline query",
+			zap.Error(err),
+			zap.Int64("user_id", q.From.ID),
+			zap.String("query", q.Query),
+		)
+		names = nil
+	}
+
+	offset, err := strconv.Atoi(q.Offset)
+	if err != nil || offset < 0 || offset > len(names) {
+		offset = 0
+	}
+
+	end := offset + inlineResultsPerPage
+	nextOffset := ""
+	if end < len(names) {
+		nextOffset = strconv.Itoa(end)
+	} else {
+		end = len(names)
+	}
+
+	results := make([]interface{}, 0, end-offset)
+	for _, name := range names[offset:end] {
+		results = append(results, h.buildInlineNameResult(name, lang))
+	}
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: q.ID,
+		Results:       results,
+		CacheTime:     inlineCacheTimeSec,
+		NextOffset:    nextOffset,
+	}
+	if len(results) == 0 && offset == 0 {
+		answer.SwitchPMText = inlineNothingFoundText(lang)
+		answer.SwitchPMParameter = "inline"
+	}
+
+	if _, err := h.bot.Request(answer); err != nil {
+		h.log(ctx).Error("failed to answer inline query",
+			zap.Error(err),
+			zap.Int64("user_id", q.From.ID),
+		)
+	}
+}
+
+// buildInlineNameResult renders a name card as an inline article result.
+func (h *Handler) buildInlineNameResult(name *entities.Name, lang string) tgbotapi.InlineQueryResultArticle {
+	title := strconv.Itoa(name.Number) + ". " + name.Transliteration + " — " + name.ArabicName
+
+	article := tgbotapi.NewInlineQueryResultArticleMarkdownV2(
+		"name_"+strconv.Itoa(name.Number),
+		title,
+		formatLocalizedNameMessage(name, lang),
+	)
+	article.Description = name.Translation
+
+	if h.bot.Self.UserName != "" {
+		kb := inlineNameKeyboard(buildNameDeepLink(h.bot.Self.UserName, name.Number), lang)
+		article.ReplyMarkup = &kb
+	}
+
+	return article
+}
+
+// inlineNothingFoundText returns the switch-to-PM hint shown when nothing matches.
+func inlineNothingFoundText(lang string) string {
+	if lang == langEN {
+		return "Nothing found — open the bot"
+	}
+	return "Ничего не найдено — открыть бота"
+}
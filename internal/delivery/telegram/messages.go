@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -20,6 +21,145 @@ const (
 	msgOutOfRangeNumber     = "Номер имени должен быть от 1 до 99."
 	msgInvalidRange         = "Некорректный диапазон. Пример: 25 30."
 	msgInvalidIntervalHours = "Неверный интервал часов. Выберите 1, 2, 3 или 4."
+	msgNameCommandUsage     = "Использование:\n/name N — открыть имя N (1–99)\n/name N stats — статистика по имени N"
+	msgFindUsage            = "Использование: /find текст\nНапример: /find милостивый или /find rahman"
+	msgFindNothing          = "Ничего не найдено. Попробуйте другой запрос."
+	msgFindExpired          = "Результаты поиска устарели. Повторите /find."
+	msgNoteEmpty            = "Заметка пустая. Отправьте текст или «отмена»."
+	msgNoteCancelled        = "Заметка не изменена."
+	msgNoteSaved            = "✅ Заметка сохранена."
+	msgNoteDeleted          = "🗑 Заметка удалена."
+	msgNoFavorites          = "⭐ В избранном пока пусто.\nОткройте любое имя и нажмите «⭐ В избранное»."
+	msgImportPrompt         = "📥 Отправьте JSON-файл, полученный командой /export.\n" +
+		"Прогресс будет восстановлен только там, где в файле больше повторений, чем сейчас. Для отмены напишите «отмена»."
+	msgImportNeedFile        = "Отправьте JSON-файл из /export или напишите «отмена»."
+	msgImportCancelled       = "Импорт отменён."
+	msgImportTooLarge        = "Файл слишком большой. Максимум — 5 МБ."
+	msgImportInvalid         = "Не удалось прочитать файл. Отправьте JSON-файл, полученный командой /export, без изменений."
+	msgImportForeignUser     = "Этот файл выгружен из другого аккаунта Telegram. Импорт возможен только в тот же аккаунт."
+	msgTimezoneSearchPrompt  = "Напишите название города (например, Казань) или зону IANA (например, Europe/Moscow)."
+	msgTimezoneNotFound      = "Не нашёл такой город. Попробуйте другой город поблизости или зону вида Europe/Moscow."
+	msgPrayerLocationPrompt  = "📍 Нажмите кнопку ниже, чтобы отправить местоположение. Оно нужно только для расчёта времени намаза. Для отмены напишите «отмена»."
+	msgPrayerLocationNeeded  = "Отправьте местоположение кнопкой ниже или напишите «отмена»."
+	msgPrayerLocationSaved   = "✅ Местоположение сохранено."
+	msgPrayerLocationKept    = "Местоположение не изменено."
+	msgAdminUserNotFound     = "Пользователь не найден."
+	msgAdminRestorePrompt    = "Отправьте JSON-снимок пользователя %d (/admin_backup или /export). Текущее состояние будет выгружено перед восстановлением и затем полностью заменено. Для отмены напишите «отмена»."
+	msgAdminSnapshotMismatch = "Снимок относится к другому пользователю, а не к %d."
+	msgDataDeleted           = "🗑 Ваш аккаунт и все данные удалены.\n\nЕсли захотите начать заново, отправьте /start."
+	msgAnkiExportCaption     = "🃏 Колода для Anki: Файл → Импорт, выберите этот файл.\n" +
+		"Поля Interval, Ease и Due повторяют ваше расписание повторений, тег phase:: — этап изучения."
+)
+
+// /feedback messages.
+const (
+	msgFeedbackPrompt    = "✉️ Напишите сообщение разработчикам: вопрос, идею или ошибку. Ответ придёт в этот чат. Для отмены напишите «отмена»."
+	msgFeedbackCancelled = "Сообщение не отправлено."
+	msgFeedbackEmpty     = "Сообщение пустое. Напишите текст или «отмена»."
+	msgFeedbackSent      = "✅ Спасибо! Обращение #%d отправлено, ответ придёт сюда."
+	msgFeedbackAnswered  = "✅ Ответ отправлен пользователю."
+	msgFeedbackNotText   = "Отправить пользователю можно только текст."
+	msgFeedbackBlocked   = "Не удалось доставить ответ: пользователь заблокировал бота."
+	msgTicketClosed      = "Обращение уже закрыто"
+	msgTicketsUsage      = "Использование: /admin_tickets [open|answered|closed|all]"
+)
+
+// Content report messages.
+const (
+	msgReportChooseField = "⚠️ Что не так в карточке имени %d. %s?"
+	msgReportCancelled   = "Сообщение об ошибке не отправлено."
+	msgReportEmpty       = "Описание пустое. Напишите, что не так, или «отмена»."
+	msgReportSent        = "✅ Спасибо! Мы проверим карточку и исправим ошибку, если она подтвердится."
+	msgReportResolved    = "Это сообщение уже рассмотрено"
+	msgReportNoneOpen    = "Открытых сообщений об ошибках нет."
+)
+
+// Survey messages.
+const (
+	msgSurveySkipped  = "Опрос пропущен. Спасибо!"
+	msgSurveyFinished = "Спасибо за ответы! Они помогают делать бота лучше 🤍"
+	msgSurveyGone     = "Этот опрос больше недоступен."
+	msgSurveyNotFound = "Опрос #%d не найден."
+	msgSurveyQueued   = "📤 Опрос #%d поставлен в очередь для %d пользователей."
+	msgSurveyNone     = "Опросов пока нет. Создайте: /admin_survey new <сегмент>"
+	msgSurveyUsage    = "Использование:\n" +
+		"/admin_survey — список опросов\n" +
+		"/admin_survey new <all|active|reminders|new>\n" +
+		"Заголовок\n" +
+		"Вопрос 1\n" +
+		"Вариант | Вариант | Вариант\n" +
+		"…\n" +
+		"/admin_survey send <id> — разослать сегменту\n" +
+		"/admin_survey results <id> — итоги"
+)
+
+// Referral messages.
+const (
+	msgReferralShareText = "Изучаю 99 прекрасных имён Аллаха с этим ботом — присоединяйся!"
+)
+
+// HTTP API token messages.
+const (
+	msgAPIDisabled     = "HTTP API на этом сервере отключено."
+	msgAPITokenNone    = "Токена для HTTP API нет."
+	msgAPITokenRevoked = "✅ Токен отозван, запросы с ним больше не принимаются."
+	msgAPITokenUsage   = "🔑 HTTP API для приложений и виджетов: список имён и ваш прогресс.\n\n" +
+		"/apitoken new — выпустить токен (старый перестанет действовать)\n" +
+		"/apitoken revoke — отозвать токен\n\n" +
+		"Запросы:\n" +
+		"GET /api/v1/names — все 99 имён\n" +
+		"GET /api/v1/names/{номер} — одно имя\n" +
+		"GET /api/v1/me/progress — ваш прогресс"
+)
+
+// Mini App messages.
+const (
+	msgWebAppDisabled = "Приложение на этом сервере отключено."
+	msgWebAppOpen     = "📱 В приложении — сетка всех 99 имён с вашим прогрессом, карточки имён и повторение свайпами."
+)
+
+// Web admin messages.
+const (
+	msgAdminWebDisabled = "Веб-админка на этом сервере отключена."
+	msgAdminWebLink     = "🔐 Ссылка для входа в веб-админку. Она действует 10 минут, никому её не пересылайте."
+)
+
+// Admin role messages.
+const (
+	msgAdminRemoveUsage = "Использование: /admin_remove <user_id>"
+	msgAdminConfigOwner = "Этот администратор задан в ADMIN_IDS, его роль меняется только в конфигурации."
+	msgAdminGranted     = "✅ Пользователь %d получил роль %s."
+	msgAdminRevoked     = "✅ Пользователь %d больше не администратор."
+	msgAdminNotAdmin    = "Пользователь %d не администратор."
+	msgAdminNoRights    = "Недостаточно прав"
+	msgAdminAddUsage    = "Использование: /admin_add <user_id> <роль>\n\nРоли:\n" +
+		"owner — всё, включая управление администраторами\n" +
+		"operator — поддержка, обслуживание и рассылки\n" +
+		"editor — поддержка и редактирование контента\n" +
+		"support — снимки пользователей и статистика"
+	msgAdminReloadDisabled = "Перезагрузка конфигурации недоступна."
+	msgAdminReloadFailed   = "❌ Конфигурация не загружена, действуют прежние настройки:\n%v"
+	msgAdminReloadNothing  = "Конфигурация перечитана, изменений нет."
+	msgTooManyUpdates      = "Слишком много запросов. Подождите минуту."
+	msgSlowDown            = "Не так быстро 🙂 Подождите пару секунд и продолжайте."
+	msgOverloaded          = "Бот сейчас перегружен, попробуйте позже."
+	msgStaleButton         = "Кнопка устарела. Откройте меню заново."
+	msgReminderExpired     = "⌛ Напоминание устарело."
+	msgAdminLogLevel       = "📝 Уровень логов: %s\nСэмплирование: %s\n\n" +
+		"Изменения действуют до перезапуска или /admin_reload."
+	msgAdminAuditUsage       = "Использование: /admin_audit <user_id> [N]"
+	msgAdminAuditEmpty       = "Пользователь %d ничего не менял."
+	msgAdminBlockUsage       = "Использование: /admin_block <user_id> [причина]"
+	msgAdminUnblockUsage     = "Использование: /admin_unblock <user_id>"
+	msgAdminBlocked          = "🚫 Пользователь %d заблокирован: его сообщения игнорируются, рассылки и напоминания ему не отправляются."
+	msgAdminUnblocked        = "Пользователь %d разблокирован."
+	msgAdminNotBlocked       = "Пользователь %d не заблокирован."
+	msgAdminBlockAdmin       = "Администратора нельзя заблокировать. Сначала снимите роль."
+	msgAdminBlockedEmpty     = "Заблокированных пользователей нет."
+	msgAdminLogLevelDisabled = "Управление логами недоступно."
+	msgAdminLogLevelUsage    = "Использование:\n/admin_loglevel — текущие настройки\n" +
+		"/admin_loglevel debug|info|warn|error — сменить уровень\n" +
+		"/admin_loglevel sampling on|off — сэмплирование повторяющихся записей"
 )
 
 // Data / service errors.
@@ -29,6 +169,17 @@ const (
 	msgSettingsUnavailable = "Не удалось получить настройки. Попробуйте позже."
 	msgQuizUnavailable     = "Не удалось создать квиз, попробуйте позже."
 	msgInternalError       = "Что‑то пошло не так. Попробуйте позже."
+	msgErrorCode           = "\nКод ошибки для поддержки: %s"
+	msgHandlerTimeout      = "⏳ Запрос выполнялся слишком долго и был прерван. Попробуйте ещё раз."
+	msgExportUnavailable   = "Не удалось подготовить экспорт данных. Попробуйте позже."
+)
+
+// /goal messages.
+const (
+	msgGoalUsage = "🎯 Укажите дату, к которой хотите начать все 99 имён: /goal 2025-12-31 или /goal 31.12.2025.\n" +
+		"Количество имён в день будет рассчитываться автоматически. /goal off — убрать цель."
+	msgGoalInPast  = "Эта дата уже прошла. Выберите сегодняшнюю или более позднюю."
+	msgGoalCleared = "🎯 Цель убрана. Количество имён в день снова задаётся в /settings."
 )
 
 // Command/help text.
@@ -39,10 +190,24 @@ const (
 		"/random — случайное имя (guided: из сегодняшних, free: из всех 99)\n" +
 		"/quiz — пройти квиз по изучаемым именам\n" +
 		"/all — посмотреть все 99 имён\n" +
+		"/name N — открыть имя по номеру (например, /name 5)\n" +
+		"/find текст — поиск по переводу, транслитерации и значению\n" +
+		"/favorites — избранные имена\n" +
 		"/progress — показать статистику прогресса\n" +
+		"/schedule — расписание повторений на неделю\n" +
+		"/report — отчёт за месяц в сравнении с прошлым\n" +
+		"/goal ГГГГ-ММ-ДД — начать все имена к дате\n" +
 		"/settings — настройки (режим обучения, квиз, напоминания, имён в день)\n" +
+		"/remindtest — прислать напоминание прямо сейчас для проверки\n" +
+		"/import — восстановить данные из файла /export\n" +
+		"/export — выгрузить свои данные (JSON и CSV), /export anki — колода для Anki\n" +
 		"/help — помощь и список команд\n" +
-		"/reset — сбросить прогресс и настройки\n\n" +
+		"/feedback — написать разработчикам\n" +
+		"/whatsnew — что нового в боте\n" +
+		"/apitoken — токен HTTP API для приложений и виджетов\n" +
+		"/app — открыть приложение с сеткой имён и повторением\n" +
+		"/reset — сбросить прогресс и настройки\n" +
+		"/deletemydata — удалить аккаунт и все данные\n\n" +
 		"💡 Также можно:\n" +
 		"• Отправить число 1–99, чтобы открыть конкретное имя.\n" +
 		"• Отправить диапазон «N M» (например, 5 10), чтобы открыть имена с N по M."
@@ -51,6 +216,11 @@ const (
 const (
 	lrm          = "\u200E"
 	namesPerPage = 3
+	scheduleDays = 7
+	findPerPage  = 8
+
+	langRU = "ru"
+	langEN = "en"
 )
 
 // md escapes plain text for MarkdownV2.
@@ -139,6 +309,15 @@ func welcomeMessage(isNewUser bool, stats *service.ProgressSummary) string {
 			stats.Learned, stats.Percentage)))
 		sb.WriteString("\n\n")
 
+		if stats.CurrentStreak > 0 {
+			sb.WriteString(md(formatStreakLine(stats.CurrentStreak, stats.BestStreak)))
+			if !stats.ActiveToday {
+				sb.WriteString("\n")
+				sb.WriteString(md("Пройдите квиз сегодня, чтобы не прервать серию."))
+			}
+			sb.WriteString("\n\n")
+		}
+
 		if stats.DueToday > 0 {
 			sb.WriteString(md(fmt.Sprintf("🔄 Сегодня на повторение: %d %s",
 				stats.DueToday, formatNamesCount(stats.DueToday))))
@@ -166,6 +345,8 @@ func helpMessage() string {
 	sb.WriteString("\n")
 	sb.WriteString(bold("/today → /quiz → /progress"))
 	sb.WriteString(md(" — базовый ежедневный цикл."))
+	sb.WriteString("\n")
+	sb.WriteString(md("Можно и словами: «сегодня», «квиз», «прогресс», «настройки», или коротко: /t, /q, /p, /s."))
 	sb.WriteString("\n\n")
 
 	sb.WriteString("📚 ")
@@ -182,7 +363,7 @@ func helpMessage() string {
 	sb.WriteString(bold("Просто посмотреть (без влияния на прогресс):"))
 	sb.WriteString("\n")
 	sb.WriteString("/all — ")
-	sb.WriteString(md("листать все 99 имён"))
+	sb.WriteString(md("листать все 99 имён (/all index — компактный список)"))
 	sb.WriteString("\n")
 	sb.WriteString("/random — ")
 	sb.WriteString(md("случайное имя"))
@@ -190,6 +371,15 @@ func helpMessage() string {
 	sb.WriteString("1\\-99 — ")
 	sb.WriteString(md("конкретное имя по номеру"))
 	sb.WriteString("\n")
+	sb.WriteString("/name N — ")
+	sb.WriteString(md("то же самое командой (работает в группах), /name N stats — ваша статистика по имени"))
+	sb.WriteString("\n")
+	sb.WriteString("/find ")
+	sb.WriteString(md("текст — поиск по переводу, транслитерации и значению (можно вставить имя на арабском)"))
+	sb.WriteString("\n")
+	sb.WriteString("/favorites — ")
+	sb.WriteString(md("избранные имена (⭐ на карточке имени) и квиз по ним"))
+	sb.WriteString("\n")
 	sb.WriteString("N M — ")
 	sb.WriteString(md("показать имена в диапазоне (N и M в пределах 1-99)"))
 	sb.WriteString("\n")
@@ -204,14 +394,49 @@ func helpMessage() string {
 	sb.WriteString("/progress — ")
 	sb.WriteString(md("статистика"))
 	sb.WriteString("\n")
+	sb.WriteString("/schedule — ")
+	sb.WriteString(md("расписание повторений на 7 дней"))
+	sb.WriteString("\n")
+	sb.WriteString("/report — ")
+	sb.WriteString(md("отчёт за месяц: новые имена, точность, активные дни"))
+	sb.WriteString("\n")
+	sb.WriteString("/goal 2025\\-12\\-31 — ")
+	sb.WriteString(md("начать все имена к дате; имён в день рассчитается само, /goal off — убрать"))
+	sb.WriteString("\n")
 	sb.WriteString("/settings — ")
 	sb.WriteString(md("режим, квиз, напоминания, имён в день"))
 	sb.WriteString("\n")
+	sb.WriteString("/remindtest — ")
+	sb.WriteString(md("прислать напоминание прямо сейчас, чтобы проверить время и содержание"))
+	sb.WriteString("\n")
+	sb.WriteString("/export — ")
+	sb.WriteString(md("выгрузить свои данные: настройки, прогресс, история квизов (JSON и CSV)"))
+	sb.WriteString("\n")
+	sb.WriteString("/import — ")
+	sb.WriteString(md("восстановить прогресс и настройки из файла /export"))
+	sb.WriteString("\n")
+	sb.WriteString("/export anki — ")
+	sb.WriteString(md("колода для Anki с вашим расписанием повторений"))
+	sb.WriteString("\n")
 	sb.WriteString("/reset — ")
 	sb.WriteString(md("сбросить прогресс и настройки"))
+	sb.WriteString("\n")
+	sb.WriteString("/deletemydata — ")
+	sb.WriteString(md("удалить аккаунт и все данные"))
+	sb.WriteString("\n")
+	sb.WriteString("/whatsnew — ")
+	sb.WriteString(md("что нового в боте и подписка на новости"))
+	sb.WriteString("\n")
+	sb.WriteString("/apitoken — ")
+	sb.WriteString(md("токен HTTP API для приложений и виджетов"))
+	sb.WriteString("\n")
+	sb.WriteString("/app — ")
+	sb.WriteString(md("открыть приложение с сеткой имён и повторением"))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(md("❓ Остались вопросы? Напишите @husna_support"))
+	sb.WriteString(md("❓ Остались вопросы или нашли ошибку? Напишите через "))
+	sb.WriteString("/feedback")
+	sb.WriteString(md(" — ответ придёт сюда же."))
 
 	return sb.String()
 }
@@ -261,6 +486,44 @@ func formatLearningMode(mode entities.LearningMode) string {
 
 // formatNameMessage formats a single name message (MarkdownV2 safe).
 func formatNameMessage(name *entities.Name) string {
+	return formatLocalizedNameMessage(name, langRU)
+}
+
+// nameCardLabels holds field labels of a name card for a single language.
+type nameCardLabels struct {
+	Transliteration string
+	Translation     string
+	Meaning         string
+}
+
+var nameCardLabelsByLang = map[string]nameCardLabels{
+	langRU: {
+		Transliteration: "Транслитерация:",
+		Translation:     "Перевод:",
+		Meaning:         "Значение:",
+	},
+	langEN: {
+		Transliteration: "Transliteration:",
+		Translation:     "Translation (ru):",
+		Meaning:         "Meaning (ru):",
+	},
+}
+
+// normalizeLang maps a Telegram or settings language code to a supported language.
+func normalizeLang(code string) string {
+	if code == "" || strings.HasPrefix(strings.ToLower(code), langRU) {
+		return langRU
+	}
+	return langEN
+}
+
+// formatLocalizedNameMessage formats a name card with labels in the given language.
+func formatLocalizedNameMessage(name *entities.Name, lang string) string {
+	labels, ok := nameCardLabelsByLang[normalizeLang(lang)]
+	if !ok {
+		labels = nameCardLabelsByLang[langRU]
+	}
+
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf(
@@ -269,11 +532,11 @@ func formatNameMessage(name *entities.Name) string {
 		md(fmt.Sprintf("%d", name.Number)),
 		md("."),
 		bold(name.ArabicName),
-		md("Транслитерация:"),
+		md(labels.Transliteration),
 		bold(name.Transliteration),
-		md("Перевод:"),
+		md(labels.Translation),
 		bold(name.Translation),
-		md("Значение:"),
+		md(labels.Meaning),
 		bold(name.Meaning),
 	))
 
@@ -303,6 +566,7 @@ func buildNameResponse(
 	}
 
 	msg := newMessage(chatID, formatNameMessage(name))
+	msg.ReplyMarkup = nameCardKeyboard(name.Number, false, false)
 
 	if name.Audio == "" {
 		return msg, nil, nil
@@ -343,6 +607,12 @@ func buildNameCardText(name *entities.Name) string {
 	return formatNameMessage(name)
 }
 
+// buildNameIndexText builds the header of the compact names index (MarkdownV2 safe).
+func buildNameIndexText(total int) string {
+	return "🗂 " + bold(fmt.Sprintf("Все %d имён", total)) + "\n\n" +
+		md("Нажмите на имя, чтобы открыть карточку.")
+}
+
 // buildRangePages builds pages for a range of names.
 func buildRangePages(names []*entities.Name, from, to int) (pages []string) {
 	if from < 1 {
@@ -447,13 +717,44 @@ func formatQuizMode(mode string) string {
 		return "🔄 Только повторение"
 	case "mixed":
 		return "🎲 Смешанный"
+	case "favorites":
+		return "⭐ Только избранное"
 	default:
 		return mode
 	}
 }
 
+// formatCalendar returns the label of a calendar choice.
+func formatCalendar(calendar entities.Calendar) string {
+	if calendar == entities.CalendarHijri {
+		return "🌙 Хиджра"
+	}
+	return "📆 Григорианский"
+}
+
+// formatNamesPerDaySetting returns the names-per-day line of the settings screen.
+func formatNamesPerDaySetting(settings *entities.UserSettings) string {
+	if settings.TargetDate == nil {
+		return fmt.Sprintf("📚 Имён в день: %d", settings.NamesPerDay)
+	}
+	return fmt.Sprintf("📚 Имён в день: %d (🎯 все имена к %s)", settings.NamesPerDay, settings.TargetDate.Format("02.01.2006"))
+}
+
+// buildTargetDateMessage builds the finish-by-date screen (MarkdownV2 safe).
+func buildTargetDateMessage(settings *entities.UserSettings) string {
+	text := "🎯 " + bold("Закончить к дате") + "\n\n" +
+		md("Выберите дату, к которой хотите начать все 99 имён. Количество имён в день будет рассчитываться автоматически и вырастет, если вы отстанете от плана.")
+
+	if settings.TargetDate != nil {
+		text += "\n\n" + md(fmt.Sprintf("Сейчас: все имена к %s, %d в день.",
+			settings.TargetDate.Format("02.01.2006"), settings.NamesPerDay))
+	}
+
+	return text
+}
+
 // formatQuizResult formats quiz results (MarkdownV2 safe).
-func formatQuizResult(session *entities.QuizSession) string {
+func formatQuizResult(session *entities.QuizSession, level entities.Level, totalXP int) string {
 	percentage := float64(session.CorrectAnswers) / float64(session.TotalQuestions) * 100
 
 	emoji, message := "📚", "Продолжайте изучать имена Аллаха!"
@@ -469,29 +770,58 @@ func formatQuizResult(session *entities.QuizSession) string {
 	progressBar := buildProgressBar(session.CorrectAnswers, session.TotalQuestions, 10)
 
 	return fmt.Sprintf(
-		"%s %s\n\n%s %s\n%s\n\n%s",
+		"%s %s\n\n%s %s\n%s\n\n%s\n\n%s",
 		md(emoji),
 		md("Квиз завершён!"),
 		md("Результат:"),
 		bold(fmt.Sprintf("%d/%d (%.0f%%)", session.CorrectAnswers, session.TotalQuestions, percentage)),
 		md(progressBar),
 		md(message),
+		md(formatLevelLine(level, totalXP)),
 	)
 }
 
 // formatAnswerFeedback formats feedback for a quiz answer (MarkdownV2 safe).
-func formatAnswerFeedback(isCorrect bool, correctAnswer string) string {
+func formatAnswerFeedback(isCorrect bool, correctAnswer string, xpGained int) string {
+	xp := ""
+	if xpGained > 0 {
+		xp = " " + md(fmt.Sprintf("+%d XP", xpGained))
+	}
+
 	if isCorrect {
-		return md("✅ Правильно!")
+		return md("✅ Правильно!") + xp
 	}
 	return fmt.Sprintf(
-		"%s\n\n%s %s",
+		"%s%s\n\n%s %s",
 		md("❌ Неправильно"),
+		xp,
 		md("Правильный ответ:"),
 		bold(correctAnswer),
 	)
 }
 
+// formatSelfReviewFeedback confirms a "remember"/"forgot" self-assessment (MarkdownV2 safe).
+func formatSelfReviewFeedback(remembered bool, progress *entities.UserProgress) string {
+	if !remembered {
+		return md("😕 Ничего страшного — повторим это имя через час.")
+	}
+
+	days := max(1, progress.IntervalDays)
+	return md(fmt.Sprintf("😊 Отлично! Следующее повторение через %d %s.", days, formatDaysCount(days)))
+}
+
+// formatLevelLine formats the level with progress towards the next one (plain text, not escaped).
+func formatLevelLine(level entities.Level, totalXP int) string {
+	return fmt.Sprintf("⭐ Уровень %d · %d XP (до следующего: %d)",
+		level.Level, totalXP, level.XPForNext-level.XPIntoLevel)
+}
+
+// formatLevelUpMessage formats a level-up notification (MarkdownV2 safe).
+func formatLevelUpMessage(level int) string {
+	return "🆙 " + bold(fmt.Sprintf("Новый уровень: %d!", level)) + "\n\n" +
+		md("Пусть Аллах увеличит вас в знании.")
+}
+
 // formatProgressMessage formats the progress summary for display.
 func formatProgressMessage(summary *service.ProgressSummary, progressBar string) string {
 	var sb strings.Builder
@@ -521,17 +851,543 @@ func formatProgressMessage(summary *service.ProgressSummary, progressBar string)
 		sb.WriteString(md(fmt.Sprintf("🔄 Повторений сегодня: %d\n", summary.DueToday)))
 	}
 
+	if summary.DueTomorrow > 0 {
+		sb.WriteString(md(fmt.Sprintf("🗓 Завтра: %d\n", summary.DueTomorrow)))
+	}
+
+	if summary.DueThisWeek > 0 {
+		sb.WriteString(md(fmt.Sprintf("🗓 На этой неделе: %d (подробнее — /schedule)\n", summary.DueThisWeek)))
+	}
+
 	if summary.Learned > 0 {
 		sb.WriteString(md(fmt.Sprintf("🎯 Точность: %.1f%%\n", summary.Accuracy)))
 	}
 
+	if len(summary.ByQuestionType) > 0 {
+		sb.WriteString(md(formatQuestionTypeStats(summary.ByQuestionType)))
+	}
+
+	sb.WriteString(md(formatLevelLine(summary.Level, summary.TotalXP) + "\n"))
+
+	if summary.CurrentStreak > 0 || summary.BestStreak > 0 {
+		sb.WriteString(md(formatStreakLine(summary.CurrentStreak, summary.BestStreak) + "\n"))
+	}
+
 	if summary.DaysToComplete > 0 {
 		sb.WriteString(md(fmt.Sprintf("📅 Примерно дней до финиша: %d", summary.DaysToComplete)))
 	}
 
+	if summary.Target != nil {
+		sb.WriteString("\n\n")
+		sb.WriteString(md(formatTargetPlan(summary.Target)))
+	}
+
+	return sb.String()
+}
+
+// formatReferralSection builds the referral block of the progress screen (MarkdownV2 safe).
+func formatReferralSection(stats *entities.ReferralStats, link string) string {
+	var sb strings.Builder
+
+	sb.WriteString("🤝 ")
+	sb.WriteString(bold("Приглашённые друзья"))
+	sb.WriteString("\n")
+	sb.WriteString(md(fmt.Sprintf("Перешли по ссылке: %d, начали учить: %d\n", stats.Invited, stats.Activated)))
+
+	if stats.HasBadge() {
+		sb.WriteString(md("🏅 Значок «Наставник» получен — спасибо, что делитесь знанием!\n"))
+	} else {
+		sb.WriteString(md(fmt.Sprintf("🏅 Значок «Наставник» — за %d друзей, начавших учить (осталось %d)\n",
+			entities.ReferralBadgeThreshold, entities.ReferralBadgeThreshold-stats.Activated)))
+	}
+
+	sb.WriteString(md("Ваша ссылка: " + link))
+
+	return sb.String()
+}
+
+// formatTodayChecklist returns the /today checklist line with how many of today's names are ticked off.
+func formatTodayChecklist(done, total int) string {
+	if total > 0 && done >= total {
+		return fmt.Sprintf("🎉 Изучено сегодня: %d/%d — все имена!", done, total)
+	}
+	return fmt.Sprintf("☑️ Изучено сегодня: %d/%d", done, total)
+}
+
+// formatTodayReplan describes how a names-per-day change affected today's plan, or returns "" if it did not.
+func formatTodayReplan(r *service.TodayReplan) string {
+	switch {
+	case r.Added > 0:
+		return fmt.Sprintf("На сегодня добавлено имён: %d, всего в плане %d — /today", r.Added, r.Planned)
+	case r.Planned > r.Quota:
+		return fmt.Sprintf("Сегодня в плане уже %d — они остаются, новая норма действует с завтра", r.Planned)
+	default:
+		return ""
+	}
+}
+
+// withReplanNote appends a note from formatTodayReplan to a confirmation text.
+func withReplanNote(text, note string) string {
+	if note == "" {
+		return text
+	}
+	return strings.TrimSuffix(text, ".") + ". " + note
+}
+
+// targetMilestones are the counts of started names shown in the projected schedule.
+var targetMilestones = []int{33, 66, 99}
+
+// formatGoalMessage builds the /goal reply (MarkdownV2 safe).
+func formatGoalMessage(plan *entities.TargetPlan) string {
+	return md(formatTargetPlan(plan)) + "\n\n" +
+		md("Изменить дату: /goal ГГГГ-ММ-ДД, убрать цель: /goal off")
+}
+
+// formatTargetPlan describes the pace and projected schedule towards the target date,
+// warning when the target can no longer be met.
+func formatTargetPlan(plan *entities.TargetPlan) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🎯 Цель: все имена к %s\n", plan.Date.Format("02.01.2006"))
+
+	if plan.Done() {
+		sb.WriteString("✅ Все имена уже начаты, осталось закрепить их повторениями.")
+		return sb.String()
+	}
+
+	if plan.DaysLeft == 0 {
+		fmt.Fprintf(&sb, "⚠️ Дата прошла, а %d имён ещё не начаты. Поставьте новую цель: /goal ГГГГ-ММ-ДД", plan.Remaining)
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "Осталось %d имён за %d дн. — %d в день\n", plan.Remaining, plan.DaysLeft, plan.NamesPerDay)
+
+	started := 99 - plan.Remaining
+	for _, m := range targetMilestones {
+		if m <= started {
+			continue
+		}
+		fmt.Fprintf(&sb, "  • %d/99 — %s\n", m, plan.DateFor(m-started).Format("02.01.2006"))
+	}
+
+	if !plan.Feasible() {
+		fmt.Fprintf(&sb, "⚠️ Даже по %d имён в день к этой дате не успеть: все имена будут начаты только %s. "+
+			"Выберите дату не раньше: /goal %s",
+			entities.MaxNamesPerDay,
+			plan.ProjectedDate().Format("02.01.2006"),
+			plan.EarliestFeasibleDate().Format(time.DateOnly),
+		)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// formatInactivityWarning builds the warning about upcoming deletion of an inactive account (MarkdownV2 safe).
+func formatInactivityWarning(deleteAt time.Time) string {
+	return md("👋 ") + bold("Давно не виделись") + "\n\n" +
+		md(fmt.Sprintf("Вы давно не занимались, поэтому %s ваш аккаунт и прогресс будут удалены.", deleteAt.Format("02.01.2006"))) + "\n\n" +
+		md("Чтобы сохранить данные, просто нажмите кнопку ниже или отправьте любую команду.")
+}
+
+// formatDeleteDataWarning builds the first /deletemydata confirmation (MarkdownV2 safe).
+func formatDeleteDataWarning() string {
+	return md("⚠️ ") + bold("Удаление аккаунта и всех данных") + "\n\n" +
+		md("Будут безвозвратно удалены настройки, напоминания, прогресс по всем именам, дневные планы, история квизов, избранное, заметки, серия и XP.") + "\n\n" +
+		md("Если нужно только начать заново, используйте /reset. Сохранить копию данных можно через /export.")
+}
+
+// formatDeleteDataFinal builds the final /deletemydata confirmation (MarkdownV2 safe).
+func formatDeleteDataFinal() string {
+	return md("❗ ") + bold("Последнее подтверждение") + "\n\n" +
+		md("Восстановить удалённые данные будет невозможно. Удалить всё?")
+}
+
+// formatImportResult summarizes restored data (MarkdownV2 safe).
+func formatImportResult(r *service.ImportResult) string {
+	var sb strings.Builder
+
+	sb.WriteString("✅ ")
+	sb.WriteString(bold("Данные импортированы"))
+	sb.WriteString("\n\n")
+
+	if r.SettingsRestored {
+		sb.WriteString(md("⚙️ Настройки восстановлены\n"))
+	}
+	sb.WriteString(md(fmt.Sprintf("📚 Прогресс восстановлен: %d %s\n", r.ProgressRestored, formatNamesCount(r.ProgressRestored))))
+	if r.ProgressKept > 0 {
+		sb.WriteString(md(fmt.Sprintf("🔒 Оставлен текущий прогресс (он новее): %d %s\n", r.ProgressKept, formatNamesCount(r.ProgressKept))))
+	}
+	if r.FavoritesAdded > 0 {
+		sb.WriteString(md(fmt.Sprintf("⭐ Добавлено в избранное: %d\n", r.FavoritesAdded)))
+	}
+	if r.NotesAdded > 0 {
+		sb.WriteString(md(fmt.Sprintf("📝 Восстановлено заметок: %d\n", r.NotesAdded)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(md("Посмотреть результат: /progress"))
+
+	return sb.String()
+}
+
+// formatExportCaption describes the exported files (plain text).
+func formatExportCaption(e *service.UserExport) string {
+	return fmt.Sprintf(
+		"📦 Ваши данные на %s (UTC)\n\n"+
+			"• JSON — настройки, прогресс, история квизов, избранное и заметки\n"+
+			"• CSV — прогресс по именам (%d) и история ответов (%d)",
+		e.ExportedAt.Format("02.01.2006 15:04"), len(e.Progress), len(e.QuizHistory),
+	)
+}
+
+// formatQuestionTypeStats formats accuracy per quiz question type (plain text, not escaped).
+func formatQuestionTypeStats(stats []repository.QuestionTypeStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("🧩 Точность по типам вопросов:\n")
+	for i, st := range stats {
+		branch := "├─"
+		if i == len(stats)-1 {
+			branch = "└─"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s: %.0f%% (%d/%d)\n",
+			branch, formatQuestionType(st.QuestionType), st.Accuracy(), st.Correct, st.Total))
+	}
+
+	return sb.String()
+}
+
+// formatQuestionType returns a short description of a quiz question type.
+func formatQuestionType(questionType string) string {
+	switch entities.QuestionType(questionType) {
+	case entities.QuestionTypeTranslation:
+		return "перевод → имя"
+	case entities.QuestionTypeTransliteration:
+		return "имя → перевод"
+	case entities.QuestionTypeMeaning:
+		return "значение → имя"
+	case entities.QuestionTypeArabic:
+		return "арабское написание → перевод"
+	default:
+		return questionType
+	}
+}
+
+// formatNameStatsMessage formats personal statistics for a single name (MarkdownV2 safe).
+func formatNameStatsMessage(name *entities.Name, stats *service.NameStats, loc *time.Location) string {
+	var sb strings.Builder
+
+	sb.WriteString("📊 ")
+	sb.WriteString(bold(fmt.Sprintf("%d. %s", name.Number, name.Transliteration)))
+	sb.WriteString(md(fmt.Sprintf(" — %s", name.Translation)))
+	sb.WriteString("\n\n")
+
+	p := stats.Progress
+	if p == nil {
+		sb.WriteString(md("Вы ещё не изучали это имя."))
+		sb.WriteString("\n\n")
+		sb.WriteString(md("Добавьте его в план через /today или пройдите /quiz."))
+		return sb.String()
+	}
+
+	sb.WriteString(md(fmt.Sprintf("📌 Этап: %s\n", formatPhase(p.Phase))))
+	sb.WriteString(md(fmt.Sprintf("🔥 Серия: %d/%d\n", p.Streak, entities.MinStreakForMastery)))
+	sb.WriteString(md(fmt.Sprintf("⚖️ Лёгкость: %.2f\n", p.Ease)))
+	sb.WriteString(md(fmt.Sprintf("⏳ Интервал: %d дн.\n", p.IntervalDays)))
+	sb.WriteString("\n")
+
+	if stats.TotalAnswers > 0 {
+		sb.WriteString(md(fmt.Sprintf("🎯 Точность: %.0f%% (%d/%d)\n",
+			stats.Accuracy(), stats.CorrectAnswers, stats.TotalAnswers)))
+	} else {
+		sb.WriteString(md("🎯 Точность: ещё нет ответов\n"))
+	}
+
+	lastReview := p.LastReviewedAt
+	if lastReview == nil {
+		lastReview = stats.LastAnsweredAt
+	}
+	sb.WriteString(md(fmt.Sprintf("🕘 Последнее повторение: %s\n", formatOptionalTime(lastReview, loc))))
+	sb.WriteString(md(fmt.Sprintf("🔄 Следующее повторение: %s", formatOptionalTime(p.NextReviewAt, loc))))
+
+	return sb.String()
+}
+
+// formatPhase formats a learning phase for display.
+func formatPhase(phase entities.Phase) string {
+	switch phase {
+	case entities.PhaseNew:
+		return "🆕 Новое"
+	case entities.PhaseLearning:
+		return "📖 Изучается"
+	case entities.PhaseMastered:
+		return "✅ Выучено"
+	default:
+		return string(phase)
+	}
+}
+
+// formatOptionalTime formats a nullable timestamp in the given location.
+func formatOptionalTime(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return "—"
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("02.01.2006 15:04")
+}
+
+// formatNoteBlock formats user's personal note appended to a name card (MarkdownV2 safe).
+func formatNoteBlock(note string) string {
+	return "\n\n✏️ " + bold("Ваша заметка:") + "\n" + md(note)
+}
+
+// formatNotePrompt returns the ForceReply prompt text for entering a note.
+func formatNotePrompt(name *entities.Name) string {
+	return fmt.Sprintf(
+		"✏️ Заметка к имени %d. %s\n\nОтправьте текст (до %d символов): ассоциация, размышление, дуа.\nЧтобы выйти без изменений, отправьте «отмена».",
+		name.Number, name.Transliteration, entities.MaxNoteLength,
+	)
+}
+
+// formatFindResults formats a page of search results (MarkdownV2 safe).
+func formatFindResults(query string, names []*entities.Name, page, totalPages int) string {
+	header := fmt.Sprintf("🔍 %s %s",
+		bold(fmt.Sprintf("Поиск: «%s»", query)),
+		md(fmt.Sprintf("(найдено: %d)", len(names))),
+	)
+	return formatNameList(header, names, page, totalPages)
+}
+
+// formatFavoritesList formats a page of user's favorite names (MarkdownV2 safe).
+func formatFavoritesList(names []*entities.Name, page, totalPages int) string {
+	header := fmt.Sprintf("⭐ %s %s",
+		bold("Избранное"),
+		md(fmt.Sprintf("(%d)", len(names))),
+	)
+	return formatNameList(header, names, page, totalPages)
+}
+
+// formatNameList formats a page of a short names list under the given header (MarkdownV2 safe).
+func formatNameList(header string, names []*entities.Name, page, totalPages int) string {
+	var sb strings.Builder
+
+	sb.WriteString(header)
+	sb.WriteString("\n\n")
+
+	for _, name := range paginateNames(names, page, findPerPage) {
+		sb.WriteString(fmt.Sprintf("%s %s — %s\n",
+			md(fmt.Sprintf("%d.", name.Number)),
+			bold(name.Transliteration),
+			md(name.Translation),
+		))
+	}
+
+	if totalPages > 1 {
+		sb.WriteString("\n")
+		sb.WriteString(md(fmt.Sprintf("Страница %d из %d", page+1, totalPages)))
+	}
+
+	return sb.String()
+}
+
+// formatScheduleMessage formats the review forecast for the upcoming days (MarkdownV2 safe).
+func formatScheduleMessage(forecast []repository.DueForecastDay) string {
+	var sb strings.Builder
+
+	sb.WriteString("🗓 ")
+	sb.WriteString(bold("Расписание повторений"))
+	sb.WriteString("\n\n")
+
+	total := 0
+	for i, day := range forecast {
+		total += day.Count
+
+		label := fmt.Sprintf("%s %s", formatWeekdayShort(day.Date.Weekday()), day.Date.Format("02.01"))
+		switch i {
+		case 0:
+			label = "Сегодня"
+		case 1:
+			label = "Завтра"
+		}
+
+		if day.Count == 0 {
+			sb.WriteString(md(fmt.Sprintf("▫️ %s: —\n", label)))
+			continue
+		}
+		sb.WriteString(md(fmt.Sprintf("🔄 %s: %d %s\n", label, day.Count, formatNamesCount(day.Count))))
+	}
+
+	sb.WriteString("\n")
+
+	if total == 0 {
+		sb.WriteString(md("На ближайшую неделю повторений нет. Изучайте новые имена в /today и проходите /quiz."))
+		return sb.String()
+	}
+
+	sb.WriteString(md(fmt.Sprintf("Всего за неделю: %d %s", total, formatNamesCount(total))))
+
+	return sb.String()
+}
+
+// monthlyReportBarWidth is the width of bars in the monthly report chart.
+const monthlyReportBarWidth = 10
+
+// formatMonthlyReport formats the monthly recap with a textual comparison chart (MarkdownV2 safe).
+func formatMonthlyReport(r *service.MonthlyReport) string {
+	var sb strings.Builder
+
+	curLabel := formatMonthName(r.Month.Month())
+	prevLabel := formatMonthName(r.Month.AddDate(0, -1, 0).Month())
+	title := fmt.Sprintf("Отчёт за %s %d", strings.ToLower(curLabel), r.Month.Year())
+	if r.Calendar == entities.CalendarHijri {
+		curLabel = r.HijriMonth.MonthName(langRU)
+		prevLabel = r.HijriMonth.MonthStart(-1).MonthName(langRU)
+		title = fmt.Sprintf("Отчёт за %s %d г. х.", curLabel, r.HijriMonth.Year)
+	}
+
+	sb.WriteString("📊 ")
+	sb.WriteString(bold(title))
+	sb.WriteString("\n")
+	sb.WriteString(md(fmt.Sprintf("Сравнение с месяцем «%s»", strings.ToLower(prevLabel))))
+	sb.WriteString("\n\n")
+
+	if r.Current.Answers == 0 && r.Previous.Answers == 0 {
+		sb.WriteString(md("За последние два месяца нет ответов в квизах. Начните с /quiz — и отчёт заполнится."))
+		return sb.String()
+	}
+
+	cur, prev := r.Current, r.Previous
+	metrics := []struct {
+		title     string
+		cur, prev int
+		suffix    string
+	}{
+		{"🆕 Новые имена", cur.NewNames, prev.NewNames, ""},
+		{"🏆 Выучено", cur.Mastered, prev.Mastered, ""},
+		{"📅 Активные дни", cur.ActiveDays, prev.ActiveDays, ""},
+		{"🎯 Точность", int(cur.Accuracy() + 0.5), int(prev.Accuracy() + 0.5), "%"},
+	}
+
+	labelWidth := max(len([]rune(curLabel)), len([]rune(prevLabel)))
+
+	sb.WriteString("```\n")
+	for i, m := range metrics {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		scale := max(m.cur, m.prev)
+		if m.suffix == "%" {
+			scale = 100
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", m.title, formatTrendArrow(m.cur, m.prev)))
+		sb.WriteString(fmt.Sprintf("%-*s %s %d%s\n", labelWidth, curLabel,
+			buildProgressBar(m.cur, scale, monthlyReportBarWidth), m.cur, m.suffix))
+		sb.WriteString(fmt.Sprintf("%-*s %s %d%s\n", labelWidth, prevLabel,
+			buildProgressBar(m.prev, scale, monthlyReportBarWidth), m.prev, m.suffix))
+	}
+	sb.WriteString("```\n")
+
+	sb.WriteString(md(fmt.Sprintf("🔄 Ответов в квизах: %d (в прошлом месяце: %d)", cur.Answers, prev.Answers)))
+
+	return sb.String()
+}
+
+// activityCalendarBusyDay is the number of answers that marks a day as intensive.
+const activityCalendarBusyDay = 10
+
+// formatActivityCalendar formats a month grid (Mon–Sun) of study days (MarkdownV2 safe).
+func formatActivityCalendar(c *service.ActivityCalendar) string {
+	var sb strings.Builder
+
+	sb.WriteString("📅 ")
+	sb.WriteString(bold(fmt.Sprintf("Календарь занятий: %s %d", strings.ToLower(formatMonthName(c.Month.Month())), c.Month.Year())))
+	sb.WriteString("\n\n")
+
+	daysInMonth := c.Month.AddDate(0, 1, -1).Day()
+	lead := (int(c.Month.Weekday()) + 6) % 7 // Monday-based offset of the first day
+
+	cells := make([]string, 0, lead+daysInMonth+6)
+	for i := 0; i < lead; i++ {
+		cells = append(cells, "▫️")
+	}
+
+	studied := 0
+	for day := 1; day <= daysInMonth; day++ {
+		answers := c.Answers[day]
+		switch {
+		case day > c.Today:
+			cells = append(cells, "▫️")
+		case answers >= activityCalendarBusyDay:
+			cells = append(cells, "🟩")
+		case answers > 0:
+			cells = append(cells, "🟨")
+		default:
+			cells = append(cells, "⬜")
+		}
+		if answers > 0 {
+			studied++
+		}
+	}
+	for len(cells)%7 != 0 {
+		cells = append(cells, "▫️")
+	}
+
+	for i := 0; i < len(cells); i += 7 {
+		sb.WriteString(strings.Join(cells[i:i+7], ""))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(md(fmt.Sprintf("Дней с занятиями: %d из %d", studied, c.Today)))
+	sb.WriteString("\n\n")
+	sb.WriteString(md(fmt.Sprintf("🟩 %d+ ответов  🟨 меньше %d  ⬜ без занятий\nСтроки — недели с понедельника по воскресенье.", activityCalendarBusyDay, activityCalendarBusyDay)))
+
 	return sb.String()
 }
 
+// formatTrendArrow returns an arrow describing the change from prev to cur.
+func formatTrendArrow(cur, prev int) string {
+	switch {
+	case cur > prev:
+		return "↑"
+	case cur < prev:
+		return "↓"
+	default:
+		return "="
+	}
+}
+
+// formatMonthName returns the Russian name of a month in the nominative case.
+func formatMonthName(m time.Month) string {
+	names := [...]string{
+		"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
+		"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь",
+	}
+	return names[m-1]
+}
+
+// formatWeekdayShort returns a short Russian weekday name.
+func formatWeekdayShort(d time.Weekday) string {
+	switch d {
+	case time.Monday:
+		return "Пн"
+	case time.Tuesday:
+		return "Вт"
+	case time.Wednesday:
+		return "Ср"
+	case time.Thursday:
+		return "Чт"
+	case time.Friday:
+		return "Пт"
+	case time.Saturday:
+		return "Сб"
+	default:
+		return "Вс"
+	}
+}
+
 // buildReminderSettingsMessage builds reminder settings screen message
 func buildReminderSettingsMessage(timezone string, reminder *entities.UserReminders) string {
 	if reminder == nil {
@@ -547,32 +1403,258 @@ func buildReminderSettingsMessage(timezone string, reminder *entities.UserRemind
 		status = "🔔 Включены"
 
 		freqText := formatIntervalHoursInt(reminder.IntervalHours)
+		if reminder.Style == entities.ReminderStyleDigest {
+			freqText = "Сводка раз в день в " + reminder.StartTime[:5]
+		}
 
 		startTime := reminder.StartTime[:5] // "08:00"
 		endTime := reminder.EndTime[:5]     // "20:00"
 
-		details = fmt.Sprintf(
-			"\n%s %s\n%s %s\n%s %s — %s",
-			md("🌍 Часовой пояс:"),
-			bold(timezone),
-			md("📅 Частота:"),
-			bold(freqText),
-			md("⏰ Время:"),
-			bold(startTime),
-			bold(endTime),
-		)
+		if reminder.Prayer.Enabled() {
+			details = fmt.Sprintf(
+				"\n%s %s\n%s %s",
+				md("🌍 Часовой пояс:"),
+				bold(entities.TimezoneLabel(timezone, time.Now())),
+				md("🕌 По времени намаза:"),
+				bold(formatPrayerAnchorsSummary(reminder)),
+			)
+		} else {
+			details = fmt.Sprintf(
+				"\n%s %s\n%s %s\n%s %s — %s",
+				md("🌍 Часовой пояс:"),
+				bold(entities.TimezoneLabel(timezone, time.Now())),
+				md("📅 Частота:"),
+				bold(freqText),
+				md("⏰ Время:"),
+				bold(startTime),
+				bold(endTime),
+			)
+		}
+	}
+
+	digest := "выключена"
+	if reminder.WeeklyDigest {
+		digest = "по воскресеньям в 09:00"
+	}
+
+	if reminder.IsEnabled && reminder.Style != entities.ReminderStyleDigest {
+		details += "\n" + md("🧩 Содержание:") + " " + bold(formatReminderContent(reminder.Content))
+	}
+
+	if reminder.IsEnabled && !reminder.Quiet.IsEmpty() {
+		details += "\n" + md("🌙 Тихие дни:") + " " + bold(formatQuietDaysSummary(reminder.Quiet))
 	}
 
 	return fmt.Sprintf(
-		"%s\n\n%s %s%s\n\n%s",
+		"%s\n\n%s %s%s\n%s %s\n\n%s",
 		md("⏰ Настройки напоминаний"),
 		md("Статус:"),
 		bold(status),
 		details,
+		md("📬 Недельная сводка:"),
+		bold(digest),
 		md("Напоминания помогут не забывать о ежедневной практике изучения имён Аллаха."),
 	)
 }
 
+// formatReminderContent returns the label of the reminder content preference.
+func formatReminderContent(content entities.ReminderContent) string {
+	switch content {
+	case entities.ReminderContentReview:
+		return "только повторения"
+	case entities.ReminderContentNew:
+		return "только имена на сегодня"
+	default:
+		return "чередовать"
+	}
+}
+
+// nextReminderContent returns the preference the settings button switches to.
+func nextReminderContent(content entities.ReminderContent) entities.ReminderContent {
+	switch content {
+	case entities.ReminderContentReview:
+		return entities.ReminderContentNew
+	case entities.ReminderContentNew:
+		return entities.ReminderContentMixed
+	default:
+		return entities.ReminderContentReview
+	}
+}
+
+// formatHijriDate returns the Hijri date of day's calendar date, e.g. "🌙 4 Джумада аль-уля 1448 г. х.".
+func formatHijriDate(day time.Time, lang string) string {
+	return "🌙 " + entities.ToHijri(day).Format(lang)
+}
+
+// buildChannelPostText builds the name of the day post for a channel or group (MarkdownV2 safe).
+func buildChannelPostText(name *entities.Name, day time.Time, lang string) string {
+	if lang == langEN {
+		return "✨ " + bold("Name of the day") + "\n" +
+			md(fmt.Sprintf("📅 %s · %s", day.Format("02.01.2006"), formatHijriDate(day, langEN))) + "\n\n" +
+			formatLocalizedNameMessage(name, langEN) + "\n\n" +
+			md(fmt.Sprintf("#name_of_the_day #name%d", name.Number))
+	}
+	return "✨ " + bold("Имя дня") + "\n" +
+		md(fmt.Sprintf("📅 %s · %s", day.Format("02.01.2006"), formatHijriDate(day, langRU))) + "\n\n" +
+		formatNameMessage(name) + "\n\n" +
+		md(fmt.Sprintf("#имя_дня #имя%d", name.Number))
+}
+
+// formatSnoozeAnswer returns the callback answer for a snoozed reminder; next is in the user's timezone.
+func formatSnoozeAnswer(next time.Time) string {
+	now := time.Now().In(next.Location())
+	if entities.LocalDay(next, next.Location()).After(entities.LocalDay(now, now.Location())) {
+		return "⏰ Напомню завтра в " + next.Format("15:04")
+	}
+	return "⏰ Напомню в " + next.Format("15:04")
+}
+
+// formatPrayerName returns the Russian name of the prayer.
+func formatPrayerName(p entities.Prayer) string {
+	switch p {
+	case entities.Fajr:
+		return "Фаджр"
+	case entities.Dhuhr:
+		return "Зухр"
+	case entities.Asr:
+		return "Аср"
+	case entities.Maghrib:
+		return "Магриб"
+	default:
+		return "Иша"
+	}
+}
+
+// formatPrayerAnchorsSummary returns the selected prayers and the offset, e.g. "Магриб, Иша +30 мин".
+// In digest style only the first selected prayer of the day is used.
+func formatPrayerAnchorsSummary(reminder *entities.UserReminders) string {
+	var names []string
+	for _, p := range entities.Prayers {
+		if reminder.Prayer.Has(p) {
+			names = append(names, formatPrayerName(p))
+		}
+	}
+	if len(names) == 0 {
+		return "не выбраны"
+	}
+	if reminder.Style == entities.ReminderStyleDigest {
+		names = names[:1]
+	}
+
+	return fmt.Sprintf("%s +%d мин", strings.Join(names, ", "), reminder.Prayer.OffsetMinutes)
+}
+
+// buildPrayerRemindersMessage builds the prayer-anchored reminders screen (MarkdownV2 safe).
+// times is nil when there is no location or prayer times cannot be calculated today.
+func buildPrayerRemindersMessage(reminder *entities.UserReminders, times *entities.PrayerTimes) string {
+	var sb strings.Builder
+	sb.WriteString(bold("🕌 Напоминания по времени намаза"))
+	sb.WriteString("\n\n")
+	sb.WriteString(md("Вместо фиксированного окна напоминания приходят через выбранное время после намаза. " +
+		"Время намаза рассчитывается по вашему местоположению (метод Всемирной исламской лиги)."))
+	sb.WriteString("\n\n")
+
+	switch {
+	case !reminder.Prayer.HasLocation():
+		sb.WriteString(md("📍 Местоположение не указано — отправьте его, чтобы включить режим."))
+	case times == nil:
+		sb.WriteString(md("⚠️ Сегодня время намаза по вашему местоположению не рассчитать, напоминания пойдут по обычному расписанию."))
+	default:
+		sb.WriteString(md("Сегодня:"))
+		for _, p := range entities.Prayers {
+			line := fmt.Sprintf("%s %s", formatPrayerName(p), times[p].Format("15:04"))
+			if reminder.Prayer.Has(p) {
+				line = "✅ " + line
+			} else {
+				line = "▫️ " + line
+			}
+			sb.WriteString("\n")
+			sb.WriteString(md(line))
+		}
+	}
+
+	sb.WriteString("\n\n")
+	if reminder.Prayer.Enabled() {
+		sb.WriteString(md("Сейчас: "))
+		sb.WriteString(bold(formatPrayerAnchorsSummary(reminder)))
+	} else {
+		sb.WriteString(md("Режим выключен: отметьте хотя бы один намаз и укажите местоположение."))
+	}
+	if reminder.Style == entities.ReminderStyleDigest {
+		sb.WriteString("\n\n")
+		sb.WriteString(md("📨 Сводка на день приходит после первого отмеченного намаза."))
+	}
+
+	return sb.String()
+}
+
+// buildQuietDaysMessage builds the quiet days screen (MarkdownV2 safe).
+func buildQuietDaysMessage(quiet entities.QuietDays) string {
+	var sb strings.Builder
+	sb.WriteString(bold("🌙 Тихие дни"))
+	sb.WriteString("\n\n")
+	sb.WriteString(md("В эти дни напоминания не приходят, остальные настройки сохраняются."))
+	sb.WriteString("\n\n")
+
+	if quiet.IsEmpty() {
+		sb.WriteString(md("Сейчас тихих дней нет."))
+	} else {
+		sb.WriteString(md("Сейчас: "))
+		sb.WriteString(bold(formatQuietDaysSummary(quiet)))
+	}
+
+	sb.WriteString("\n\n")
+	sb.WriteString(md("Отметьте дни недели или добавьте отдельные даты. Нажмите на дату, чтобы убрать её."))
+	if quiet.AllWeekdaysQuiet() {
+		sb.WriteString("\n\n")
+		sb.WriteString(md("⚠️ Отмечены все дни недели — напоминания приходить не будут."))
+	}
+
+	return sb.String()
+}
+
+// formatQuietDaysSummary returns a short list of quiet weekdays and dates.
+func formatQuietDaysSummary(quiet entities.QuietDays) string {
+	var parts []string
+	for i := 1; i <= 7; i++ {
+		d := time.Weekday(i % 7)
+		if quiet.HasWeekday(d) {
+			parts = append(parts, formatWeekdayShort(d))
+		}
+	}
+
+	const maxListedDates = 3
+	for i, date := range quiet.Dates {
+		if i == maxListedDates {
+			parts = append(parts, fmt.Sprintf("+%d", len(quiet.Dates)-maxListedDates))
+			break
+		}
+		parts = append(parts, date.Format("02.01"))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatTimezoneConfirmMessage asks to confirm a timezone resolved from user input (MarkdownV2 safe).
+func formatTimezoneConfirmMessage(city entities.TimezoneCity, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(md("🌍 "))
+	sb.WriteString(bold(city.Name))
+	if city.Name != city.Zone {
+		sb.WriteString(md(" → " + city.Zone))
+	}
+	sb.WriteString("\n\n")
+
+	if loc, err := entities.ParseTimezoneLocation(city.Zone); err == nil {
+		sb.WriteString(md(fmt.Sprintf("Сейчас там %s (%s).",
+			now.In(loc).Format("15:04"), entities.TimezoneOffsetLabel(loc, now))))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(md("Сохранить этот часовой пояс?"))
+	return sb.String()
+}
+
 func buildTimezoneMenuMessage(current string) string {
 	if current == "" {
 		current = "UTC"
@@ -583,9 +1665,9 @@ func buildTimezoneMenuMessage(current string) string {
 	sb.WriteString(bold("Часовой пояс"))
 	sb.WriteString("\n\n")
 	sb.WriteString(md("Текущий: "))
-	sb.WriteString(bold(current))
+	sb.WriteString(bold(entities.TimezoneLabel(current, time.Now())))
 	sb.WriteString("\n\n")
-	sb.WriteString(md("Выберите смещение от UTC, чтобы напоминания приходили по местному времени."))
+	sb.WriteString(md("Выберите регион и город или найдите город поиском, чтобы напоминания приходили по местному времени."))
 
 	return sb.String()
 }
@@ -628,41 +1710,156 @@ func formatReminderStatus(reminder *entities.UserReminders) string {
 		return "🔕 Отключены"
 	}
 
-	freqText := formatIntervalHoursInt(reminder.IntervalHours)
-
 	startTime := reminder.StartTime[:5] // "08:00"
 	endTime := reminder.EndTime[:5]     // "20:00"
 
+	if reminder.Prayer.Enabled() {
+		return "🔔 После намаза: " + formatPrayerAnchorsSummary(reminder)
+	}
+
+	if reminder.Style == entities.ReminderStyleDigest {
+		return fmt.Sprintf("🔔 Сводка на день в %s", startTime)
+	}
+
+	freqText := formatIntervalHoursInt(reminder.IntervalHours)
+
 	return fmt.Sprintf("🔔 %s в день (%s-%s)", freqText, startTime, endTime)
 }
 
-// buildReminderNotification builds reminder notification message.
-func buildReminderNotification(payload entities.ReminderPayload) string {
+// buildReminderPreviewMessage describes when reminders are sent and what /remindtest shows below it.
+// now is in the user's timezone.
+func buildReminderPreviewMessage(reminder *entities.UserReminders, preview *entities.ReminderPreview, now time.Time) string {
 	var sb strings.Builder
 
-	switch payload.Kind {
-	case entities.ReminderKindReview:
-		sb.WriteString(md("🔔 "))
-		sb.WriteString(bold("Время повторить имена Аллаха!"))
-		sb.WriteString("\n\n")
-		sb.WriteString(md("📖 Имя для повторения:"))
-	case entities.ReminderKindStudy:
-		sb.WriteString(md("📚 "))
-		sb.WriteString(bold("Время продолжить изучение сегодняшних имён!"))
-		sb.WriteString("\n\n")
-		sb.WriteString(md("📖 Имя на сегодня:"))
-	case entities.ReminderKindNew:
-		fallthrough
+	sb.WriteString(md("🧪 "))
+	sb.WriteString(bold("Проверка напоминания"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(md("Статус: "))
+	sb.WriteString(bold(formatReminderStatus(reminder)))
+	sb.WriteString("\n")
+	sb.WriteString(md("Сейчас: "))
+	sb.WriteString(bold(now.Format("15:04")))
+	sb.WriteString(md(" (" + entities.TimezoneLabel(preview.Timezone, now) + ")"))
+	sb.WriteString("\n")
+
+	switch {
+	case !preview.Enabled:
+	case preview.DueNow:
+		sb.WriteString(md("Следующее: при ближайшей проверке (раз в 5 минут)"))
+		sb.WriteString("\n")
+	case preview.NextSendAt != nil:
+		sb.WriteString(md("Следующее: "))
+		sb.WriteString(bold(formatPreviewTime(preview.NextSendAt.In(now.Location()), now)))
+		sb.WriteString("\n")
+	}
+
+	if preview.Enabled && preview.QuietDay {
+		sb.WriteString(md("🌙 Сегодня тихий день — напоминания не отправляются."))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+
+	switch {
+	case preview.Reminder == nil && preview.Digest == nil:
+		sb.WriteString(md("Сейчас напоминать не о чем: нет новых имён на сегодня и повторений. Планировщик проверит снова через час."))
+	case !preview.Enabled:
+		sb.WriteString(md("Напоминания выключены. Ниже — сообщение, которое пришло бы сейчас, если их включить:"))
+	default:
+		sb.WriteString(md("Ниже — сообщение, которое планировщик отправил бы сейчас:"))
+	}
+
+	return sb.String()
+}
+
+// formatPreviewTime formats t relative to now: today, tomorrow or a date.
+func formatPreviewTime(t, now time.Time) string {
+	day := entities.LocalDay(t, t.Location())
+	today := entities.LocalDay(now, now.Location())
+
+	switch {
+	case day.Equal(today):
+		return "сегодня в " + t.Format("15:04")
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "завтра в " + t.Format("15:04")
 	default:
-		sb.WriteString(md("🌟 "))
-		sb.WriteString(bold("Время узнать новое имя Аллаха!"))
+		return t.Format("02.01 в 15:04")
+	}
+}
+
+// buildWeeklyDigestMessage builds the weekly progress digest message.
+func buildWeeklyDigestMessage(d entities.WeeklyDigest) string {
+	var sb strings.Builder
+
+	sb.WriteString(md("📬 "))
+	sb.WriteString(bold("Итоги недели"))
+	sb.WriteString("\n")
+	if !d.Date.IsZero() {
+		sb.WriteString(md(formatHijriDate(d.Date, langRU)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if d.Reviews == 0 && d.Mastered == 0 {
+		sb.WriteString(md("На этой неделе не было ни одного ответа в квизе. Начните новую неделю с короткого повторения: /quiz"))
 		sb.WriteString("\n\n")
-		sb.WriteString(md("📖 Имя на сегодня:"))
+		sb.WriteString(md(fmt.Sprintf("✅ Выучено всего: %d/99", d.TotalMastered)))
+		return sb.String()
 	}
 
+	sb.WriteString(md(fmt.Sprintf("🏆 Выучено за неделю: %d\n", d.Mastered)))
+	sb.WriteString(md(fmt.Sprintf("✅ Выучено всего: %d/99\n", d.TotalMastered)))
+	sb.WriteString(md(fmt.Sprintf("🔄 Ответов в квизах: %d\n", d.Reviews)))
+	sb.WriteString(md(fmt.Sprintf("🎯 Точность: %d%%", d.Accuracy())))
+	sb.WriteString(md(formatAccuracyTrend(d)))
+	sb.WriteString("\n")
+
+	if d.CurrentStreak > 0 {
+		sb.WriteString(md(formatStreakLine(d.CurrentStreak, d.BestStreak)))
+	} else {
+		sb.WriteString(md("🔥 Серия прервана — начните новую сегодня: /quiz"))
+	}
+
+	return sb.String()
+}
+
+// formatAccuracyTrend compares this week's accuracy with the previous week.
+func formatAccuracyTrend(d entities.WeeklyDigest) string {
+	if d.PrevReviews == 0 {
+		return ""
+	}
+
+	diff := d.Accuracy() - d.PrevAccuracy()
+	switch {
+	case diff > 0:
+		return fmt.Sprintf(" (📈 +%d%% к прошлой неделе)", diff)
+	case diff < 0:
+		return fmt.Sprintf(" (📉 %d%% к прошлой неделе)", diff)
+	default:
+		return " (➡️ как на прошлой неделе)"
+	}
+}
+
+// buildReminderNotification builds reminder notification message.
+func buildReminderNotification(payload entities.ReminderPayload) string {
+	var sb strings.Builder
+
+	tpl := pickReminderTemplate(payload.Kind, payload.Language)
+	sb.WriteString(md(tpl.Emoji + " "))
+	sb.WriteString(bold(tpl.Title))
+	sb.WriteString("\n\n")
+	sb.WriteString(md(tpl.Lead))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(formatNameMessage(&payload.Name))
+	// The card would give the answer away, so a question replaces it until answered.
+	if payload.Question != nil {
+		sb.WriteString(md("❓ Проверьте себя:"))
+		sb.WriteString("\n")
+		sb.WriteString(bold(formatQuestionPrompt(string(payload.Question.Type), &payload.Name)))
+	} else {
+		sb.WriteString(formatLocalizedNameMessage(&payload.Name, payload.Language))
+	}
 	sb.WriteString("\n\n")
 
 	sb.WriteString(md("📊 "))
@@ -686,6 +1883,48 @@ func buildReminderNotification(payload entities.ReminderPayload) string {
 	return sb.String()
 }
 
+// buildDailyDigestMessage lists today's names and due reviews (MarkdownV2 safe).
+func buildDailyDigestMessage(d entities.DailyDigest) string {
+	var sb strings.Builder
+
+	sb.WriteString(md("☀️ "))
+	sb.WriteString(bold("Ваш план на сегодня"))
+	sb.WriteString("\n\n")
+
+	if len(d.Planned) > 0 {
+		sb.WriteString(md("📖 Имена на сегодня:"))
+		sb.WriteString("\n")
+		writeDigestNames(&sb, d.Planned)
+		sb.WriteString("\n")
+	}
+
+	if d.DueToday > 0 {
+		sb.WriteString(md(fmt.Sprintf("🔄 На повторение: %d", d.DueToday)))
+		sb.WriteString("\n")
+		writeDigestNames(&sb, d.Reviews)
+		if rest := d.DueToday - len(d.Reviews); rest > 0 {
+			sb.WriteString(md(fmt.Sprintf("…и ещё %d", rest)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(md("Откройте имена или сразу начните квиз."))
+
+	return sb.String()
+}
+
+// writeDigestNames writes one "N. Transliteration — translation" line per name.
+func writeDigestNames(sb *strings.Builder, names []entities.Name) {
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("%s %s — %s\n",
+			md(fmt.Sprintf("%d.", name.Number)),
+			bold(name.Transliteration),
+			md(name.Translation),
+		))
+	}
+}
+
 func buildFirstQuizMessage() string {
 	var sb strings.Builder
 
@@ -710,26 +1949,95 @@ func buildQuizQuestionText(
 
 	sb.WriteString(md(fmt.Sprintf("Вопрос %d из %d", currentNum, totalQuestions)))
 	sb.WriteString("\n\n")
+	sb.WriteString(bold(formatQuestionPrompt(question.QuestionType, name)))
 
-	var questionPrompt string
-	switch question.QuestionType {
+	return sb.String()
+}
+
+// formatQuestionPrompt returns the question text for a quiz question type (plain text, not escaped).
+func formatQuestionPrompt(questionType string, name *entities.Name) string {
+	switch questionType {
 	case string(entities.QuestionTypeTranslation):
-		questionPrompt = fmt.Sprintf("Какое арабское имя означает: %s?", name.Translation)
+		return fmt.Sprintf("Какое арабское имя означает: %s?", name.Translation)
 	case string(entities.QuestionTypeTransliteration):
-		questionPrompt = fmt.Sprintf("Что означает имя %s?", name.Transliteration)
+		return fmt.Sprintf("Что означает имя %s?", name.Transliteration)
 	case string(entities.QuestionTypeMeaning):
-		questionPrompt = fmt.Sprintf("Какое из имён соответствует значению: %s?", name.Meaning)
+		return fmt.Sprintf("Какое из имён соответствует значению: %s?", name.Meaning)
 	case string(entities.QuestionTypeArabic):
-		questionPrompt = fmt.Sprintf("Что означает арабское имя %s?", name.ArabicName)
+		return fmt.Sprintf("Что означает арабское имя %s?", name.ArabicName)
+	default:
+		return name.ArabicName
+	}
+}
+
+// formatDaysCount returns the Russian plural form of "день" for n.
+func formatDaysCount(n int) string {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return "день"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "дня"
 	default:
-		questionPrompt = name.ArabicName
+		return "дней"
 	}
+}
+
+// formatStreakLine formats the current and best daily streak (plain text, not escaped).
+func formatStreakLine(current, best int) string {
+	line := fmt.Sprintf("🔥 Серия: %d %s подряд", current, formatDaysCount(current))
+	if best > current {
+		line += fmt.Sprintf(" (рекорд: %d)", best)
+	}
+	return line
+}
+
+// formatStreakAlertMessage formats the streak-protection reminder (MarkdownV2 safe).
+func formatStreakAlertMessage(streak, hoursLeft int) string {
+	var sb strings.Builder
+
+	sb.WriteString("🔥 ")
+	sb.WriteString(bold(fmt.Sprintf("Осталось %d %s, чтобы сохранить серию!", hoursLeft, formatHoursCount(hoursLeft))))
+	sb.WriteString("\n\n")
+	sb.WriteString(md(fmt.Sprintf("Ваша серия — %d %s подряд. Сегодня вы ещё не занимались: ответьте хотя бы на один вопрос квиза до полуночи.",
+		streak, formatDaysCount(streak))))
+
+	return sb.String()
+}
 
-	sb.WriteString(bold(questionPrompt))
+// formatStreakMilestoneMessage formats a celebration for reaching a streak milestone (MarkdownV2 safe).
+func formatStreakMilestoneMessage(days int) string {
+	var sb strings.Builder
+
+	sb.WriteString("🎉 ")
+	sb.WriteString(bold(fmt.Sprintf("%d %s подряд!", days, formatDaysCount(days))))
+	sb.WriteString("\n\n")
+
+	switch days {
+	case 99:
+		sb.WriteString(md("По дню на каждое из 99 имён. МашаАллах, это выдающееся постоянство!"))
+	case 30:
+		sb.WriteString(md("Целый месяц ежедневных занятий. Пусть Аллах сделает это дело постоянным!"))
+	default:
+		sb.WriteString(md("Неделя без пропусков — отличное начало. Продолжайте в том же духе!"))
+	}
 
 	return sb.String()
 }
 
+// formatHoursCount returns the Russian plural form of "час" for n.
+func formatHoursCount(n int) string {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return "час"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "часа"
+	default:
+		return "часов"
+	}
+}
+
 func formatNamesCount(n int) string {
 	if n == 1 {
 		return "имя"
@@ -2,11 +2,75 @@ package telegram
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
 )
 
+// log returns the handler logger with the request ID of the update being handled, if any.
+func (h *Handler) log(ctx context.Context) *zap.Logger {
+	return requestid.Logger(ctx, h.logger)
+}
+
+// internalErrorMessage returns the generic error reply with the request ID, which support
+// can look up in the logs.
+func (h *Handler) internalErrorMessage(ctx context.Context, chatID int64) tgbotapi.MessageConfig {
+	return newPlainMessage(chatID, withErrorCode(ctx, msgInternalError))
+}
+
+// errorMessage returns the reply to a failed handler: a timeout notice if the handler ran
+// out of time, the generic error reply otherwise. Both carry the request ID.
+func (h *Handler) errorMessage(ctx context.Context, chatID int64, err error) tgbotapi.MessageConfig {
+	if timedOut(ctx, err) {
+		return newPlainMessage(chatID, withErrorCode(ctx, msgHandlerTimeout))
+	}
+	return h.internalErrorMessage(ctx, chatID)
+}
+
+// withErrorCode appends the request ID of ctx to an error reply, if there is one.
+func withErrorCode(ctx context.Context, text string) string {
+	if id := requestid.FromContext(ctx); id != "" {
+		text += fmt.Sprintf(msgErrorCode, id)
+	}
+	return text
+}
+
+// SetHandlerTimeout bounds the handling of one update; a handler still running when it
+// expires gets a cancelled context. Zero leaves handlers unbounded. It must be called before Run.
+func (h *Handler) SetHandlerTimeout(timeout time.Duration) {
+	h.handlerTimeout = max(timeout, 0)
+}
+
+// withTimeout returns ctx bounded by the handler timeout, if one is set.
+func (h *Handler) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.handlerTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, h.handlerTimeout)
+}
+
+// logHandlerError logs a failed handler; running out of time is a warning, as the
+// handler waited on something else.
+func (h *Handler) logHandlerError(ctx context.Context, msg string, err error, fields ...zap.Field) {
+	fields = append(fields, zap.Error(err))
+	if timedOut(ctx, err) {
+		h.log(ctx).Warn(msg+": timed out", append(fields, zap.Duration("timeout", h.handlerTimeout))...)
+		return
+	}
+	h.log(ctx).Error(msg, fields...)
+}
+
+// timedOut reports whether a handler failed because it ran out of time. Errors from the
+// database do not always wrap the deadline, so the context is checked too.
+func timedOut(ctx context.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
 // HandlerFunc is a function type for message handlers.
 type HandlerFunc func(ctx context.Context, chatID int64) error
 
@@ -14,12 +78,10 @@ type HandlerFunc func(ctx context.Context, chatID int64) error
 func (h *Handler) withErrorHandling(fn HandlerFunc) HandlerFunc {
 	return func(ctx context.Context, chatID int64) error {
 		if err := fn(ctx, chatID); err != nil {
-			h.logger.Error("handle error",
+			h.logHandlerError(ctx, "handle error", err,
 				zap.Int64("chat_id", chatID),
-				zap.Error(err),
 			)
-			msg := newPlainMessage(chatID, msgInternalError)
-			return h.send(msg)
+			return h.send(h.errorMessage(ctx, chatID, err))
 		}
 		return nil
 	}
@@ -32,13 +94,12 @@ type CallbackHandlerFunc func(ctx context.Context, cb *tgbotapi.CallbackQuery) e
 func (h *Handler) withCallbackErrorHandling(fn CallbackHandlerFunc) func(ctx context.Context, cb *tgbotapi.CallbackQuery) {
 	return func(ctx context.Context, cb *tgbotapi.CallbackQuery) {
 		if err := fn(ctx, cb); err != nil {
-			h.logger.Error("callback handler error",
-				zap.Error(err),
+			h.logHandlerError(ctx, "callback handler error", err,
 				zap.String("data", cb.Data),
 				zap.Int64("user_id", cb.From.ID),
 			)
 			if cb.Message != nil {
-				_ = h.send(newPlainMessage(cb.Message.Chat.ID, msgInternalError))
+				_ = h.send(h.errorMessage(ctx, cb.Message.Chat.ID, err))
 			}
 		}
 	}
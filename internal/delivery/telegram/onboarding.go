@@ -1,9 +1,22 @@
 package telegram
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+const (
+	// onboardingFunnelDefaultDays is the period /admin_onboarding covers without an argument.
+	onboardingFunnelDefaultDays = 30
+	// onboardingFunnelMaxDays caps the period /admin_onboarding covers.
+	onboardingFunnelMaxDays = 365
 )
 
 type OnboardingState struct {
@@ -41,6 +54,85 @@ func (s OnboardingStep) Message() string {
 	return ""
 }
 
+// trackOnboarding records that an onboarding step was shown or completed; the funnel numbers steps from 1.
+// choice is the user's answer on a completed step and may be empty.
+func (h *Handler) trackOnboarding(ctx context.Context, userID int64, name entities.EventName, step OnboardingStep, choice string) {
+	properties := map[string]any{"step": int(step) + 1}
+	if choice != "" {
+		properties["choice"] = choice
+	}
+	h.analyticsService.Track(ctx, userID, name, properties)
+}
+
+// handleAdminOnboarding shows conversion through the onboarding steps: /admin_onboarding [days].
+func (h *Handler) handleAdminOnboarding(args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		days := onboardingFunnelDefaultDays
+		if n, err := strconv.Atoi(args); err == nil && n > 0 {
+			days = min(n, onboardingFunnelMaxDays)
+		}
+
+		funnel, err := h.analyticsService.OnboardingFunnel(ctx, time.Duration(days)*24*time.Hour)
+		if err != nil {
+			return err
+		}
+
+		return h.send(newPlainMessage(chatID, formatOnboardingFunnel(funnel, days)))
+	}
+}
+
+// formatOnboardingFunnel renders per-step reach, completion and drop-off for /admin_onboarding.
+func formatOnboardingFunnel(f *entities.OnboardingFunnel, days int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🧭 Онбординг за %d дн. (с %s UTC)\n", days, f.Since.Format("2006-01-02"))
+
+	started := f.Steps[0].Shown
+	if started == 0 {
+		sb.WriteString("\nЗа этот период онбординг никто не начинал.")
+		return sb.String()
+	}
+
+	for _, step := range f.Steps {
+		fmt.Fprintf(&sb, "\n%d. %s\n   показан %d (%d%% от начавших), пройден %d",
+			step.Step, onboardingStepTitle(OnboardingStep(step.Step-1)), step.Shown, percent(step.Shown, started), step.Completed)
+		if step.Shown > 0 {
+			fmt.Fprintf(&sb, " (%d%%), ушли %d", percent(step.Completed, step.Shown), max(step.Shown-step.Completed, 0))
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\n✅ Завершили: %d (%d%%)\n", f.Finished, percent(f.Finished, started))
+	sb.WriteString("Шаг 5 показывается только тем, кто включил напоминания.")
+
+	return sb.String()
+}
+
+// onboardingStepTitle returns the short name of an onboarding step.
+func onboardingStepTitle(s OnboardingStep) string {
+	switch s {
+	case StepWelcome:
+		return "Приветствие"
+	case StepNamesPerDay:
+		return "Имён в день"
+	case StepLearningMode:
+		return "Режим обучения"
+	case StepReminders:
+		return "Напоминания"
+	case StepTimezone:
+		return "Часовой пояс"
+	default:
+		return "Готово"
+	}
+}
+
+// percent returns part as a whole percentage of total, or 0 if total is 0.
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
+
 func onboardingStep1Message() string {
 	var sb strings.Builder
 
@@ -161,38 +253,17 @@ func onboardingStepTimezoneMessage() string {
 	var sb strings.Builder
 	sb.WriteString(md("Шаг 3 из 3"))
 	sb.WriteString("\n\n")
-	sb.WriteString(bold("Выберите ваш часовой пояс (UTC)"))
+	sb.WriteString(bold("Выберите ваш часовой пояс"))
 	sb.WriteString("\n\n")
 	sb.WriteString(md("Это нужно, чтобы напоминания приходили по местному времени.\n"))
-	sb.WriteString(md("Пример: Москва — UTC+3."))
+	sb.WriteString(md("Выберите регион и город или найдите город поиском — переход на летнее время учитывается автоматически."))
 	sb.WriteString("\n\n")
 	sb.WriteString(md("Можно поменять позже в /settings."))
 	return sb.String()
 }
 
 func onboardingStepTimezoneKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+0", buildOnboardingTimezoneCallback("UTC+0")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+1", buildOnboardingTimezoneCallback("UTC+1")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+2", buildOnboardingTimezoneCallback("UTC+2")),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+3", buildOnboardingTimezoneCallback("UTC+3")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+4", buildOnboardingTimezoneCallback("UTC+4")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+5", buildOnboardingTimezoneCallback("UTC+5")),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+6", buildOnboardingTimezoneCallback("UTC+6")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+7", buildOnboardingTimezoneCallback("UTC+7")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+8", buildOnboardingTimezoneCallback("UTC+8")),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+9", buildOnboardingTimezoneCallback("UTC+9")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+10", buildOnboardingTimezoneCallback("UTC+10")),
-			tgbotapi.NewInlineKeyboardButtonData("Другой", buildOnboardingTimezoneCallback("manual")),
-		),
-	)
+	return buildTimezoneRegionsKeyboard(tzFlowOnboarding)
 }
 
 func onboardingCompleteMessage() string {
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"math/rand"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// reminderTemplate is one phrasing of a reminder header (plain text, not escaped).
+type reminderTemplate struct {
+	Emoji string
+	Title string
+	Lead  string // line above the name card or question
+}
+
+// reminderTemplates holds phrasing pools per language and reminder kind.
+// A random template is picked for every reminder so the wording varies over the day.
+var reminderTemplates = map[string]map[entities.ReminderKind][]reminderTemplate{
+	langRU: {
+		entities.ReminderKindNew: {
+			{Emoji: "🌟", Title: "Время узнать новое имя Аллаха!", Lead: "📖 Имя на сегодня:"},
+			{Emoji: "✨", Title: "Новое имя ждёт вас", Lead: "📖 Познакомьтесь:"},
+			{Emoji: "🌱", Title: "Пара минут для нового имени", Lead: "📖 Сегодня изучаем:"},
+			{Emoji: "📿", Title: "Продолжим путь по 99 именам", Lead: "📖 Следующее имя:"},
+		},
+		entities.ReminderKindReview: {
+			{Emoji: "🔔", Title: "Время повторить имена Аллаха!", Lead: "📖 Имя для повторения:"},
+			{Emoji: "🔄", Title: "Небольшое повторение", Lead: "📖 Помните это имя?"},
+			{Emoji: "🧠", Title: "Освежим память", Lead: "📖 Пора повторить:"},
+			{Emoji: "⏳", Title: "Подошёл срок повторения", Lead: "📖 Вернёмся к имени:"},
+		},
+		entities.ReminderKindStudy: {
+			{Emoji: "📚", Title: "Время продолжить изучение сегодняшних имён!", Lead: "📖 Имя на сегодня:"},
+			{Emoji: "🎯", Title: "Закрепим сегодняшние имена", Lead: "📖 Из сегодняшнего плана:"},
+			{Emoji: "💪", Title: "Ещё немного практики", Lead: "📖 Продолжаем с именем:"},
+			{Emoji: "🌿", Title: "Минутка для сегодняшних имён", Lead: "📖 Повторим:"},
+		},
+	},
+	langEN: {
+		entities.ReminderKindNew: {
+			{Emoji: "🌟", Title: "Time to learn a new name of Allah!", Lead: "📖 Today's name:"},
+			{Emoji: "✨", Title: "A new name is waiting for you", Lead: "📖 Meet:"},
+			{Emoji: "🌱", Title: "A couple of minutes for a new name", Lead: "📖 Today we learn:"},
+			{Emoji: "📿", Title: "Let's continue through the 99 names", Lead: "📖 Next name:"},
+		},
+		entities.ReminderKindReview: {
+			{Emoji: "🔔", Title: "Time to review the names of Allah!", Lead: "📖 Name to review:"},
+			{Emoji: "🔄", Title: "A quick review", Lead: "📖 Do you remember this name?"},
+			{Emoji: "🧠", Title: "Let's refresh your memory", Lead: "📖 Time to review:"},
+			{Emoji: "⏳", Title: "A review is due", Lead: "📖 Back to this name:"},
+		},
+		entities.ReminderKindStudy: {
+			{Emoji: "📚", Title: "Time to keep studying today's names!", Lead: "📖 Today's name:"},
+			{Emoji: "🎯", Title: "Let's reinforce today's names", Lead: "📖 From today's plan:"},
+			{Emoji: "💪", Title: "A little more practice", Lead: "📖 Continuing with:"},
+			{Emoji: "🌿", Title: "A minute for today's names", Lead: "📖 Let's go over:"},
+		},
+	},
+}
+
+// pickReminderTemplate returns a random template for the kind in the given language,
+// falling back to Russian and to the "new" kind.
+func pickReminderTemplate(kind entities.ReminderKind, lang string) reminderTemplate {
+	byKind, ok := reminderTemplates[normalizeLang(lang)]
+	if !ok {
+		byKind = reminderTemplates[langRU]
+	}
+
+	pool := byKind[kind]
+	if len(pool) == 0 {
+		pool = byKind[entities.ReminderKindNew]
+	}
+
+	return pool[rand.Intn(len(pool))]
+}
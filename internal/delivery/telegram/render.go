@@ -14,7 +14,7 @@ import (
 func (h *Handler) RenderProgress(ctx context.Context, userID int64, withKeyboard bool) (string, *tgbotapi.InlineKeyboardMarkup, error) {
 	summary, err := h.progressService.GetProgressSummary(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get progress summary",
+		h.log(ctx).Error("failed to get progress summary",
 			zap.Int64("user_id", userID),
 			zap.Error(err),
 		)
@@ -24,9 +24,21 @@ func (h *Handler) RenderProgress(ctx context.Context, userID int64, withKeyboard
 	progressBar := buildProgressBar(summary.Learned, 99, 20)
 	text := formatProgressMessage(summary, progressBar)
 
+	referralLink := buildReferralLink(h.bot.Self.UserName, userID)
+	referrals, err := h.userService.GetReferralStats(ctx, userID)
+	if err != nil {
+		// Referrals are secondary to the progress itself, so the screen is shown without them.
+		h.log(ctx).Warn("failed to get referral stats",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+	} else {
+		text += "\n\n" + formatReferralSection(referrals, referralLink)
+	}
+
 	var keyboard *tgbotapi.InlineKeyboardMarkup
 	if withKeyboard {
-		kb := buildProgressKeyboard()
+		kb := buildProgressKeyboard(referralLink)
 		keyboard = &kb
 	}
 
@@ -37,7 +49,7 @@ func (h *Handler) RenderProgress(ctx context.Context, userID int64, withKeyboard
 func (h *Handler) RenderSettings(ctx context.Context, userID int64) (string, tgbotapi.InlineKeyboardMarkup, error) {
 	settings, err := h.settingsService.GetOrCreate(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get settings",
+		h.log(ctx).Error("failed to get settings",
 			zap.Int64("user_id", userID),
 			zap.Error(err),
 		)
@@ -46,7 +58,7 @@ func (h *Handler) RenderSettings(ctx context.Context, userID int64) (string, tgb
 
 	reminders, err := h.reminderService.GetOrCreate(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get or create reminders",
+		h.log(ctx).Error("failed to get or create reminders",
 			zap.Int64("user_id", userID),
 			zap.Error(err),
 		)
@@ -58,12 +70,13 @@ func (h *Handler) RenderSettings(ctx context.Context, userID int64) (string, tgb
 	quizMode := formatQuizMode(settings.QuizMode)
 
 	text := fmt.Sprintf(
-		"%s\n\n%s\n%s\n%s\n%s",
+		"%s\n\n%s\n%s\n%s\n%s\n%s",
 		md("⚙️ Настройки"),
-		md(fmt.Sprintf("📚 Имён в день: %d", settings.NamesPerDay)),
+		md(formatNamesPerDaySetting(settings)),
 		md(fmt.Sprintf("🎯 Режим обучения: %s", learningModeText)),
 		md(fmt.Sprintf("🎲 Режим квиза: %s", quizMode)),
 		md(fmt.Sprintf("⏰ Напоминания: %s", reminderStatus)),
+		md(fmt.Sprintf("🗓 Календарь: %s", formatCalendar(settings.Calendar))),
 	)
 
 	kb := buildSettingsKeyboard()
@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// SendRetentionReport posts the monthly retention cohort summary to an admin chat.
+func (h *Handler) SendRetentionReport(chatID int64, cohorts []entities.RetentionCohort) error {
+	return h.send(newPlainMessage(chatID, formatRetentionReport(cohorts)))
+}
+
+// formatRetentionReport renders retention per signup week, newest first.
+func formatRetentionReport(cohorts []entities.RetentionCohort) string {
+	var sb strings.Builder
+	sb.WriteString("📈 Удержание по неделям регистрации (UTC)\n")
+
+	if len(cohorts) == 0 {
+		sb.WriteString("\nДанных пока нет.")
+		return sb.String()
+	}
+
+	sb.WriteString("\nНеделя · польз. · D1 · D7 · D30\n")
+	for _, c := range cohorts {
+		fmt.Fprintf(&sb, "%s · %d · %s · %s · %s\n",
+			c.Week.Format("2006-01-02"), c.Users,
+			formatRetentionRate(c.D1, c.Users), formatRetentionRate(c.D7, c.Users), formatRetentionRate(c.D30, c.Users))
+	}
+	sb.WriteString("\nDN — доля вернувшихся ровно через N дней после регистрации; «—» — день ещё не наступил.")
+
+	return sb.String()
+}
+
+// formatRetentionRate renders a retained count as a share of the cohort.
+func formatRetentionRate(retained *int, users int) string {
+	if retained == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%d%%", percent(*retained, users))
+}
@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// Retry policy for notification sends. Waits between attempts add up to at most a few
+// seconds, well within the notification worker's per-job timeout.
+const (
+	sendMaxAttempts   = 3
+	sendRetryBase     = time.Second     // first backoff for 5xx errors, doubled on every retry
+	sendMaxRetryAfter = 5 * time.Second // longer flood waits are handed back to the notification worker
+)
+
+// sendNotification sends a background notification, retrying on flood control (429,
+// waiting retry_after) and transient 5xx errors. Waiting stops when ctx is done. A flood
+// wait that is too long to sit out is returned as service.RetryAfterError, so the job is
+// rescheduled instead of holding its lock. Errors that cannot be fixed by a retry, e.g.
+// the user blocked the bot, are marked with service.ErrNotificationUndeliverable.
+func (h *Handler) sendNotification(ctx context.Context, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	backoff := sendRetryBase
+
+	for attempt := 1; ; attempt++ {
+		sent, err := h.bot.Send(c)
+		if err == nil {
+			return sent, nil
+		}
+
+		var apiErr *tgbotapi.Error
+		if !errors.As(err, &apiErr) {
+			return sent, err
+		}
+
+		wait, retry := sendRetryDelay(apiErr, backoff)
+		if !retry || attempt == sendMaxAttempts {
+			if apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusForbidden {
+				return sent, fmt.Errorf("%w: %w", service.ErrNotificationUndeliverable, err)
+			}
+			if apiErr.Code == http.StatusTooManyRequests {
+				return sent, &service.RetryAfterError{After: time.Duration(apiErr.RetryAfter) * time.Second, Err: err}
+			}
+			return sent, err
+		}
+
+		h.logger.Warn("telegram send failed, retrying",
+			zap.Int("code", apiErr.Code),
+			zap.Int("attempt", attempt),
+			zap.Duration("wait", wait),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return sent, fmt.Errorf("wait to retry send: %w: %w", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// sendRetryDelay reports whether the API error is worth retrying and how long to wait first.
+func sendRetryDelay(apiErr *tgbotapi.Error, backoff time.Duration) (time.Duration, bool) {
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests:
+		wait := time.Duration(apiErr.RetryAfter) * time.Second
+		if wait > sendMaxRetryAfter {
+			return 0, false
+		}
+		return max(wait, sendRetryBase), true
+	case apiErr.Code >= http.StatusInternalServerError:
+		return backoff, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,61 @@
+package telegram
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// Feature flags that can be turned off in the configuration; a flag missing there is on.
+const (
+	FeatureInlineQueries = "inline_queries" // name search in inline mode
+	FeatureGroupChats    = "group_chats"    // commands and settings in group chats
+)
+
+// rateLimitSweepSize is the number of tracked users after which idle limiters are dropped.
+const rateLimitSweepSize = 10000
+
+// RuntimeSettings are handler settings that can change while the bot is running.
+type RuntimeSettings struct {
+	UserUpdatesPerMinute int             // 0 for no limit
+	UserBurst            int             // updates in a quick row, 0 for no limit
+	UserRefill           time.Duration   // time after which one more quick update is allowed
+	Features             map[string]bool // flags by name; a missing flag is on
+}
+
+// runtimeState holds the current RuntimeSettings and the per-user update limiters.
+type runtimeState struct {
+	mu       sync.Mutex
+	settings RuntimeSettings
+	limiters map[int64]*userLimiter
+}
+
+// ApplyRuntime replaces the runtime settings. It is safe to call while updates are handled.
+func (h *Handler) ApplyRuntime(settings RuntimeSettings) {
+	settings.Features = maps.Clone(settings.Features)
+
+	h.runtime.mu.Lock()
+	defer h.runtime.mu.Unlock()
+	h.runtime.settings = settings
+	// Limiters are built from the settings, so new limits start from full ones.
+	h.runtime.limiters = nil
+}
+
+// SetReloader sets the configuration reloader used by /admin_reload.
+func (h *Handler) SetReloader(reloader ConfigReloader) {
+	h.reloader = reloader
+}
+
+// SetLogControls sets the log controls used by /admin_loglevel.
+func (h *Handler) SetLogControls(logs LogControls) {
+	h.logControls = logs
+}
+
+// featureEnabled reports whether a feature flag is on.
+func (h *Handler) featureEnabled(name string) bool {
+	h.runtime.mu.Lock()
+	defer h.runtime.mu.Unlock()
+
+	on, ok := h.runtime.settings.Features[name]
+	return !ok || on
+}
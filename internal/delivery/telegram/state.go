@@ -0,0 +1,110 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// stateTTL is how long a pending prompt or a search waits for the user.
+	stateTTL = 24 * time.Hour
+	// stateTimeout bounds one state store call.
+	stateTimeout = 2 * time.Second
+)
+
+// userStates is one kind of conversation state, keyed by user ID.
+// Store errors are logged and read as missing state, like an expired prompt.
+type userStates[T any] struct {
+	store  StateStore
+	prefix string
+	logger *zap.Logger
+}
+
+func newUserStates[T any](store StateStore, prefix string, logger *zap.Logger) userStates[T] {
+	return userStates[T]{store: store, prefix: prefix + ":", logger: logger}
+}
+
+func (s userStates[T]) get(ctx context.Context, userID int64) (T, bool) {
+	ctx, cancel := context.WithTimeout(ctx, stateTimeout)
+	defer cancel()
+
+	var st T
+	ok, err := s.store.Get(ctx, s.key(userID), &st)
+	if err != nil {
+		s.logger.Warn("failed to read conversation state", zap.String("key", s.key(userID)), zap.Error(err))
+		return st, false
+	}
+	return st, ok
+}
+
+func (s userStates[T]) set(ctx context.Context, userID int64, st T) {
+	ctx, cancel := context.WithTimeout(ctx, stateTimeout)
+	defer cancel()
+
+	if err := s.store.Set(ctx, s.key(userID), st, stateTTL); err != nil {
+		s.logger.Warn("failed to store conversation state", zap.String("key", s.key(userID)), zap.Error(err))
+	}
+}
+
+func (s userStates[T]) delete(ctx context.Context, userID int64) {
+	ctx, cancel := context.WithTimeout(ctx, stateTimeout)
+	defer cancel()
+
+	if err := s.store.Delete(ctx, s.key(userID)); err != nil {
+		s.logger.Warn("failed to delete conversation state", zap.String("key", s.key(userID)), zap.Error(err))
+	}
+}
+
+func (s userStates[T]) key(userID int64) string {
+	return s.prefix + strconv.FormatInt(userID, 10)
+}
+
+// waitKind is the prompt a user's next text message answers.
+type waitKind int
+
+const (
+	waitNone waitKind = iota
+	waitImport
+	waitFeedback
+	waitReport
+	waitNote
+	waitTimezone
+	waitLocation
+)
+
+// pendingWait returns the prompt the user's next text message answers, reading all wait
+// states in one store call. When several are set, the earlier kind wins.
+func (h *Handler) pendingWait(ctx context.Context, userID int64) waitKind {
+	ctx, cancel := context.WithTimeout(ctx, stateTimeout)
+	defer cancel()
+
+	kinds := []waitKind{waitImport, waitFeedback, waitReport, waitNote, waitTimezone, waitLocation}
+	keys := []string{
+		h.importWait.key(userID),
+		h.feedbackWait.key(userID),
+		h.reportWait.key(userID),
+		h.noteInputWait.key(userID),
+		h.tzInputWait.key(userID),
+		h.locationWait.key(userID),
+	}
+	vs := make([]any, len(keys))
+	for i := range vs {
+		vs[i] = new(json.RawMessage)
+	}
+
+	found, err := h.importWait.store.GetMany(ctx, keys, vs)
+	if err != nil {
+		h.logger.Warn("failed to read conversation state", zap.Int64("user_id", userID), zap.Error(err))
+		return waitNone
+	}
+	for i, ok := range found {
+		if ok {
+			return kinds[i]
+		}
+	}
+	return waitNone
+}
@@ -0,0 +1,211 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/service"
+)
+
+// surveyListLimit is the number of surveys /admin_survey lists.
+const surveyListLimit = 10
+
+// SendSurvey sends the first question of a survey to a user.
+func (h *Handler) SendSurvey(ctx context.Context, userID, chatID int64, invite entities.SurveyInvite) error {
+	msg := newPlainMessage(chatID, formatSurveyQuestion(invite.Title, invite.Question, 0, invite.Questions))
+	msg.ReplyMarkup = surveyQuestionKeyboard(invite.SurveyID, 0, invite.Question.Options)
+	_, err := h.sendNotification(ctx, msg)
+	return err
+}
+
+// handleSurveyCallback stores a survey answer and shows the next question in the same message.
+func (h *Handler) handleSurveyCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) < 2 {
+		h.log(ctx).Warn("invalid survey callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	surveyID, err := strconv.ParseInt(data.Params[1], 10, 64)
+	if err != nil {
+		h.log(ctx).Warn("invalid survey id in callback", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	chatID := cb.Message.Chat.ID
+	messageID := cb.Message.MessageID
+
+	switch data.Params[0] {
+	case surveySkip:
+		return h.send(tgbotapi.NewEditMessageText(chatID, messageID, msgSurveySkipped))
+
+	case surveyAnswer:
+		if len(data.Params) != 4 {
+			return nil
+		}
+		question, err1 := strconv.Atoi(data.Params[2])
+		option, err2 := strconv.Atoi(data.Params[3])
+		if err1 != nil || err2 != nil {
+			h.log(ctx).Warn("invalid survey answer in callback", zap.String("raw", data.Raw))
+			return nil
+		}
+
+		survey, done, err := h.surveyService.Answer(ctx, cb.From.ID, surveyID, question, option)
+		switch {
+		case errors.Is(err, repository.ErrSurveyNotFound), errors.Is(err, service.ErrSurveyAnswerNotFound):
+			return h.send(tgbotapi.NewEditMessageText(chatID, messageID, msgSurveyGone))
+		case err != nil:
+			return err
+		}
+
+		if done {
+			return h.send(tgbotapi.NewEditMessageText(chatID, messageID,
+				fmt.Sprintf("📋 %s\n\n%s", survey.Title, msgSurveyFinished)))
+		}
+
+		next := question + 1
+		edit := tgbotapi.NewEditMessageText(chatID, messageID,
+			formatSurveyQuestion(survey.Title, survey.Questions[next], next, len(survey.Questions)))
+		kb := surveyQuestionKeyboard(survey.ID, next, survey.Questions[next].Options)
+		edit.ReplyMarkup = &kb
+		return h.send(edit)
+
+	default:
+		h.log(ctx).Warn("unknown survey sub-action", zap.String("raw", data.Raw))
+		return nil
+	}
+}
+
+// handleAdminSurvey creates, sends and summarizes surveys: /admin_survey [new|send|results].
+func (h *Handler) handleAdminSurvey(adminID int64, args string) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		firstLine, definition, _ := strings.Cut(args, "\n")
+		fields := strings.Fields(firstLine)
+		if len(fields) == 0 {
+			return h.sendSurveyList(ctx, chatID)
+		}
+
+		switch {
+		case fields[0] == "new" && len(fields) == 2:
+			survey, err := h.surveyService.Create(ctx, adminID, fields[1], definition)
+			switch {
+			case errors.Is(err, service.ErrSurveySegment), errors.Is(err, service.ErrSurveyFormat):
+				return h.send(newPlainMessage(chatID, err.Error()+"\n\n"+msgSurveyUsage))
+			case err != nil:
+				return err
+			}
+			return h.send(newPlainMessage(chatID, formatSurveyCreated(survey)))
+
+		case fields[0] == "send" && len(fields) == 2:
+			surveyID, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return h.send(newPlainMessage(chatID, msgSurveyUsage))
+			}
+
+			queued, err := h.surveyService.Send(ctx, surveyID)
+			switch {
+			case errors.Is(err, repository.ErrSurveyNotFound):
+				return h.send(newPlainMessage(chatID, fmt.Sprintf(msgSurveyNotFound, surveyID)))
+			case err != nil:
+				return err
+			}
+			return h.send(newPlainMessage(chatID, fmt.Sprintf(msgSurveyQueued, surveyID, queued)))
+
+		case fields[0] == "results" && len(fields) == 2:
+			surveyID, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return h.send(newPlainMessage(chatID, msgSurveyUsage))
+			}
+
+			results, err := h.surveyService.Results(ctx, surveyID)
+			switch {
+			case errors.Is(err, repository.ErrSurveyNotFound):
+				return h.send(newPlainMessage(chatID, fmt.Sprintf(msgSurveyNotFound, surveyID)))
+			case err != nil:
+				return err
+			}
+			return h.send(newPlainMessage(chatID, formatSurveyResults(results)))
+
+		default:
+			return h.send(newPlainMessage(chatID, msgSurveyUsage))
+		}
+	}
+}
+
+// sendSurveyList sends the latest surveys.
+func (h *Handler) sendSurveyList(ctx context.Context, chatID int64) error {
+	surveys, err := h.surveyService.List(ctx, surveyListLimit)
+	if err != nil {
+		return err
+	}
+	if len(surveys) == 0 {
+		return h.send(newPlainMessage(chatID, msgSurveyNone))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📋 Опросы\n")
+	for _, s := range surveys {
+		sent := "не отправлен"
+		if s.SentAt != nil {
+			sent = "отправлен " + s.SentAt.UTC().Format(time.DateOnly)
+		}
+		fmt.Fprintf(&sb, "\n#%d %s · %s · %d вопр. · %s", s.ID, s.Title, s.Segment, len(s.Questions), sent)
+	}
+	sb.WriteString("\n\n" + msgSurveyUsage)
+
+	return h.send(newPlainMessage(chatID, sb.String()))
+}
+
+// formatSurveyQuestion renders a survey question for the user.
+func formatSurveyQuestion(title string, q entities.SurveyQuestion, index, total int) string {
+	return fmt.Sprintf("📋 %s\n\nВопрос %d из %d\n%s", title, index+1, total, q.Text)
+}
+
+// formatSurveyCreated renders a preview of a new survey for the admin.
+func formatSurveyCreated(s *entities.Survey) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "✅ Опрос #%d «%s» создан, сегмент %s.\n", s.ID, s.Title, s.Segment)
+	for i, q := range s.Questions {
+		fmt.Fprintf(&sb, "\n%d. %s\n   %s", i+1, q.Text, strings.Join(q.Options, " | "))
+	}
+	fmt.Fprintf(&sb, "\n\nРазослать: /admin_survey send %d", s.ID)
+	return sb.String()
+}
+
+// formatSurveyResults renders answer counts and shares per option.
+func formatSurveyResults(r *entities.SurveyResults) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 Опрос #%d «%s» (%s)\n", r.Survey.ID, r.Survey.Title, r.Survey.Segment)
+	fmt.Fprintf(&sb, "Отправлено: %d · ответили: %d · до конца: %d\n", r.Sent, r.Started, r.Completed)
+
+	for i, q := range r.Survey.Questions {
+		total := 0
+		for _, n := range r.Counts[i] {
+			total += n
+		}
+
+		fmt.Fprintf(&sb, "\n%d. %s (%d)\n", i+1, q.Text, total)
+		for j, opt := range q.Options {
+			percent := 0
+			if total > 0 {
+				percent = r.Counts[i][j] * 100 / total
+			}
+			fmt.Fprintf(&sb, "   %s — %d (%d%%)\n", opt, r.Counts[i][j], percent)
+		}
+	}
+
+	return sb.String()
+}
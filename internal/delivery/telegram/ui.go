@@ -1,6 +1,11 @@
 package telegram
 
 import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
@@ -28,21 +33,163 @@ func buildNameKeyboard(page, totalPages int, prevData, nextData string) *tgbotap
 	return &kb
 }
 
+// buildAllNamesKeyboard builds pagination keyboard for /all with first/last buttons
+// and a page picker button showing the current page.
+func buildAllNamesKeyboard(page, totalPages int) *tgbotapi.InlineKeyboardMarkup {
+	if totalPages <= 1 {
+		return nil
+	}
+
+	var row []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		row = append(row,
+			tgbotapi.NewInlineKeyboardButtonData("⏮", buildNameCallback(0)),
+			tgbotapi.NewInlineKeyboardButtonData("◀️", buildNameCallback(page-1)),
+		)
+	}
+
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+		fmt.Sprintf("📄 %d/%d", page+1, totalPages),
+		buildNamePagesCallback(page),
+	))
+
+	if page < totalPages-1 {
+		row = append(row,
+			tgbotapi.NewInlineKeyboardButtonData("▶️", buildNameCallback(page+1)),
+			tgbotapi.NewInlineKeyboardButtonData("⏭", buildNameCallback(totalPages-1)),
+		)
+	}
+
+	kb := tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{
+			row,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🗂 Компактный список", buildNameIndexCallback()),
+			),
+		},
+	}
+
+	return &kb
+}
+
+// buildNameIndexKeyboard builds a compact 3-column index of names with tap-to-open buttons
+// and a button to switch back to the cards view.
+// Telegram allows up to 100 buttons per keyboard, which fits 99 names plus the switch button.
+func buildNameIndexKeyboard(names []*entities.Name) tgbotapi.InlineKeyboardMarkup {
+	const perRow = 3
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(names)/perRow+2)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, name := range names {
+		label := fmt.Sprintf("%d. %s", name.Number, name.Transliteration)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, buildOpenNameCallback(name.Number)))
+		if len(row) == perRow {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("📖 Карточки", buildNameCallback(0)),
+	))
+
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// buildNamePagePickerKeyboard builds a grid of /all pages labeled with the names range they contain.
+// currentPage is used for the "back" button.
+func buildNamePagePickerKeyboard(totalNames, currentPage int) tgbotapi.InlineKeyboardMarkup {
+	const perRow = 4
+
+	totalPages := (totalNames + namesPerPage - 1) / namesPerPage
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for p := 0; p < totalPages; p++ {
+		from := p*namesPerPage + 1
+		to := min(from+namesPerPage-1, totalNames)
+
+		label := fmt.Sprintf("%d–%d", from, to)
+		if p == currentPage {
+			label = "• " + label
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, buildNameCallback(p)))
+		if len(row) == perRow {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", buildNameCallback(currentPage)),
+	))
+
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// nameListKeyboard builds keyboard with open buttons for a page of names and pagination.
+// pageData builds callback data for the given page.
+func nameListKeyboard(names []*entities.Name, page, totalPages int, pageData func(page int) string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, name := range paginateNames(names, page, findPerPage) {
+		label := fmt.Sprintf("%d. %s", name.Number, name.Transliteration)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, buildOpenNameCallback(name.Number)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	if nav := buildNameKeyboard(page, totalPages, pageData(page-1), pageData(page+1)); nav != nil {
+		rows = append(rows, nav.InlineKeyboard...)
+	}
+
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
 // buildProgressKeyboard builds keyboard for progress screen.
-func buildProgressKeyboard() tgbotapi.InlineKeyboardMarkup {
+func buildProgressKeyboard(referralLink string) tgbotapi.InlineKeyboardMarkup {
+	shareURL := "https://t.me/share/url?url=" + url.QueryEscape(referralLink) +
+		"&text=" + url.QueryEscape(msgReferralShareText)
+
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить", buildProgressCallback()),
+			tgbotapi.NewInlineKeyboardButtonData("📅 Календарь", buildProgressCalendarCallback()),
 		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🎯 Начать квиз", buildQuizStartCallback()),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL("🤝 Пригласить друга", shareURL),
+		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("⚙️ Настройки", buildSettingsCallback(settingsMenu)),
 		),
 	)
 }
 
+// buildActivityCalendarKeyboard builds keyboard for the activity calendar screen.
+func buildActivityCalendarKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("« Назад к прогрессу", buildProgressCallback()),
+		),
+	)
+}
+
 // buildSettingsKeyboard builds main settings keyboard.
 func buildSettingsKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
@@ -58,9 +205,62 @@ func buildSettingsKeyboard() tgbotapi.InlineKeyboardMarkup {
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("⏰ Напоминания", buildSettingsCallback(settingsReminders)),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🌙 Календарь", buildSettingsCallback(settingsCalendar)),
+		),
+	)
+}
+
+// buildCalendarKeyboard builds the calendar selection keyboard.
+func buildCalendarKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				formatCalendar(entities.CalendarGregorian),
+				buildSettingsCallback(settingsCalendar, string(entities.CalendarGregorian)),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				formatCalendar(entities.CalendarHijri),
+				buildSettingsCallback(settingsCalendar, string(entities.CalendarHijri)),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("« Назад к настройкам", buildSettingsCallback(settingsMenu)),
+		),
 	)
 }
 
+// buildTargetDateKeyboard builds keyboard for choosing the date by which all names should be started.
+func buildTargetDateKeyboard(ramadanEnd time.Time, hasTarget bool) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"🌙 К концу Рамадана ("+ramadanEnd.Format("02.01.2006")+")",
+				buildSettingsCallback(settingsTargetDate, targetDateRamadan),
+			),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("30 дней", buildSettingsCallback(settingsTargetDate, "30")),
+			tgbotapi.NewInlineKeyboardButtonData("60 дней", buildSettingsCallback(settingsTargetDate, "60")),
+			tgbotapi.NewInlineKeyboardButtonData("90 дней", buildSettingsCallback(settingsTargetDate, "90")),
+		),
+	}
+
+	if hasTarget {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✖️ Убрать дату", buildSettingsCallback(settingsTargetDate, targetDateOff)),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("« Назад", buildSettingsCallback(settingsNamesPerDay)),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
 func buildLearningModeKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
@@ -115,6 +315,9 @@ func buildNamesPerDayKeyboard() tgbotapi.InlineKeyboardMarkup {
 			tgbotapi.NewInlineKeyboardButtonData("3️⃣ (33 дня)", buildSettingsCallback(settingsNamesPerDay, "3")),
 			tgbotapi.NewInlineKeyboardButtonData("5️⃣ (20 дней)", buildSettingsCallback(settingsNamesPerDay, "5")),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🎯 Закончить к дате", buildSettingsCallback(settingsTargetDate)),
+		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("« Назад к настройкам", buildSettingsCallback(settingsMenu)),
 		),
@@ -133,6 +336,9 @@ func buildQuizModeKeyboard() tgbotapi.InlineKeyboardMarkup {
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🎲 Смешанный", buildSettingsCallback(settingsQuizMode, "mixed")),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⭐ Только избранное", buildSettingsCallback(settingsQuizMode, "favorites")),
+		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("« Назад к настройкам", buildSettingsCallback(settingsMenu)),
 		),
@@ -148,6 +354,16 @@ func buildRemindersKeyboard(reminder *entities.UserReminders) tgbotapi.InlineKey
 		toggleText = "🔔 Включить"
 	}
 
+	digestText := "📬 Недельная сводка: выкл"
+	if reminder != nil && reminder.WeeklyDigest {
+		digestText = "📬 Недельная сводка: вкл"
+	}
+
+	styleText, nextStyle := "📨 Формат: по одному имени", entities.ReminderStyleDigest
+	if reminder != nil && reminder.Style == entities.ReminderStyleDigest {
+		styleText, nextStyle = "📨 Формат: сводка на день", entities.ReminderStyleName
+	}
+
 	rows := [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(toggleText, buildReminderToggleCallback()),
@@ -155,15 +371,51 @@ func buildRemindersKeyboard(reminder *entities.UserReminders) tgbotapi.InlineKey
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🌍 Часовой пояс", buildSettingsCallback(settingsReminders, "timezone")),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(digestText, buildSettingsCallback(settingsReminders, reminderDigest)),
+		),
 	}
 
 	if enabled {
 		rows = append(rows,
 			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(styleText, buildSettingsCallback(settingsReminders, reminderStyle, string(nextStyle))),
+			),
+		)
+		// The digest lists the whole day, so the content choice applies only to single names.
+		if nextStyle == entities.ReminderStyleDigest {
+			content := reminder.Content
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					"🧩 Содержание: "+formatReminderContent(content),
+					buildSettingsCallback(settingsReminders, reminderContent, string(nextReminderContent(content))),
+				),
+			))
+		}
+		// Prayer times replace the fixed window, and the digest is sent once a day,
+		// so the frequency and the window do not apply.
+		prayerMode := reminder.Prayer.Enabled()
+		if nextStyle == entities.ReminderStyleDigest && !prayerMode {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData("📅 Частота", buildSettingsCallback(settingsReminders, "frequency")),
+			))
+		}
+		if !prayerMode {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("⏰ Время", buildSettingsCallback(settingsReminders, "time")),
+			))
+		}
+
+		prayerText := "🕌 По времени намаза: выкл"
+		if prayerMode {
+			prayerText = "🕌 По времени намаза: вкл"
+		}
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(prayerText, buildSettingsCallback(settingsReminders, reminderPrayer)),
 			),
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("⏰ Время", buildSettingsCallback(settingsReminders, "time")),
+				tgbotapi.NewInlineKeyboardButtonData("🌙 Тихие дни", buildSettingsCallback(settingsReminders, reminderQuiet)),
 			),
 		)
 	}
@@ -177,43 +429,308 @@ func buildRemindersKeyboard(reminder *entities.UserReminders) tgbotapi.InlineKey
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
-// buildTimezoneKeyboard builds a simple UTC offset picker for MVP.
-func buildTimezoneKeyboard() tgbotapi.InlineKeyboardMarkup {
+// prayerOffsetOptions are the offsets (in minutes after the prayer) offered in settings.
+var prayerOffsetOptions = []int{0, 15, 30, 60}
+
+// buildPrayerRemindersKeyboard builds prayer toggles, offset choices and the location button.
+func buildPrayerRemindersKeyboard(anchors entities.PrayerAnchors) tgbotapi.InlineKeyboardMarkup {
+	prayerButton := func(p entities.Prayer) tgbotapi.InlineKeyboardButton {
+		label := formatPrayerName(p)
+		if anchors.Has(p) {
+			label = "✅ " + label
+		}
+		return tgbotapi.NewInlineKeyboardButtonData(label,
+			buildSettingsCallback(settingsReminders, reminderPrayerToggle, strconv.Itoa(int(p))),
+		)
+	}
+
+	var offsetRow []tgbotapi.InlineKeyboardButton
+	for _, m := range prayerOffsetOptions {
+		label := fmt.Sprintf("+%d мин", m)
+		if m == anchors.OffsetMinutes {
+			label = "• " + label + " •"
+		}
+		offsetRow = append(offsetRow, tgbotapi.NewInlineKeyboardButtonData(label,
+			buildSettingsCallback(settingsReminders, reminderPrayerOffset, strconv.Itoa(m)),
+		))
+	}
+
+	locationText := "📍 Указать местоположение"
+	if anchors.HasLocation() {
+		locationText = "📍 Изменить местоположение"
+	}
+
 	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(prayerButton(entities.Fajr), prayerButton(entities.Dhuhr), prayerButton(entities.Asr)),
+		tgbotapi.NewInlineKeyboardRow(prayerButton(entities.Maghrib), prayerButton(entities.Isha)),
+		offsetRow,
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+0", buildSettingsCallback(settingsReminders, "tz", "UTC+0")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+1", buildSettingsCallback(settingsReminders, "tz", "UTC+1")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+2", buildSettingsCallback(settingsReminders, "tz", "UTC+2")),
+			tgbotapi.NewInlineKeyboardButtonData(locationText, buildSettingsCallback(settingsReminders, reminderPrayerLocation)),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+3", buildSettingsCallback(settingsReminders, "tz", "UTC+3")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+4", buildSettingsCallback(settingsReminders, "tz", "UTC+4")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+5", buildSettingsCallback(settingsReminders, "tz", "UTC+5")),
+			tgbotapi.NewInlineKeyboardButtonData("« Назад", buildSettingsCallback(settingsReminders)),
 		),
+	)
+}
+
+// buildLocationRequestKeyboard builds a one-time reply keyboard that shares the user's location.
+func buildLocationRequestKeyboard() tgbotapi.ReplyKeyboardMarkup {
+	kb := tgbotapi.NewOneTimeReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonLocation("📍 Отправить местоположение")),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton("Отмена")),
+	)
+	kb.ResizeKeyboard = true
+	return kb
+}
+
+// quietDatePickerDays is how many upcoming days one page of the quiet date picker shows.
+const quietDatePickerDays = 14
+
+// buildQuietDaysKeyboard builds weekday toggles and removable quiet dates.
+func buildQuietDaysKeyboard(quiet entities.QuietDays) tgbotapi.InlineKeyboardMarkup {
+	weekdays := make([]tgbotapi.InlineKeyboardButton, 0, 7)
+	for i := 1; i <= 7; i++ {
+		d := time.Weekday(i % 7) // Monday first
+		label := formatWeekdayShort(d)
+		if quiet.HasWeekday(d) {
+			label = "✅ " + label
+		}
+		weekdays = append(weekdays, tgbotapi.NewInlineKeyboardButtonData(
+			label,
+			buildSettingsCallback(settingsReminders, reminderQuietWeekday, strconv.Itoa(int(d))),
+		))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{weekdays[:4], weekdays[4:]}
+
+	for _, date := range quiet.Dates {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				"❌ "+formatWeekdayShort(date.Weekday())+" "+date.Format("02.01.2006"),
+				buildSettingsCallback(settingsReminders, reminderQuietDate, date.Format(quietDateLayout)),
+			),
+		))
+	}
+
+	if len(quiet.Dates) < entities.MaxQuietDates {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("➕ Добавить дату", buildSettingsCallback(settingsReminders, reminderQuietAdd, "0")),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("« Назад", buildSettingsCallback(settingsReminders)),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// buildQuietDatePickerKeyboard builds a page of upcoming dates starting from today.
+func buildQuietDatePickerKeyboard(quiet entities.QuietDays, today time.Time, page int) tgbotapi.InlineKeyboardMarkup {
+	start := today.AddDate(0, 0, page*quietDatePickerDays)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for i := 0; i < quietDatePickerDays; i++ {
+		day := start.AddDate(0, 0, i)
+		label := formatWeekdayShort(day.Weekday()) + " " + day.Format("02.01")
+		if quiet.HasDate(day) {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			label,
+			buildSettingsCallback(settingsReminders, reminderQuietDate, day.Format(quietDateLayout), strconv.Itoa(page)),
+		))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("◀️", buildSettingsCallback(settingsReminders, reminderQuietAdd, strconv.Itoa(page-1))))
+	}
+	if page < maxQuietDatePickerPage {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("▶️", buildSettingsCallback(settingsReminders, reminderQuietAdd, strconv.Itoa(page+1))))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("« Назад", buildSettingsCallback(settingsReminders, reminderQuiet)),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// maxQuietDatePickerPage limits the date picker to roughly a year ahead.
+const maxQuietDatePickerPage = 365 / quietDatePickerDays
+
+// Timezone picker flows, matching tzWaitState.Flow.
+const (
+	tzFlowOnboarding = "onboarding"
+	tzFlowSettings   = "settings"
+)
+
+// timezoneZoneCallback builds callback data for choosing a timezone in the given flow.
+// The zone is passed by its ID, since a zone name can be too long for callback data.
+func timezoneZoneCallback(flow, zone string) string {
+	id := entities.TimezoneID(zone)
+	if flow == tzFlowOnboarding {
+		return buildOnboardingTimezoneCallback(id)
+	}
+	return buildSettingsCallback(settingsReminders, "tz", id)
+}
+
+// timezoneRegionCallback builds callback data for opening a region; an empty key opens the region list.
+func timezoneRegionCallback(flow, key string) string {
+	if flow == tzFlowOnboarding {
+		return buildOnboardingTimezoneRegionCallback(key)
+	}
+	if key == "" {
+		return buildSettingsCallback(settingsReminders, "timezone")
+	}
+	return buildSettingsCallback(settingsReminders, "tz_region", key)
+}
+
+// timezoneSearchCallback builds callback data for starting a city search.
+func timezoneSearchCallback(flow string) string {
+	if flow == tzFlowOnboarding {
+		return buildOnboardingTimezoneCallback("manual")
+	}
+	return buildSettingsCallback(settingsReminders, "timezone_manual")
+}
+
+// buildTimezoneConfirmKeyboard asks the user to confirm a timezone found by city search.
+func buildTimezoneConfirmKeyboard(flow, zone string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+6", buildSettingsCallback(settingsReminders, "tz", "UTC+6")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+7", buildSettingsCallback(settingsReminders, "tz", "UTC+7")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+8", buildSettingsCallback(settingsReminders, "tz", "UTC+8")),
+			tgbotapi.NewInlineKeyboardButtonData("✅ Сохранить", timezoneZoneCallback(flow, zone)),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("UTC+9", buildSettingsCallback(settingsReminders, "tz", "UTC+9")),
-			tgbotapi.NewInlineKeyboardButtonData("UTC+10", buildSettingsCallback(settingsReminders, "tz", "UTC+10")),
-			tgbotapi.NewInlineKeyboardButtonData("Другой", buildSettingsCallback(settingsReminders, "timezone_manual")),
+			tgbotapi.NewInlineKeyboardButtonData("🔎 Другой город", timezoneSearchCallback(flow)),
+			tgbotapi.NewInlineKeyboardButtonData("« К регионам", timezoneRegionCallback(flow, "")),
 		),
-		tgbotapi.NewInlineKeyboardRow(
+	)
+}
+
+// buildTimezoneRegionsKeyboard builds the first step of the timezone picker: regions and search.
+func buildTimezoneRegionsKeyboard(flow string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+
+	for _, r := range entities.TimezoneRegions {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(r.Name, timezoneRegionCallback(flow, r.Key)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔎 Найти город", timezoneSearchCallback(flow)),
+		tgbotapi.NewInlineKeyboardButtonData("UTC", timezoneZoneCallback(flow, "UTC")),
+	))
+
+	if flow == tzFlowSettings {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("« Назад", buildSettingsCallback(settingsReminders)),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// buildTimezoneCitiesKeyboard builds a keyboard with cities and their current UTC offsets.
+func buildTimezoneCitiesKeyboard(flow string, cities []entities.TimezoneCity, now time.Time) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+
+	for _, c := range cities {
+		loc, err := time.LoadLocation(c.Zone)
+		if err != nil {
+			continue
+		}
+		label := fmt.Sprintf("%s (%s)", c.Name, entities.TimezoneOffsetLabel(loc, now))
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, timezoneZoneCallback(flow, c.Zone)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("« К регионам", timezoneRegionCallback(flow, "")),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// buildStreakAlertKeyboard builds keyboard for the streak-protection reminder.
+func buildStreakAlertKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔥 Сохранить серию", buildReminderStartQuizCallback()),
 		),
 	)
 }
 
-// buildReminderKeyboard builds keyboard for reminder notification
-func buildReminderKeyboard() tgbotapi.InlineKeyboardMarkup {
+// buildReminderKeyboard builds keyboard for reminder notification.
+// With a question, its answer options come first; selfReview adds "remember"/"forgot" buttons.
+func buildReminderKeyboard(nameNumber int, question *entities.ReminderQuestion, selfReview bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if question != nil {
+		for _, option := range question.Options {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(
+					question.Type.AnswerFor(&option),
+					buildReminderAnswerCallback(nameNumber, question.Type, option.Number),
+				),
+			))
+		}
+	}
+	if selfReview {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("😊 Помню", buildReminderSelfReviewCallback(nameNumber, true)),
+			tgbotapi.NewInlineKeyboardButtonData("😕 Забыл", buildReminderSelfReviewCallback(nameNumber, false)),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Начать квиз", buildReminderStartQuizCallback()),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⏰ Через 1 ч", buildReminderSnoozeCallback(entities.SnoozeOneHour)),
+			tgbotapi.NewInlineKeyboardButtonData("⏰ Через 3 ч", buildReminderSnoozeCallback(entities.SnoozeThreeHours)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🌆 Вечером", buildReminderSnoozeCallback(entities.SnoozeEvening)),
+			tgbotapi.NewInlineKeyboardButtonData("🌅 Завтра утром", buildReminderSnoozeCallback(entities.SnoozeMorning)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔕 Отключить", buildReminderDisableCallback()),
+		),
+	)...)
+}
+
+// buildDailyDigestKeyboard builds keyboard for the daily digest reminder.
+func buildDailyDigestKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📖 Имена на сегодня", buildReminderOpenTodayCallback()),
 			tgbotapi.NewInlineKeyboardButtonData("✅ Начать квиз", buildReminderStartQuizCallback()),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("⏰ Напомнить позже", buildReminderSnoozeCallback()),
 			tgbotapi.NewInlineKeyboardButtonData("🔕 Отключить", buildReminderDisableCallback()),
 		),
 	)
@@ -271,6 +788,37 @@ func buildResetKeyboard() *tgbotapi.InlineKeyboardMarkup {
 	return &kb
 }
 
+// buildInactivityWarningKeyboard builds the keyboard for the inactivity warning.
+func buildInactivityWarningKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📅 Продолжить обучение", buildTodayPageCallback(0)),
+		),
+	)
+}
+
+// buildDeleteDataKeyboard builds the first confirmation step of /deletemydata.
+func buildDeleteDataKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Продолжить", buildDeleteDataCallback(deleteDataProceed)),
+			tgbotapi.NewInlineKeyboardButtonData("✅ Отменить", buildDeleteDataCallback(deleteDataCancel)),
+		),
+	)
+}
+
+// buildDeleteDataFinalKeyboard builds the final confirmation step of /deletemydata.
+func buildDeleteDataFinalKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить навсегда", buildDeleteDataCallback(deleteDataConfirm)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Отменить", buildDeleteDataCallback(deleteDataCancel)),
+		),
+	)
+}
+
 func welcomeReturningKeyboard() tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
@@ -284,9 +832,17 @@ func welcomeReturningKeyboard() tgbotapi.InlineKeyboardMarkup {
 	)
 }
 
-func todayCardsKeyboard(page, total, nameNumber int) *tgbotapi.InlineKeyboardMarkup {
+func todayCardsKeyboard(page, total, nameNumber int, studied bool) *tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
 
+	doneLabel := "✅ Изучил"
+	if studied {
+		doneLabel = "☑️ Изучено сегодня"
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(doneLabel, buildTodayDoneCallback(nameNumber, page)),
+	))
+
 	if total > 1 {
 		var nav []tgbotapi.InlineKeyboardButton
 		if page > 0 {
@@ -302,6 +858,23 @@ func todayCardsKeyboard(page, total, nameNumber int) *tgbotapi.InlineKeyboardMar
 		if len(nav) > 0 {
 			rows = append(rows, nav)
 		}
+
+		var order []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			order = append(order,
+				tgbotapi.NewInlineKeyboardButtonData("⏫ Раньше", buildTodayMoveCallback(nameNumber, -1)),
+			)
+		}
+		order = append(order,
+			tgbotapi.NewInlineKeyboardButtonData("🔀", buildTodayReorderCallback(todayShuffle)),
+			tgbotapi.NewInlineKeyboardButtonData("🔢", buildTodayReorderCallback(todaySort)),
+		)
+		if page+1 < total {
+			order = append(order,
+				tgbotapi.NewInlineKeyboardButtonData("⏬ Позже", buildTodayMoveCallback(nameNumber, 1)),
+			)
+		}
+		rows = append(rows, order)
 	}
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
@@ -310,12 +883,225 @@ func todayCardsKeyboard(page, total, nameNumber int) *tgbotapi.InlineKeyboardMar
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData("🔊 Прослушать", buildTodayAudioCallback(nameNumber)),
+		tgbotapi.NewInlineKeyboardButtonData("📊 Статистика", buildNameStatsCallback(nameNumber)),
 	))
 
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔁 Заменить имя", buildTodaySwapCallback(nameNumber, page)),
 		tgbotapi.NewInlineKeyboardButtonData("⚙️ Настройки", buildSettingsCallback(settingsMenu)),
 	))
 
 	kb := tgbotapi.NewInlineKeyboardMarkup(rows...)
 	return &kb
 }
+
+// nameCardKeyboard builds keyboard for a single name card.
+func nameCardKeyboard(nameNumber int, isFavorite, hasNote bool) tgbotapi.InlineKeyboardMarkup {
+	noteRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✏️ Заметка", buildNoteEditCallback(nameNumber)),
+	)
+	if hasNote {
+		noteRow = tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✏️ Изменить заметку", buildNoteEditCallback(nameNumber)),
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить заметку", buildNoteDeleteCallback(nameNumber)),
+		)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			favoriteButton(nameNumber, isFavorite),
+			tgbotapi.NewInlineKeyboardButtonData("📊 Моя статистика", buildNameStatsCallback(nameNumber)),
+		),
+		noteRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⚠️ Ошибка в карточке?", buildReportStartCallback(nameNumber)),
+		),
+	)
+}
+
+// reportFieldKeyboard builds the keyboard for choosing the part of a name card a report is about.
+func reportFieldKeyboard(nameNumber int) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, field := range entities.ContentReportFields {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(reportFieldTitle(field), buildReportFieldCallback(nameNumber, field)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", buildReportCancelCallback()),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// ticketAdminKeyboard builds the keyboard of a ticket copy sent to admins.
+func ticketAdminKeyboard(ticketID int64) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔒 Закрыть", buildTicketCloseCallback(ticketID)),
+		),
+	)
+}
+
+// surveyQuestionKeyboard builds the option buttons of a survey question, one per row.
+func surveyQuestionKeyboard(surveyID int64, question int, options []string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(options)+1)
+	for i, opt := range options {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(opt, buildSurveyAnswerCallback(surveyID, question, i)),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⏭ Пропустить опрос", buildSurveySkipCallback(surveyID)),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// whatsNewKeyboard builds the announcement subscription toggle.
+func whatsNewKeyboard(subscribed bool, lang string) tgbotapi.InlineKeyboardMarkup {
+	text := "🔔 Присылать новости"
+	if subscribed {
+		text = "🔕 Не присылать новости"
+	}
+	if lang == "en" {
+		text = "🔔 Send me news"
+		if subscribed {
+			text = "🔕 Stop sending news"
+		}
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(text, buildWhatsNewCallback(!subscribed)),
+		),
+	)
+}
+
+// reportReviewKeyboard builds the accept/dismiss keyboard of a report sent to admins.
+func reportReviewKeyboard(reportID int64) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Принять", buildReportResolveCallback(reportID, true)),
+			tgbotapi.NewInlineKeyboardButtonData("🚫 Отклонить", buildReportResolveCallback(reportID, false)),
+		),
+	)
+}
+
+// favoriteButton builds the favorite toggle button reflecting the current state.
+func favoriteButton(nameNumber int, isFavorite bool) tgbotapi.InlineKeyboardButton {
+	text := "⭐ В избранное"
+	if isFavorite {
+		text = "✅ В избранном"
+	}
+	return tgbotapi.NewInlineKeyboardButtonData(text, buildFavoriteToggleCallback(nameNumber))
+}
+
+// withFavoriteState returns a copy of the keyboard with the favorite button of the name
+// updated to the given state. Other buttons are kept as is.
+func withFavoriteState(kb *tgbotapi.InlineKeyboardMarkup, nameNumber int, isFavorite bool) tgbotapi.InlineKeyboardMarkup {
+	if kb == nil {
+		return nameCardKeyboard(nameNumber, isFavorite, false)
+	}
+
+	toggleData := buildFavoriteToggleCallback(nameNumber)
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(kb.InlineKeyboard))
+	for _, row := range kb.InlineKeyboard {
+		newRow := make([]tgbotapi.InlineKeyboardButton, 0, len(row))
+		for _, btn := range row {
+			if btn.CallbackData != nil && *btn.CallbackData == toggleData {
+				btn = favoriteButton(nameNumber, isFavorite)
+			}
+			newRow = append(newRow, btn)
+		}
+		rows = append(rows, newRow)
+	}
+
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// favoritesKeyboard builds keyboard for the favorites list with a favorites-only quiz button.
+func favoritesKeyboard(names []*entities.Name, page, totalPages int) tgbotapi.InlineKeyboardMarkup {
+	kb := nameListKeyboard(names, page, totalPages, buildFavoritesPageCallback)
+	kb.InlineKeyboard = append(kb.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🎯 Квиз по избранному", buildFavoritesQuizCallback()),
+	))
+	return kb
+}
+
+// inlineNameKeyboard builds keyboard for a name card shared via inline mode.
+// Callback buttons do not work in foreign chats, so it links back to the bot.
+func inlineNameKeyboard(deepLink, lang string) tgbotapi.InlineKeyboardMarkup {
+	text := "📖 Открыть в боте"
+	if lang == langEN {
+		text = "📖 Open in bot"
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(text, deepLink),
+		),
+	)
+}
+
+// groupSettingsKeyboard builds keyboard for group settings; labels follow the group language.
+func groupSettingsKeyboard(group *entities.GroupChat) tgbotapi.InlineKeyboardMarkup {
+	langText, postText, timeText := "🌐 Язык: русский", "📬 Имя дня: выкл", "⏰ Время: "+group.PostTime
+	quizText := "🧩 Мини-квиз: выкл"
+	if group.DailyPost {
+		postText = "📬 Имя дня: вкл"
+	}
+	if group.DailyQuiz {
+		quizText = "🧩 Мини-квиз: вкл"
+	}
+	if group.LanguageCode == langEN {
+		langText, postText, timeText = "🌐 Language: English", "📬 Name of the day: off", "⏰ Time: "+group.PostTime
+		quizText = "🧩 Mini-quiz: off"
+		if group.DailyPost {
+			postText = "📬 Name of the day: on"
+		}
+		if group.DailyQuiz {
+			quizText = "🧩 Mini-quiz: on"
+		}
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(langText, buildGroupSettingsCallback(groupLanguage)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(postText, buildGroupSettingsCallback(groupDailyPost)),
+			tgbotapi.NewInlineKeyboardButtonData(timeText, buildGroupSettingsCallback(groupPostTime)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(quizText, buildGroupSettingsCallback(groupDailyQuiz)),
+		),
+	)
+}
+
+// privateChatKeyboard builds a keyboard with a link to the private chat with the bot.
+func privateChatKeyboard(botUsername, lang string) tgbotapi.InlineKeyboardMarkup {
+	text := "💬 Открыть бота"
+	if lang == langEN {
+		text = "💬 Open the bot"
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(text, "https://t.me/"+botUsername),
+		),
+	)
+}
+
+// nameStatsKeyboard builds keyboard for the personal name statistics screen.
+func nameStatsKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🎯 Начать квиз", buildQuizStartCallback()),
+			tgbotapi.NewInlineKeyboardButtonData("📊 Прогресс", buildProgressCallback()),
+		),
+	)
+}
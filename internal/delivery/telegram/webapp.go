@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleApp sends a button that opens the Mini App: /app.
+func (h *Handler) handleApp() HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		if h.webAppURL == "" {
+			return h.send(newPlainMessage(chatID, msgWebAppDisabled))
+		}
+		return h.sendWebAppButton(chatID, msgWebAppOpen, "📱 Открыть приложение")
+	}
+}
+
+// sendWebAppButton sends text with an inline button that opens the Mini App.
+// tgbotapi has no web_app buttons, so the request is built by hand.
+func (h *Handler) sendWebAppButton(chatID int64, text, button string) error {
+	markup, err := json.Marshal(map[string]any{
+		"inline_keyboard": [][]map[string]any{{{
+			"text":    button,
+			"web_app": map[string]string{"url": h.webAppURL},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.bot.MakeRequest("sendMessage", tgbotapi.Params{
+		"chat_id":      strconv.FormatInt(chatID, 10),
+		"text":         text,
+		"reply_markup": string(markup),
+	})
+	return err
+}
@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// whatsNewReleases is the number of releases /whatsnew shows.
+const whatsNewReleases = 3
+
+// handleWhatsNew shows the latest release notes and the announcement subscription toggle.
+func (h *Handler) handleWhatsNew(userID int64) HandlerFunc {
+	return func(ctx context.Context, chatID int64) error {
+		lang := h.userLanguage(ctx, userID)
+
+		subscribed, err := h.changelogService.IsSubscribed(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		msg := newPlainMessage(chatID, formatWhatsNew(h.changelogService.Latest(whatsNewReleases), subscribed, lang))
+		msg.ReplyMarkup = whatsNewKeyboard(subscribed, lang)
+		return h.send(msg)
+	}
+}
+
+// handleWhatsNewCallback subscribes the user to announcements or unsubscribes them.
+func (h *Handler) handleWhatsNewCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if cb.Message == nil {
+		return nil
+	}
+
+	data := decodeCallback(cb.Data)
+	if len(data.Params) != 1 {
+		h.log(ctx).Warn("invalid whatsnew callback params", zap.String("raw", data.Raw))
+		return nil
+	}
+
+	subscribe := data.Params[0] == whatsNewSubscribe
+	if err := h.changelogService.SetSubscribed(ctx, cb.From.ID, subscribe); err != nil {
+		return err
+	}
+
+	lang := h.userLanguage(ctx, cb.From.ID)
+	_ = h.answerCallback(cb.ID, whatsNewToggledText(subscribe, lang))
+
+	return h.send(tgbotapi.NewEditMessageReplyMarkup(
+		cb.Message.Chat.ID, cb.Message.MessageID, whatsNewKeyboard(subscribe, lang),
+	))
+}
+
+// SendChangelog announces a new release to a subscriber.
+func (h *Handler) SendChangelog(ctx context.Context, userID, chatID int64, announcement entities.ChangelogAnnouncement) error {
+	msg := newPlainMessage(chatID, formatChangelogAnnouncement(announcement))
+	msg.ReplyMarkup = whatsNewKeyboard(true, announcement.Language)
+	_, err := h.sendNotification(ctx, msg)
+	return err
+}
+
+// userLanguage returns the user's language code, the default one if settings are unavailable.
+func (h *Handler) userLanguage(ctx context.Context, userID int64) string {
+	settings, err := h.settingsService.GetOrCreate(ctx, userID)
+	if err != nil {
+		h.log(ctx).Warn("failed to get user language",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+		return entities.DefaultChangelogLanguage
+	}
+	return settings.LanguageCode
+}
+
+// formatWhatsNew renders the latest releases for /whatsnew.
+func formatWhatsNew(releases []entities.Release, subscribed bool, lang string) string {
+	var sb strings.Builder
+	if lang == "en" {
+		sb.WriteString("🆕 What's new")
+	} else {
+		sb.WriteString("🆕 Что нового")
+	}
+
+	if len(releases) == 0 {
+		if lang == "en" {
+			return sb.String() + "\n\nNo news yet."
+		}
+		return sb.String() + "\n\nПока новостей нет."
+	}
+
+	for _, r := range releases {
+		fmt.Fprintf(&sb, "\n\n%s · %s", r.Version, r.Date.Format("02.01.2006"))
+		for _, note := range r.NotesFor(lang) {
+			sb.WriteString("\n• " + note)
+		}
+	}
+
+	switch {
+	case subscribed && lang == "en":
+		sb.WriteString("\n\nYou get a short note after each update.")
+	case subscribed:
+		sb.WriteString("\n\nВы получаете короткую заметку после каждого обновления.")
+	case lang == "en":
+		sb.WriteString("\n\nWant a short note after each update? Turn it on below.")
+	default:
+		sb.WriteString("\n\nХотите получать короткую заметку после каждого обновления? Включите ниже.")
+	}
+
+	return sb.String()
+}
+
+// formatChangelogAnnouncement renders a release announcement.
+func formatChangelogAnnouncement(a entities.ChangelogAnnouncement) string {
+	var sb strings.Builder
+	if a.Language == "en" {
+		fmt.Fprintf(&sb, "🆕 Bot update %s", a.Version)
+	} else {
+		fmt.Fprintf(&sb, "🆕 Обновление бота %s", a.Version)
+	}
+
+	for _, note := range a.Notes {
+		sb.WriteString("\n• " + note)
+	}
+
+	if a.Language == "en" {
+		sb.WriteString("\n\nAll updates: /whatsnew")
+	} else {
+		sb.WriteString("\n\nВсе обновления: /whatsnew")
+	}
+	return sb.String()
+}
+
+// whatsNewToggledText confirms a subscription change.
+func whatsNewToggledText(subscribed bool, lang string) string {
+	switch {
+	case subscribed && lang == "en":
+		return "You will get news after updates"
+	case subscribed:
+		return "Новости будут приходить после обновлений"
+	case lang == "en":
+		return "News turned off"
+	default:
+		return "Новости отключены"
+	}
+}
@@ -0,0 +1,16 @@
+package entities
+
+// MinActivitySamples is how many active hours must be recorded before reminders follow them.
+const MinActivitySamples = 10
+
+// ActivityHours counts the user's recorded interactions per local hour of day.
+type ActivityHours [24]int
+
+// Total returns the number of recorded active hours.
+func (a ActivityHours) Total() int {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	return total
+}
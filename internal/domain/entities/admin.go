@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// AdminRole is a named set of admin permissions.
+type AdminRole string
+
+const (
+	AdminRoleOwner    AdminRole = "owner"    // everything, including managing admins
+	AdminRoleOperator AdminRole = "operator" // support, maintenance and broadcasts
+	AdminRoleEditor   AdminRole = "editor"   // support and content editing
+	AdminRoleSupport  AdminRole = "support"  // user snapshots and statistics
+)
+
+// AdminRoles lists all roles from the most to the least privileged.
+var AdminRoles = []AdminRole{AdminRoleOwner, AdminRoleOperator, AdminRoleEditor, AdminRoleSupport}
+
+// AdminPermission is a group of admin commands.
+type AdminPermission string
+
+const (
+	AdminPermSupport      AdminPermission = "support"       // read-only user lookups and statistics
+	AdminPermMaintenance  AdminPermission = "maintenance"   // changing user data and running jobs
+	AdminPermBroadcast    AdminPermission = "broadcast"     // messages to many users
+	AdminPermContent      AdminPermission = "content"       // editing name cards and other texts
+	AdminPermManageAdmins AdminPermission = "manage_admins" // granting and revoking roles
+)
+
+var adminRolePermissions = map[AdminRole][]AdminPermission{
+	AdminRoleOwner:    {AdminPermSupport, AdminPermMaintenance, AdminPermBroadcast, AdminPermContent, AdminPermManageAdmins},
+	AdminRoleOperator: {AdminPermSupport, AdminPermMaintenance, AdminPermBroadcast},
+	AdminRoleEditor:   {AdminPermSupport, AdminPermContent},
+	AdminRoleSupport:  {AdminPermSupport},
+}
+
+// ParseAdminRole returns the role with the given name.
+func ParseAdminRole(s string) (AdminRole, bool) {
+	role := AdminRole(s)
+	_, ok := adminRolePermissions[role]
+	return role, ok
+}
+
+// Can reports whether the role grants the permission.
+func (r AdminRole) Can(p AdminPermission) bool {
+	for _, granted := range adminRolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Admin is a user with an admin role.
+type Admin struct {
+	UserID     int64
+	Role       AdminRole
+	AddedBy    int64
+	CreatedAt  time.Time
+	FromConfig bool // listed in ADMIN_IDS; always an owner and cannot be revoked in the bot
+}
+
+// AdminAction is an audit record of an admin command.
+type AdminAction struct {
+	ID           int64
+	AdminID      int64
+	Action       string
+	TargetUserID int64 // 0 if the action has no target user
+	Details      string
+	CreatedAt    time.Time
+}
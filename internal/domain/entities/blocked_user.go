@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// BlockedUser is a user blocked by an admin.
+type BlockedUser struct {
+	UserID    int64
+	Reason    string // empty if none was given
+	BlockedBy int64
+	CreatedAt time.Time
+}
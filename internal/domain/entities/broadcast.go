@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// MaxBroadcastLength is the maximum length of a broadcast message in characters.
+const MaxBroadcastLength = 4000
+
+// Broadcast is a message sent to a segment of users. It reuses the survey segments.
+type Broadcast struct {
+	ID        int64
+	Segment   SurveySegment
+	Text      string
+	CreatedBy int64
+	Queued    int // users the message was queued for
+	CreatedAt time.Time
+}
+
+// BroadcastMessage is the payload of a broadcast notification.
+type BroadcastMessage struct {
+	BroadcastID int64
+	Text        string
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// DefaultChangelogLanguage is the language release notes fall back to.
+const DefaultChangelogLanguage = "ru"
+
+// Release describes what changed in a bot release.
+type Release struct {
+	Version string
+	Date    time.Time
+	Notes   map[string][]string // language code -> short bullet points
+}
+
+// NotesFor returns the release notes in the language, falling back to the default one.
+func (r Release) NotesFor(lang string) []string {
+	if notes, ok := r.Notes[lang]; ok && len(notes) > 0 {
+		return notes
+	}
+	return r.Notes[DefaultChangelogLanguage]
+}
+
+// ChangelogAnnouncement is the payload of a "what's new" notification.
+type ChangelogAnnouncement struct {
+	Version  string
+	Language string
+	Notes    []string
+}
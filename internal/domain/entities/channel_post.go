@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+// ChannelPost is the name of the day posted to a Telegram channel.
+type ChannelPost struct {
+	ChatID      int64
+	PostDate    time.Time // channel's local calendar day (midnight UTC, see LocalDay)
+	NameNumber  int
+	MessageID   *int       // nil until sent, or if the channel rejected the post
+	PublishedAt *time.Time // nil until sent
+}
+
+// Published reports whether the post reached the channel.
+func (p *ChannelPost) Published() bool {
+	return p.MessageID != nil
+}
+
+// ChannelQuiz is a mini-quiz question sent to a group after the name of the day.
+// Options are translations of names, one of them belongs to Name.
+type ChannelQuiz struct {
+	Name         *Name
+	Options      []string
+	CorrectIndex int
+}
+
+// ChannelQuizStats sums up mini-quiz answers of a chat. Telegram quizzes in groups are
+// anonymous, so answers are counted per chat, not per user.
+type ChannelQuizStats struct {
+	Quizzes int // mini-quizzes sent
+	Answers int // answers given, summed over quizzes
+	Correct int // correct answers
+}
+
+// CorrectPercent returns the share of correct answers, 0 if nobody answered.
+func (s ChannelQuizStats) CorrectPercent() int {
+	if s.Answers == 0 {
+		return 0
+	}
+	return s.Correct * 100 / s.Answers
+}
+
+// NextChannelName returns the name that follows last in a channel's cycle through all names.
+// A channel without published posts starts from the first name.
+func NextChannelName(last int) int {
+	if last < 1 || last >= 99 {
+		return 1
+	}
+	return last + 1
+}
@@ -0,0 +1,61 @@
+package entities
+
+import "time"
+
+// MaxContentReportLength is the maximum length of a content error report in characters.
+const MaxContentReportLength = 1000
+
+// ContentReportField is the part of a name card a report is about.
+type ContentReportField string
+
+const (
+	ContentReportArabic          ContentReportField = "arabic"
+	ContentReportTransliteration ContentReportField = "translit"
+	ContentReportTranslation     ContentReportField = "translation"
+	ContentReportMeaning         ContentReportField = "meaning"
+	ContentReportAudio           ContentReportField = "audio"
+	ContentReportOther           ContentReportField = "other"
+)
+
+// ContentReportFields lists the report fields in the order they are offered to users.
+var ContentReportFields = []ContentReportField{
+	ContentReportArabic,
+	ContentReportTransliteration,
+	ContentReportTranslation,
+	ContentReportMeaning,
+	ContentReportAudio,
+	ContentReportOther,
+}
+
+// ParseContentReportField returns the field with the given name.
+func ParseContentReportField(s string) (ContentReportField, bool) {
+	for _, f := range ContentReportFields {
+		if string(f) == s {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// ContentReportStatus is the review state of a content report.
+type ContentReportStatus string
+
+const (
+	ContentReportOpen      ContentReportStatus = "open"
+	ContentReportAccepted  ContentReportStatus = "accepted"
+	ContentReportDismissed ContentReportStatus = "dismissed"
+)
+
+// ContentReport is a user report about a typo or a content error on a name card.
+type ContentReport struct {
+	ID         int64
+	UserID     int64
+	ChatID     int64
+	NameNumber int
+	Field      ContentReportField
+	Text       string
+	Status     ContentReportStatus
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+	ResolvedBy *int64
+}
@@ -0,0 +1,64 @@
+package entities
+
+import "time"
+
+// Weekly digest schedule in the user's local time.
+const (
+	WeeklyDigestWeekday = time.Sunday
+	WeeklyDigestHour    = 9
+)
+
+// WeeklyDigest summarizes a user's learning over the last seven days.
+type WeeklyDigest struct {
+	Date          time.Time // user's local calendar date the digest is built for
+	Mastered      int       // names mastered this week
+	TotalMastered int       // names mastered overall
+	Reviews       int       // quiz answers this week
+	Correct       int       // correct quiz answers this week
+	PrevReviews   int       // quiz answers the week before
+	PrevCorrect   int       // correct quiz answers the week before
+	CurrentStreak int
+	BestStreak    int
+}
+
+// Accuracy returns this week's share of correct answers in percent.
+func (d WeeklyDigest) Accuracy() int {
+	return percent(d.Correct, d.Reviews)
+}
+
+// PrevAccuracy returns the previous week's share of correct answers in percent.
+func (d WeeklyDigest) PrevAccuracy() int {
+	return percent(d.PrevCorrect, d.PrevReviews)
+}
+
+// DigestRecipient is a user who opted in to the weekly digest.
+type DigestRecipient struct {
+	UserID           int64
+	ChatID           int64
+	Timezone         string
+	LastDigestSentAt *time.Time
+}
+
+// IsDigestDue reports whether the weekly digest should be sent at now:
+// it is the digest hour of the digest weekday in the user's timezone
+// and no digest has been sent during the last day.
+func (r *DigestRecipient) IsDigestDue(now time.Time) bool {
+	loc, err := ParseTimezoneLocation(r.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	if local.Weekday() != WeeklyDigestWeekday || local.Hour() != WeeklyDigestHour {
+		return false
+	}
+
+	return r.LastDigestSentAt == nil || now.Sub(*r.LastDigestSentAt) >= 24*time.Hour
+}
+
+func percent(part, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	return part * 100 / total
+}
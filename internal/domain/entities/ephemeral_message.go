@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// EphemeralKind is the kind of a bot message that is deleted after a while.
+type EphemeralKind string
+
+const (
+	EphemeralQuiz     EphemeralKind = "quiz"     // quiz question
+	EphemeralPrompt   EphemeralKind = "prompt"   // request for text input
+	EphemeralReminder EphemeralKind = "reminder" // reminder or digest
+)
+
+// EphemeralMessage is a bot message that is deleted from the chat once DeleteAfter has passed.
+type EphemeralMessage struct {
+	ChatID      int64
+	MessageID   int
+	Kind        EphemeralKind
+	SentAt      time.Time
+	DeleteAfter time.Time
+}
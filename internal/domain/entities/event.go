@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// EventName identifies the kind of analytics event.
+type EventName string
+
+const (
+	EventCommand         EventName = "command"          // a bot command was used; properties: command
+	EventQuizStarted     EventName = "quiz_started"     // a new quiz session started; properties: session_id, mode, questions
+	EventQuizFinished    EventName = "quiz_finished"    // a quiz session was completed; properties: session_id, score, total
+	EventReminderClicked EventName = "reminder_clicked" // a button on a reminder was pressed; properties: action
+	EventSettingChanged  EventName = "setting_changed"  // a setting was changed from /settings; properties: setting, value
+
+	EventOnboardingStepShown     EventName = "onboarding_step_shown"     // an onboarding step was shown; properties: step
+	EventOnboardingStepCompleted EventName = "onboarding_step_completed" // an onboarding step was answered; properties: step, choice
+)
+
+// OnboardingSteps is the number of onboarding steps in the funnel; step OnboardingSteps+1
+// is the final "all set" screen.
+const OnboardingSteps = 5
+
+// Event is a single user action recorded for product analytics.
+type Event struct {
+	UserID     int64
+	Name       EventName
+	Properties map[string]any
+	CreatedAt  time.Time
+}
+
+// OnboardingFunnelStep counts distinct users who saw and completed an onboarding step.
+type OnboardingFunnelStep struct {
+	Step      int
+	Shown     int
+	Completed int
+}
+
+// OnboardingFunnel summarizes conversion through the onboarding steps since a point in time.
+type OnboardingFunnel struct {
+	Since    time.Time
+	Steps    []OnboardingFunnelStep // steps 1..OnboardingSteps in order
+	Finished int                    // users who reached the final screen
+}
+
+// DailyCount is a number measured for one UTC day.
+type DailyCount struct {
+	Day   time.Time
+	Count int
+}
@@ -0,0 +1,43 @@
+package entities
+
+// Default settings of a group chat.
+const (
+	DefaultGroupLanguage = "ru"
+	DefaultGroupPostTime = "08:00"
+	DefaultGroupTimezone = "UTC"
+)
+
+// GroupPostTimes are the posting times a group admin can choose from.
+var GroupPostTimes = []string{"06:00", "08:00", "12:00", "18:00", "21:00"}
+
+// GroupChat holds per-chat settings of a group or supergroup the bot was added to.
+type GroupChat struct {
+	ChatID       int64
+	Title        string
+	LanguageCode string // "ru" or "en"
+	DailyPost    bool   // post the name of the day to the group
+	DailyQuiz    bool   // follow the daily post with a mini-quiz
+	PostTime     string // local posting time, HH:MM
+	Timezone     string
+}
+
+// NewGroupChat creates settings for a group with default values.
+func NewGroupChat(chatID int64, title string) *GroupChat {
+	return &GroupChat{
+		ChatID:       chatID,
+		Title:        title,
+		LanguageCode: DefaultGroupLanguage,
+		PostTime:     DefaultGroupPostTime,
+		Timezone:     DefaultGroupTimezone,
+	}
+}
+
+// NextGroupPostTime returns the posting time that follows current in GroupPostTimes.
+func NextGroupPostTime(current string) string {
+	for i, t := range GroupPostTimes {
+		if t == current {
+			return GroupPostTimes[(i+1)%len(GroupPostTimes)]
+		}
+	}
+	return GroupPostTimes[0]
+}
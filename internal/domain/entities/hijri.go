@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calendar selects which calendar month-based boundaries (e.g. the monthly report) follow.
+type Calendar string
+
+const (
+	CalendarGregorian Calendar = "gregorian"
+	CalendarHijri     Calendar = "hijri"
+)
+
+// IsValid reports whether the calendar is known.
+func (c Calendar) IsValid() bool {
+	return c == CalendarGregorian || c == CalendarHijri
+}
+
+// HijriDate is a date in the tabular (arithmetic) Islamic calendar.
+// It may differ by a day or two from calendars based on moon sighting.
+type HijriDate struct {
+	Year  int
+	Month int // 1 (Muharram) to 12 (Dhu al-Hijjah)
+	Day   int
+}
+
+// hijriEpoch is the Julian day number of 1 Muharram 1 AH (16 July 622 CE, Julian calendar).
+const hijriEpoch = 1948440
+
+// unixEpochJDN is the Julian day number of 1 January 1970.
+const unixEpochJDN = 2440588
+
+var hijriMonthNames = map[string][12]string{
+	"ru": {
+		"Мухаррам", "Сафар", "Раби аль-авваль", "Раби ас-сани", "Джумада аль-уля", "Джумада ас-сания",
+		"Раджаб", "Шаабан", "Рамадан", "Шавваль", "Зуль-када", "Зуль-хиджа",
+	},
+	"en": {
+		"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Ula", "Jumada al-Thaniyah",
+		"Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah",
+	},
+}
+
+// ToHijri converts the calendar date of t (in t's location) to the Hijri calendar.
+func ToHijri(t time.Time) HijriDate {
+	y, m, d := t.Date()
+	jdn := gregorianToJDN(y, int(m), d)
+
+	year := (30*(jdn-hijriEpoch) + 10646) / 10631
+	month := min(12, (jdn-hijriToJDN(year, 1, 1))*2/59+1)
+	day := jdn - hijriToJDN(year, month, 1) + 1
+
+	return HijriDate{Year: year, Month: month, Day: day}
+}
+
+// Gregorian returns the Gregorian calendar date as midnight UTC.
+func (d HijriDate) Gregorian() time.Time {
+	days := hijriToJDN(d.Year, d.Month, d.Day) - unixEpochJDN
+	return time.Unix(int64(days)*86400, 0).UTC()
+}
+
+// In returns local midnight of the date in loc.
+func (d HijriDate) In(loc *time.Location) time.Time {
+	y, m, day := d.Gregorian().Date()
+	return time.Date(y, m, day, 0, 0, 0, 0, loc)
+}
+
+// MonthStart returns the first day of the month n months away (n may be negative).
+func (d HijriDate) MonthStart(n int) HijriDate {
+	months := d.Year*12 + d.Month - 1 + n
+	return HijriDate{Year: months / 12, Month: months%12 + 1, Day: 1}
+}
+
+// MonthName returns the month name in the given language, Russian by default.
+func (d HijriDate) MonthName(lang string) string {
+	return HijriMonthName(d.Month, lang)
+}
+
+// Format returns the date like "12 Рамадан 1447 г. х." or "12 Ramadan 1447 AH".
+func (d HijriDate) Format(lang string) string {
+	if lang == "en" {
+		return fmt.Sprintf("%d %s %d AH", d.Day, d.MonthName(lang), d.Year)
+	}
+	return fmt.Sprintf("%d %s %d г. х.", d.Day, d.MonthName(lang), d.Year)
+}
+
+// RamadanEnd returns the last day of the current Ramadan, or of the next one after it has ended,
+// as a Gregorian date at midnight UTC.
+func RamadanEnd(today time.Time) time.Time {
+	h := ToHijri(today)
+	year := h.Year
+	if h.Month > 9 {
+		year++
+	}
+	return HijriDate{Year: year, Month: 10, Day: 1}.Gregorian().AddDate(0, 0, -1)
+}
+
+// HijriMonthName returns the name of a Hijri month (1–12) in the given language, Russian by default.
+func HijriMonthName(month int, lang string) string {
+	names, ok := hijriMonthNames[lang]
+	if !ok {
+		names = hijriMonthNames["ru"]
+	}
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return names[month-1]
+}
+
+// hijriToJDN returns the Julian day number of a tabular Hijri date.
+// Months alternate 30 and 29 days; 11 years in each 30-year cycle are leap years.
+func hijriToJDN(year, month, day int) int {
+	return day + (59*(month-1)+1)/2 + (year-1)*354 + (3+11*year)/30 + hijriEpoch - 1
+}
+
+// gregorianToJDN returns the Julian day number of a Gregorian date.
+func gregorianToJDN(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// MaxNoteLength is the maximum length of a personal note in characters.
+const MaxNoteLength = 1000
+
+// NameNote is a private user note attached to a name (mnemonic, reflection).
+type NameNote struct {
+	UserID     int64
+	NameNumber int
+	Text       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,61 @@
+package entities
+
+import "time"
+
+// NotificationKind identifies what a queued notification delivers.
+type NotificationKind string
+
+const (
+	NotificationReminder     NotificationKind = "reminder"      // payload is a ReminderPayload
+	NotificationDailyDigest  NotificationKind = "daily_digest"  // payload is a DailyDigest
+	NotificationWeeklyDigest NotificationKind = "weekly_digest" // payload is a WeeklyDigest
+	NotificationStreakAlert  NotificationKind = "streak_alert"  // payload is a StreakAlert
+	NotificationSurvey       NotificationKind = "survey"        // payload is a SurveyInvite
+	NotificationChangelog    NotificationKind = "changelog"     // payload is a ChangelogAnnouncement
+	NotificationBroadcast    NotificationKind = "broadcast"     // payload is a BroadcastMessage
+	NotificationWebhook      NotificationKind = "webhook"       // payload is a WebhookEvent
+)
+
+// StreakAlert is the payload of a streak-protection notification.
+type StreakAlert struct {
+	Streak    int
+	HoursLeft int
+}
+
+// MaxNotificationAttempts is how many times delivery is tried before the job is marked failed.
+const MaxNotificationAttempts = 5
+
+// Notification retry backoff bounds.
+const (
+	notificationRetryBase = time.Minute
+	notificationRetryMax  = time.Hour
+)
+
+// NotificationJob is a persisted notification waiting to be delivered by a worker.
+// Jobs are written in the same transaction as the state change they announce
+// (transactional outbox), so a change is never recorded without its notification.
+type NotificationJob struct {
+	ID          int64
+	UserID      int64
+	ChatID      int64
+	Kind        NotificationKind
+	Payload     []byte // JSON-encoded payload for Kind
+	Attempts    int    // delivery attempts made so far, including the current one
+	ScheduledAt time.Time
+	LastError   string
+	CreatedAt   time.Time
+}
+
+// CanRetry reports whether another delivery attempt is allowed after a failure.
+func (j *NotificationJob) CanRetry() bool {
+	return j.Attempts < MaxNotificationAttempts
+}
+
+// RetryDelay returns the exponential backoff before the next attempt: 1m, 2m, 4m, ... up to 1h.
+func (j *NotificationJob) RetryDelay() time.Duration {
+	delay := notificationRetryBase
+	for i := 1; i < j.Attempts && delay < notificationRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, notificationRetryMax)
+}
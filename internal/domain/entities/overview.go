@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// BotOverview is a snapshot of bot usage shown to admins.
+type BotOverview struct {
+	At                time.Time
+	TotalUsers        int
+	DAU               int // users active in the last 24 hours
+	WAU               int // users active in the last 7 days
+	ActiveReminders   int // users with reminders enabled
+	QuizzesToday      int // quiz sessions started since midnight UTC
+	QuizzesCompleted  int // of them, completed
+	RemindersSent24h  int // reminder notifications delivered in the last 24 hours
+	DeliveryErrors24h int // failed delivery attempts of any kind in the last 24 hours
+	FailedJobs        int // notification jobs given up, kept for inspection
+}
@@ -0,0 +1,200 @@
+package entities
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Prayer is one of the five daily prayers.
+type Prayer int
+
+const (
+	Fajr Prayer = iota
+	Dhuhr
+	Asr
+	Maghrib
+	Isha
+
+	prayerCount = 5
+)
+
+// Prayers lists the daily prayers in chronological order.
+var Prayers = []Prayer{Fajr, Dhuhr, Asr, Maghrib, Isha}
+
+// Prayer time calculation parameters (Muslim World League method, standard Asr).
+const (
+	fajrAngle     = 18.0
+	ishaAngle     = 17.0
+	horizonAngle  = 0.833 // sunrise and sunset, including refraction
+	asrShadowSize = 1.0
+)
+
+// DefaultPrayerOffsetMinutes is how long after the prayer a reminder is sent by default.
+const DefaultPrayerOffsetMinutes = 30
+
+// MaxPrayerOffsetMinutes limits how far after the prayer a reminder may be scheduled.
+const MaxPrayerOffsetMinutes = 180
+
+// ErrPrayerTimesUnavailable is returned when the sun does not rise or set on the given day.
+var ErrPrayerTimesUnavailable = errors.New("prayer times unavailable")
+
+// PrayerTimes holds the start of each prayer, indexed by Prayer.
+type PrayerTimes [prayerCount]time.Time
+
+// PrayerAnchors configures reminders sent relative to local prayer times
+// instead of the fixed time window.
+type PrayerAnchors struct {
+	Prayers       int      // bitmask, bit N set means Prayer(N) is selected
+	OffsetMinutes int      // minutes after the prayer starts
+	Latitude      *float64 // nil until the user shares a location
+	Longitude     *float64
+}
+
+// HasLocation reports whether coordinates are set.
+func (a PrayerAnchors) HasLocation() bool {
+	return a.Latitude != nil && a.Longitude != nil
+}
+
+// Enabled reports whether reminders follow prayer times.
+func (a PrayerAnchors) Enabled() bool {
+	return a.Prayers != 0 && a.HasLocation()
+}
+
+// Has reports whether the prayer is selected.
+func (a PrayerAnchors) Has(p Prayer) bool {
+	return a.Prayers&(1<<p) != 0
+}
+
+// Toggle switches the prayer on or off.
+func (a *PrayerAnchors) Toggle(p Prayer) {
+	a.Prayers ^= 1 << p
+}
+
+// TimesOn calculates prayer times for the local calendar day of day in loc.
+func (a PrayerAnchors) TimesOn(day time.Time, loc *time.Location) (PrayerTimes, error) {
+	if !a.HasLocation() {
+		return PrayerTimes{}, ErrPrayerTimesUnavailable
+	}
+	return CalculatePrayerTimes(day.In(loc), *a.Latitude, *a.Longitude)
+}
+
+// CalculatePrayerTimes returns prayer times for the calendar date of day at the given coordinates.
+// The results are absolute instants, located in day's location.
+//
+// Fajr and Isha use the sun's depression angle; when it is never reached
+// (high latitudes in summer) they fall back to a fraction of the night.
+func CalculatePrayerTimes(day time.Time, lat, lon float64) (PrayerTimes, error) {
+	y, m, d := day.Date()
+	jd := julianDate(y, int(m), d) - lon/(15*24)
+
+	// The sun's position depends on the time itself, so start from rough
+	// estimates (in hours) and refine them once.
+	fajr, sunrise, dhuhr, asr, sunset, isha := 5.0, 6.0, 12.0, 13.0, 18.0, 18.0
+	for range 2 {
+		fajr = sunAngleTime(jd, lat, fajrAngle, dayPortion(fajr, 5), true)
+		sunrise = sunAngleTime(jd, lat, horizonAngle, dayPortion(sunrise, 6), true)
+		dhuhr = midDay(jd, dayPortion(dhuhr, 12))
+		asr = asrTime(jd, lat, dayPortion(asr, 13))
+		sunset = sunAngleTime(jd, lat, horizonAngle, dayPortion(sunset, 18), false)
+		isha = sunAngleTime(jd, lat, ishaAngle, dayPortion(isha, 18), false)
+	}
+
+	if math.IsNaN(sunrise) || math.IsNaN(sunset) || math.IsNaN(asr) {
+		return PrayerTimes{}, ErrPrayerTimesUnavailable
+	}
+
+	night := 24 + sunrise - sunset
+	if portion := fajrAngle / 60 * night; math.IsNaN(fajr) || sunrise-fajr > portion {
+		fajr = sunrise - portion
+	}
+	if portion := ishaAngle / 60 * night; math.IsNaN(isha) || isha-sunset > portion {
+		isha = sunset + portion
+	}
+
+	midnightUTC := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64) time.Time {
+		utcHours := hours - lon/15
+		return midnightUTC.Add(time.Duration(utcHours * float64(time.Hour))).Round(time.Minute).In(day.Location())
+	}
+
+	return PrayerTimes{
+		Fajr:    at(fajr),
+		Dhuhr:   at(dhuhr),
+		Asr:     at(asr),
+		Maghrib: at(sunset),
+		Isha:    at(isha),
+	}, nil
+}
+
+// dayPortion converts an estimate in hours to a fraction of the day, using fallback if it is undefined.
+func dayPortion(hours, fallback float64) float64 {
+	if math.IsNaN(hours) {
+		hours = fallback
+	}
+	return hours / 24
+}
+
+// julianDate returns the Julian date at 0h UT of the Gregorian calendar date.
+func julianDate(year, month, day int) float64 {
+	if month <= 2 {
+		year--
+		month += 12
+	}
+	a := math.Floor(float64(year) / 100)
+	b := 2 - a + math.Floor(a/4)
+
+	return math.Floor(365.25*float64(year+4716)) + math.Floor(30.6001*float64(month+1)) + float64(day) + b - 1524.5
+}
+
+// sunPosition returns the sun's declination (degrees) and the equation of time (hours).
+func sunPosition(jd float64) (decl, eqt float64) {
+	d := jd - 2451545.0
+	g := fixAngle(357.529 + 0.98560028*d)
+	q := fixAngle(280.459 + 0.98564736*d)
+	l := fixAngle(q + 1.915*dsin(g) + 0.020*dsin(2*g))
+	e := 23.439 - 0.00000036*d
+
+	ra := fixHour(darctan2(dcos(e)*dsin(l), dcos(l)) / 15)
+	eqt = q/15 - ra
+	decl = darcsin(dsin(e) * dsin(l))
+
+	return decl, eqt
+}
+
+// midDay returns the local solar noon in hours for the day portion t.
+func midDay(jd, t float64) float64 {
+	_, eqt := sunPosition(jd + t)
+	return fixHour(12 - eqt)
+}
+
+// sunAngleTime returns when the sun reaches the angle below the horizon,
+// before noon if ccw is set. The result is NaN if the angle is never reached.
+func sunAngleTime(jd, lat, angle, t float64, ccw bool) float64 {
+	decl, _ := sunPosition(jd + t)
+	noon := midDay(jd, t)
+	h := darccos((-dsin(angle)-dsin(decl)*dsin(lat))/(dcos(decl)*dcos(lat))) / 15
+	if ccw {
+		return noon - h
+	}
+	return noon + h
+}
+
+// asrTime returns the start of Asr, when an object's shadow exceeds its noon shadow by its length.
+func asrTime(jd, lat, t float64) float64 {
+	decl, _ := sunPosition(jd + t)
+	angle := -darccot(asrShadowSize + dtan(math.Abs(lat-decl)))
+	return sunAngleTime(jd, lat, angle, t, false)
+}
+
+func dsin(d float64) float64    { return math.Sin(d * math.Pi / 180) }
+func dcos(d float64) float64    { return math.Cos(d * math.Pi / 180) }
+func dtan(d float64) float64    { return math.Tan(d * math.Pi / 180) }
+func darcsin(x float64) float64 { return math.Asin(x) * 180 / math.Pi }
+func darccos(x float64) float64 { return math.Acos(x) * 180 / math.Pi }
+func darccot(x float64) float64 { return math.Atan(1/x) * 180 / math.Pi }
+
+func darctan2(y, x float64) float64 { return math.Atan2(y, x) * 180 / math.Pi }
+
+func fixAngle(a float64) float64 { return a - 360*math.Floor(a/360) }
+func fixHour(h float64) float64  { return h - 24*math.Floor(h/24) }
@@ -100,6 +100,34 @@ func (p *UserProgress) UpdateSRS(quality AnswerQuality, now time.Time) {
 	}
 }
 
+// SelfReviewForgotDelay is how soon a name marked as forgotten comes back for review.
+const SelfReviewForgotDelay = time.Hour
+
+// RecordSelfReview applies a self-assessed review from a reminder card.
+// It is lighter than UpdateSRS and leaves answer counters alone: remembering keeps
+// streak and ease and postpones the next review by the current interval (at least a day);
+// forgetting resets the streak and brings the name back after SelfReviewForgotDelay.
+func (p *UserProgress) RecordSelfReview(remembered bool, now time.Time) {
+	p.LastReviewedAt = &now
+
+	if remembered {
+		next := now.Add(time.Duration(max(1, p.IntervalDays)) * 24 * time.Hour)
+		p.NextReviewAt = &next
+		return
+	}
+
+	p.Streak = 0
+	p.Ease = max(1.3, p.Ease-0.2)
+	p.IntervalDays = 0
+
+	next := now.Add(SelfReviewForgotDelay)
+	p.NextReviewAt = &next
+
+	if p.Phase == PhaseMastered {
+		p.Phase = PhaseLearning
+	}
+}
+
 // updatePhase transitions between learning phases based on streak and interval.
 func (p *UserProgress) updatePhase() {
 	if p.Streak >= MinStreakForMastery && p.IntervalDays >= MinIntervalForMastery {
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxQuietDates limits how many specific quiet dates a user can keep.
+const MaxQuietDates = 30
+
+// QuietDays describes days on which reminders are muted.
+type QuietDays struct {
+	Weekdays int         // bitmask, bit N set means time.Weekday(N) is quiet
+	Dates    []time.Time // specific calendar dates (midnight UTC, see LocalDay)
+}
+
+// IsEmpty reports whether no quiet days are configured.
+func (q QuietDays) IsEmpty() bool {
+	return q.Weekdays == 0 && len(q.Dates) == 0
+}
+
+// HasWeekday reports whether the weekday is quiet.
+func (q QuietDays) HasWeekday(d time.Weekday) bool {
+	return q.Weekdays&(1<<d) != 0
+}
+
+// ToggleWeekday switches the weekday on or off.
+func (q *QuietDays) ToggleWeekday(d time.Weekday) {
+	q.Weekdays ^= 1 << d
+}
+
+// HasDate reports whether the calendar date is quiet.
+func (q QuietDays) HasDate(day time.Time) bool {
+	day = truncateToDay(day)
+	return slices.ContainsFunc(q.Dates, func(d time.Time) bool {
+		return truncateToDay(d).Equal(day)
+	})
+}
+
+// ToggleDate adds or removes a calendar date. Adding fails silently once MaxQuietDates is reached.
+func (q *QuietDays) ToggleDate(day time.Time) {
+	day = truncateToDay(day)
+
+	if i := slices.IndexFunc(q.Dates, func(d time.Time) bool { return truncateToDay(d).Equal(day) }); i >= 0 {
+		q.Dates = slices.Delete(q.Dates, i, i+1)
+		return
+	}
+
+	if len(q.Dates) >= MaxQuietDates {
+		return
+	}
+
+	q.Dates = append(q.Dates, day)
+	slices.SortFunc(q.Dates, func(a, b time.Time) int { return a.Compare(b) })
+}
+
+// DropPast removes dates before the given day.
+func (q *QuietDays) DropPast(today time.Time) {
+	today = truncateToDay(today)
+	q.Dates = slices.DeleteFunc(q.Dates, func(d time.Time) bool {
+		return truncateToDay(d).Before(today)
+	})
+}
+
+// IsQuiet reports whether the local day of t is quiet.
+func (q QuietDays) IsQuiet(t time.Time, loc *time.Location) bool {
+	if q.IsEmpty() {
+		return false
+	}
+
+	local := t.In(loc)
+	return q.HasWeekday(local.Weekday()) || q.HasDate(LocalDay(t, loc))
+}
+
+// AllWeekdaysQuiet reports whether every weekday is muted.
+func (q QuietDays) AllWeekdaysQuiet() bool {
+	return q.Weekdays&0x7f == 0x7f
+}
@@ -12,7 +12,7 @@ type QuizSession struct {
 	CurrentQuestionNum int        // current question number in the quiz
 	CorrectAnswers     int        // number of correct answers so far
 	TotalQuestions     int        // total number of questions in the quiz
-	QuizMode           string     // quiz mode: "new", "review", or "mixed"
+	QuizMode           string     // quiz mode: "new", "review", "mixed" or "favorites"
 	SessionStatus      string     // session status: "active", "completed", or "abandoned"
 	StartedAt          time.Time  // timestamp when the quiz started
 	CompletedAt        *time.Time // timestamp when the quiz was completed (nullable)
@@ -57,6 +57,22 @@ const (
 	QuestionTypeArabic          QuestionType = "arabic"
 )
 
+// AnswerFor returns the field of the name that answers a question of this type.
+func (t QuestionType) AnswerFor(name *Name) string {
+	switch t {
+	case QuestionTypeTranslation:
+		return name.ArabicName
+	case QuestionTypeTransliteration:
+		return name.Translation
+	case QuestionTypeMeaning:
+		return name.Transliteration
+	case QuestionTypeArabic:
+		return name.Translation
+	default:
+		return name.Translation
+	}
+}
+
 // IsActive returns true if the session is currently active.
 func (q *QuizSession) IsActive() bool {
 	return q.SessionStatus == "active"
@@ -0,0 +1,15 @@
+package entities
+
+// ReferralBadgeThreshold is the number of activated referrals that unlocks the referral badge.
+const ReferralBadgeThreshold = 3
+
+// ReferralStats counts the users a user invited with their referral link.
+type ReferralStats struct {
+	Invited   int // users who started the bot via the link
+	Activated int // invited users who have started learning at least one name
+}
+
+// HasBadge reports whether enough referrals are activated to unlock the badge.
+func (s ReferralStats) HasBadge() bool {
+	return s.Activated >= ReferralBadgeThreshold
+}
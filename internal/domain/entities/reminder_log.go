@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// DeliveryOutcome is the result of one notification delivery attempt.
+type DeliveryOutcome string
+
+const (
+	DeliveryDelivered DeliveryOutcome = "delivered"
+	DeliveryRetry     DeliveryOutcome = "retry"  // failed, another attempt is scheduled
+	DeliveryFailed    DeliveryOutcome = "failed" // failed, the job was given up
+)
+
+// DeliveryOutcomes lists all outcomes in display order.
+var DeliveryOutcomes = []DeliveryOutcome{DeliveryDelivered, DeliveryRetry, DeliveryFailed}
+
+// ReminderLogEntry records one delivery attempt of a queued notification.
+type ReminderLogEntry struct {
+	UserID       int64
+	JobID        int64
+	Kind         NotificationKind
+	ReminderKind ReminderKind // set for single-name reminders
+	NameNumber   int          // set for single-name reminders
+	Outcome      DeliveryOutcome
+	Attempt      int
+	Error        string
+	CreatedAt    time.Time
+}
+
+// DeliveryCounts holds the number of attempts per outcome.
+type DeliveryCounts map[DeliveryOutcome]int
+
+// Total returns the number of attempts.
+func (c DeliveryCounts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// DeliveryStats aggregates delivery attempts since a point in time and the current queue.
+type DeliveryStats struct {
+	Since        time.Time
+	ByKind       map[NotificationKind]DeliveryCounts
+	QueuePending int // jobs waiting for delivery
+	QueueFailed  int // jobs given up, kept for inspection
+}
+
+// Totals returns attempts per outcome over all kinds.
+func (s DeliveryStats) Totals() DeliveryCounts {
+	totals := DeliveryCounts{}
+	for _, counts := range s.ByKind {
+		for outcome, n := range counts {
+			totals[outcome] += n
+		}
+	}
+	return totals
+}
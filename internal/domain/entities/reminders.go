@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type ReminderKind string
 
@@ -10,12 +13,56 @@ const (
 	ReminderKindStudy  ReminderKind = "study"
 )
 
+// ReminderStyle controls what a reminder ping contains.
+type ReminderStyle string
+
+const (
+	ReminderStyleName   ReminderStyle = "name"   // one name per ping, every IntervalHours
+	ReminderStyleDigest ReminderStyle = "digest" // one morning message with the whole day
+)
+
+// ReminderContent limits which names a single-name reminder may contain.
+type ReminderContent string
+
+const (
+	ReminderContentMixed  ReminderContent = "mixed"  // alternate between new and review names
+	ReminderContentReview ReminderContent = "review" // only names due for SRS review
+	ReminderContentNew    ReminderContent = "new"    // only today's planned names
+)
+
+// IsValid reports whether the content preference is known.
+func (c ReminderContent) IsValid() bool {
+	switch c {
+	case ReminderContentMixed, ReminderContentReview, ReminderContentNew:
+		return true
+	default:
+		return false
+	}
+}
+
+// DailyDigest is the once-a-day reminder listing today's plan and due reviews.
+type DailyDigest struct {
+	Planned  []Name // today's plan
+	Reviews  []Name // names due for review, capped for display
+	DueToday int    // total number of names due for review
+}
+
 // ReminderPayload is used to build a reminder message payload
 // that includes the name to review and related statistics.
 type ReminderPayload struct {
-	Kind  ReminderKind
-	Name  Name
-	Stats ReminderStats
+	Kind     ReminderKind
+	Name     Name
+	Stats    ReminderStats
+	Question *ReminderQuestion // optional question answered right in the reminder
+	Language string            // user's language code for the message wording
+}
+
+// ReminderQuestion is a multiple-choice question about the reminder's name.
+// Each option is a name; its text is Type.AnswerFor(option).
+type ReminderQuestion struct {
+	Type         QuestionType
+	Options      []Name
+	CorrectIndex int
 }
 
 // ReminderStats contains user progress statistics
@@ -39,6 +86,24 @@ type ReminderWithUser struct {
 	LastSentAt    *time.Time
 	NextSendAt    *time.Time
 	Timezone      string
+	Quiet         QuietDays
+	Style         ReminderStyle
+	Content       ReminderContent
+	Prayer        PrayerAnchors
+	Language      string
+}
+
+// ReminderPreview is what the scheduler would send to the user at a given moment.
+// Exactly one of Reminder and Digest is set, or neither if there is nothing to remind about.
+type ReminderPreview struct {
+	Enabled    bool
+	Style      ReminderStyle
+	QuietDay   bool       // today is muted by quiet days
+	DueNow     bool       // the scheduler would send on its next run
+	NextSendAt *time.Time // planned next reminder, nil if not scheduled yet
+	Timezone   string
+	Reminder   *ReminderPayload
+	Digest     *DailyDigest
 }
 
 // UserReminders contains reminder configuration for a user.
@@ -51,6 +116,11 @@ type UserReminders struct {
 	LastKind      ReminderKind
 	LastSentAt    *time.Time // timestamp of the last sent reminder
 	NextSendAt    *time.Time
+	WeeklyDigest  bool            // weekly progress digest opt-in
+	Quiet         QuietDays       // weekdays and dates without reminders
+	Style         ReminderStyle   // one name per ping or a daily digest
+	Content       ReminderContent // which names single-name reminders contain
+	Prayer        PrayerAnchors   // optional schedule relative to prayer times
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -65,6 +135,9 @@ func NewUserReminders(userID int64) *UserReminders {
 		StartTime:     "08:00:00",
 		EndTime:       "20:00:00",
 		LastKind:      ReminderKindNew,
+		Style:         ReminderStyleName,
+		Content:       ReminderContentMixed,
+		Prayer:        PrayerAnchors{OffsetMinutes: DefaultPrayerOffsetMinutes},
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
@@ -72,12 +145,25 @@ func NewUserReminders(userID int64) *UserReminders {
 
 // CalculateNextSendAt calculates the next scheduled reminder time.
 // It ensures reminders are sent only at round hours (e.g., 8:00, 9:00).
+// In digest style there is a single slot per day at the window start.
+// When prayer anchors are enabled, slots follow prayer times instead (see nextPrayerSlot).
+//
+// Slots are computed in local wall-clock time, so with an IANA timezone a
+// reminder set for 09:00 stays at 09:00 across DST transitions. A slot skipped
+// by a transition moves past the gap, a slot that happens twice is taken at its
+// first occurrence (see wallTime).
 func (r *UserReminders) CalculateNextSendAt(timezone string, nowUTC time.Time) time.Time {
 	loc, err := ParseTimezoneLocation(timezone)
 	if err != nil {
 		loc = time.UTC
 	}
 
+	if r.Prayer.Enabled() {
+		if next, ok := r.nextPrayerSlot(loc, nowUTC); ok {
+			return next
+		}
+	}
+
 	userNow := nowUTC.In(loc)
 
 	y, m, d := userNow.Date()
@@ -85,37 +171,233 @@ func (r *UserReminders) CalculateNextSendAt(timezone string, nowUTC time.Time) t
 	startTOD, _ := time.Parse("15:04:05", r.StartTime)
 	endTOD, _ := time.Parse("15:04:05", r.EndTime)
 
-	startLocal := time.Date(y, m, d, startTOD.Hour(), startTOD.Minute(), startTOD.Second(), 0, loc)
-	endLocal := time.Date(y, m, d, endTOD.Hour(), endTOD.Minute(), endTOD.Second(), 0, loc)
-
-	if !endLocal.After(startLocal) {
-		startLocal = time.Date(y, m, d, 8, 0, 0, 0, loc)
-		endLocal = time.Date(y, m, d, 20, 0, 0, 0, loc)
+	start := wallClockSeconds(startTOD)
+	end := wallClockSeconds(endTOD)
+	if end <= start {
+		start, end = 8*3600, 20*3600
 	}
 
-	interval := time.Duration(r.IntervalHours) * time.Hour
+	interval := r.IntervalHours * 3600
 	if interval <= 0 {
-		interval = time.Hour
+		interval = 3600
+	}
+	if r.Style == ReminderStyleDigest {
+		interval = end - start
+	}
+
+	// slotAt returns the local time of a slot counted from the window start.
+	slotAt := func(day, offset int) time.Time {
+		return wallTime(y, m, d+day, start+offset, loc)
+	}
+
+	day, offset := 0, 0
+	if now := wallClockSeconds(userNow); now >= start {
+		k := (now - start) / interval
+		next := start + (k+1)*interval
+		if next >= end {
+			day = 1
+		} else {
+			offset = next - start
+		}
+	}
+
+	// Skip quiet days; the weekly pattern repeats, so a year is always enough.
+	if !r.Quiet.AllWeekdaysQuiet() {
+		for i := 0; i < 366 && r.Quiet.IsQuiet(slotAt(day, offset), loc); i++ {
+			day, offset = day+1, 0
+		}
+	}
+
+	return slotAt(day, offset).UTC()
+}
+
+// nextPrayerSlot returns the first selected prayer time plus the offset after nowUTC,
+// skipping quiet days. In digest style only the day's first selected prayer is used.
+// It reports false if no slot is found, e.g. when the sun does not set for months.
+func (r *UserReminders) nextPrayerSlot(loc *time.Location, nowUTC time.Time) (time.Time, bool) {
+	offset := time.Duration(r.Prayer.OffsetMinutes) * time.Minute
+	skipQuiet := !r.Quiet.AllWeekdaysQuiet()
+
+	y, m, d := nowUTC.In(loc).Date()
+	for i := 0; i < 366; i++ {
+		day := time.Date(y, m, d+i, 12, 0, 0, 0, loc)
+		if skipQuiet && r.Quiet.IsQuiet(day, loc) {
+			continue
+		}
+
+		times, err := r.Prayer.TimesOn(day, loc)
+		if err != nil {
+			continue
+		}
+
+		for _, p := range Prayers {
+			if !r.Prayer.Has(p) {
+				continue
+			}
+			if slot := times[p].Add(offset); slot.After(nowUTC) {
+				return slot.UTC(), true
+			}
+			if r.Style == ReminderStyleDigest {
+				break
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// SnoozeOption is a snooze choice offered on the reminder message.
+type SnoozeOption string
+
+const (
+	SnoozeOneHour    SnoozeOption = "1h"
+	SnoozeThreeHours SnoozeOption = "3h"
+	SnoozeEvening    SnoozeOption = "evening"
+	SnoozeMorning    SnoozeOption = "morning"
+)
+
+// snoozeEveningHour is the local hour used for "this evening".
+const snoozeEveningHour = 19
+
+// ErrUnknownSnoozeOption is returned for an unsupported snooze choice.
+var ErrUnknownSnoozeOption = errors.New("unknown snooze option")
+
+// SnoozeUntil returns when a snoozed reminder should be sent, aligned to the hourly scheduler tick.
+//
+// "This evening" falls back to one hour when the evening has already started;
+// "tomorrow morning" uses the start of the user's reminder window.
+func (r *UserReminders) SnoozeUntil(option SnoozeOption, timezone string, nowUTC time.Time) (time.Time, error) {
+	loc, err := ParseTimezoneLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	userNow := nowUTC.In(loc)
+	y, m, d := userNow.Date()
+
+	var target time.Time
+	switch option {
+	case SnoozeOneHour:
+		target = nowUTC.Add(time.Hour).Round(time.Hour)
+	case SnoozeThreeHours:
+		target = nowUTC.Add(3 * time.Hour).Round(time.Hour)
+	case SnoozeEvening:
+		target = time.Date(y, m, d, snoozeEveningHour, 0, 0, 0, loc)
+		if !target.After(nowUTC) {
+			target = nowUTC.Add(time.Hour).Round(time.Hour)
+		}
+	case SnoozeMorning:
+		start := 8 * 3600
+		if tod, err := time.Parse("15:04:05", r.StartTime); err == nil {
+			start = wallClockSeconds(tod)
+		}
+		target = time.Date(y, m, d+1, 0, 0, start, 0, loc)
+	default:
+		return time.Time{}, ErrUnknownSnoozeOption
+	}
+
+	// Reminders are dispatched at full UTC hours.
+	target = target.UTC()
+	if aligned := target.Truncate(time.Hour); !aligned.Equal(target) {
+		target = aligned.Add(time.Hour)
+	}
+	if minNext := nowUTC.Truncate(time.Hour).Add(time.Hour); target.Before(minNext) {
+		target = minNext
+	}
+
+	return target, nil
+}
+
+// BiasToActiveHours moves a scheduled slot toward the hours when the user usually
+// interacts with the bot. Candidates are whole hours within IntervalHours-1 of next,
+// inside the reminder window on next's local day and at least half an interval after now.
+// The busiest hour wins; ties keep the candidate closest to next. Digest style and
+// users with too little recorded activity keep next unchanged, as do prayer-anchored reminders.
+func (r *UserReminders) BiasToActiveHours(next time.Time, timezone string, nowUTC time.Time, activity ActivityHours) time.Time {
+	if r.Style == ReminderStyleDigest || r.Prayer.Enabled() || r.IntervalHours <= 1 || activity.Total() < MinActivitySamples {
+		return next
+	}
+
+	loc, err := ParseTimezoneLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	startTOD, _ := time.Parse("15:04:05", r.StartTime)
+	endTOD, _ := time.Parse("15:04:05", r.EndTime)
+	start := wallClockSeconds(startTOD)
+	end := wallClockSeconds(endTOD)
+	if end <= start {
+		start, end = 8*3600, 20*3600
 	}
 
-	if userNow.Before(startLocal) {
-		return startLocal.UTC()
+	earliest := nowUTC.Add(time.Duration(r.IntervalHours) * time.Hour / 2)
+	earliest = earliest.Truncate(time.Hour)
+	if minNext := nowUTC.Truncate(time.Hour).Add(time.Hour); earliest.Before(minNext) {
+		earliest = minNext
 	}
 
-	if !userNow.Before(endLocal) {
-		return startLocal.AddDate(0, 0, 1).UTC()
+	localNext := next.In(loc)
+	ny, nm, nd := localNext.Date()
+
+	best, bestScore, bestDist := next, activity[localNext.Hour()], 0
+	spread := r.IntervalHours - 1
+	for d := -spread; d <= spread; d++ {
+		t := next.Add(time.Duration(d) * time.Hour)
+		if d == 0 || t.Before(earliest) {
+			continue
+		}
+
+		lt := t.In(loc)
+		if y, m, day := lt.Date(); y != ny || m != nm || day != nd {
+			continue
+		}
+		if sec := wallClockSeconds(lt); sec < start || sec >= end {
+			continue
+		}
+
+		score, dist := activity[lt.Hour()], max(d, -d)
+		if score > bestScore || (score == bestScore && dist < bestDist) {
+			best, bestScore, bestDist = t, score, dist
+		}
 	}
 
-	elapsed := userNow.Sub(startLocal)
-	k := elapsed / interval
-	next := startLocal.Add((k + 1) * interval)
+	return best
+}
+
+// wallTime returns the instant at which a local clock shows sec seconds after
+// midnight of the given day. time.Date leaves the choice open when DST makes such a
+// time missing or repeated; here a missing time falls after the gap by the same
+// distance and a repeated time resolves to its first occurrence.
+func wallTime(y int, m time.Month, d, sec int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, sec, 0, loc)
 
-	if !next.Before(endLocal) {
-		next = startLocal.AddDate(0, 0, 1)
+	if w := wallClockSeconds(t); w < sec {
+		return t.Add(time.Duration(sec-w) * time.Second)
+	}
+
+	_, off := t.Zone()
+	if _, prev := t.Add(-12 * time.Hour).Zone(); prev > off {
+		if e := t.Add(-time.Duration(prev-off) * time.Second); wallClockSeconds(e) == sec && e.Day() == t.Day() {
+			return e
+		}
 	}
 
-	next = next.Truncate(time.Second)
-	return next.UTC()
+	return t
+}
+
+// wallClockSeconds returns seconds since local midnight as shown on the clock.
+func wallClockSeconds(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
+
+// IsQuietDay reports whether reminders are muted on the user's local day.
+func (r *ReminderWithUser) IsQuietDay(now time.Time) bool {
+	loc, err := ParseTimezoneLocation(r.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return r.Quiet.IsQuiet(now, loc)
 }
 
 // CanSendNow checks if it's time to send a reminder.
@@ -123,6 +405,11 @@ func (r *ReminderWithUser) CanSendNow(now time.Time) bool {
 	if !r.IsEnabled {
 		return false
 	}
+
+	if r.IsQuietDay(now) {
+		return false
+	}
+
 	if r.NextSendAt == nil {
 		return true
 	}
@@ -130,9 +417,10 @@ func (r *ReminderWithUser) CanSendNow(now time.Time) bool {
 		return false
 	}
 
-	// Hard safety: do not send more often than IntervalHours since LastSentAt.
-	if r.LastSentAt != nil && r.IntervalHours > 0 {
-		minNext := r.LastSentAt.Add(time.Duration(r.IntervalHours) * time.Hour)
+	// Hard safety: do not send twice within the same hourly tick.
+	// A snooze may be shorter than IntervalHours, so the interval itself is left to NextSendAt.
+	if r.LastSentAt != nil {
+		minNext := r.LastSentAt.Truncate(time.Hour).Add(time.Hour)
 		if now.Before(minNext) {
 			return false
 		}
@@ -140,3 +428,11 @@ func (r *ReminderWithUser) CanSendNow(now time.Time) bool {
 
 	return true
 }
+
+// ReminderCandidates are the names a reminder can be about, read in one query.
+type ReminderCandidates struct {
+	DueName int   // name most overdue for review; 0 if none is due
+	Planned []int // today's plan in order
+	Study   []int // planned names not mastered yet, in plan order
+	New     []int // planned names without progress, in plan order
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateNextSendAtDST(t *testing.T) {
+	utc := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		timezone string
+		start    string
+		end      string
+		interval int
+		now      string
+		want     string
+	}{
+		// Europe/Berlin springs forward on 2026-03-29 at 02:00 CET to 03:00 CEST.
+		{
+			name:     "spring forward keeps wall clock of next day",
+			timezone: "Europe/Berlin",
+			start:    "09:00:00", end: "20:00:00", interval: 1,
+			now:  "2026-03-28T20:00:00Z", // 21:00 CET
+			want: "2026-03-29T07:00:00Z", // 09:00 CEST
+		},
+		{
+			name:     "spring forward nonexistent wall time is moved past the gap",
+			timezone: "Europe/Berlin",
+			start:    "02:30:00", end: "06:30:00", interval: 1,
+			now:  "2026-03-29T00:00:00Z", // 01:00 CET
+			want: "2026-03-29T01:30:00Z", // 03:30 CEST
+		},
+		{
+			name:     "spring forward hourly slot after the gap",
+			timezone: "Europe/Berlin",
+			start:    "00:00:00", end: "06:00:00", interval: 1,
+			now:  "2026-03-29T01:15:00Z", // 03:15 CEST
+			want: "2026-03-29T02:00:00Z", // 04:00 CEST
+		},
+		// Europe/Berlin falls back on 2026-10-25 at 03:00 CEST to 02:00 CET, so 02:00-03:00 happens twice.
+		{
+			name:     "fall back repeated wall time is taken at its first occurrence",
+			timezone: "Europe/Berlin",
+			start:    "02:30:00", end: "06:30:00", interval: 1,
+			now:  "2026-10-24T22:00:00Z", // 00:00 CEST
+			want: "2026-10-25T00:30:00Z", // 02:30 CEST
+		},
+		{
+			name:     "fall back during the first occurrence of the repeated hour",
+			timezone: "Europe/Berlin",
+			start:    "01:00:00", end: "06:00:00", interval: 1,
+			now:  "2026-10-25T00:45:00Z", // 02:45 CEST
+			want: "2026-10-25T02:00:00Z", // 03:00 CET
+		},
+		{
+			name:     "fall back during the second occurrence of the repeated hour",
+			timezone: "Europe/Berlin",
+			start:    "01:00:00", end: "06:00:00", interval: 1,
+			now:  "2026-10-25T01:45:00Z", // 02:45 CET
+			want: "2026-10-25T02:00:00Z", // 03:00 CET
+		},
+		{
+			name:     "fall back keeps wall clock of next day",
+			timezone: "Europe/Berlin",
+			start:    "09:00:00", end: "20:00:00", interval: 1,
+			now:  "2026-10-24T19:00:00Z", // 21:00 CEST
+			want: "2026-10-25T08:00:00Z", // 09:00 CET
+		},
+		// Fixed offsets have no DST.
+		{
+			name:     "fixed offset",
+			timezone: "UTC+3",
+			start:    "08:00:00", end: "20:00:00", interval: 2,
+			now:  "2026-03-29T05:30:00Z", // 08:30 +03
+			want: "2026-03-29T07:00:00Z", // 10:00 +03
+		},
+		{
+			name:     "fixed half-hour offset rolls over to the next day",
+			timezone: "UTC+5:30",
+			start:    "08:00:00", end: "20:00:00", interval: 4,
+			now:  "2026-10-25T12:00:00Z", // 17:30 +05:30
+			want: "2026-10-26T02:30:00Z", // 08:00 +05:30
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewUserReminders(1)
+			r.StartTime, r.EndTime, r.IntervalHours = tt.start, tt.end, tt.interval
+
+			got := r.CalculateNextSendAt(tt.timezone, utc(tt.now))
+			if want := utc(tt.want); !got.Equal(want) {
+				t.Errorf("CalculateNextSendAt(%s, %s) = %s, want %s", tt.timezone, tt.now, got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
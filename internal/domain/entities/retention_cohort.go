@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// RetentionCohort is the retention of users who signed up in the same week.
+// A user is retained on day N if they were active between N and N+1 days after signing up.
+type RetentionCohort struct {
+	Week       time.Time // Monday of the signup week, UTC
+	Users      int       // users who signed up that week
+	D1         *int      // retained on day 1; nil until measurable for the whole cohort
+	D7         *int      // retained on day 7; nil until measurable
+	D30        *int      // retained on day 30; nil until measurable
+	ComputedAt time.Time
+}
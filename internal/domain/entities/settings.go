@@ -17,10 +17,12 @@ type UserSettings struct {
 	UserID           int64
 	NamesPerDay      int    // number of new names to learn per day
 	MaxReviewsPerDay int    // maximum number of reviews allowed per day
-	QuizMode         string // quiz type: "new", "review", "mixed"
+	QuizMode         string // quiz type: "new", "review", "mixed", "favorites"
 	LearningMode     string
 	LanguageCode     string // "ru", "en"
 	Timezone         string
+	Calendar         Calendar   // calendar of month-based boundaries such as the monthly report
+	TargetDate       *time.Time // optional date by which all names should be started; drives NamesPerDay
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
@@ -36,11 +38,94 @@ func NewUserSettings(userID int64) *UserSettings {
 		LearningMode:     "guided",
 		LanguageCode:     "ru",
 		Timezone:         "UTC",
+		Calendar:         CalendarGregorian,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
 }
 
+// MaxNamesPerDay caps the daily quota, including the pace computed for a target date.
+const MaxNamesPerDay = 20
+
+// DaysUntilTarget returns how many days, today included, are left until the target date.
+// It returns 0 if no target is set or it has passed; today is a local calendar date at midnight UTC.
+func (s *UserSettings) DaysUntilTarget(today time.Time) int {
+	if s.TargetDate == nil || s.TargetDate.Before(today) {
+		return 0
+	}
+	return int(s.TargetDate.Sub(today).Hours()/24) + 1
+}
+
+// ApplyTargetPace replaces NamesPerDay with the pace needed to start the remaining names
+// by the target date, so a user who falls behind gets more names a day.
+// Without an active target NamesPerDay is left unchanged.
+func (s *UserSettings) ApplyTargetPace(today time.Time, remaining int) {
+	if plan := s.TargetPlan(today, remaining); plan != nil {
+		s.NamesPerDay = plan.NamesPerDay
+	}
+}
+
+// TargetPlan returns the schedule towards the target date, or nil if no target is set.
+// remaining is the number of names not started yet.
+func (s *UserSettings) TargetPlan(today time.Time, remaining int) *TargetPlan {
+	if s.TargetDate == nil {
+		return nil
+	}
+
+	plan := &TargetPlan{
+		Today:       today,
+		Date:        *s.TargetDate,
+		DaysLeft:    s.DaysUntilTarget(today),
+		Remaining:   max(remaining, 0),
+		NamesPerDay: s.NamesPerDay,
+	}
+	if plan.DaysLeft > 0 && plan.Remaining > 0 {
+		plan.RequiredPace = (plan.Remaining + plan.DaysLeft - 1) / plan.DaysLeft
+		plan.NamesPerDay = min(max(plan.RequiredPace, 1), MaxNamesPerDay)
+	}
+
+	return plan
+}
+
+// TargetPlan is the schedule for starting the remaining names by the target date.
+type TargetPlan struct {
+	Today        time.Time // local calendar date at midnight UTC
+	Date         time.Time // target date
+	DaysLeft     int       // days until the target, today included; 0 once it has passed
+	Remaining    int       // names not started yet
+	NamesPerDay  int       // pace of the daily plan, at most MaxNamesPerDay
+	RequiredPace int       // pace needed to meet the target, 0 if it has passed or nothing remains
+}
+
+// Done reports whether every name has been started.
+func (p *TargetPlan) Done() bool {
+	return p.Remaining == 0
+}
+
+// Feasible reports whether the remaining names can be started by the target date
+// without exceeding MaxNamesPerDay.
+func (p *TargetPlan) Feasible() bool {
+	return p.Done() || (p.DaysLeft > 0 && p.RequiredPace <= MaxNamesPerDay)
+}
+
+// DateFor returns the day on which the n-th of the remaining names is started at the plan's pace.
+func (p *TargetPlan) DateFor(n int) time.Time {
+	if n <= 0 || p.NamesPerDay <= 0 {
+		return p.Today
+	}
+	return p.Today.AddDate(0, 0, (n-1)/p.NamesPerDay)
+}
+
+// ProjectedDate returns the day on which the last remaining name is started at the plan's pace.
+func (p *TargetPlan) ProjectedDate() time.Time {
+	return p.DateFor(p.Remaining)
+}
+
+// EarliestFeasibleDate returns the earliest target date reachable at MaxNamesPerDay.
+func (p *TargetPlan) EarliestFeasibleDate() time.Time {
+	return p.Today.AddDate(0, 0, max(p.Remaining-1, 0)/MaxNamesPerDay)
+}
+
 // DaysToComplete estimates days to complete learning based on current progress.
 func (s *UserSettings) DaysToComplete(learnedCount int) int {
 	if s.NamesPerDay < 0 {
@@ -0,0 +1,126 @@
+package entities
+
+import "time"
+
+// StreakMilestones are streak lengths (in days) that are celebrated.
+var StreakMilestones = []int{7, 30, 99}
+
+// UserStreak tracks consecutive days with at least one quiz answer or review.
+// Dates are local calendar days of the user stored as UTC midnight.
+type UserStreak struct {
+	UserID         int64
+	CurrentStreak  int
+	BestStreak     int
+	LastActiveDate *time.Time
+}
+
+// NewUserStreak creates an empty streak for a user.
+func NewUserStreak(userID int64) *UserStreak {
+	return &UserStreak{UserID: userID}
+}
+
+// RegisterActivity records activity on the given local day.
+// It returns true if the current streak grew (first activity of the day).
+func (s *UserStreak) RegisterActivity(day time.Time) bool {
+	day = truncateToDay(day)
+
+	if s.LastActiveDate != nil {
+		last := truncateToDay(*s.LastActiveDate)
+		switch {
+		case !day.After(last):
+			return false
+		case last.AddDate(0, 0, 1).Equal(day):
+			s.CurrentStreak++
+		default:
+			s.CurrentStreak = 1
+		}
+	} else {
+		s.CurrentStreak = 1
+	}
+
+	s.LastActiveDate = &day
+	if s.CurrentStreak > s.BestStreak {
+		s.BestStreak = s.CurrentStreak
+	}
+
+	return true
+}
+
+// Current returns the streak as seen on the given local day: a streak is kept
+// alive until the end of the day after the last activity.
+func (s *UserStreak) Current(day time.Time) int {
+	if s == nil || s.LastActiveDate == nil {
+		return 0
+	}
+
+	day = truncateToDay(day)
+	last := truncateToDay(*s.LastActiveDate)
+	if day.After(last.AddDate(0, 0, 1)) {
+		return 0
+	}
+
+	return s.CurrentStreak
+}
+
+// StreakAlertHoursLeft is how many hours before local midnight the
+// streak-protection reminder is sent.
+const StreakAlertHoursLeft = 2
+
+// StreakAlertCandidate is a user with reminders enabled and an active streak.
+type StreakAlertCandidate struct {
+	UserID      int64
+	ChatID      int64
+	Timezone    string
+	Streak      UserStreak
+	LastAlertAt *time.Time // last streak-protection reminder
+	Quiet       QuietDays  // days on which reminders are muted
+}
+
+// IsAlertDue reports whether the streak-protection reminder should be sent at now:
+// it is StreakAlertHoursLeft hours before the user's local midnight, the user was
+// active yesterday but not yet today, and no alert has been sent today.
+func (c *StreakAlertCandidate) IsAlertDue(now time.Time) bool {
+	loc, err := ParseTimezoneLocation(c.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	if now.In(loc).Hour() != 24-StreakAlertHoursLeft {
+		return false
+	}
+
+	if c.Quiet.IsQuiet(now, loc) {
+		return false
+	}
+
+	if c.Streak.LastActiveDate == nil || c.Streak.CurrentStreak == 0 {
+		return false
+	}
+
+	today := LocalDay(now, loc)
+	if !truncateToDay(*c.Streak.LastActiveDate).Equal(today.AddDate(0, 0, -1)) {
+		return false
+	}
+
+	return c.LastAlertAt == nil || now.Sub(*c.LastAlertAt) >= 12*time.Hour
+}
+
+// IsStreakMilestone reports whether the streak length should be celebrated.
+func IsStreakMilestone(days int) bool {
+	for _, m := range StreakMilestones {
+		if days == m {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalDay returns the user's local calendar day for t as UTC midnight.
+func LocalDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
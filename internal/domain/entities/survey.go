@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+// Survey limits.
+const (
+	MaxSurveyQuestions      = 10
+	MinSurveyOptions        = 2
+	MaxSurveyOptions        = 8
+	MaxSurveyOptionLength   = 40
+	MaxSurveyQuestionLength = 300
+)
+
+// SurveySegment selects the users a survey is sent to.
+type SurveySegment string
+
+const (
+	SurveySegmentAll       SurveySegment = "all"       // every active user
+	SurveySegmentActive    SurveySegment = "active"    // used the bot in the last 7 days
+	SurveySegmentReminders SurveySegment = "reminders" // reminders enabled
+	SurveySegmentNew       SurveySegment = "new"       // registered in the last 30 days
+)
+
+// SurveySegments lists all segments.
+var SurveySegments = []SurveySegment{SurveySegmentAll, SurveySegmentActive, SurveySegmentReminders, SurveySegmentNew}
+
+// ParseSurveySegment returns the segment with the given name.
+func ParseSurveySegment(s string) (SurveySegment, bool) {
+	for _, seg := range SurveySegments {
+		if string(seg) == s {
+			return seg, true
+		}
+	}
+	return "", false
+}
+
+// SurveyQuestion is a single-choice survey question.
+type SurveyQuestion struct {
+	Text    string   `json:"text"`
+	Options []string `json:"options"`
+}
+
+// Survey is a short product questionnaire sent to a segment of users.
+type Survey struct {
+	ID        int64
+	Title     string
+	Segment   SurveySegment
+	Questions []SurveyQuestion
+	CreatedBy int64
+	CreatedAt time.Time
+	SentAt    *time.Time // nil until the survey is sent
+}
+
+// SurveyInvite is the payload of a survey notification: the first question of the survey.
+type SurveyInvite struct {
+	SurveyID  int64
+	Title     string
+	Question  SurveyQuestion
+	Questions int
+}
+
+// SurveyResults aggregates the answers to a survey.
+type SurveyResults struct {
+	Survey    *Survey
+	Sent      int     // users the survey was sent to
+	Started   int     // users who answered at least one question
+	Completed int     // users who answered every question
+	Counts    [][]int // answers per question and option
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// MaxTicketLength is the maximum length of a support ticket message in characters.
+const MaxTicketLength = 2000
+
+// TicketStatus is the state of a support ticket.
+type TicketStatus string
+
+const (
+	TicketOpen     TicketStatus = "open"     // waiting for an admin answer
+	TicketAnswered TicketStatus = "answered" // an admin answered, the user may follow up
+	TicketClosed   TicketStatus = "closed"   // no further action needed
+)
+
+// TicketStatuses lists all ticket states in their lifecycle order.
+var TicketStatuses = []TicketStatus{TicketOpen, TicketAnswered, TicketClosed}
+
+// ParseTicketStatus returns the status with the given name.
+func ParseTicketStatus(s string) (TicketStatus, bool) {
+	for _, st := range TicketStatuses {
+		if string(st) == s {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+// Ticket is a message a user sent to the bot admins with /feedback.
+type Ticket struct {
+	ID         int64
+	UserID     int64
+	ChatID     int64
+	Text       string
+	Status     TicketStatus
+	CreatedAt  time.Time
+	AnsweredAt *time.Time
+	AnsweredBy *int64
+	ClosedAt   *time.Time
+	ClosedBy   *int64
+}
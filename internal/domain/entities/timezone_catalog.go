@@ -0,0 +1,346 @@
+package entities
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTimezone is returned when a timezone cannot be resolved to an IANA zone.
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// TimezoneCity is a city offered in the timezone picker.
+type TimezoneCity struct {
+	Name string // city name shown to the user
+	Zone string // IANA timezone, e.g. "Europe/Moscow"
+}
+
+// TimezoneRegion groups picker cities by region.
+type TimezoneRegion struct {
+	Key    string
+	Name   string
+	Cities []TimezoneCity
+}
+
+// TimezoneRegions is the catalog used by the timezone picker and search.
+var TimezoneRegions = []TimezoneRegion{
+	{Key: "ru", Name: "🇷🇺 Россия", Cities: []TimezoneCity{
+		{"Калининград", "Europe/Kaliningrad"},
+		{"Москва", "Europe/Moscow"},
+		{"Казань", "Europe/Moscow"},
+		{"Грозный", "Europe/Moscow"},
+		{"Махачкала", "Europe/Moscow"},
+		{"Самара", "Europe/Samara"},
+		{"Екатеринбург", "Asia/Yekaterinburg"},
+		{"Уфа", "Asia/Yekaterinburg"},
+		{"Омск", "Asia/Omsk"},
+		{"Новосибирск", "Asia/Novosibirsk"},
+		{"Красноярск", "Asia/Krasnoyarsk"},
+		{"Иркутск", "Asia/Irkutsk"},
+		{"Якутск", "Asia/Yakutsk"},
+		{"Владивосток", "Asia/Vladivostok"},
+		{"Магадан", "Asia/Magadan"},
+		{"Петропавловск-Камчатский", "Asia/Kamchatka"},
+	}},
+	{Key: "cis", Name: "🌐 СНГ и Кавказ", Cities: []TimezoneCity{
+		{"Минск", "Europe/Minsk"},
+		{"Киев", "Europe/Kyiv"},
+		{"Кишинёв", "Europe/Chisinau"},
+		{"Баку", "Asia/Baku"},
+		{"Тбилиси", "Asia/Tbilisi"},
+		{"Ереван", "Asia/Yerevan"},
+		{"Астана", "Asia/Almaty"},
+		{"Алматы", "Asia/Almaty"},
+		{"Ташкент", "Asia/Tashkent"},
+		{"Бишкек", "Asia/Bishkek"},
+		{"Душанбе", "Asia/Dushanbe"},
+		{"Ашхабад", "Asia/Ashgabat"},
+	}},
+	{Key: "europe", Name: "🇪🇺 Европа", Cities: []TimezoneCity{
+		{"Лондон", "Europe/London"},
+		{"Мадрид", "Europe/Madrid"},
+		{"Париж", "Europe/Paris"},
+		{"Амстердам", "Europe/Amsterdam"},
+		{"Берлин", "Europe/Berlin"},
+		{"Рим", "Europe/Rome"},
+		{"Вена", "Europe/Vienna"},
+		{"Прага", "Europe/Prague"},
+		{"Варшава", "Europe/Warsaw"},
+		{"Стокгольм", "Europe/Stockholm"},
+		{"Хельсинки", "Europe/Helsinki"},
+		{"Стамбул", "Europe/Istanbul"},
+	}},
+	{Key: "mideast", Name: "🕋 Ближний Восток", Cities: []TimezoneCity{
+		{"Мекка", "Asia/Riyadh"},
+		{"Медина", "Asia/Riyadh"},
+		{"Эр-Рияд", "Asia/Riyadh"},
+		{"Дубай", "Asia/Dubai"},
+		{"Доха", "Asia/Qatar"},
+		{"Кувейт", "Asia/Kuwait"},
+		{"Маскат", "Asia/Muscat"},
+		{"Амман", "Asia/Amman"},
+		{"Бейрут", "Asia/Beirut"},
+		{"Дамаск", "Asia/Damascus"},
+		{"Багдад", "Asia/Baghdad"},
+		{"Тегеран", "Asia/Tehran"},
+	}},
+	{Key: "asia", Name: "🌏 Азия", Cities: []TimezoneCity{
+		{"Кабул", "Asia/Kabul"},
+		{"Карачи", "Asia/Karachi"},
+		{"Дели", "Asia/Kolkata"},
+		{"Дакка", "Asia/Dhaka"},
+		{"Бангкок", "Asia/Bangkok"},
+		{"Джакарта", "Asia/Jakarta"},
+		{"Куала-Лумпур", "Asia/Kuala_Lumpur"},
+		{"Сингапур", "Asia/Singapore"},
+		{"Пекин", "Asia/Shanghai"},
+		{"Сеул", "Asia/Seoul"},
+		{"Токио", "Asia/Tokyo"},
+	}},
+	{Key: "africa", Name: "🌍 Африка", Cities: []TimezoneCity{
+		{"Касабланка", "Africa/Casablanca"},
+		{"Дакар", "Africa/Dakar"},
+		{"Алжир", "Africa/Algiers"},
+		{"Тунис", "Africa/Tunis"},
+		{"Лагос", "Africa/Lagos"},
+		{"Каир", "Africa/Cairo"},
+		{"Хартум", "Africa/Khartoum"},
+		{"Аддис-Абеба", "Africa/Addis_Ababa"},
+		{"Найроби", "Africa/Nairobi"},
+		{"Йоханнесбург", "Africa/Johannesburg"},
+	}},
+	{Key: "america", Name: "🌎 Америка", Cities: []TimezoneCity{
+		{"Лос-Анджелес", "America/Los_Angeles"},
+		{"Денвер", "America/Denver"},
+		{"Чикаго", "America/Chicago"},
+		{"Мехико", "America/Mexico_City"},
+		{"Нью-Йорк", "America/New_York"},
+		{"Торонто", "America/Toronto"},
+		{"Сан-Паулу", "America/Sao_Paulo"},
+		{"Буэнос-Айрес", "America/Argentina/Buenos_Aires"},
+	}},
+	{Key: "oceania", Name: "🌊 Океания", Cities: []TimezoneCity{
+		{"Перт", "Australia/Perth"},
+		{"Сидней", "Australia/Sydney"},
+		{"Мельбурн", "Australia/Melbourne"},
+		{"Окленд", "Pacific/Auckland"},
+	}},
+}
+
+// FindTimezoneRegion returns the catalog region with the given key.
+func FindTimezoneRegion(key string) (TimezoneRegion, bool) {
+	for _, r := range TimezoneRegions {
+		if r.Key == key {
+			return r, true
+		}
+	}
+	return TimezoneRegion{}, false
+}
+
+// timezoneCitiesTSV is the city → timezone index used by search, one "city<TAB>zone" per line.
+//
+//go:embed timezone_cities.tsv
+var timezoneCitiesTSV string
+
+var (
+	timezoneIndexOnce sync.Once
+	timezoneIndex     []TimezoneCity
+)
+
+// timezoneCities returns the picker catalog followed by the embedded index, without duplicates.
+func timezoneCities() []TimezoneCity {
+	timezoneIndexOnce.Do(func() {
+		seen := make(map[string]bool)
+		add := func(c TimezoneCity) {
+			key := normalizeTimezoneQuery(c.Name)
+			if key == "" || seen[key] {
+				return
+			}
+			seen[key] = true
+			timezoneIndex = append(timezoneIndex, c)
+		}
+
+		for _, r := range TimezoneRegions {
+			for _, c := range r.Cities {
+				add(c)
+			}
+		}
+
+		for _, line := range strings.Split(timezoneCitiesTSV, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			name, zone, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			add(TimezoneCity{Name: strings.TrimSpace(name), Zone: strings.TrimSpace(zone)})
+		}
+	})
+
+	return timezoneIndex
+}
+
+// SearchTimezones finds cities by name (Russian or English) or by IANA zone name.
+// An exact city match is returned alone; otherwise cities starting with the query
+// come first, followed by other partial matches.
+func SearchTimezones(query string) []TimezoneCity {
+	q := normalizeTimezoneQuery(query)
+	if q == "" {
+		return nil
+	}
+
+	if strings.Contains(q, "/") {
+		for _, c := range timezoneCities() {
+			if normalizeTimezoneQuery(c.Zone) == q {
+				return []TimezoneCity{{Name: c.Zone, Zone: c.Zone}}
+			}
+		}
+	}
+
+	var prefix, partial []TimezoneCity
+	for _, c := range timezoneCities() {
+		name := normalizeTimezoneQuery(c.Name)
+		switch {
+		case name == q:
+			return []TimezoneCity{c}
+		case strings.HasPrefix(name, q):
+			prefix = append(prefix, c)
+		case strings.Contains(name, q) || strings.Contains(normalizeTimezoneQuery(c.Zone), q):
+			partial = append(partial, c)
+		}
+	}
+
+	return append(prefix, partial...)
+}
+
+var (
+	timezoneIDsOnce sync.Once
+	timezoneIDs     map[string]string // ID → zone
+)
+
+// TimezoneID returns a short ID of a zone for button data, where a zone name can be too
+// long: 6 bytes of its SHA-256 in base64url, 8 characters. It does not depend on the
+// order of the catalog, so buttons keep working when cities are added.
+func TimezoneID(zone string) string {
+	sum := sha256.Sum256([]byte(zone))
+	return base64.RawURLEncoding.EncodeToString(sum[:6])
+}
+
+// TimezoneByID returns the zone with the given ID among the zones of the picker catalog,
+// the search index, UTC and the whole-hour offsets. Other zones have to be matched by the caller.
+func TimezoneByID(id string) (string, bool) {
+	timezoneIDsOnce.Do(func() {
+		timezoneIDs = make(map[string]string)
+		add := func(zone string) { timezoneIDs[TimezoneID(zone)] = zone }
+
+		add("UTC")
+		for h := -12; h <= 14; h++ {
+			add(offsetZoneName(h))
+		}
+		for _, c := range timezoneCities() {
+			add(c.Zone)
+		}
+	})
+
+	zone, ok := timezoneIDs[id]
+	return zone, ok
+}
+
+// NormalizeTimezone resolves user input to an IANA zone name validated with time.LoadLocation.
+// Whole-hour UTC offsets are converted to the matching "Etc/GMT" zone.
+func NormalizeTimezone(input string) (string, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return "", ErrInvalidTimezone
+	}
+
+	if strings.EqualFold(s, "UTC") || strings.EqualFold(s, "GMT") {
+		return "UTC", nil
+	}
+
+	if strings.Contains(s, "/") {
+		loc, err := time.LoadLocation(s)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidTimezone, err)
+		}
+		return loc.String(), nil
+	}
+
+	offSec, ok := parseUTCOffsetSeconds(s)
+	if !ok || offSec%3600 != 0 {
+		return "", ErrInvalidTimezone
+	}
+
+	return offsetZoneName(offSec / 3600), nil
+}
+
+// TimezoneLabel returns a human-readable label for a stored timezone, e.g. "Москва (UTC+3)".
+func TimezoneLabel(tz string, now time.Time) string {
+	loc, err := ParseTimezoneLocation(tz)
+	if err != nil {
+		return tz
+	}
+
+	offset := TimezoneOffsetLabel(loc, now)
+
+	for _, r := range TimezoneRegions {
+		for _, c := range r.Cities {
+			if c.Zone == tz {
+				return fmt.Sprintf("%s (%s)", c.Name, offset)
+			}
+		}
+	}
+
+	if loc == time.UTC || strings.HasPrefix(tz, "Etc/") || loc.String() != tz {
+		return offset
+	}
+
+	return fmt.Sprintf("%s (%s)", tz, offset)
+}
+
+// TimezoneOffsetLabel returns the current UTC offset of the location, e.g. "UTC+3".
+func TimezoneOffsetLabel(loc *time.Location, now time.Time) string {
+	_, offSec := now.In(loc).Zone()
+	return FormatShortUTCOffset(offSec)
+}
+
+// FormatShortUTCOffset formats an offset in seconds as "UTC+3" or "UTC+5:30".
+func FormatShortUTCOffset(offsetSec int) string {
+	sign := "+"
+	if offsetSec < 0 {
+		sign = "-"
+		offsetSec = -offsetSec
+	}
+
+	h := offsetSec / 3600
+	m := (offsetSec % 3600) / 60
+	if m == 0 {
+		return fmt.Sprintf("UTC%s%d", sign, h)
+	}
+	return fmt.Sprintf("UTC%s%d:%02d", sign, h, m)
+}
+
+// offsetZoneName returns the IANA "Etc/GMT" zone for a whole-hour offset.
+// Note the inverted sign: "Etc/GMT-3" is UTC+3.
+func offsetZoneName(hours int) string {
+	switch {
+	case hours == 0:
+		return "UTC"
+	case hours > 0:
+		return fmt.Sprintf("Etc/GMT-%d", hours)
+	default:
+		return fmt.Sprintf("Etc/GMT+%d", -hours)
+	}
+}
+
+func normalizeTimezoneQuery(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	return strings.NewReplacer("_", " ", "-", " ").Replace(s)
+}
@@ -19,3 +19,20 @@ func NewUser(id, chatID int64) *User {
 		CreatedAt: time.Now(),
 	}
 }
+
+// UserInfo is a user as shown to admins.
+type UserInfo struct {
+	ID           int64
+	ChatID       int64
+	IsActive     bool
+	CreatedAt    time.Time
+	LastActiveAt time.Time
+	ReferredBy   *int64 // nil if the user was not invited
+}
+
+// InactiveUser is a user selected by the retention job for a deletion warning.
+type InactiveUser struct {
+	UserID       int64
+	ChatID       int64
+	LastActiveAt time.Time
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// User audit actions.
+const (
+	AuditNamesPerDay      = "settings.names_per_day"
+	AuditTargetDate       = "settings.target_date"
+	AuditQuizMode         = "settings.quiz_mode"
+	AuditLearningMode     = "settings.learning_mode"
+	AuditTimezone         = "settings.timezone"
+	AuditCalendar         = "settings.calendar"
+	AuditReminderEnabled  = "reminders.enabled"
+	AuditReminderInterval = "reminders.interval_hours"
+	AuditReminderWindow   = "reminders.time_window"
+	AuditWeeklyDigest     = "reminders.weekly_digest"
+	AuditPlanAdd          = "plan.add"
+	AuditPlanRemove       = "plan.remove"
+	AuditPlanSwap         = "plan.swap"
+	AuditPlanReorder      = "plan.reorder"
+	AuditPlanReplan       = "plan.replan"
+	AuditReset            = "reset"
+)
+
+// UserAuditEntry is a change a user made to their own state, with the values before
+// and after as JSON; either may be nil.
+type UserAuditEntry struct {
+	ID        int64
+	UserID    int64
+	Action    string
+	OldValue  json.RawMessage
+	NewValue  json.RawMessage
+	RequestID string // ID of the update that made the change, empty if unknown
+	CreatedAt time.Time
+}
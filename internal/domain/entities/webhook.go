@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// WebhookEventType identifies a learning event sent to the webhook endpoint.
+type WebhookEventType string
+
+const (
+	WebhookQuizCompleted WebhookEventType = "quiz.completed" // data is a QuizCompletedData
+	WebhookNameMastered  WebhookEventType = "name.mastered"  // data is a NameMasteredData
+)
+
+// WebhookEvent is the JSON body posted to the webhook endpoint. Delivery is at least once,
+// so receivers should ignore events whose ID they have already seen.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	UserID    int64            `json:"user_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      any              `json:"data"`
+}
+
+// QuizCompletedData describes a finished quiz session.
+type QuizCompletedData struct {
+	SessionID int64  `json:"session_id"`
+	Mode      string `json:"mode"`
+	Score     int    `json:"score"`
+	Total     int    `json:"total"`
+}
+
+// NameMasteredData describes a name that reached the mastered phase.
+type NameMasteredData struct {
+	NameNumber    int `json:"name_number"`
+	MasteredCount int `json:"mastered_count"` // names the user has mastered, including this one
+}
@@ -0,0 +1,65 @@
+package entities
+
+// XP rewards.
+const (
+	XPCorrectAnswer   = 10 // correct quiz answer
+	XPIncorrectAnswer = 2  // any attempt counts
+	XPReviewBonus     = 5  // answering a name that was due for review
+	XPStreakDay       = 5  // per streak day, awarded once a day
+	XPMaxStreakBonus  = 50 // cap for the daily streak bonus
+)
+
+// xpLevelStep defines level thresholds: level L starts at xpLevelStep * L * (L-1) XP
+// (0, 100, 300, 600, 1000, ...).
+const xpLevelStep = 50
+
+// XPForAnswer returns XP awarded for a quiz answer.
+func XPForAnswer(isCorrect, wasDue bool) int {
+	xp := XPIncorrectAnswer
+	if isCorrect {
+		xp = XPCorrectAnswer
+	}
+	if wasDue {
+		xp += XPReviewBonus
+	}
+	return xp
+}
+
+// XPForStreakDay returns the bonus awarded for the first activity of a day
+// when the daily streak reaches the given length.
+func XPForStreakDay(streak int) int {
+	if streak <= 1 {
+		return 0
+	}
+	return min(streak*XPStreakDay, XPMaxStreakBonus)
+}
+
+// Level describes a user's level derived from total XP.
+type Level struct {
+	Level       int // current level, starting from 1
+	XPIntoLevel int // XP earned since the current level started
+	XPForNext   int // XP required to go from the current level to the next one
+}
+
+// LevelForXP computes the level for the given total XP.
+func LevelForXP(xp int) Level {
+	if xp < 0 {
+		xp = 0
+	}
+
+	level := 1
+	for levelThreshold(level+1) <= xp {
+		level++
+	}
+
+	start := levelThreshold(level)
+	return Level{
+		Level:       level,
+		XPIntoLevel: xp - start,
+		XPForNext:   levelThreshold(level+1) - start,
+	}
+}
+
+func levelThreshold(level int) int {
+	return xpLevelStep * level * (level - 1)
+}
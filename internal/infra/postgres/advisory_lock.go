@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// advisoryLockRetryInterval is how often a waiting instance retries the lock
+// and how often the holder checks that its connection is still alive.
+const advisoryLockRetryInterval = 15 * time.Second
+
+// AdvisoryLock is a session-level Postgres advisory lock used to elect a single
+// instance for background jobs when several bot replicas share one database.
+//
+// The lock lives as long as the connection that took it, so a crashed instance
+// releases it automatically and another replica takes over.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	name string
+}
+
+// NewAdvisoryLock creates a lock identified by name; instances using the same name exclude each other.
+func NewAdvisoryLock(pool *pgxpool.Pool, name string) *AdvisoryLock {
+	return &AdvisoryLock{pool: pool, name: name}
+}
+
+// RunExclusive waits until the lock is acquired and runs fn while holding it.
+// The context passed to fn is cancelled when ctx is done or the lock connection is lost;
+// in the latter case the lock is requested again and fn runs anew once it is re-acquired.
+// RunExclusive returns ctx.Err() after ctx is done.
+func (l *AdvisoryLock) RunExclusive(ctx context.Context, fn func(ctx context.Context)) error {
+	for {
+		conn, err := l.tryAcquire(ctx)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if conn != nil {
+			l.hold(ctx, conn, fn)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(advisoryLockRetryInterval):
+		}
+	}
+}
+
+// tryAcquire takes the lock on a dedicated pool connection. It returns nil if another instance holds it.
+func (l *AdvisoryLock) tryAcquire(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", l.name).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("try advisory lock: %w", err)
+	}
+
+	if !locked {
+		conn.Release()
+		return nil, nil
+	}
+
+	return conn, nil
+}
+
+// hold runs fn while watching the lock connection, then releases the lock.
+func (l *AdvisoryLock) hold(ctx context.Context, conn *pgxpool.Conn, fn func(ctx context.Context)) {
+	lockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(watchDone)
+
+		ticker := time.NewTicker(advisoryLockRetryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-lockCtx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.Ping(lockCtx); err != nil && !errors.Is(err, context.Canceled) {
+					cancel() // the lock went away together with the session
+					return
+				}
+			}
+		}
+	}()
+
+	fn(lockCtx)
+
+	cancel()
+	<-watchDone
+
+	// ctx may already be done, so unlock with a short independent deadline.
+	unlockCtx, unlockCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer unlockCancel()
+
+	if _, err := conn.Exec(unlockCtx, "SELECT pg_advisory_unlock(hashtext($1))", l.name); err != nil {
+		// Closing the session is the only other way to drop the lock.
+		_ = conn.Hijack().Close(unlockCtx)
+		return
+	}
+	conn.Release()
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// Migration is one SQL migration file in goose format.
+type Migration struct {
+	Version int64
+	Name    string // file name
+}
+
+// MigrationStatus reports whether a migration is applied.
+type MigrationStatus struct {
+	Migration *Migration
+	Applied   bool
+}
+
+// Migrator applies goose-format SQL migrations with the goose library. It keeps its state in
+// the goose_db_version table, so databases migrated with the goose CLI are picked up where
+// they were left.
+type Migrator struct {
+	db       *sql.DB
+	provider *goose.Provider
+}
+
+// NewMigrator reads the *.sql migrations in the root of fsys. Migrations run on their own
+// connections to the pool's database without its statement timeout, since schema changes
+// may rewrite large tables. Replicas starting at the same time wait for each other on
+// goose's advisory lock. Close releases the connections.
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	connConfig := pool.Config().ConnConfig.Copy()
+	if connConfig.RuntimeParams == nil {
+		connConfig.RuntimeParams = make(map[string]string)
+	}
+	connConfig.RuntimeParams["statement_timeout"] = "0"
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("create migrations lock: %w", err)
+	}
+
+	db := stdlib.OpenDB(*connConfig)
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, fsys, goose.WithSessionLocker(locker))
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("load migrations: %w", err)
+	}
+
+	return &Migrator{db: db, provider: provider}, nil
+}
+
+// Close closes the migration connections.
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Up applies all pending migrations in version order and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	results, err := m.provider.Up(ctx)
+	if err != nil {
+		return len(results), fmt.Errorf("apply migrations: %w", err)
+	}
+	return len(results), nil
+}
+
+// Down rolls back the latest applied migration and returns it, or nil if none is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	result, err := m.provider.Down(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("roll back migration: %w", err)
+	}
+	return migrationFromSource(result.Source), nil
+}
+
+// Status lists every known migration with whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get migration status: %w", err)
+	}
+
+	result := make([]MigrationStatus, 0, len(statuses))
+	for _, st := range statuses {
+		result = append(result, MigrationStatus{
+			Migration: migrationFromSource(st.Source),
+			Applied:   st.State == goose.StateApplied,
+		})
+	}
+	return result, nil
+}
+
+// migrationFromSource converts a goose migration source.
+func migrationFromSource(src *goose.Source) *Migration {
+	return &Migration{Version: src.Version, Name: path.Base(src.Path)}
+}
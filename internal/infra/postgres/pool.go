@@ -3,14 +3,35 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Connect retry backoff bounds; the wait doubles after every failed attempt.
+const (
+	connectRetryBase = time.Second
+	connectRetryMax  = 30 * time.Second
+)
+
 type PoolConfig struct {
 	MaxConns        int32
 	MaxConnLifetime time.Duration
+	// ConnectAttempts is how many times the first connection is tried before giving up;
+	// values below 1 mean a single attempt.
+	ConnectAttempts int
+	// OnConnectRetry, if set, is called after a failed attempt before waiting to retry.
+	OnConnectRetry func(attempt int, wait time.Duration, err error)
+	// StatementTimeout makes the server cancel statements running longer; zero keeps the server default.
+	StatementTimeout time.Duration
+	// SlowQueryThreshold is the duration from which a query is passed to OnSlowQuery; zero disables it.
+	SlowQueryThreshold time.Duration
+	// OnSlowQuery, if set, is called for queries slower than SlowQueryThreshold and for timed out ones.
+	OnSlowQuery func(QueryEvent)
+	// OnQuery, if set, is called after every query.
+	OnQuery func(QueryEvent)
 }
 
 func NewPool(ctx context.Context, dsn string, cfg PoolConfig) (*pgxpool.Pool, error) {
@@ -23,11 +44,59 @@ func NewPool(ctx context.Context, dsn string, cfg PoolConfig) (*pgxpool.Pool, er
 	poolConfig.MaxConns = int32(cfg.MaxConns) // set maximum number of connections in pool
 	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
 
+	// Bound every statement on the server, so a runaway query cannot hold a connection indefinitely.
+	if cfg.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	if cfg.OnSlowQuery != nil || cfg.OnQuery != nil {
+		poolConfig.ConnConfig.Tracer = &queryTracer{
+			threshold: cfg.SlowQueryThreshold,
+			onSlow:    cfg.OnSlowQuery,
+			onQuery:   cfg.OnQuery,
+		}
+	}
+
 	// Initialize connection pool for PostgreSQL.
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("new pool: %w", err)
 	}
 
+	// The pool connects lazily; ping so that a database that is still starting
+	// is waited for here rather than failing the first query.
+	if err := pingWithRetry(ctx, pool, cfg); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
 	return pool, nil
 }
+
+// pingWithRetry pings the database up to cfg.ConnectAttempts times with jittered exponential backoff.
+func pingWithRetry(ctx context.Context, pool *pgxpool.Pool, cfg PoolConfig) error {
+	attempts := max(cfg.ConnectAttempts, 1)
+	backoff := connectRetryBase
+
+	for attempt := 1; ; attempt++ {
+		err := pool.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts || ctx.Err() != nil {
+			return fmt.Errorf("ping database after %d attempt(s): %w", attempt, err)
+		}
+
+		// Jitter keeps replicas restarted together from reconnecting in lockstep.
+		wait := backoff/2 + rand.N(backoff/2+1)
+		if cfg.OnConnectRetry != nil {
+			cfg.OnConnectRetry(attempt, wait, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ping database: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, connectRetryMax)
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrAdminNotFound = errors.New("admin not found")
+
+// AdminRepository stores admin roles granted in the bot and the audit log of admin actions.
+type AdminRepository struct {
+	db postgres.DBTX
+}
+
+// NewAdminRepository creates a new AdminRepository.
+func NewAdminRepository(db postgres.DBTX) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+// Get retrieves the admin record of a user.
+func (r *AdminRepository) Get(ctx context.Context, userID int64) (*entities.Admin, error) {
+	query := `
+		SELECT user_id, role, added_by, created_at
+		FROM admins
+		WHERE user_id = $1
+	`
+
+	var a entities.Admin
+	err := r.db.QueryRow(ctx, query, userID).Scan(&a.UserID, &a.Role, &a.AddedBy, &a.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("get admin: %w", err)
+	}
+
+	return &a, nil
+}
+
+// List returns all admins granted in the bot, oldest first.
+func (r *AdminRepository) List(ctx context.Context) ([]entities.Admin, error) {
+	query := `
+		SELECT user_id, role, added_by, created_at
+		FROM admins
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list admins: %w", err)
+	}
+	defer rows.Close()
+
+	var admins []entities.Admin
+	for rows.Next() {
+		var a entities.Admin
+		if err := rows.Scan(&a.UserID, &a.Role, &a.AddedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan admin: %w", err)
+		}
+		admins = append(admins, a)
+	}
+
+	return admins, rows.Err()
+}
+
+// Upsert grants a role to a user, replacing the previous one.
+func (r *AdminRepository) Upsert(ctx context.Context, userID int64, role entities.AdminRole, addedBy int64) error {
+	query := `
+		INSERT INTO admins (user_id, role, added_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			added_by = EXCLUDED.added_by,
+			created_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, role, addedBy); err != nil {
+		return fmt.Errorf("upsert admin: %w", err)
+	}
+
+	return nil
+}
+
+// Delete revokes the admin role of a user.
+func (r *AdminRepository) Delete(ctx context.Context, userID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM admins WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("delete admin: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAdminNotFound
+	}
+
+	return nil
+}
+
+// LogAction appends an admin action to the audit log.
+func (r *AdminRepository) LogAction(ctx context.Context, action *entities.AdminAction) error {
+	query := `
+		INSERT INTO admin_actions (admin_id, action, target_user_id, details)
+		VALUES ($1, $2, NULLIF($3, 0), $4)
+	`
+
+	_, err := r.db.Exec(ctx, query, action.AdminID, action.Action, action.TargetUserID, action.Details)
+	if err != nil {
+		return fmt.Errorf("log admin action: %w", err)
+	}
+
+	return nil
+}
+
+// ListActions returns the latest admin actions, newest first.
+func (r *AdminRepository) ListActions(ctx context.Context, limit int) ([]entities.AdminAction, error) {
+	query := `
+		SELECT id, admin_id, action, COALESCE(target_user_id, 0), details, created_at
+		FROM admin_actions
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list admin actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []entities.AdminAction
+	for rows.Next() {
+		var a entities.AdminAction
+		if err := rows.Scan(&a.ID, &a.AdminID, &a.Action, &a.TargetUserID, &a.Details, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan admin action: %w", err)
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, rows.Err()
+}
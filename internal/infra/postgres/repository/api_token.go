@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// APITokenRepository stores hashes of personal HTTP API tokens, one per user.
+type APITokenRepository struct {
+	db postgres.DBTX
+}
+
+// NewAPITokenRepository creates a new APITokenRepository.
+func NewAPITokenRepository(db postgres.DBTX) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Save stores the user's token hash, replacing the previous token.
+func (r *APITokenRepository) Save(ctx context.Context, userID int64, tokenHash []byte, now time.Time) error {
+	query := `
+		INSERT INTO api_tokens (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			token_hash = EXCLUDED.token_hash,
+			created_at = EXCLUDED.created_at,
+			last_used_at = NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, tokenHash, now); err != nil {
+		return fmt.Errorf("save api token: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the user's token. It reports whether there was one.
+func (r *APITokenRepository) Delete(ctx context.Context, userID int64) (bool, error) {
+	query := `DELETE FROM api_tokens WHERE user_id = $1`
+
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete api token: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Touch finds the owner of a token hash and records the use.
+func (r *APITokenRepository) Touch(ctx context.Context, tokenHash []byte, now time.Time) (int64, error) {
+	query := `
+		UPDATE api_tokens
+		SET last_used_at = $2
+		WHERE token_hash = $1
+		RETURNING user_id
+	`
+
+	var userID int64
+	if err := r.db.QueryRow(ctx, query, tokenHash, now).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrAPITokenNotFound
+		}
+		return 0, fmt.Errorf("touch api token: %w", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// BlockListRepository stores the users blocked by admins.
+type BlockListRepository struct {
+	db postgres.DBTX
+}
+
+// NewBlockListRepository creates a new BlockListRepository.
+func NewBlockListRepository(db postgres.DBTX) *BlockListRepository {
+	return &BlockListRepository{db: db}
+}
+
+// Block adds a user to the block list, replacing the reason if they are already there,
+// and drops the notifications still queued for them.
+func (r *BlockListRepository) Block(ctx context.Context, user *entities.BlockedUser) error {
+	query := `
+		WITH dropped AS (
+			DELETE FROM notification_jobs
+			WHERE user_id = $1 AND status = 'pending'
+		)
+		INSERT INTO blocked_users (user_id, reason, blocked_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			blocked_by = EXCLUDED.blocked_by
+		RETURNING created_at
+	`
+
+	if err := r.db.QueryRow(ctx, query, user.UserID, user.Reason, user.BlockedBy).Scan(&user.CreatedAt); err != nil {
+		return fmt.Errorf("block user: %w", err)
+	}
+
+	return nil
+}
+
+// Unblock removes a user from the block list and reports whether they were on it.
+func (r *BlockListRepository) Unblock(ctx context.Context, userID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM blocked_users WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("unblock user: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// List returns the blocked users, most recently blocked first.
+func (r *BlockListRepository) List(ctx context.Context) ([]entities.BlockedUser, error) {
+	query := `
+		SELECT user_id, reason, blocked_by, created_at
+		FROM blocked_users
+		ORDER BY created_at DESC, user_id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.BlockedUser
+	for rows.Next() {
+		var u entities.BlockedUser
+		if err := rows.Scan(&u.UserID, &u.Reason, &u.BlockedBy, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan blocked user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// BroadcastRepository stores broadcasts and queues their notifications.
+type BroadcastRepository struct {
+	db postgres.DBTX
+}
+
+// NewBroadcastRepository creates a new BroadcastRepository.
+func NewBroadcastRepository(db postgres.DBTX) *BroadcastRepository {
+	return &BroadcastRepository{db: db}
+}
+
+// Enqueue stores the broadcast and queues a notification for every active user of its segment
+// in one statement. It fills the ID, the creation time and the number of queued users.
+func (r *BroadcastRepository) Enqueue(ctx context.Context, broadcast *entities.Broadcast) error {
+	condition, ok := surveySegmentConditions[broadcast.Segment]
+	if !ok {
+		return fmt.Errorf("unknown broadcast segment: %q", broadcast.Segment)
+	}
+
+	query := `
+		WITH broadcast AS (
+			SELECT nextval(pg_get_serial_sequence('broadcasts', 'id')) AS id
+		), jobs AS (
+			INSERT INTO notification_jobs (user_id, chat_id, kind, payload)
+			SELECT u.id, u.chat_id, $4::text,
+			       jsonb_build_object('BroadcastID', b.id, 'Text', $2::text)
+			FROM users u, broadcast b
+			WHERE u.is_active
+				AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+				AND ` + condition + `
+			RETURNING 1
+		)
+		INSERT INTO broadcasts (id, segment, text, created_by, queued)
+		SELECT id, $1, $2, $3, (SELECT COUNT(*) FROM jobs)
+		FROM broadcast
+		RETURNING id, created_at, queued
+	`
+
+	err := r.db.QueryRow(ctx, query, broadcast.Segment, broadcast.Text, broadcast.CreatedBy, entities.NotificationBroadcast).
+		Scan(&broadcast.ID, &broadcast.CreatedAt, &broadcast.Queued)
+	if err != nil {
+		return fmt.Errorf("enqueue broadcast: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the latest broadcasts, newest first.
+func (r *BroadcastRepository) List(ctx context.Context, limit int) ([]entities.Broadcast, error) {
+	query := `
+		SELECT id, segment, text, created_by, queued, created_at
+		FROM broadcasts
+		ORDER BY id DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	var broadcasts []entities.Broadcast
+	for rows.Next() {
+		var b entities.Broadcast
+		if err := rows.Scan(&b.ID, &b.Segment, &b.Text, &b.CreatedBy, &b.Queued, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan broadcast: %w", err)
+		}
+		broadcasts = append(broadcasts, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate broadcasts: %w", err)
+	}
+
+	return broadcasts, nil
+}
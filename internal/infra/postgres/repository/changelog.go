@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// ChangelogRepository stores "what's new" subscriptions and announced releases.
+type ChangelogRepository struct {
+	db postgres.DBTX
+}
+
+// NewChangelogRepository creates a new ChangelogRepository.
+func NewChangelogRepository(db postgres.DBTX) *ChangelogRepository {
+	return &ChangelogRepository{db: db}
+}
+
+// IsSubscribed reports whether the user opted in to announcements.
+func (r *ChangelogRepository) IsSubscribed(ctx context.Context, userID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM changelog_subscribers WHERE user_id = $1)`
+
+	var subscribed bool
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&subscribed); err != nil {
+		return false, fmt.Errorf("check changelog subscription: %w", err)
+	}
+
+	return subscribed, nil
+}
+
+// Subscribe opts the user in to announcements.
+func (r *ChangelogRepository) Subscribe(ctx context.Context, userID int64) error {
+	query := `
+		INSERT INTO changelog_subscribers (user_id)
+		VALUES ($1)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("subscribe to changelog: %w", err)
+	}
+
+	return nil
+}
+
+// Unsubscribe opts the user out of announcements.
+func (r *ChangelogRepository) Unsubscribe(ctx context.Context, userID int64) error {
+	query := `DELETE FROM changelog_subscribers WHERE user_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("unsubscribe from changelog: %w", err)
+	}
+
+	return nil
+}
+
+// EnqueueAnnouncement claims the release and queues an announcement for every active subscriber,
+// with payloads[lang] chosen by the user's language and payloads[entities.DefaultChangelogLanguage]
+// otherwise. It returns claimed = false without queueing anything if the release was already announced.
+func (r *ChangelogRepository) EnqueueAnnouncement(
+	ctx context.Context,
+	version string,
+	payloads map[string][]byte,
+) (claimed bool, queued int, err error) {
+	query := `
+		WITH claim AS (
+			INSERT INTO changelog_announcements (version)
+			VALUES ($1)
+			ON CONFLICT (version) DO NOTHING
+			RETURNING version
+		), jobs AS (
+			INSERT INTO notification_jobs (user_id, chat_id, kind, payload)
+			SELECT u.id, u.chat_id, $2::text,
+			       COALESCE(($3::jsonb) -> COALESCE(us.language_code, ''), ($3::jsonb) -> $4::text)
+			FROM changelog_subscribers s
+			JOIN users u ON u.id = s.user_id
+			LEFT JOIN user_settings us ON us.user_id = s.user_id
+			WHERE u.is_active AND EXISTS (SELECT 1 FROM claim)
+				AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+			RETURNING 1
+		)
+		SELECT EXISTS (SELECT 1 FROM claim), (SELECT COUNT(*) FROM jobs)
+	`
+
+	byLanguage := make(map[string]json.RawMessage, len(payloads))
+	for lang, payload := range payloads {
+		byLanguage[lang] = payload
+	}
+	encoded, err := json.Marshal(byLanguage)
+	if err != nil {
+		return false, 0, fmt.Errorf("encode changelog payloads: %w", err)
+	}
+
+	err = r.db.QueryRow(ctx, query, version, entities.NotificationChangelog, encoded, entities.DefaultChangelogLanguage).
+		Scan(&claimed, &queued)
+	if err != nil {
+		return false, 0, fmt.Errorf("enqueue changelog announcement: %w", err)
+	}
+
+	return claimed, queued, nil
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrChannelPostNotFound = errors.New("channel post not found")
+
+// ChannelPostRepository tracks names of the day posted to Telegram channels.
+type ChannelPostRepository struct {
+	db postgres.DBTX
+}
+
+// NewChannelPostRepository creates a new ChannelPostRepository.
+func NewChannelPostRepository(db postgres.DBTX) *ChannelPostRepository {
+	return &ChannelPostRepository{db: db}
+}
+
+// GetLast returns the latest post of a channel, published or not.
+func (r *ChannelPostRepository) GetLast(ctx context.Context, chatID int64) (*entities.ChannelPost, error) {
+	query := `
+		SELECT chat_id, post_date, name_number, message_id, published_at
+		FROM channel_posts
+		WHERE chat_id = $1
+		ORDER BY post_date DESC
+		LIMIT 1
+	`
+
+	var post entities.ChannelPost
+	var messageID *int64
+	err := r.db.QueryRow(ctx, query, chatID).Scan(
+		&post.ChatID,
+		&post.PostDate,
+		&post.NameNumber,
+		&messageID,
+		&post.PublishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChannelPostNotFound
+		}
+		return nil, fmt.Errorf("get last channel post: %w", err)
+	}
+
+	if messageID != nil {
+		id := int(*messageID)
+		post.MessageID = &id
+	}
+
+	return &post, nil
+}
+
+// GetLastPublishedName returns the name of the latest post that reached the channel, or 0 if there is none.
+func (r *ChannelPostRepository) GetLastPublishedName(ctx context.Context, chatID int64) (int, error) {
+	query := `
+		SELECT COALESCE((
+			SELECT name_number
+			FROM channel_posts
+			WHERE chat_id = $1 AND message_id IS NOT NULL
+			ORDER BY post_date DESC
+			LIMIT 1
+		), 0)
+	`
+
+	var name int
+	if err := r.db.QueryRow(ctx, query, chatID).Scan(&name); err != nil {
+		return 0, fmt.Errorf("get last published channel name: %w", err)
+	}
+
+	return name, nil
+}
+
+// Reserve claims the post for a channel's day before it is sent. It reports false
+// if the day is already claimed, which keeps a channel from getting two posts a day.
+func (r *ChannelPostRepository) Reserve(ctx context.Context, chatID int64, postDate time.Time, nameNumber int) (bool, error) {
+	query := `
+		INSERT INTO channel_posts (chat_id, post_date, name_number)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, post_date) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, chatID, postDate, nameNumber)
+	if err != nil {
+		return false, fmt.Errorf("reserve channel post: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// MarkPublished stores the message ID of a sent post.
+func (r *ChannelPostRepository) MarkPublished(ctx context.Context, chatID int64, postDate time.Time, messageID int) error {
+	query := `
+		UPDATE channel_posts
+		SET message_id = $3, published_at = NOW()
+		WHERE chat_id = $1 AND post_date = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, chatID, postDate, messageID); err != nil {
+		return fmt.Errorf("mark channel post published: %w", err)
+	}
+
+	return nil
+}
+
+// Release drops an unsent reservation so the post is retried.
+func (r *ChannelPostRepository) Release(ctx context.Context, chatID int64, postDate time.Time) error {
+	query := `
+		DELETE FROM channel_posts
+		WHERE chat_id = $1 AND post_date = $2 AND message_id IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, chatID, postDate); err != nil {
+		return fmt.Errorf("release channel post: %w", err)
+	}
+
+	return nil
+}
+
+// ListPublishedNamesBefore returns distinct names that reached the channel before postDate.
+func (r *ChannelPostRepository) ListPublishedNamesBefore(ctx context.Context, chatID int64, postDate time.Time) ([]int, error) {
+	query := `
+		SELECT DISTINCT name_number
+		FROM channel_posts
+		WHERE chat_id = $1 AND post_date < $2 AND message_id IS NOT NULL
+		ORDER BY name_number
+	`
+
+	rows, err := r.db.Query(ctx, query, chatID, postDate)
+	if err != nil {
+		return nil, fmt.Errorf("list published channel names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []int
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("scan channel name: %w", err)
+		}
+		names = append(names, n)
+	}
+
+	return names, rows.Err()
+}
+
+// SetQuiz records the mini-quiz sent after the post of a channel's day.
+func (r *ChannelPostRepository) SetQuiz(ctx context.Context, chatID int64, postDate time.Time, nameNumber int, pollID string) error {
+	query := `
+		UPDATE channel_posts
+		SET quiz_name_number = $3, quiz_poll_id = $4
+		WHERE chat_id = $1 AND post_date = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, chatID, postDate, nameNumber, pollID); err != nil {
+		return fmt.Errorf("set channel quiz: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateQuizResults stores the latest answer counts of a mini-quiz poll.
+// Polls that are not mini-quizzes are ignored.
+func (r *ChannelPostRepository) UpdateQuizResults(ctx context.Context, pollID string, voters, correct int) error {
+	query := `
+		UPDATE channel_posts
+		SET quiz_voters = $2, quiz_correct = $3
+		WHERE quiz_poll_id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, pollID, voters, correct); err != nil {
+		return fmt.Errorf("update channel quiz results: %w", err)
+	}
+
+	return nil
+}
+
+// GetQuizStats sums up mini-quiz answers of a chat.
+func (r *ChannelPostRepository) GetQuizStats(ctx context.Context, chatID int64) (entities.ChannelQuizStats, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(quiz_voters), 0), COALESCE(SUM(quiz_correct), 0)
+		FROM channel_posts
+		WHERE chat_id = $1 AND quiz_poll_id IS NOT NULL
+	`
+
+	var stats entities.ChannelQuizStats
+	if err := r.db.QueryRow(ctx, query, chatID).Scan(&stats.Quizzes, &stats.Answers, &stats.Correct); err != nil {
+		return entities.ChannelQuizStats{}, fmt.Errorf("get channel quiz stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrContentReportNotFound = errors.New("content report not found")
+
+// ContentReportRepository stores user reports about errors on name cards.
+type ContentReportRepository struct {
+	db postgres.DBTX
+}
+
+// NewContentReportRepository creates a new ContentReportRepository.
+func NewContentReportRepository(db postgres.DBTX) *ContentReportRepository {
+	return &ContentReportRepository{db: db}
+}
+
+// Create stores a report and fills its ID, status and creation time.
+func (r *ContentReportRepository) Create(ctx context.Context, report *entities.ContentReport) error {
+	query := `
+		INSERT INTO content_reports (user_id, chat_id, name_number, field, text)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		report.UserID,
+		report.ChatID,
+		report.NameNumber,
+		report.Field,
+		report.Text,
+	).Scan(&report.ID, &report.Status, &report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create content report: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a report by ID.
+func (r *ContentReportRepository) Get(ctx context.Context, id int64) (*entities.ContentReport, error) {
+	query := `
+		SELECT id, user_id, chat_id, name_number, field, text, status, created_at, resolved_at, resolved_by
+		FROM content_reports
+		WHERE id = $1
+	`
+
+	report, err := scanContentReport(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrContentReportNotFound
+		}
+		return nil, fmt.Errorf("get content report: %w", err)
+	}
+
+	return report, nil
+}
+
+// Resolve sets the final status of an open report. It reports false if the report
+// does not exist or was already resolved.
+func (r *ContentReportRepository) Resolve(
+	ctx context.Context,
+	id int64,
+	status entities.ContentReportStatus,
+	adminID int64,
+) (bool, error) {
+	query := `
+		UPDATE content_reports
+		SET status = $2, resolved_at = NOW(), resolved_by = $3
+		WHERE id = $1 AND status = 'open'
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, status, adminID)
+	if err != nil {
+		return false, fmt.Errorf("resolve content report: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListOpen returns the oldest open reports.
+func (r *ContentReportRepository) ListOpen(ctx context.Context, limit int) ([]entities.ContentReport, error) {
+	query := `
+		SELECT id, user_id, chat_id, name_number, field, text, status, created_at, resolved_at, resolved_by
+		FROM content_reports
+		WHERE status = 'open'
+		ORDER BY created_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list open content reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []entities.ContentReport
+	for rows.Next() {
+		report, err := scanContentReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan content report: %w", err)
+		}
+		reports = append(reports, *report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate content reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// scanContentReport scans a content_reports row selected in the column order used above.
+func scanContentReport(row pgx.Row) (*entities.ContentReport, error) {
+	var report entities.ContentReport
+	err := row.Scan(
+		&report.ID,
+		&report.UserID,
+		&report.ChatID,
+		&report.NameNumber,
+		&report.Field,
+		&report.Text,
+		&report.Status,
+		&report.CreatedAt,
+		&report.ResolvedAt,
+		&report.ResolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -124,6 +124,98 @@ func (r *DailyNameRepository) AddNameForDate(ctx context.Context, userID int64,
 	return nil
 }
 
+// SetOrderForDate rewrites slot indexes so the names for a date follow order.
+// order must list every name planned for the date exactly once.
+//
+// New slots are taken from the range (0.. or 1000..) the current slots are not in,
+// so a single UPDATE never collides with the primary key.
+func (r *DailyNameRepository) SetOrderForDate(ctx context.Context, userID int64, dateUTC time.Time, order []int) error {
+	dateUTC = dateUTC.UTC().Truncate(24 * time.Hour)
+
+	query := `
+		WITH base AS (
+			SELECT CASE WHEN MIN(slot_index) >= 1000 THEN 0 ELSE 1000 END AS start
+			FROM user_daily_name
+			WHERE user_id = $1 AND date_utc = $2
+		)
+		UPDATE user_daily_name udn
+		SET slot_index = base.start + o.pos - 1
+		FROM base, unnest($3::int[]) WITH ORDINALITY AS o(name_number, pos)
+		WHERE udn.user_id = $1
+			AND udn.date_utc = $2
+			AND udn.name_number = o.name_number
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, dateUTC, order); err != nil {
+		return fmt.Errorf("set order for date: %w", err)
+	}
+	return nil
+}
+
+// ReplaceNameForDate puts newName into the slot oldName occupies on the date.
+// It reports whether oldName was planned for the date.
+func (r *DailyNameRepository) ReplaceNameForDate(ctx context.Context, userID int64, dateUTC time.Time, oldName, newName int) (bool, error) {
+	dateUTC = dateUTC.UTC().Truncate(24 * time.Hour)
+
+	query := `
+		UPDATE user_daily_name
+		SET name_number = $4, studied_at = NULL
+		WHERE user_id = $1 AND date_utc = $2 AND name_number = $3
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, dateUTC, oldName, newName)
+	if err != nil {
+		return false, fmt.Errorf("replace name for date: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// MarkStudied ticks a planned name off for the date. It reports whether the name
+// was planned and not marked yet.
+func (r *DailyNameRepository) MarkStudied(ctx context.Context, userID int64, dateUTC time.Time, nameNumber int) (bool, error) {
+	dateUTC = dateUTC.UTC().Truncate(24 * time.Hour)
+
+	query := `
+		UPDATE user_daily_name
+		SET studied_at = NOW()
+		WHERE user_id = $1 AND date_utc = $2 AND name_number = $3 AND studied_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, dateUTC, nameNumber)
+	if err != nil {
+		return false, fmt.Errorf("mark studied: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetStudiedByDate returns the names ticked off for the date.
+func (r *DailyNameRepository) GetStudiedByDate(ctx context.Context, userID int64, dateUTC time.Time) ([]int, error) {
+	dateUTC = dateUTC.UTC().Truncate(24 * time.Hour)
+
+	query := `
+		SELECT name_number
+		FROM user_daily_name
+		WHERE user_id = $1 AND date_utc = $2 AND studied_at IS NOT NULL
+		ORDER BY slot_index
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, dateUTC)
+	if err != nil {
+		return nil, fmt.Errorf("get studied by date: %w", err)
+	}
+	defer rows.Close()
+
+	var names []int
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("scan studied name: %w", err)
+		}
+		names = append(names, n)
+	}
+	return names, rows.Err()
+}
+
 // GetCarryOverUnfinishedFromPast returns unique nameNumbers that were planned before today
 // and are currently in PhaseLearning. Order is by oldest plan slot.
 func (r *DailyNameRepository) GetCarryOverUnfinishedFromPast(ctx context.Context, userID int64, todayDateUTC time.Time, limit int) ([]int, error) {
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// EphemeralMessageRepository stores bot messages that are deleted from chats after a while.
+type EphemeralMessageRepository struct {
+	db postgres.DBTX
+}
+
+func NewEphemeralMessageRepository(db postgres.DBTX) *EphemeralMessageRepository {
+	return &EphemeralMessageRepository{db: db}
+}
+
+// Track remembers a message; tracking it again replaces its kind and deletion time.
+func (r *EphemeralMessageRepository) Track(ctx context.Context, msg *entities.EphemeralMessage) error {
+	query := `
+		INSERT INTO ephemeral_messages (chat_id, message_id, kind, sent_at, delete_after)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (chat_id, message_id) DO UPDATE
+		SET kind = EXCLUDED.kind,
+		    delete_after = EXCLUDED.delete_after
+	`
+
+	_, err := r.db.Exec(ctx, query, msg.ChatID, msg.MessageID, msg.Kind, msg.SentAt, msg.DeleteAfter)
+	if err != nil {
+		return fmt.Errorf("track ephemeral message: %w", err)
+	}
+
+	return nil
+}
+
+// Forget stops tracking a message, e.g. one already removed from the chat.
+func (r *EphemeralMessageRepository) Forget(ctx context.Context, chatID int64, messageID int) error {
+	query := `DELETE FROM ephemeral_messages WHERE chat_id = $1 AND message_id = $2`
+
+	if _, err := r.db.Exec(ctx, query, chatID, messageID); err != nil {
+		return fmt.Errorf("forget ephemeral message: %w", err)
+	}
+
+	return nil
+}
+
+// TakeDue removes up to limit messages whose deletion time has passed and returns them,
+// oldest first. Rows locked by a concurrent call are skipped.
+func (r *EphemeralMessageRepository) TakeDue(ctx context.Context, now time.Time, limit int) ([]entities.EphemeralMessage, error) {
+	query := `
+		DELETE FROM ephemeral_messages
+		WHERE (chat_id, message_id) IN (
+			SELECT chat_id, message_id
+			FROM ephemeral_messages
+			WHERE delete_after <= $1
+			ORDER BY delete_after
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING chat_id, message_id, kind, sent_at, delete_after
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("take due ephemeral messages: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []entities.EphemeralMessage
+	for rows.Next() {
+		var m entities.EphemeralMessage
+		if err := rows.Scan(&m.ChatID, &m.MessageID, &m.Kind, &m.SentAt, &m.DeleteAfter); err != nil {
+			return nil, fmt.Errorf("scan ephemeral message: %w", err)
+		}
+		msgs = append(msgs, m)
+	}
+
+	return msgs, rows.Err()
+}
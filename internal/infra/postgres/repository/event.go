@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// EventRepository stores analytics events.
+type EventRepository struct {
+	db      postgres.DBTX
+	replica postgres.DBTX // optional; reports are read from it
+}
+
+// NewEventRepository creates a new EventRepository.
+func NewEventRepository(db postgres.DBTX) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// WithReplica returns a copy of the repository that reads reports from a read-only replica.
+func (r *EventRepository) WithReplica(replica postgres.DBTX) *EventRepository {
+	return &EventRepository{db: r.db, replica: replica}
+}
+
+// reader returns the replica if one is set and the primary otherwise.
+func (r *EventRepository) reader() postgres.DBTX {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.db
+}
+
+// Create inserts an analytics event.
+func (r *EventRepository) Create(ctx context.Context, event *entities.Event) error {
+	query := `
+		INSERT INTO events (user_id, name, properties, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	properties := []byte("{}")
+	if len(event.Properties) > 0 {
+		b, err := json.Marshal(event.Properties)
+		if err != nil {
+			return fmt.Errorf("marshal event properties: %w", err)
+		}
+		properties = b
+	}
+
+	if _, err := r.db.Exec(ctx, query, event.UserID, event.Name, properties, event.CreatedAt); err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+
+	return nil
+}
+
+// GetOnboardingFunnel counts distinct users per onboarding step who saw and completed it since the given time.
+// Only steps with events are returned.
+func (r *EventRepository) GetOnboardingFunnel(ctx context.Context, since time.Time) ([]entities.OnboardingFunnelStep, error) {
+	query := `
+		SELECT
+			(properties->>'step')::int AS step,
+			COUNT(DISTINCT user_id) FILTER (WHERE name = $1),
+			COUNT(DISTINCT user_id) FILTER (WHERE name = $2)
+		FROM events
+		WHERE name IN ($1, $2)
+			AND created_at >= $3
+		GROUP BY step
+		ORDER BY step
+	`
+
+	rows, err := r.reader().Query(ctx, query,
+		entities.EventOnboardingStepShown, entities.EventOnboardingStepCompleted, since)
+	if err != nil {
+		return nil, fmt.Errorf("query onboarding funnel: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []entities.OnboardingFunnelStep
+	for rows.Next() {
+		var step entities.OnboardingFunnelStep
+		if err := rows.Scan(&step.Step, &step.Shown, &step.Completed); err != nil {
+			return nil, fmt.Errorf("scan onboarding funnel step: %w", err)
+		}
+		steps = append(steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate onboarding funnel: %w", err)
+	}
+
+	return steps, nil
+}
+
+// GetDailyActiveUsers counts distinct users with events per UTC day since the given time.
+// Only days with events are returned.
+func (r *EventRepository) GetDailyActiveUsers(ctx context.Context, since time.Time) ([]entities.DailyCount, error) {
+	query := `
+		SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day, COUNT(DISTINCT user_id)
+		FROM events
+		WHERE created_at >= $1
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.reader().Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("query daily active users: %w", err)
+	}
+	defer rows.Close()
+
+	var days []entities.DailyCount
+	for rows.Next() {
+		var d entities.DailyCount
+		if err := rows.Scan(&d.Day, &d.Count); err != nil {
+			return nil, fmt.Errorf("scan daily active users: %w", err)
+		}
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate daily active users: %w", err)
+	}
+
+	return days, nil
+}
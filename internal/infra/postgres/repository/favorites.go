@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// FavoritesRepository manages names bookmarked by users.
+type FavoritesRepository struct {
+	db postgres.DBTX
+}
+
+// NewFavoritesRepository creates a new FavoritesRepository.
+func NewFavoritesRepository(db postgres.DBTX) *FavoritesRepository {
+	return &FavoritesRepository{db: db}
+}
+
+// Add bookmarks a name for a user. Adding an existing favorite is a no-op.
+func (r *FavoritesRepository) Add(ctx context.Context, userID int64, nameNumber int) error {
+	query := `
+		INSERT INTO user_favorites (user_id, name_number, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, name_number) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, nameNumber); err != nil {
+		return fmt.Errorf("add favorite: %w", err)
+	}
+
+	return nil
+}
+
+// Remove deletes a name from user's favorites.
+func (r *FavoritesRepository) Remove(ctx context.Context, userID int64, nameNumber int) error {
+	query := `
+		DELETE FROM user_favorites
+		WHERE user_id = $1 AND name_number = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, nameNumber); err != nil {
+		return fmt.Errorf("remove favorite: %w", err)
+	}
+
+	return nil
+}
+
+// Exists checks whether a name is in user's favorites.
+func (r *FavoritesRepository) Exists(ctx context.Context, userID int64, nameNumber int) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_favorites
+			WHERE user_id = $1 AND name_number = $2
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, userID, nameNumber).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check favorite: %w", err)
+	}
+
+	return exists, nil
+}
+
+// List returns user's favorite name numbers ordered by name number.
+func (r *FavoritesRepository) List(ctx context.Context, userID int64) ([]int, error) {
+	query := `
+		SELECT name_number
+		FROM user_favorites
+		WHERE user_id = $1
+		ORDER BY name_number
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list favorites: %w", err)
+	}
+	defer rows.Close()
+
+	var nums []int
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("scan favorite: %w", err)
+		}
+		nums = append(nums, n)
+	}
+
+	return nums, rows.Err()
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrGroupChatNotFound = errors.New("group chat not found")
+
+// GroupChatRepository manages settings of group chats the bot was added to.
+type GroupChatRepository struct {
+	db postgres.DBTX
+}
+
+// NewGroupChatRepository creates a new GroupChatRepository.
+func NewGroupChatRepository(db postgres.DBTX) *GroupChatRepository {
+	return &GroupChatRepository{db: db}
+}
+
+// Get retrieves the settings of a group chat.
+func (r *GroupChatRepository) Get(ctx context.Context, chatID int64) (*entities.GroupChat, error) {
+	query := `
+		SELECT chat_id, title, language_code, daily_post, daily_quiz, post_time, timezone
+		FROM group_chats
+		WHERE chat_id = $1
+	`
+
+	var g entities.GroupChat
+	err := r.db.QueryRow(ctx, query, chatID).Scan(
+		&g.ChatID,
+		&g.Title,
+		&g.LanguageCode,
+		&g.DailyPost,
+		&g.DailyQuiz,
+		&g.PostTime,
+		&g.Timezone,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrGroupChatNotFound
+		}
+		return nil, fmt.Errorf("get group chat: %w", err)
+	}
+
+	return &g, nil
+}
+
+// Create stores a group chat with the given settings, refreshing only the title if it already exists.
+func (r *GroupChatRepository) Create(ctx context.Context, g *entities.GroupChat) error {
+	query := `
+		INSERT INTO group_chats (chat_id, title, language_code, daily_post, daily_quiz, post_time, timezone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chat_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.Exec(ctx, query, g.ChatID, g.Title, g.LanguageCode, g.DailyPost, g.DailyQuiz, g.PostTime, g.Timezone)
+	if err != nil {
+		return fmt.Errorf("create group chat: %w", err)
+	}
+
+	return nil
+}
+
+// Update stores changed settings of a group chat.
+func (r *GroupChatRepository) Update(ctx context.Context, g *entities.GroupChat) error {
+	query := `
+		UPDATE group_chats
+		SET language_code = $2,
+		    daily_post = $3,
+		    daily_quiz = $4,
+		    post_time = $5,
+		    timezone = $6,
+		    updated_at = NOW()
+		WHERE chat_id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, g.ChatID, g.LanguageCode, g.DailyPost, g.DailyQuiz, g.PostTime, g.Timezone)
+	if err != nil {
+		return fmt.Errorf("update group chat: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrGroupChatNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a group chat, e.g. after the bot was removed from it.
+func (r *GroupChatRepository) Delete(ctx context.Context, chatID int64) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM group_chats WHERE chat_id = $1`, chatID); err != nil {
+		return fmt.Errorf("delete group chat: %w", err)
+	}
+	return nil
+}
+
+// ListDailyPost returns groups with daily posting enabled.
+func (r *GroupChatRepository) ListDailyPost(ctx context.Context) ([]entities.GroupChat, error) {
+	query := `
+		SELECT chat_id, title, language_code, daily_post, daily_quiz, post_time, timezone
+		FROM group_chats
+		WHERE daily_post
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list daily post groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []entities.GroupChat
+	for rows.Next() {
+		var g entities.GroupChat
+		if err := rows.Scan(&g.ChatID, &g.Title, &g.LanguageCode, &g.DailyPost, &g.DailyQuiz, &g.PostTime, &g.Timezone); err != nil {
+			return nil, fmt.Errorf("scan group chat: %w", err)
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, rows.Err()
+}
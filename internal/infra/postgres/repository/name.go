@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"sync"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 )
@@ -17,7 +18,9 @@ var (
 
 // NameRepository provides access to the 99 Names of Allah.
 // This implementation uses an in-memory dataset, but you could load from DB or JSON.
+// Names are replaced as a whole, never modified, so readers may keep the values they got.
 type NameRepository struct {
+	mu    sync.RWMutex
 	names []*entities.Name
 }
 
@@ -41,6 +44,9 @@ func (r *NameRepository) GetByNumber(number int) (*entities.Name, error) {
 		return nil, ErrInvalidNumber
 	}
 
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, name := range r.names {
 		if name.Number == number {
 			return name, nil
@@ -52,6 +58,9 @@ func (r *NameRepository) GetByNumber(number int) (*entities.Name, error) {
 
 // GetRandom retrieves a random name.
 func (r *NameRepository) GetRandom() (*entities.Name, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if len(r.names) == 0 {
 		return nil, ErrNameNotFound
 	}
@@ -62,9 +71,31 @@ func (r *NameRepository) GetRandom() (*entities.Name, error) {
 
 // GetAll retrieves all 99 names.
 func (r *NameRepository) GetAll() ([]*entities.Name, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return r.names, nil
 }
 
+// Replace swaps the name with the same number for the given one.
+// The list is copied, so slices returned by GetAll earlier stay unchanged.
+func (r *NameRepository) Replace(name *entities.Name) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, old := range r.names {
+		if old.Number == name.Number {
+			names := make([]*entities.Name, len(r.names))
+			copy(names, r.names)
+			names[i] = name
+			r.names = names
+			return nil
+		}
+	}
+
+	return ErrNameNotFound
+}
+
 // GetByNumbers retrieves multiple names by their numbers.
 func (r *NameRepository) GetByNumbers(numbers []int) ([]entities.Name, error) {
 	result := make([]entities.Name, 0, len(numbers))
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// NameDatasetRepository stores the names dataset in the names table.
+type NameDatasetRepository struct {
+	db postgres.DBTX
+}
+
+// NewNameDatasetRepository creates a new NameDatasetRepository.
+func NewNameDatasetRepository(db postgres.DBTX) *NameDatasetRepository {
+	return &NameDatasetRepository{db: db}
+}
+
+// List returns every stored name in number order.
+func (r *NameDatasetRepository) List(ctx context.Context) ([]*entities.Name, error) {
+	query := `
+		SELECT number, arabic, transliteration, translation, meaning, audio
+		FROM names
+		ORDER BY number
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []*entities.Name
+	for rows.Next() {
+		var n entities.Name
+		if err := rows.Scan(&n.Number, &n.ArabicName, &n.Transliteration, &n.Translation, &n.Meaning, &n.Audio); err != nil {
+			return nil, fmt.Errorf("scan name: %w", err)
+		}
+		names = append(names, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate names: %w", err)
+	}
+
+	return names, nil
+}
+
+// UpsertMany creates or updates several names in one statement. Rows whose texts
+// are already equal are left untouched, so updated_at shows when a name last changed.
+func (r *NameDatasetRepository) UpsertMany(ctx context.Context, names []*entities.Name) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO names (number, arabic, transliteration, translation, meaning, audio)
+		SELECT *
+		FROM unnest($1::smallint[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[])
+		ON CONFLICT (number) DO UPDATE
+		SET arabic = EXCLUDED.arabic,
+		    transliteration = EXCLUDED.transliteration,
+		    translation = EXCLUDED.translation,
+		    meaning = EXCLUDED.meaning,
+		    audio = EXCLUDED.audio,
+		    updated_at = NOW()
+		WHERE (names.arabic, names.transliteration, names.translation, names.meaning, names.audio)
+		      IS DISTINCT FROM
+		      (EXCLUDED.arabic, EXCLUDED.transliteration, EXCLUDED.translation, EXCLUDED.meaning, EXCLUDED.audio)
+	`
+
+	n := len(names)
+	var (
+		numbers          = make([]int, n)
+		arabic           = make([]string, n)
+		transliterations = make([]string, n)
+		translations     = make([]string, n)
+		meanings         = make([]string, n)
+		audio            = make([]string, n)
+	)
+	for i, name := range names {
+		numbers[i] = name.Number
+		arabic[i] = name.ArabicName
+		transliterations[i] = name.Transliteration
+		translations[i] = name.Translation
+		meanings[i] = name.Meaning
+		audio[i] = name.Audio
+	}
+
+	_, err := r.db.Exec(ctx, query, numbers, arabic, transliterations, translations, meanings, audio)
+	if err != nil {
+		return fmt.Errorf("upsert names: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// NameOverrideRepository stores name card texts edited by admins.
+type NameOverrideRepository struct {
+	db postgres.DBTX
+}
+
+// NewNameOverrideRepository creates a new NameOverrideRepository.
+func NewNameOverrideRepository(db postgres.DBTX) *NameOverrideRepository {
+	return &NameOverrideRepository{db: db}
+}
+
+// List returns every edited name. Only the text fields are filled.
+func (r *NameOverrideRepository) List(ctx context.Context) ([]entities.Name, error) {
+	query := `
+		SELECT number, arabic, transliteration, translation, meaning
+		FROM name_overrides
+		ORDER BY number
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query name overrides: %w", err)
+	}
+	defer rows.Close()
+
+	var names []entities.Name
+	for rows.Next() {
+		var n entities.Name
+		if err := rows.Scan(&n.Number, &n.ArabicName, &n.Transliteration, &n.Translation, &n.Meaning); err != nil {
+			return nil, fmt.Errorf("scan name override: %w", err)
+		}
+		names = append(names, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate name overrides: %w", err)
+	}
+
+	return names, nil
+}
+
+// Save stores the text fields of a name, replacing a previous edit.
+func (r *NameOverrideRepository) Save(ctx context.Context, name *entities.Name, adminID int64) error {
+	query := `
+		INSERT INTO name_overrides (number, arabic, transliteration, translation, meaning, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (number) DO UPDATE
+		SET arabic = EXCLUDED.arabic,
+		    transliteration = EXCLUDED.transliteration,
+		    translation = EXCLUDED.translation,
+		    meaning = EXCLUDED.meaning,
+		    updated_by = EXCLUDED.updated_by,
+		    updated_at = NOW()
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		name.Number, name.ArabicName, name.Transliteration, name.Translation, name.Meaning, adminID)
+	if err != nil {
+		return fmt.Errorf("save name override: %w", err)
+	}
+
+	return nil
+}
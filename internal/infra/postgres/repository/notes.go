@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrNoteNotFound = errors.New("note not found")
+
+// NotesRepository manages personal notes attached to names.
+type NotesRepository struct {
+	db postgres.DBTX
+}
+
+// NewNotesRepository creates a new NotesRepository.
+func NewNotesRepository(db postgres.DBTX) *NotesRepository {
+	return &NotesRepository{db: db}
+}
+
+// Get retrieves user's note for a name.
+func (r *NotesRepository) Get(ctx context.Context, userID int64, nameNumber int) (*entities.NameNote, error) {
+	query := `
+		SELECT user_id, name_number, note, created_at, updated_at
+		FROM user_name_notes
+		WHERE user_id = $1 AND name_number = $2
+	`
+
+	var n entities.NameNote
+	err := r.db.QueryRow(ctx, query, userID, nameNumber).Scan(
+		&n.UserID,
+		&n.NameNumber,
+		&n.Text,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("get note: %w", err)
+	}
+
+	return &n, nil
+}
+
+// Upsert creates or replaces user's note for a name.
+func (r *NotesRepository) Upsert(ctx context.Context, userID int64, nameNumber int, text string) error {
+	query := `
+		INSERT INTO user_name_notes (user_id, name_number, note, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (user_id, name_number) DO UPDATE SET
+			note = EXCLUDED.note,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, nameNumber, text); err != nil {
+		return fmt.Errorf("upsert note: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes user's note for a name.
+func (r *NotesRepository) Delete(ctx context.Context, userID int64, nameNumber int) error {
+	query := `
+		DELETE FROM user_name_notes
+		WHERE user_id = $1 AND name_number = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, nameNumber); err != nil {
+		return fmt.Errorf("delete note: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all notes of a user ordered by name number.
+func (r *NotesRepository) List(ctx context.Context, userID int64) ([]*entities.NameNote, error) {
+	query := `
+		SELECT user_id, name_number, note, created_at, updated_at
+		FROM user_name_notes
+		WHERE user_id = $1
+		ORDER BY name_number
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*entities.NameNote
+	for rows.Next() {
+		var n entities.NameNote
+		if err := rows.Scan(&n.UserID, &n.NameNumber, &n.Text, &n.CreatedAt, &n.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+		notes = append(notes, &n)
+	}
+
+	return notes, rows.Err()
+}
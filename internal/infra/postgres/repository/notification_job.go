@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// NotificationJobRepository stores queued notifications for the delivery workers.
+type NotificationJobRepository struct {
+	db postgres.DBTX
+}
+
+// NewNotificationJobRepository creates a new NotificationJobRepository.
+func NewNotificationJobRepository(db postgres.DBTX) *NotificationJobRepository {
+	return &NotificationJobRepository{db: db}
+}
+
+// Enqueue stores a new pending job and sets its ID.
+func (r *NotificationJobRepository) Enqueue(ctx context.Context, job *entities.NotificationJob) error {
+	query := `
+		INSERT INTO notification_jobs (user_id, chat_id, kind, payload, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		job.UserID,
+		job.ChatID,
+		job.Kind,
+		job.Payload,
+		job.ScheduledAt,
+	).Scan(&job.ID, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("enqueue notification job: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue locks up to limit pending jobs scheduled before now for lockTTL and counts the attempt.
+// Jobs locked by another worker are skipped; a lock that expired (e.g. the worker stopped
+// mid-batch) makes the job available again.
+func (r *NotificationJobRepository) ClaimDue(ctx context.Context, now time.Time, lockTTL time.Duration, limit int) ([]*entities.NotificationJob, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM notification_jobs
+			WHERE status = 'pending'
+				AND scheduled_at <= $1
+				AND (locked_until IS NULL OR locked_until < $1)
+			ORDER BY scheduled_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE notification_jobs j
+		SET locked_until = $2,
+		    attempts = j.attempts + 1
+		FROM due
+		WHERE j.id = due.id
+		RETURNING j.id, j.user_id, j.chat_id, j.kind, j.payload, j.attempts,
+		          j.scheduled_at, COALESCE(j.last_error, ''), j.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, now, now.Add(lockTTL), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim notification jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*entities.NotificationJob
+	for rows.Next() {
+		var j entities.NotificationJob
+		if err := rows.Scan(
+			&j.ID,
+			&j.UserID,
+			&j.ChatID,
+			&j.Kind,
+			&j.Payload,
+			&j.Attempts,
+			&j.ScheduledAt,
+			&j.LastError,
+			&j.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan notification job: %w", err)
+		}
+		jobs = append(jobs, &j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// MarkDelivered records that the job was sent; delivered jobs are pruned by the retention cleanup.
+func (r *NotificationJobRepository) MarkDelivered(ctx context.Context, id int64, deliveredAt time.Time) error {
+	query := `
+		UPDATE notification_jobs
+		SET status = 'delivered',
+		    delivered_at = $2,
+		    locked_until = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, deliveredAt); err != nil {
+		return fmt.Errorf("mark notification job delivered: %w", err)
+	}
+	return nil
+}
+
+// Retry unlocks a job and schedules the next delivery attempt.
+func (r *NotificationJobRepository) Retry(ctx context.Context, id int64, scheduledAt time.Time, lastError string) error {
+	query := `
+		UPDATE notification_jobs
+		SET scheduled_at = $2,
+		    locked_until = NULL,
+		    last_error = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, scheduledAt, lastError); err != nil {
+		return fmt.Errorf("retry notification job: %w", err)
+	}
+	return nil
+}
+
+// Fail marks a job as permanently failed; it stays in the table for inspection.
+func (r *NotificationJobRepository) Fail(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE notification_jobs
+		SET status = 'failed',
+		    locked_until = NULL,
+		    last_error = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("fail notification job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// OverviewRepository aggregates bot usage counters for admins.
+type OverviewRepository struct {
+	db postgres.DBTX
+}
+
+// NewOverviewRepository creates a new OverviewRepository.
+func NewOverviewRepository(db postgres.DBTX) *OverviewRepository {
+	return &OverviewRepository{db: db}
+}
+
+// GetOverview counts users, activity, quizzes and notification delivery as of now.
+// Activity comes from users.last_active_at, which is updated at most once an hour.
+func (r *OverviewRepository) GetOverview(ctx context.Context, now time.Time) (*entities.BotOverview, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM users WHERE last_active_at >= $1 - INTERVAL '1 day'),
+			(SELECT COUNT(*) FROM users WHERE last_active_at >= $1 - INTERVAL '7 days'),
+			(SELECT COUNT(*) FROM user_reminders WHERE is_enabled),
+			(SELECT COUNT(*) FROM quiz_sessions WHERE started_at >= $2),
+			(SELECT COUNT(*) FROM quiz_sessions WHERE started_at >= $2 AND session_status = 'completed'),
+			(SELECT COUNT(*) FROM reminder_log
+				WHERE created_at >= $1 - INTERVAL '1 day' AND kind = $3 AND outcome = $4),
+			(SELECT COUNT(*) FROM reminder_log
+				WHERE created_at >= $1 - INTERVAL '1 day' AND outcome <> $4),
+			(SELECT COUNT(*) FROM notification_jobs WHERE status = 'failed')
+	`
+
+	y, m, d := now.UTC().Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	o := entities.BotOverview{At: now}
+	err := r.db.QueryRow(ctx, query, now, midnight, entities.NotificationReminder, entities.DeliveryDelivered).Scan(
+		&o.TotalUsers,
+		&o.DAU,
+		&o.WAU,
+		&o.ActiveReminders,
+		&o.QuizzesToday,
+		&o.QuizzesCompleted,
+		&o.RemindersSent24h,
+		&o.DeliveryErrors24h,
+		&o.FailedJobs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get overview: %w", err)
+	}
+
+	return &o, nil
+}
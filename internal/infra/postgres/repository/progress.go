@@ -16,7 +16,8 @@ var ErrProgressNotFound = errors.New("progress not found")
 
 // ProgressRepository provides access to user progress data in the database.
 type ProgressRepository struct {
-	db postgres.DBTX
+	db      postgres.DBTX
+	replica postgres.DBTX // optional; stats and listings are read from it
 }
 
 // NewProgressRepository creates a new ProgressRepository with the provided database pool.
@@ -24,13 +25,23 @@ func NewProgressRepository(db postgres.DBTX) *ProgressRepository {
 	return &ProgressRepository{db: db}
 }
 
-// Upsert creates or updates a progress record within a transaction.
-func (r *ProgressRepository) Upsert(ctx context.Context, progress *entities.UserProgress) error {
-	query := `
-		INSERT INTO user_progress (
-			user_id, name_number, phase, ease, streak, interval_days,
-			next_review_at, review_count, correct_count, first_seen_at, last_reviewed_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+// WithReplica returns a copy of the repository that reads stats and listings from a
+// read-only replica. They may lag slightly behind writes made through the primary.
+func (r *ProgressRepository) WithReplica(replica postgres.DBTX) *ProgressRepository {
+	return &ProgressRepository{db: r.db, replica: replica}
+}
+
+// reader returns the replica if one is set and the primary otherwise.
+func (r *ProgressRepository) reader() postgres.DBTX {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.db
+}
+
+// progressUpsertConflict updates an existing progress row with the inserted values.
+// mastered_at keeps the first time a name reached the mastered phase.
+const progressUpsertConflict = `
 		ON CONFLICT (user_id, name_number) DO UPDATE SET
 			phase = EXCLUDED.phase,
 			ease = EXCLUDED.ease,
@@ -40,8 +51,25 @@ func (r *ProgressRepository) Upsert(ctx context.Context, progress *entities.User
 			review_count = EXCLUDED.review_count,
 			correct_count = EXCLUDED.correct_count,
 			first_seen_at = COALESCE(user_progress.first_seen_at, EXCLUDED.first_seen_at),
-			last_reviewed_at = EXCLUDED.last_reviewed_at
-	`
+			last_reviewed_at = EXCLUDED.last_reviewed_at,
+			mastered_at = CASE
+				WHEN EXCLUDED.phase <> 'mastered' THEN NULL
+				WHEN user_progress.phase = 'mastered' THEN user_progress.mastered_at
+				ELSE COALESCE(EXCLUDED.last_reviewed_at, NOW())
+			END
+`
+
+// Upsert creates or updates a progress record within a transaction.
+func (r *ProgressRepository) Upsert(ctx context.Context, progress *entities.UserProgress) error {
+	query := `
+		INSERT INTO user_progress (
+			user_id, name_number, phase, ease, streak, interval_days,
+			next_review_at, review_count, correct_count, first_seen_at, last_reviewed_at,
+			mastered_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+			CASE WHEN $3 = 'mastered' THEN COALESCE($11, NOW()) END
+		)` + progressUpsertConflict
 
 	_, err := r.db.Exec(
 		ctx,
@@ -66,6 +94,69 @@ func (r *ProgressRepository) Upsert(ctx context.Context, progress *entities.User
 	return nil
 }
 
+// UpsertMany creates or updates several progress records in one statement.
+// Each (user, name) pair may appear only once.
+func (r *ProgressRepository) UpsertMany(ctx context.Context, progress []*entities.UserProgress) error {
+	if len(progress) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_progress (
+			user_id, name_number, phase, ease, streak, interval_days,
+			next_review_at, review_count, correct_count, first_seen_at, last_reviewed_at,
+			mastered_at
+		)
+		SELECT p.user_id, p.name_number, p.phase, p.ease, p.streak, p.interval_days,
+		       p.next_review_at, p.review_count, p.correct_count, p.first_seen_at, p.last_reviewed_at,
+		       CASE WHEN p.phase = 'mastered' THEN COALESCE(p.last_reviewed_at, NOW()) END
+		FROM unnest(
+			$1::bigint[], $2::smallint[], $3::varchar[], $4::numeric[], $5::smallint[], $6::int[],
+			$7::timestamptz[], $8::smallint[], $9::smallint[], $10::timestamptz[], $11::timestamptz[]
+		) AS p(
+			user_id, name_number, phase, ease, streak, interval_days,
+			next_review_at, review_count, correct_count, first_seen_at, last_reviewed_at
+		)` + progressUpsertConflict
+
+	n := len(progress)
+	var (
+		userIDs        = make([]int64, n)
+		nameNumbers    = make([]int, n)
+		phases         = make([]string, n)
+		eases          = make([]float64, n)
+		streaks        = make([]int, n)
+		intervals      = make([]int, n)
+		nextReviews    = make([]*time.Time, n)
+		reviewCounts   = make([]int, n)
+		correctCounts  = make([]int, n)
+		firstSeen      = make([]*time.Time, n)
+		lastReviewedAt = make([]*time.Time, n)
+	)
+	for i, p := range progress {
+		userIDs[i] = p.UserID
+		nameNumbers[i] = p.NameNumber
+		phases[i] = string(p.Phase)
+		eases[i] = p.Ease
+		streaks[i] = p.Streak
+		intervals[i] = p.IntervalDays
+		nextReviews[i] = p.NextReviewAt
+		reviewCounts[i] = p.ReviewCount
+		correctCounts[i] = p.CorrectCount
+		firstSeen[i] = p.FirstSeenAt
+		lastReviewedAt[i] = p.LastReviewedAt
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		userIDs, nameNumbers, phases, eases, streaks, intervals,
+		nextReviews, reviewCounts, correctCounts, firstSeen, lastReviewedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert progress batch: %w", err)
+	}
+
+	return nil
+}
+
 // Get retrieves a single progress record by userID and nameNumber.
 func (r *ProgressRepository) Get(ctx context.Context, userID int64, nameNumber int) (*entities.UserProgress, error) {
 	query := `
@@ -140,22 +231,75 @@ func toInt4(nums []int) []int32 {
 	return out
 }
 
-func (r *ProgressRepository) GetStreak(ctx context.Context, userID int64, nameNumber int) (int, error) {
+// GetStreaks returns the streaks of several names in one query. Names without progress are omitted.
+func (r *ProgressRepository) GetStreaks(ctx context.Context, userID int64, nums []int) (map[int]int, error) {
+	streaks := make(map[int]int, len(nums))
+	if len(nums) == 0 {
+		return streaks, nil
+	}
+
 	query := `
-		SELECT streak
-		FROM user_progress WHERE user_id = $1 AND name_number = $2
+		SELECT name_number, streak
+		FROM user_progress
+		WHERE user_id = $1 AND name_number = ANY($2::int4[])
 	`
 
-	var streak int
-	err := r.db.QueryRow(ctx, query, userID, nameNumber).Scan(&streak)
+	rows, err := r.db.Query(ctx, query, userID, toInt4(nums))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, ErrProgressNotFound
+		return nil, fmt.Errorf("get streaks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var num, streak int
+		if err := rows.Scan(&num, &streak); err != nil {
+			return nil, fmt.Errorf("scan streak: %w", err)
 		}
-		return 0, fmt.Errorf("get streak: %w", err)
+		streaks[num] = streak
 	}
 
-	return streak, nil
+	return streaks, rows.Err()
+}
+
+// MarkIntroducedMany stamps introduced_at on the progress of names that have none yet.
+// Names without progress get a new row in the "new" phase, which takes them out of the
+// pool of names not introduced yet.
+func (r *ProgressRepository) MarkIntroducedMany(ctx context.Context, userID int64, nums []int) error {
+	if len(nums) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_progress (user_id, name_number, phase, introduced_at)
+		SELECT DISTINCT $1::bigint, n, 'new', NOW()
+		FROM unnest($2::int4[]) AS n
+		ON CONFLICT (user_id, name_number) DO UPDATE SET
+			introduced_at = COALESCE(user_progress.introduced_at, EXCLUDED.introduced_at),
+			updated_at = NOW()
+		WHERE user_progress.introduced_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, toInt4(nums)); err != nil {
+		return fmt.Errorf("mark names introduced: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarkIntroduced returns a name to the pool of names not introduced yet by deleting its
+// progress, as long as the name was never reviewed. Names with reviews keep their progress.
+func (r *ProgressRepository) UnmarkIntroduced(ctx context.Context, userID int64, nameNumber int) error {
+	query := `
+		DELETE FROM user_progress
+		WHERE user_id = $1 AND name_number = $2 AND phase = 'new' AND review_count = 0
+		  AND last_reviewed_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, nameNumber); err != nil {
+		return fmt.Errorf("unmark name introduced: %w", err)
+	}
+
+	return nil
 }
 
 // GetNamesDueForReview retrieves names that need review based on SRS.
@@ -350,7 +494,7 @@ func (r *ProgressRepository) GetStats(ctx context.Context, userID int64) (*Progr
 	`
 
 	var stats ProgressStats
-	err := r.db.QueryRow(ctx, query, userID).Scan(
+	err := r.reader().QueryRow(ctx, query, userID).Scan(
 		&stats.TotalViewed,
 		&stats.NewCount,
 		&stats.LearningCount,
@@ -400,22 +544,25 @@ func (r *ProgressRepository) GetNextDueName(ctx context.Context, userID int64) (
 	return nameNumber, nil
 }
 
-// GetByUserID retrieves all progress records for a given user.
-func (r *ProgressRepository) GetByUserID(ctx context.Context, userID int64) ([]*entities.UserProgress, error) {
+// GetPage returns up to limit progress records of a user with name numbers greater than
+// afterNameNumber, in name order. Pass the last name number of a page to get the next one.
+func (r *ProgressRepository) GetPage(ctx context.Context, userID int64, afterNameNumber, limit int) ([]*entities.UserProgress, error) {
 	query := `
-    	SELECT user_id, name_number, last_reviewed_at, correct_count,
-    	       phase, ease, streak, interval_days, next_review_at, review_count, first_seen_at
-    	FROM user_progress
-    	WHERE user_id = $1
-    `
+		SELECT user_id, name_number, last_reviewed_at, correct_count,
+		       phase, ease, streak, interval_days, next_review_at, review_count, first_seen_at
+		FROM user_progress
+		WHERE user_id = $1 AND name_number > $2
+		ORDER BY name_number
+		LIMIT $3
+	`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.reader().Query(ctx, query, userID, afterNameNumber, limit)
 	if err != nil {
-		return nil, fmt.Errorf("get by user id: %w", err)
+		return nil, fmt.Errorf("get progress page: %w", err)
 	}
 	defer rows.Close()
 
-	var progress []*entities.UserProgress
+	progress := make([]*entities.UserProgress, 0, limit)
 	for rows.Next() {
 		var p entities.UserProgress
 		var phase string
@@ -442,3 +589,240 @@ func (r *ProgressRepository) GetByUserID(ctx context.Context, userID int64) ([]*
 
 	return progress, rows.Err()
 }
+
+// GetPhases returns the learning phases of several names. Names without progress are omitted.
+func (r *ProgressRepository) GetPhases(ctx context.Context, userID int64, nums []int) (map[int]entities.Phase, error) {
+	phases := make(map[int]entities.Phase, len(nums))
+	if len(nums) == 0 {
+		return phases, nil
+	}
+
+	query := `
+		SELECT name_number, phase
+		FROM user_progress
+		WHERE user_id = $1 AND name_number = ANY($2::int4[])
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, toInt4(nums))
+	if err != nil {
+		return nil, fmt.Errorf("get phases: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var num int
+		var phase string
+		if err := rows.Scan(&num, &phase); err != nil {
+			return nil, fmt.Errorf("scan phase: %w", err)
+		}
+		phases[num] = entities.Phase(phase)
+	}
+
+	return phases, rows.Err()
+}
+
+// DueForecastDay contains the number of names due for review on a single local day.
+type DueForecastDay struct {
+	Date  time.Time // local calendar day (time part is zero)
+	Count int       // number of names due on that day
+}
+
+// GetDueTimes returns the review times of names due before until, overdue ones
+// included. The caller groups them by local day, since a day's UTC offset depends on DST.
+func (r *ProgressRepository) GetDueTimes(ctx context.Context, userID int64, until time.Time) ([]time.Time, error) {
+	query := `
+		SELECT next_review_at
+		FROM user_progress
+		WHERE user_id = $1
+		  AND next_review_at IS NOT NULL
+		  AND next_review_at < $2
+		ORDER BY next_review_at
+	`
+
+	rows, err := r.reader().Query(ctx, query, userID, until)
+	if err != nil {
+		return nil, fmt.Errorf("get due times: %w", err)
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("scan due time: %w", err)
+		}
+		times = append(times, t)
+	}
+
+	return times, rows.Err()
+}
+
+// NameAnswerStats contains quiz answer statistics of a user for a single name.
+type NameAnswerStats struct {
+	Total          int        // total number of answers
+	Correct        int        // number of correct answers
+	LastAnsweredAt *time.Time // timestamp of the last answer (nullable)
+}
+
+// GetNameAnswerStats returns quiz answer statistics for a single name, archived answers included.
+func (r *ProgressRepository) GetNameAnswerStats(ctx context.Context, userID int64, nameNumber int) (*NameAnswerStats, error) {
+	query := `
+		SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_correct) AS correct,
+			MAX(answered_at) AS last_answered_at
+		FROM ` + allQuizAnswers + ` qa
+		WHERE user_id = $1 AND name_number = $2
+	`
+
+	var stats NameAnswerStats
+	err := r.reader().QueryRow(ctx, query, userID, nameNumber).Scan(
+		&stats.Total,
+		&stats.Correct,
+		&stats.LastAnsweredAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get name answer stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// PeriodStats contains quiz activity of a user within a time range.
+type PeriodStats struct {
+	Answers    int // number of quiz answers
+	Correct    int // number of correct answers
+	Mastered   int // names that reached the mastered phase
+	NewNames   int // names answered for the first time
+	ActiveDays int // local days with at least one answer
+}
+
+// Accuracy returns the percentage of correct answers within the period.
+func (s *PeriodStats) Accuracy() float64 {
+	if s.Answers == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Answers) * 100
+}
+
+// GetPeriodStats returns quiz activity and newly mastered names within [from, to).
+// offsetSec is the user's UTC offset in seconds used to count local active days.
+func (r *ProgressRepository) GetPeriodStats(ctx context.Context, userID int64, from, to time.Time, offsetSec int) (*PeriodStats, error) {
+	query := `
+		SELECT
+			COUNT(*) AS answers,
+			COUNT(*) FILTER (WHERE qa.is_correct) AS correct,
+			COUNT(DISTINCT ((qa.answered_at AT TIME ZONE 'UTC') + make_interval(secs => $4))::date) AS active_days,
+			(SELECT COUNT(*)
+			 FROM user_progress
+			 WHERE user_id = $1 AND phase = 'mastered'
+			   AND mastered_at >= $2 AND mastered_at < $3) AS mastered,
+			(SELECT COUNT(*)
+			 FROM (SELECT MIN(answered_at) AS first_answered_at
+			       FROM ` + allQuizAnswers + ` a
+			       WHERE user_id = $1
+			       GROUP BY name_number) first
+			 WHERE first.first_answered_at >= $2 AND first.first_answered_at < $3) AS new_names
+		FROM ` + allQuizAnswers + ` qa
+		WHERE qa.user_id = $1 AND qa.answered_at >= $2 AND qa.answered_at < $3
+	`
+
+	var stats PeriodStats
+	err := r.reader().QueryRow(ctx, query, userID, from, to, offsetSec).Scan(
+		&stats.Answers,
+		&stats.Correct,
+		&stats.ActiveDays,
+		&stats.Mastered,
+		&stats.NewNames,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get period stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// ActivityDay contains the number of quiz answers on a single local day.
+type ActivityDay struct {
+	Date    time.Time // local calendar day (time part is zero)
+	Answers int       // number of quiz answers on that day
+}
+
+// GetActivityDays returns quiz answers within [from, to) grouped by local day.
+// offsetSec is the user's UTC offset in seconds.
+func (r *ProgressRepository) GetActivityDays(ctx context.Context, userID int64, from, to time.Time, offsetSec int) ([]ActivityDay, error) {
+	query := `
+		SELECT
+			((answered_at AT TIME ZONE 'UTC') + make_interval(secs => $4))::date AS day,
+			COUNT(*) AS answers
+		FROM ` + allQuizAnswers + ` qa
+		WHERE user_id = $1
+		  AND answered_at >= $2
+		  AND answered_at < $3
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.reader().Query(ctx, query, userID, from, to, offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get activity days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []ActivityDay
+	for rows.Next() {
+		var d ActivityDay
+		if err := rows.Scan(&d.Date, &d.Answers); err != nil {
+			return nil, fmt.Errorf("scan activity day: %w", err)
+		}
+		days = append(days, d)
+	}
+
+	return days, rows.Err()
+}
+
+// QuestionTypeStats contains quiz answer statistics for a single question type.
+type QuestionTypeStats struct {
+	QuestionType string // "translation", "transliteration", "meaning", "arabic"
+	Total        int    // total number of answers
+	Correct      int    // number of correct answers
+}
+
+// Accuracy returns the percentage of correct answers for the question type.
+func (s *QuestionTypeStats) Accuracy() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Total) * 100
+}
+
+// GetQuestionTypeStats returns quiz answer statistics grouped by question type.
+func (r *ProgressRepository) GetQuestionTypeStats(ctx context.Context, userID int64) ([]QuestionTypeStats, error) {
+	query := `
+		SELECT
+			question_type,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_correct) AS correct
+		FROM ` + allQuizAnswers + ` qa
+		WHERE user_id = $1 AND question_type IS NOT NULL
+		GROUP BY question_type
+		ORDER BY question_type
+	`
+
+	rows, err := r.reader().Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get question type stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []QuestionTypeStats
+	for rows.Next() {
+		var st QuestionTypeStats
+		if err := rows.Scan(&st.QuestionType, &st.Total, &st.Correct); err != nil {
+			return nil, fmt.Errorf("scan question type stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+
+	return stats, rows.Err()
+}
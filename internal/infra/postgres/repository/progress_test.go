@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+	"github.com/aliskhannn/asma-ul-husna-bot/migrations"
+)
+
+// testPool connects to the database in TEST_DATABASE_URL and applies the migrations.
+// The test is skipped when the variable is not set.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrator, err := postgres.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	defer migrator.Close()
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return pool
+}
+
+// testUser inserts a user that is deleted with its progress when the test ends.
+func testUser(t *testing.T, pool *pgxpool.Pool) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	userID := -time.Now().UnixNano()
+	if _, err := pool.Exec(ctx, `INSERT INTO users (id, chat_id) VALUES ($1, $1)`, userID); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	return userID
+}
+
+func TestMarkIntroducedMany(t *testing.T) {
+	pool := testPool(t)
+	userID := testUser(t, pool)
+	ctx := context.Background()
+	repo := NewProgressRepository(pool)
+
+	// Name 2 has progress without introduced_at, name 3 was introduced earlier and
+	// name 1 has no progress row at all.
+	introduced := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO user_progress (user_id, name_number, phase, review_count, introduced_at)
+		VALUES ($1, 2, 'learning', 1, NULL), ($1, 3, 'learning', 1, $2)
+	`, userID, introduced); err != nil {
+		t.Fatalf("insert progress: %v", err)
+	}
+
+	if err := repo.MarkIntroducedMany(ctx, userID, []int{1, 2, 3, 1}); err != nil {
+		t.Fatalf("MarkIntroducedMany: %v", err)
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT name_number, phase, introduced_at
+		FROM user_progress
+		WHERE user_id = $1
+		ORDER BY name_number
+	`, userID)
+	if err != nil {
+		t.Fatalf("query progress: %v", err)
+	}
+	defer rows.Close()
+
+	type row struct {
+		phase        string
+		introducedAt *time.Time
+	}
+	got := make(map[int]row)
+	for rows.Next() {
+		var num int
+		var r row
+		if err := rows.Scan(&num, &r.phase, &r.introducedAt); err != nil {
+			t.Fatalf("scan progress: %v", err)
+		}
+		got[num] = r
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("read progress: %v", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d progress rows, want 3", len(got))
+	}
+	if r := got[1]; r.phase != "new" || r.introducedAt == nil {
+		t.Errorf("name without progress: phase %q, introduced_at %v; want new and set", r.phase, r.introducedAt)
+	}
+	if r := got[2]; r.phase != "learning" || r.introducedAt == nil {
+		t.Errorf("name not introduced yet: phase %q, introduced_at %v; want learning and set", r.phase, r.introducedAt)
+	}
+	if r := got[3]; r.introducedAt == nil || !r.introducedAt.Equal(introduced) {
+		t.Errorf("introduced name: introduced_at %v, want %v", r.introducedAt, introduced)
+	}
+
+	names, err := repo.GetNamesForIntroduction(ctx, userID, 3)
+	if err != nil {
+		t.Fatalf("GetNamesForIntroduction: %v", err)
+	}
+	for _, n := range names {
+		if n == 1 {
+			t.Errorf("name 1 is still offered for introduction after being marked introduced")
+		}
+	}
+}
+
+func TestUnmarkIntroduced(t *testing.T) {
+	pool := testPool(t)
+	userID := testUser(t, pool)
+	ctx := context.Background()
+	repo := NewProgressRepository(pool)
+
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO user_progress (user_id, name_number, phase, review_count, introduced_at)
+		VALUES ($1, 2, 'learning', 1, NOW())
+	`, userID); err != nil {
+		t.Fatalf("insert progress: %v", err)
+	}
+	if err := repo.MarkIntroducedMany(ctx, userID, []int{1}); err != nil {
+		t.Fatalf("MarkIntroducedMany: %v", err)
+	}
+
+	for _, n := range []int{1, 2} {
+		if err := repo.UnmarkIntroduced(ctx, userID, n); err != nil {
+			t.Fatalf("UnmarkIntroduced(%d): %v", n, err)
+		}
+	}
+
+	names, err := repo.GetNamesForIntroduction(ctx, userID, 2)
+	if err != nil {
+		t.Fatalf("GetNamesForIntroduction: %v", err)
+	}
+	if want := []int{1, 3}; !slices.Equal(names, want) {
+		t.Errorf("names for introduction = %v, want %v", names, want)
+	}
+}
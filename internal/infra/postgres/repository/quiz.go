@@ -214,6 +214,53 @@ func (r *QuizRepository) SaveAnswer(ctx context.Context, answer *entities.QuizAn
 	return nil
 }
 
+// RecordAnswerAndUpdateSRS saves an answer and the progress of its name updated for it
+// in a single round trip.
+func (r *QuizRepository) RecordAnswerAndUpdateSRS(ctx context.Context, answer *entities.QuizAnswer, progress *entities.UserProgress) error {
+	query := `
+		WITH answer AS (
+			INSERT INTO quiz_answers (user_id, session_id, question_id, name_number, user_answer, correct_answer, question_type, is_correct, answered_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		)
+		INSERT INTO user_progress (
+			user_id, name_number, phase, ease, streak, interval_days,
+			next_review_at, review_count, correct_count, first_seen_at, last_reviewed_at,
+			mastered_at
+		) VALUES (
+			$1, $4, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			CASE WHEN $10 = 'mastered' THEN COALESCE($18, NOW()) END
+		)` + progressUpsertConflict
+
+	_, err := r.db.Exec(
+		ctx,
+		query,
+		answer.UserID,
+		answer.SessionID,
+		answer.QuestionID,
+		answer.NameNumber,
+		answer.UserAnswer,
+		answer.CorrectAnswer,
+		answer.QuestionType,
+		answer.IsCorrect,
+		answer.AnsweredAt,
+		progress.Phase,
+		progress.Ease,
+		progress.Streak,
+		progress.IntervalDays,
+		progress.NextReviewAt,
+		progress.ReviewCount,
+		progress.CorrectCount,
+		progress.FirstSeenAt,
+		progress.LastReviewedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("record answer: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateSession updates a quiz session using optimistic locking.
 func (r *QuizRepository) UpdateSession(ctx context.Context, session *entities.QuizSession) error {
 	query := `
@@ -282,3 +329,72 @@ func (r *QuizRepository) IsFirstQuiz(ctx context.Context, userID int64) (bool, e
 	}
 	return first, nil
 }
+
+// allQuizAnswers is a subquery over recent and archived quiz answers for lifetime
+// history and stats. Filters on user_id and answered_at reach both tables' indexes.
+const allQuizAnswers = `(
+	SELECT id, user_id, session_id, question_id, name_number, user_answer, correct_answer,
+	       question_type, is_correct, answered_at
+	FROM quiz_answers
+	UNION ALL
+	SELECT id, user_id, session_id, question_id, name_number, user_answer, correct_answer,
+	       question_type, is_correct, answered_at
+	FROM quiz_answers_archive
+)`
+
+// ListAnswers returns the whole quiz answer history of a user in chronological order,
+// archived answers included.
+func (r *QuizRepository) ListAnswers(ctx context.Context, userID int64) ([]*entities.QuizAnswer, error) {
+	query := `
+		SELECT id, user_id, session_id, question_id, name_number,
+		       COALESCE(user_answer, ''), COALESCE(correct_answer, ''), COALESCE(question_type, ''),
+		       is_correct, answered_at
+		FROM ` + allQuizAnswers + ` qa
+		WHERE user_id = $1
+		ORDER BY answered_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list answers: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []*entities.QuizAnswer
+	for rows.Next() {
+		var a entities.QuizAnswer
+		if err := rows.Scan(
+			&a.ID,
+			&a.UserID,
+			&a.SessionID,
+			&a.QuestionID,
+			&a.NameNumber,
+			&a.UserAnswer,
+			&a.CorrectAnswer,
+			&a.QuestionType,
+			&a.IsCorrect,
+			&a.AnsweredAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan answer: %w", err)
+		}
+		answers = append(answers, &a)
+	}
+
+	return answers, rows.Err()
+}
+
+// CountAnswers returns how many quiz answers a user has given, archived answers included.
+func (r *QuizRepository) CountAnswers(ctx context.Context, userID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM ` + allQuizAnswers + ` qa
+		WHERE user_id = $1
+	`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count answers: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// ReleaseNotesRepository provides release notes loaded from a JSON file shipped with the bot.
+type ReleaseNotesRepository struct {
+	releases []entities.Release // newest first
+}
+
+// NewReleaseNotesRepository loads release notes from the JSON file at path.
+func NewReleaseNotesRepository(path string) (*ReleaseNotesRepository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper struct {
+		Releases []struct {
+			Version string              `json:"version"`
+			Date    string              `json:"date"`
+			Notes   map[string][]string `json:"notes"`
+		} `json:"releases"`
+	}
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal changelog JSON: %w", err)
+	}
+
+	releases := make([]entities.Release, 0, len(wrapper.Releases))
+	for _, r := range wrapper.Releases {
+		date, err := time.Parse(time.DateOnly, r.Date)
+		if err != nil {
+			return nil, fmt.Errorf("release %s: invalid date %q: %w", r.Version, r.Date, err)
+		}
+		if r.Version == "" || len(r.Notes[entities.DefaultChangelogLanguage]) == 0 {
+			return nil, fmt.Errorf("release %q: version and %s notes are required", r.Version, entities.DefaultChangelogLanguage)
+		}
+		releases = append(releases, entities.Release{Version: r.Version, Date: date, Notes: r.Notes})
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].Date.After(releases[j].Date)
+	})
+
+	return &ReleaseNotesRepository{releases: releases}, nil
+}
+
+// Latest returns up to limit releases, newest first.
+func (r *ReleaseNotesRepository) Latest(limit int) []entities.Release {
+	return r.releases[:min(limit, len(r.releases))]
+}
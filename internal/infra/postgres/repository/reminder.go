@@ -29,7 +29,9 @@ func NewRemindersRepository(db postgres.DBTX) *ReminderRepository {
 func (r *ReminderRepository) GetByUserID(ctx context.Context, userID int64) (*entities.UserReminders, error) {
 	query := `
 		SELECT user_id, is_enabled, interval_hours, start_time, end_time,
-		       last_sent_at, next_send_at, last_kind, created_at, updated_at
+		       last_sent_at, next_send_at, last_kind, weekly_digest_enabled,
+		       quiet_weekdays, quiet_dates, style, content, prayer_anchors, prayer_offset_minutes,
+		       latitude, longitude, created_at, updated_at
 		FROM user_reminders
 		WHERE user_id = $1
 	`
@@ -48,6 +50,15 @@ func (r *ReminderRepository) GetByUserID(ctx context.Context, userID int64) (*en
 		&lastSent,
 		&nextSend,
 		&lastKind,
+		&reminder.WeeklyDigest,
+		&reminder.Quiet.Weekdays,
+		&reminder.Quiet.Dates,
+		&reminder.Style,
+		&reminder.Content,
+		&reminder.Prayer.Prayers,
+		&reminder.Prayer.OffsetMinutes,
+		&reminder.Prayer.Latitude,
+		&reminder.Prayer.Longitude,
 		&reminder.CreatedAt,
 		&reminder.UpdatedAt,
 	)
@@ -98,8 +109,10 @@ func (r *ReminderRepository) Upsert(ctx context.Context, reminder *entities.User
 	query := `
 		INSERT INTO user_reminders (
 			user_id, is_enabled, interval_hours, start_time, end_time,
-			last_sent_at, next_send_at, last_kind, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			last_sent_at, next_send_at, last_kind, weekly_digest_enabled,
+			quiet_weekdays, quiet_dates, style, content, prayer_anchors, prayer_offset_minutes,
+			latitude, longitude, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		ON CONFLICT (user_id) DO UPDATE SET
 			is_enabled = EXCLUDED.is_enabled,
 			interval_hours = EXCLUDED.interval_hours,
@@ -108,9 +121,33 @@ func (r *ReminderRepository) Upsert(ctx context.Context, reminder *entities.User
 			last_sent_at = EXCLUDED.last_sent_at,
 			next_send_at = EXCLUDED.next_send_at,
 			last_kind = EXCLUDED.last_kind,
+			weekly_digest_enabled = EXCLUDED.weekly_digest_enabled,
+			quiet_weekdays = EXCLUDED.quiet_weekdays,
+			quiet_dates = EXCLUDED.quiet_dates,
+			style = EXCLUDED.style,
+			content = EXCLUDED.content,
+			prayer_anchors = EXCLUDED.prayer_anchors,
+			prayer_offset_minutes = EXCLUDED.prayer_offset_minutes,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
 			updated_at = EXCLUDED.updated_at
 	`
 
+	quietDates := reminder.Quiet.Dates
+	if quietDates == nil {
+		quietDates = []time.Time{}
+	}
+
+	style := reminder.Style
+	if style == "" {
+		style = entities.ReminderStyleName
+	}
+
+	content := reminder.Content
+	if !content.IsValid() {
+		content = entities.ReminderContentMixed
+	}
+
 	_, err = r.db.Exec(
 		ctx,
 		query,
@@ -122,6 +159,15 @@ func (r *ReminderRepository) Upsert(ctx context.Context, reminder *entities.User
 		reminder.LastSentAt,
 		nextSendAt,
 		reminder.LastKind,
+		reminder.WeeklyDigest,
+		reminder.Quiet.Weekdays,
+		quietDates,
+		style,
+		content,
+		reminder.Prayer.Prayers,
+		reminder.Prayer.OffsetMinutes,
+		reminder.Prayer.Latitude,
+		reminder.Prayer.Longitude,
 		reminder.CreatedAt,
 		reminder.UpdatedAt,
 	)
@@ -153,6 +199,7 @@ func (r *ReminderRepository) GetDueReminder(ctx context.Context, userID int64) (
         LEFT JOIN user_settings us ON ur.user_id = us.user_id
         WHERE ur.is_enabled = true
           AND u.is_active = true
+          AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
           AND ur.user_id = $1
           AND (ur.next_send_at IS NULL OR ur.next_send_at <= $2)
         ORDER BY ur.next_send_at NULLS FIRST
@@ -201,10 +248,11 @@ func (r *ReminderRepository) GetDueReminder(ctx context.Context, userID int64) (
 	return &rwu, nil
 }
 
-// GetDueRemindersBatch retrieves reminders that are due to be sent (paginated).
-func (r *ReminderRepository) GetDueRemindersBatch(ctx context.Context, now time.Time, limit, offset int) ([]*entities.ReminderWithUser, error) {
+// GetReminderWithUser retrieves the reminder of a user together with user data,
+// whether or not it is enabled or due.
+func (r *ReminderRepository) GetReminderWithUser(ctx context.Context, userID int64) (*entities.ReminderWithUser, error) {
 	query := `
-		SELECT 
+		SELECT
 			ur.user_id,
 			u.chat_id,
 			ur.is_enabled,
@@ -214,20 +262,141 @@ func (r *ReminderRepository) GetDueRemindersBatch(ctx context.Context, now time.
 			ur.last_sent_at,
 			ur.next_send_at,
 			ur.last_kind,
-			COALESCE(us.timezone, 'UTC') as timezone
+			COALESCE(us.timezone, 'UTC') as timezone,
+			ur.quiet_weekdays,
+			ur.quiet_dates,
+			ur.style,
+			ur.content,
+			ur.prayer_anchors,
+			ur.prayer_offset_minutes,
+			ur.latitude,
+			ur.longitude,
+			COALESCE(us.language_code, 'ru') as language_code
 		FROM user_reminders ur
 		INNER JOIN users u ON ur.user_id = u.id
 		LEFT JOIN user_settings us ON ur.user_id = us.user_id
-		WHERE ur.is_enabled = true
-			AND u.is_active = true
-			AND (ur.next_send_at IS NULL OR ur.next_send_at <= $1)
-		ORDER BY ur.next_send_at NULLS FIRST, ur.user_id
-		LIMIT $2 OFFSET $3
+		WHERE ur.user_id = $1
+	`
+
+	var rwu entities.ReminderWithUser
+	var lastSent pgtype.Timestamptz
+	var nextSend pgtype.Timestamptz
+	var lastKind string
+
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&rwu.UserID,
+		&rwu.ChatID,
+		&rwu.IsEnabled,
+		&rwu.IntervalHours,
+		&rwu.StartTime,
+		&rwu.EndTime,
+		&lastSent,
+		&nextSend,
+		&lastKind,
+		&rwu.Timezone,
+		&rwu.Quiet.Weekdays,
+		&rwu.Quiet.Dates,
+		&rwu.Style,
+		&rwu.Content,
+		&rwu.Prayer.Prayers,
+		&rwu.Prayer.OffsetMinutes,
+		&rwu.Prayer.Latitude,
+		&rwu.Prayer.Longitude,
+		&rwu.Language,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReminderNotFound
+		}
+		return nil, fmt.Errorf("get reminder with user: %w", err)
+	}
+
+	if lastSent.Valid {
+		t := lastSent.Time
+		rwu.LastSentAt = &t
+	}
+	if nextSend.Valid {
+		t := nextSend.Time
+		rwu.NextSendAt = &t
+	}
+	rwu.LastKind = entities.ReminderKind(lastKind)
+	if rwu.LastKind == "" {
+		rwu.LastKind = entities.ReminderKindNew
+	}
+
+	return &rwu, nil
+}
+
+// ClaimDueRemindersBatch claims up to limit due reminders for this instance and returns them.
+//
+// Rows are locked with FOR UPDATE SKIP LOCKED and stamped with claimed_at, so concurrent
+// bot instances never receive the same reminder. A claim is released by UpdateAfterSend or
+// RescheduleNext; claims older than claimTTL are treated as abandoned (e.g. the instance
+// crashed mid-batch) and can be taken again.
+func (r *ReminderRepository) ClaimDueRemindersBatch(ctx context.Context, now time.Time, claimTTL time.Duration, limit int) ([]*entities.ReminderWithUser, error) {
+	query := `
+		WITH due AS (
+			SELECT
+				ur.user_id,
+				u.chat_id,
+				ur.is_enabled,
+				ur.interval_hours,
+				ur.start_time,
+				ur.end_time,
+				ur.last_sent_at,
+				ur.next_send_at,
+				ur.last_kind,
+				COALESCE(us.timezone, 'UTC') as timezone,
+				ur.quiet_weekdays,
+				ur.quiet_dates,
+				ur.style,
+				ur.content,
+				ur.prayer_anchors,
+				ur.prayer_offset_minutes,
+				ur.latitude,
+				ur.longitude,
+				COALESCE(us.language_code, 'ru') as language_code
+			FROM user_reminders ur
+			INNER JOIN users u ON ur.user_id = u.id
+			LEFT JOIN user_settings us ON ur.user_id = us.user_id
+			WHERE ur.is_enabled = true
+				AND u.is_active = true
+				AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+				AND (ur.next_send_at IS NULL OR ur.next_send_at <= $1)
+				AND (ur.claimed_at IS NULL OR ur.claimed_at < $2)
+			ORDER BY ur.next_send_at NULLS FIRST, ur.user_id
+			LIMIT $3
+			FOR UPDATE OF ur SKIP LOCKED
+		)
+		UPDATE user_reminders ur
+		SET claimed_at = $1
+		FROM due
+		WHERE ur.user_id = due.user_id
+		RETURNING
+			due.user_id,
+			due.chat_id,
+			due.is_enabled,
+			due.interval_hours,
+			due.start_time,
+			due.end_time,
+			due.last_sent_at,
+			due.next_send_at,
+			due.last_kind,
+			due.timezone,
+			due.quiet_weekdays,
+			due.quiet_dates,
+			due.style,
+			due.content,
+			due.prayer_anchors,
+			due.prayer_offset_minutes,
+			due.latitude,
+			due.longitude,
+			due.language_code
 	`
 
-	rows, err := r.db.Query(ctx, query, now, limit, offset)
+	rows, err := r.db.Query(ctx, query, now, now.Add(-claimTTL), limit)
 	if err != nil {
-		return nil, fmt.Errorf("get due reminders batch: %w", err)
+		return nil, fmt.Errorf("claim due reminders batch: %w", err)
 	}
 	defer rows.Close()
 
@@ -249,6 +418,15 @@ func (r *ReminderRepository) GetDueRemindersBatch(ctx context.Context, now time.
 			&nextSend,
 			&lastKind,
 			&rwu.Timezone,
+			&rwu.Quiet.Weekdays,
+			&rwu.Quiet.Dates,
+			&rwu.Style,
+			&rwu.Content,
+			&rwu.Prayer.Prayers,
+			&rwu.Prayer.OffsetMinutes,
+			&rwu.Prayer.Latitude,
+			&rwu.Prayer.Longitude,
+			&rwu.Language,
 		); err != nil {
 			return nil, fmt.Errorf("scan reminder: %w", err)
 		}
@@ -272,13 +450,14 @@ func (r *ReminderRepository) GetDueRemindersBatch(ctx context.Context, now time.
 	return reminders, rows.Err()
 }
 
-// UpdateAfterSend updates last_sent_at and next_send_at after sending a reminder.
+// UpdateAfterSend updates last_sent_at and next_send_at after sending a reminder and releases the claim.
 func (r *ReminderRepository) UpdateAfterSend(ctx context.Context, userID int64, sentAt time.Time, nextSendAt time.Time, lastKind entities.ReminderKind) error {
 	query := `
 		UPDATE user_reminders
 		SET last_sent_at = $1,
 		    next_send_at = $2,
 		    last_kind = $3,
+		    claimed_at = NULL,
 		    updated_at = $4
 		WHERE user_id = $5
 	`
@@ -315,10 +494,12 @@ func (r *ReminderRepository) MarkAsSent(ctx context.Context, userID int64, sentA
 	return nil
 }
 
+// RescheduleNext moves the next reminder without sending and releases the claim.
 func (r *ReminderRepository) RescheduleNext(ctx context.Context, userID int64, nextSendAt time.Time) error {
 	query := `
         UPDATE user_reminders
         SET next_send_at = $1,
+            claimed_at = NULL,
             updated_at = $2
         WHERE user_id = $3
     `
@@ -331,3 +512,221 @@ func (r *ReminderRepository) RescheduleNext(ctx context.Context, userID int64, n
 	}
 	return nil
 }
+
+// GetDigestRecipientsBatch retrieves users who opted in to the weekly digest (paginated).
+func (r *ReminderRepository) GetDigestRecipientsBatch(ctx context.Context, limit, offset int) ([]*entities.DigestRecipient, error) {
+	query := `
+		SELECT
+			ur.user_id,
+			u.chat_id,
+			COALESCE(us.timezone, 'UTC') as timezone,
+			ur.last_digest_sent_at
+		FROM user_reminders ur
+		INNER JOIN users u ON ur.user_id = u.id
+		LEFT JOIN user_settings us ON ur.user_id = us.user_id
+		WHERE ur.weekly_digest_enabled = true
+			AND u.is_active = true
+			AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+		ORDER BY ur.user_id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get digest recipients batch: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*entities.DigestRecipient
+	for rows.Next() {
+		var dr entities.DigestRecipient
+		var lastSent pgtype.Timestamptz
+
+		if err := rows.Scan(&dr.UserID, &dr.ChatID, &dr.Timezone, &lastSent); err != nil {
+			return nil, fmt.Errorf("scan digest recipient: %w", err)
+		}
+
+		if lastSent.Valid {
+			t := lastSent.Time
+			dr.LastDigestSentAt = &t
+		}
+
+		recipients = append(recipients, &dr)
+	}
+
+	return recipients, rows.Err()
+}
+
+// MarkDigestSent updates the timestamp of the last sent weekly digest.
+func (r *ReminderRepository) MarkDigestSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	query := `
+		UPDATE user_reminders
+		SET last_digest_sent_at = $1, updated_at = $2
+		WHERE user_id = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, sentAt, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("mark digest sent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrReminderNotFound
+	}
+
+	return nil
+}
+
+// GetStreakAlertCandidatesBatch retrieves users with reminders enabled and an active streak (paginated).
+func (r *ReminderRepository) GetStreakAlertCandidatesBatch(ctx context.Context, limit, offset int) ([]*entities.StreakAlertCandidate, error) {
+	query := `
+		SELECT
+			ur.user_id,
+			u.chat_id,
+			COALESCE(us.timezone, 'UTC') as timezone,
+			s.current_streak,
+			s.best_streak,
+			s.last_active_date,
+			ur.last_streak_alert_at,
+			ur.quiet_weekdays,
+			ur.quiet_dates
+		FROM user_reminders ur
+		INNER JOIN users u ON ur.user_id = u.id
+		INNER JOIN user_streaks s ON ur.user_id = s.user_id
+		LEFT JOIN user_settings us ON ur.user_id = us.user_id
+		WHERE ur.is_enabled = true
+			AND u.is_active = true
+			AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+			AND s.current_streak > 0
+			AND s.last_active_date IS NOT NULL
+		ORDER BY ur.user_id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get streak alert candidates batch: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*entities.StreakAlertCandidate
+	for rows.Next() {
+		var c entities.StreakAlertCandidate
+		var lastAlert pgtype.Timestamptz
+
+		if err := rows.Scan(
+			&c.UserID,
+			&c.ChatID,
+			&c.Timezone,
+			&c.Streak.CurrentStreak,
+			&c.Streak.BestStreak,
+			&c.Streak.LastActiveDate,
+			&lastAlert,
+			&c.Quiet.Weekdays,
+			&c.Quiet.Dates,
+		); err != nil {
+			return nil, fmt.Errorf("scan streak alert candidate: %w", err)
+		}
+
+		c.Streak.UserID = c.UserID
+		if lastAlert.Valid {
+			t := lastAlert.Time
+			c.LastAlertAt = &t
+		}
+
+		candidates = append(candidates, &c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// GetReminderCandidates reads today's plan, the progress of planned names and the most overdue
+// name in one query. A planned name is studied while its streak is below masteryStreak.
+func (r *ReminderRepository) GetReminderCandidates(ctx context.Context, userID int64, dateUTC time.Time, masteryStreak int) (*entities.ReminderCandidates, error) {
+	dateUTC = dateUTC.UTC().Truncate(24 * time.Hour)
+
+	query := `
+		WITH plan AS (
+			SELECT d.name_number::int AS name_number, d.slot_index,
+			       COALESCE(p.streak, 0) AS streak, p.user_id IS NOT NULL AS has_progress
+			FROM user_daily_name d
+			LEFT JOIN user_progress p ON p.user_id = d.user_id AND p.name_number = d.name_number
+			WHERE d.user_id = $1 AND d.date_utc = $2
+		)
+		SELECT
+			COALESCE((
+				SELECT name_number::int
+				FROM user_progress
+				WHERE user_id = $1
+				  AND next_review_at IS NOT NULL
+				  AND next_review_at <= NOW()
+				ORDER BY next_review_at
+				LIMIT 1
+			), 0),
+			ARRAY(SELECT name_number FROM plan ORDER BY slot_index),
+			ARRAY(SELECT name_number FROM plan WHERE streak < $3 ORDER BY slot_index),
+			ARRAY(SELECT name_number FROM plan WHERE NOT has_progress ORDER BY slot_index)
+	`
+
+	var c entities.ReminderCandidates
+	err := r.db.QueryRow(ctx, query, userID, dateUTC, masteryStreak).Scan(
+		&c.DueName,
+		&c.Planned,
+		&c.Study,
+		&c.New,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get reminder candidates: %w", err)
+	}
+
+	return &c, nil
+}
+
+// MarkStreakAlertSent updates the timestamp of the last streak-protection reminder.
+func (r *ReminderRepository) MarkStreakAlertSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	query := `
+		UPDATE user_reminders
+		SET last_streak_alert_at = $1, updated_at = $2
+		WHERE user_id = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, sentAt, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("mark streak alert sent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrReminderNotFound
+	}
+
+	return nil
+}
+
+// GetActivityHours returns how often the user interacted with the bot in each local hour.
+func (r *ReminderRepository) GetActivityHours(ctx context.Context, userID int64) (entities.ActivityHours, error) {
+	query := `
+		SELECT hour, hits
+		FROM user_activity_hours
+		WHERE user_id = $1
+	`
+
+	var hours entities.ActivityHours
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return hours, fmt.Errorf("get activity hours: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour, hits int
+		if err := rows.Scan(&hour, &hits); err != nil {
+			return hours, fmt.Errorf("scan activity hour: %w", err)
+		}
+		if hour >= 0 && hour < len(hours) {
+			hours[hour] = hits
+		}
+	}
+
+	return hours, rows.Err()
+}
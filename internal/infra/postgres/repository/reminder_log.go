@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// ReminderLogRepository stores the history of notification delivery attempts.
+type ReminderLogRepository struct {
+	db postgres.DBTX
+}
+
+// NewReminderLogRepository creates a new ReminderLogRepository.
+func NewReminderLogRepository(db postgres.DBTX) *ReminderLogRepository {
+	return &ReminderLogRepository{db: db}
+}
+
+// Record stores a delivery attempt.
+func (r *ReminderLogRepository) Record(ctx context.Context, entry *entities.ReminderLogEntry) error {
+	query := `
+		INSERT INTO reminder_log (
+			user_id, job_id, kind, reminder_kind, name_number, outcome, attempt, error, created_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, 0), $6, $7, NULLIF($8, ''), $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		entry.UserID,
+		entry.JobID,
+		entry.Kind,
+		string(entry.ReminderKind),
+		entry.NameNumber,
+		entry.Outcome,
+		entry.Attempt,
+		entry.Error,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("record reminder log: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveryStats counts delivery attempts since the given time by kind and outcome,
+// together with the current size of the notification queue.
+func (r *ReminderLogRepository) GetDeliveryStats(ctx context.Context, since time.Time) (*entities.DeliveryStats, error) {
+	stats := &entities.DeliveryStats{
+		Since:  since,
+		ByKind: make(map[entities.NotificationKind]entities.DeliveryCounts),
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT kind, outcome, COUNT(*)
+		FROM reminder_log
+		WHERE created_at >= $1
+		GROUP BY kind, outcome
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("get delivery stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var kind entities.NotificationKind
+		var outcome entities.DeliveryOutcome
+		var n int
+		if err := rows.Scan(&kind, &outcome, &n); err != nil {
+			return nil, fmt.Errorf("scan delivery stats: %w", err)
+		}
+		if stats.ByKind[kind] == nil {
+			stats.ByKind[kind] = entities.DeliveryCounts{}
+		}
+		stats.ByKind[kind][outcome] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get delivery stats: %w", err)
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'failed')
+		FROM notification_jobs
+	`).Scan(&stats.QueuePending, &stats.QueueFailed)
+	if err != nil {
+		return nil, fmt.Errorf("get notification queue size: %w", err)
+	}
+
+	return stats, nil
+}
@@ -19,12 +19,44 @@ func (s *ResetRepository) ResetUser(ctx context.Context, userID int64) error {
 	if _, err := s.db.Exec(ctx, `DELETE FROM quiz_sessions WHERE user_id = $1`, userID); err != nil {
 		return fmt.Errorf("delete quiz_sessions: %w", err)
 	}
+	if _, err := s.db.Exec(ctx, `DELETE FROM quiz_answers_archive WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete quiz_answers_archive: %w", err)
+	}
 	if _, err := s.db.Exec(ctx, `DELETE FROM user_daily_name WHERE user_id = $1`, userID); err != nil {
 		return fmt.Errorf("delete user_daily_name: %w", err)
 	}
 	if _, err := s.db.Exec(ctx, `DELETE FROM user_progress WHERE user_id = $1`, userID); err != nil {
 		return fmt.Errorf("delete user_progress: %w", err)
 	}
+	if _, err := s.db.Exec(ctx, `DELETE FROM user_streaks WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user_streaks: %w", err)
+	}
+	if _, err := s.db.Exec(ctx, `DELETE FROM user_xp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user_xp: %w", err)
+	}
+
+	return nil
+}
+
+// ClearPersonalData deletes favorites and notes of the user.
+func (s *ResetRepository) ClearPersonalData(ctx context.Context, userID int64) error {
+	if _, err := s.db.Exec(ctx, `DELETE FROM user_favorites WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user_favorites: %w", err)
+	}
+	if _, err := s.db.Exec(ctx, `DELETE FROM user_name_notes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete user_name_notes: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser removes the user row. All user-owned tables reference users
+// with ON DELETE CASCADE, so settings, reminders, progress, quiz history,
+// favorites, notes, streaks and XP are removed together with it.
+func (s *ResetRepository) DeleteUser(ctx context.Context, userID int64) error {
+	if _, err := s.db.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return fmt.Errorf("delete users: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// RetentionRepository removes outdated data.
+type RetentionRepository struct {
+	db postgres.DBTX
+}
+
+// NewRetentionRepository creates a new RetentionRepository.
+func NewRetentionRepository(db postgres.DBTX) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// PruneQuizHistory deletes quiz sessions finished (or started) before the cutoff and
+// archived answers given before it. Questions and answers of these sessions are removed
+// by cascade. It returns the number of sessions deleted.
+func (r *RetentionRepository) PruneQuizHistory(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM quiz_sessions
+		WHERE COALESCE(completed_at, started_at) < $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune quiz history: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM quiz_answers_archive WHERE answered_at < $1`, before); err != nil {
+		return 0, fmt.Errorf("prune quiz answers archive: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ArchiveQuizAnswers moves up to limit quiz answers given before the cutoff to
+// quiz_answers_archive and returns the number of answers moved.
+func (r *RetentionRepository) ArchiveQuizAnswers(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `
+		WITH moved AS (
+			DELETE FROM quiz_answers
+			WHERE id IN (
+				SELECT id
+				FROM quiz_answers
+				WHERE answered_at < $1
+				ORDER BY id
+				LIMIT $2
+			)
+			RETURNING id, user_id, session_id, question_id, name_number, user_answer, correct_answer,
+			          question_type, is_correct, answered_at
+		)
+		INSERT INTO quiz_answers_archive (
+			id, user_id, session_id, question_id, name_number, user_answer, correct_answer,
+			question_type, is_correct, answered_at
+		)
+		SELECT * FROM moved
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("archive quiz answers: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// PruneDeliveredNotifications deletes notification jobs delivered before the cutoff.
+func (r *RetentionRepository) PruneDeliveredNotifications(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM notification_jobs
+		WHERE status = 'delivered'
+			AND delivered_at < $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune delivered notifications: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// PruneReminderLog deletes delivery attempts recorded before the cutoff.
+func (r *RetentionRepository) PruneReminderLog(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM reminder_log
+		WHERE created_at < $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune reminder log: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// GetInactiveUsersBatch returns users inactive since the cutoff who have not been warned yet.
+// Users are paginated by ID because warned users drop out of the result set.
+func (r *RetentionRepository) GetInactiveUsersBatch(ctx context.Context, inactiveSince time.Time, afterUserID int64, limit int) ([]*entities.InactiveUser, error) {
+	query := `
+		SELECT id, chat_id, last_active_at
+		FROM users
+		WHERE last_active_at < $1
+			AND deletion_warned_at IS NULL
+			AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, inactiveSince, afterUserID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get inactive users batch: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entities.InactiveUser
+	for rows.Next() {
+		var u entities.InactiveUser
+		if err := rows.Scan(&u.UserID, &u.ChatID, &u.LastActiveAt); err != nil {
+			return nil, fmt.Errorf("scan inactive user: %w", err)
+		}
+		users = append(users, &u)
+	}
+
+	return users, rows.Err()
+}
+
+// MarkDeletionWarned stores the time the user was warned about upcoming deletion.
+func (r *RetentionRepository) MarkDeletionWarned(ctx context.Context, userID int64, warnedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_warned_at = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, warnedAt); err != nil {
+		return fmt.Errorf("mark deletion warned: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWarnedUsers deletes users warned before the cutoff who stayed inactive.
+// All user-owned data is removed by cascade.
+func (r *RetentionRepository) DeleteWarnedUsers(ctx context.Context, warnedBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM users
+		WHERE deletion_warned_at IS NOT NULL
+			AND deletion_warned_at < $1
+			AND last_active_at < deletion_warned_at
+	`
+
+	tag, err := r.db.Exec(ctx, query, warnedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("delete warned users: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
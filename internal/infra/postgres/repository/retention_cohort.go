@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// RetentionCohortRepository computes and stores weekly retention cohorts.
+type RetentionCohortRepository struct {
+	db postgres.DBTX
+}
+
+// NewRetentionCohortRepository creates a new RetentionCohortRepository.
+func NewRetentionCohortRepository(db postgres.DBTX) *RetentionCohortRepository {
+	return &RetentionCohortRepository{db: db}
+}
+
+// Refresh recomputes the cohorts of users who signed up on or after since and stores them.
+// Activity is taken from analytics events, quiz sessions and name reviews. A dN value
+// stays NULL until day N has passed for the last user of the cohort.
+func (r *RetentionCohortRepository) Refresh(ctx context.Context, since, now time.Time) (int64, error) {
+	query := `
+		WITH cohort_users AS (
+			SELECT id, created_at, date_trunc('week', created_at AT TIME ZONE 'UTC')::date AS cohort_week
+			FROM users
+			WHERE created_at >= $1
+		),
+		activity AS (
+			SELECT user_id, created_at AS at FROM events WHERE created_at >= $1
+			UNION ALL
+			SELECT user_id, started_at FROM quiz_sessions WHERE started_at >= $1
+			UNION ALL
+			SELECT user_id, last_reviewed_at FROM user_progress WHERE last_reviewed_at >= $1
+		),
+		retained AS (
+			SELECT
+				cu.cohort_week,
+				COALESCE(bool_or(a.at >= cu.created_at + INTERVAL '1 day'
+					AND a.at < cu.created_at + INTERVAL '2 days'), false) AS d1,
+				COALESCE(bool_or(a.at >= cu.created_at + INTERVAL '7 days'
+					AND a.at < cu.created_at + INTERVAL '8 days'), false) AS d7,
+				COALESCE(bool_or(a.at >= cu.created_at + INTERVAL '30 days'
+					AND a.at < cu.created_at + INTERVAL '31 days'), false) AS d30
+			FROM cohort_users cu
+			LEFT JOIN activity a ON a.user_id = cu.id
+			GROUP BY cu.id, cu.cohort_week
+		),
+		today AS (
+			SELECT ($2::timestamptz AT TIME ZONE 'UTC')::date AS day
+		)
+		INSERT INTO retention_cohorts (cohort_week, users, d1, d7, d30, computed_at)
+		SELECT
+			cohort_week,
+			COUNT(*),
+			CASE WHEN cohort_week + 9 <= today.day THEN COUNT(*) FILTER (WHERE d1) END,
+			CASE WHEN cohort_week + 15 <= today.day THEN COUNT(*) FILTER (WHERE d7) END,
+			CASE WHEN cohort_week + 38 <= today.day THEN COUNT(*) FILTER (WHERE d30) END,
+			$2::timestamptz
+		FROM retained, today
+		GROUP BY cohort_week, today.day
+		ON CONFLICT (cohort_week) DO UPDATE SET
+			users = EXCLUDED.users,
+			d1 = EXCLUDED.d1,
+			d7 = EXCLUDED.d7,
+			d30 = EXCLUDED.d30,
+			computed_at = EXCLUDED.computed_at
+	`
+
+	tag, err := r.db.Exec(ctx, query, since, now)
+	if err != nil {
+		return 0, fmt.Errorf("refresh retention cohorts: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// List returns the latest cohorts, newest first.
+func (r *RetentionCohortRepository) List(ctx context.Context, limit int) ([]entities.RetentionCohort, error) {
+	query := `
+		SELECT cohort_week, users, d1, d7, d30, computed_at
+		FROM retention_cohorts
+		ORDER BY cohort_week DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query retention cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	var cohorts []entities.RetentionCohort
+	for rows.Next() {
+		var c entities.RetentionCohort
+		if err := rows.Scan(&c.Week, &c.Users, &c.D1, &c.D7, &c.D30, &c.ComputedAt); err != nil {
+			return nil, fmt.Errorf("scan retention cohort: %w", err)
+		}
+		cohorts = append(cohorts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate retention cohorts: %w", err)
+	}
+
+	return cohorts, nil
+}
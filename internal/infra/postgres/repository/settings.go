@@ -46,7 +46,7 @@ func (r *SettingsRepository) Create(ctx context.Context, userID int64) error {
 func (r *SettingsRepository) GetByUserID(ctx context.Context, userID int64) (*entities.UserSettings, error) {
 	query := `
 		SELECT user_id, names_per_day, max_reviews_per_day, quiz_mode,
-		       learning_mode, language_code, timezone, created_at, updated_at
+		       learning_mode, language_code, timezone, calendar, target_date, created_at, updated_at
 		FROM user_settings
 		WHERE user_id = $1
 	`
@@ -60,6 +60,8 @@ func (r *SettingsRepository) GetByUserID(ctx context.Context, userID int64) (*en
 		&settings.LearningMode,
 		&settings.LanguageCode,
 		&settings.Timezone,
+		&settings.Calendar,
+		&settings.TargetDate,
 		&settings.CreatedAt,
 		&settings.UpdatedAt,
 	)
@@ -78,8 +80,8 @@ func (r *SettingsRepository) UpsertDefaults(ctx context.Context, userID int64) e
 	query := `
 		INSERT INTO user_settings (
 			user_id, names_per_day, max_reviews_per_day, quiz_mode,
-			learning_mode, language_code, timezone, created_at, updated_at
-		) VALUES ($1, 1, 50, 'mixed', 'guided', 'ru', 'UTC', NOW(), NOW())
+			learning_mode, language_code, timezone, calendar, created_at, updated_at
+		) VALUES ($1, 1, 50, 'mixed', 'guided', 'ru', 'UTC', 'gregorian', NOW(), NOW())
 		ON CONFLICT (user_id) DO UPDATE
 		SET names_per_day = EXCLUDED.names_per_day,
 		    max_reviews_per_day = EXCLUDED.max_reviews_per_day,
@@ -87,6 +89,8 @@ func (r *SettingsRepository) UpsertDefaults(ctx context.Context, userID int64) e
 		    learning_mode = EXCLUDED.learning_mode,
 		    language_code = EXCLUDED.language_code,
 		    timezone = EXCLUDED.timezone,
+		    calendar = EXCLUDED.calendar,
+		    target_date = NULL,
 		    updated_at = NOW()
 	`
 	_, err := r.db.Exec(ctx, query, userID)
@@ -96,11 +100,11 @@ func (r *SettingsRepository) UpsertDefaults(ctx context.Context, userID int64) e
 	return nil
 }
 
-// UpdateNamesPerDay updates the number of names to learn per day.
+// UpdateNamesPerDay sets a fixed number of names to learn per day and clears the target date.
 func (r *SettingsRepository) UpdateNamesPerDay(ctx context.Context, userID int64, namesPerDay int) error {
 	query := `
 		UPDATE user_settings
-		SET names_per_day = $1, updated_at = $2
+		SET names_per_day = $1, target_date = NULL, updated_at = $2
 		WHERE user_id = $3
 	`
 
@@ -176,6 +180,46 @@ func (r *SettingsRepository) UpdateTimezone(ctx context.Context, userID int64, t
 	return nil
 }
 
+// UpdateTargetDate sets or clears (nil) the date by which all names should be started.
+func (r *SettingsRepository) UpdateTargetDate(ctx context.Context, userID int64, targetDate *time.Time) error {
+	query := `
+		UPDATE user_settings
+		SET target_date = $1, updated_at = $2
+		WHERE user_id = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, targetDate, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("update target date: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSettingsNotFound
+	}
+
+	return nil
+}
+
+// UpdateCalendar updates the calendar used for month-based boundaries.
+func (r *SettingsRepository) UpdateCalendar(ctx context.Context, userID int64, calendar entities.Calendar) error {
+	query := `
+		UPDATE user_settings
+		SET calendar = $1, updated_at = $2
+		WHERE user_id = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, calendar, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("update calendar: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSettingsNotFound
+	}
+
+	return nil
+}
+
 // UpdateMaxReviewsPerDay updates the maximum reviews per day.
 func (r *SettingsRepository) UpdateMaxReviewsPerDay(ctx context.Context, userID int64, maxReviews int) error {
 	query := `
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/storage"
+)
+
+const (
+	// settingsCacheTTL bounds how long an entry stays stale if its invalidation is missed,
+	// e.g. when each replica keeps its own in-memory cache.
+	settingsCacheTTL = time.Minute
+	// settingsCacheTimeout bounds one cache call.
+	settingsCacheTimeout = time.Second
+)
+
+// CachedSettingsRepository keeps settings read through it in a KV for a short time.
+// Updates made through it drop the cached entry; writes made elsewhere, e.g. with a
+// SettingsRepository inside a transaction, must call Invalidate. With storage.RedisKV
+// the cache and its invalidation are shared between replicas. Cache errors are logged
+// and fall back to the database.
+type CachedSettingsRepository struct {
+	repo   *SettingsRepository
+	cache  storage.KV
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	version uint64 // bumped by Invalidate, so a read racing an update here is not cached
+}
+
+// NewCachedSettingsRepository wraps repo with a cache kept in cache.
+func NewCachedSettingsRepository(repo *SettingsRepository, cache storage.KV, logger *zap.Logger) *CachedSettingsRepository {
+	return &CachedSettingsRepository{repo: repo, cache: cache, logger: logger}
+}
+
+// Invalidate drops the cached settings of a user.
+func (r *CachedSettingsRepository) Invalidate(userID int64) {
+	r.mu.Lock()
+	r.version++
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), settingsCacheTimeout)
+	defer cancel()
+
+	if err := r.cache.Delete(ctx, settingsCacheKey(userID)); err != nil {
+		r.logger.Warn("failed to invalidate cached settings", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// Create creates default settings for a user.
+func (r *CachedSettingsRepository) Create(ctx context.Context, userID int64) error {
+	defer r.Invalidate(userID)
+	return r.repo.Create(ctx, userID)
+}
+
+// GetByUserID returns cached settings or reads and caches them. Callers get their own copy.
+func (r *CachedSettingsRepository) GetByUserID(ctx context.Context, userID int64) (*entities.UserSettings, error) {
+	key := settingsCacheKey(userID)
+
+	r.mu.Lock()
+	version := r.version
+	r.mu.Unlock()
+
+	var cached entities.UserSettings
+	ok, err := r.cache.Get(ctx, key, &cached)
+	if err != nil {
+		requestid.Logger(ctx, r.logger).Warn("failed to read cached settings", zap.Int64("user_id", userID), zap.Error(err))
+	}
+	if ok {
+		return &cached, nil
+	}
+
+	settings, err := r.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	current := r.version == version
+	r.mu.Unlock()
+	if current {
+		if err := r.cache.Set(ctx, key, settings, settingsCacheTTL); err != nil {
+			requestid.Logger(ctx, r.logger).Warn("failed to cache settings", zap.Int64("user_id", userID), zap.Error(err))
+		}
+	}
+
+	return settings, nil
+}
+
+func settingsCacheKey(userID int64) string {
+	return "settings:" + strconv.FormatInt(userID, 10)
+}
+
+func (r *CachedSettingsRepository) UpsertDefaults(ctx context.Context, userID int64) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpsertDefaults(ctx, userID)
+}
+
+// UpdateNamesPerDay sets a fixed number of names to learn per day and clears the target date.
+func (r *CachedSettingsRepository) UpdateNamesPerDay(ctx context.Context, userID int64, namesPerDay int) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateNamesPerDay(ctx, userID, namesPerDay)
+}
+
+// UpdateQuizMode updates the quiz mode setting.
+func (r *CachedSettingsRepository) UpdateQuizMode(ctx context.Context, userID int64, quizMode string) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateQuizMode(ctx, userID, quizMode)
+}
+
+func (r *CachedSettingsRepository) UpdateLearningMode(ctx context.Context, userID int64, learningMode string) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateLearningMode(ctx, userID, learningMode)
+}
+
+func (r *CachedSettingsRepository) UpdateTimezone(ctx context.Context, userID int64, timezone string) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateTimezone(ctx, userID, timezone)
+}
+
+func (r *CachedSettingsRepository) UpdateTargetDate(ctx context.Context, userID int64, targetDate *time.Time) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateTargetDate(ctx, userID, targetDate)
+}
+
+func (r *CachedSettingsRepository) UpdateCalendar(ctx context.Context, userID int64, calendar entities.Calendar) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateCalendar(ctx, userID, calendar)
+}
+
+func (r *CachedSettingsRepository) UpdateMaxReviewsPerDay(ctx context.Context, userID int64, maxReviews int) error {
+	defer r.Invalidate(userID)
+	return r.repo.UpdateMaxReviewsPerDay(ctx, userID, maxReviews)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrStreakNotFound = errors.New("streak not found")
+
+// StreakRepository manages daily activity streaks.
+type StreakRepository struct {
+	db postgres.DBTX
+}
+
+// NewStreakRepository creates a new StreakRepository.
+func NewStreakRepository(db postgres.DBTX) *StreakRepository {
+	return &StreakRepository{db: db}
+}
+
+// Get retrieves the streak of a user.
+func (r *StreakRepository) Get(ctx context.Context, userID int64) (*entities.UserStreak, error) {
+	query := `
+		SELECT user_id, current_streak, best_streak, last_active_date
+		FROM user_streaks
+		WHERE user_id = $1
+	`
+
+	var s entities.UserStreak
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&s.UserID,
+		&s.CurrentStreak,
+		&s.BestStreak,
+		&s.LastActiveDate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStreakNotFound
+		}
+		return nil, fmt.Errorf("get streak: %w", err)
+	}
+
+	return &s, nil
+}
+
+// GetForUpdate retrieves the streak of a user and locks the row within a transaction.
+func (r *StreakRepository) GetForUpdate(ctx context.Context, userID int64) (*entities.UserStreak, error) {
+	query := `
+		SELECT user_id, current_streak, best_streak, last_active_date
+		FROM user_streaks
+		WHERE user_id = $1
+		FOR UPDATE
+	`
+
+	var s entities.UserStreak
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&s.UserID,
+		&s.CurrentStreak,
+		&s.BestStreak,
+		&s.LastActiveDate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStreakNotFound
+		}
+		return nil, fmt.Errorf("get streak for update: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Upsert creates or updates the streak of a user.
+func (r *StreakRepository) Upsert(ctx context.Context, s *entities.UserStreak) error {
+	query := `
+		INSERT INTO user_streaks (user_id, current_streak, best_streak, last_active_date, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			current_streak = EXCLUDED.current_streak,
+			best_streak = EXCLUDED.best_streak,
+			last_active_date = EXCLUDED.last_active_date,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, s.UserID, s.CurrentStreak, s.BestStreak, s.LastActiveDate); err != nil {
+		return fmt.Errorf("upsert streak: %w", err)
+	}
+
+	return nil
+}
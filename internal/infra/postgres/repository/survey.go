@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrSurveyNotFound = errors.New("survey not found")
+
+// surveySegmentConditions are the users filters of survey segments, applied to users u.
+var surveySegmentConditions = map[entities.SurveySegment]string{
+	entities.SurveySegmentAll:    "TRUE",
+	entities.SurveySegmentActive: "u.last_active_at >= NOW() - INTERVAL '7 days'",
+	entities.SurveySegmentReminders: `EXISTS (
+		SELECT 1 FROM user_reminders r WHERE r.user_id = u.id AND r.is_enabled
+	)`,
+	entities.SurveySegmentNew: "u.created_at >= NOW() - INTERVAL '30 days'",
+}
+
+// SurveyRepository stores surveys, their recipients and answers.
+type SurveyRepository struct {
+	db postgres.DBTX
+}
+
+// NewSurveyRepository creates a new SurveyRepository.
+func NewSurveyRepository(db postgres.DBTX) *SurveyRepository {
+	return &SurveyRepository{db: db}
+}
+
+// Create stores a survey and fills its ID and creation time.
+func (r *SurveyRepository) Create(ctx context.Context, survey *entities.Survey) error {
+	questions, err := json.Marshal(survey.Questions)
+	if err != nil {
+		return fmt.Errorf("encode survey questions: %w", err)
+	}
+
+	query := `
+		INSERT INTO surveys (title, segment, questions, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err = r.db.QueryRow(ctx, query, survey.Title, survey.Segment, questions, survey.CreatedBy).
+		Scan(&survey.ID, &survey.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create survey: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a survey by ID.
+func (r *SurveyRepository) Get(ctx context.Context, id int64) (*entities.Survey, error) {
+	query := `
+		SELECT id, title, segment, questions, created_by, created_at, sent_at
+		FROM surveys
+		WHERE id = $1
+	`
+
+	survey, err := scanSurvey(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSurveyNotFound
+		}
+		return nil, fmt.Errorf("get survey: %w", err)
+	}
+
+	return survey, nil
+}
+
+// List returns the latest surveys.
+func (r *SurveyRepository) List(ctx context.Context, limit int) ([]entities.Survey, error) {
+	query := `
+		SELECT id, title, segment, questions, created_by, created_at, sent_at
+		FROM surveys
+		ORDER BY created_at DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list surveys: %w", err)
+	}
+	defer rows.Close()
+
+	var surveys []entities.Survey
+	for rows.Next() {
+		survey, err := scanSurvey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan survey: %w", err)
+		}
+		surveys = append(surveys, *survey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate surveys: %w", err)
+	}
+
+	return surveys, nil
+}
+
+// EnqueueInvites queues a survey notification with the payload for every active user of the
+// survey segment who has not received it yet, records them as recipients and marks the survey sent.
+// It returns the number of queued notifications.
+func (r *SurveyRepository) EnqueueInvites(ctx context.Context, survey *entities.Survey, payload []byte) (int, error) {
+	condition, ok := surveySegmentConditions[survey.Segment]
+	if !ok {
+		return 0, fmt.Errorf("unknown survey segment: %q", survey.Segment)
+	}
+
+	query := `
+		WITH targets AS (
+			SELECT u.id, u.chat_id
+			FROM users u
+			WHERE u.is_active
+				AND NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.user_id = u.id)
+				AND ` + condition + `
+				AND NOT EXISTS (
+					SELECT 1 FROM survey_recipients sr
+					WHERE sr.survey_id = $1 AND sr.user_id = u.id
+				)
+		), recipients AS (
+			INSERT INTO survey_recipients (survey_id, user_id)
+			SELECT $1, id FROM targets
+		), jobs AS (
+			INSERT INTO notification_jobs (user_id, chat_id, kind, payload)
+			SELECT id, chat_id, $2::text, $3::jsonb FROM targets
+			RETURNING 1
+		), sent AS (
+			UPDATE surveys SET sent_at = COALESCE(sent_at, NOW()) WHERE id = $1
+		)
+		SELECT COUNT(*) FROM jobs
+	`
+
+	var queued int
+	if err := r.db.QueryRow(ctx, query, survey.ID, entities.NotificationSurvey, payload).Scan(&queued); err != nil {
+		return 0, fmt.Errorf("enqueue survey invites: %w", err)
+	}
+
+	return queued, nil
+}
+
+// SaveAnswer stores the user's answer to a question, replacing a previous one.
+func (r *SurveyRepository) SaveAnswer(ctx context.Context, surveyID, userID int64, question, option int) error {
+	query := `
+		INSERT INTO survey_answers (survey_id, user_id, question_index, option_index)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (survey_id, user_id, question_index) DO UPDATE
+		SET option_index = EXCLUDED.option_index,
+		    answered_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, surveyID, userID, question, option); err != nil {
+		return fmt.Errorf("save survey answer: %w", err)
+	}
+
+	return nil
+}
+
+// MarkCompleted records that the user answered every question of the survey.
+func (r *SurveyRepository) MarkCompleted(ctx context.Context, surveyID, userID int64) error {
+	query := `
+		UPDATE survey_recipients
+		SET completed_at = NOW()
+		WHERE survey_id = $1 AND user_id = $2 AND completed_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, surveyID, userID); err != nil {
+		return fmt.Errorf("mark survey completed: %w", err)
+	}
+
+	return nil
+}
+
+// GetResults aggregates recipients and answers of the survey.
+func (r *SurveyRepository) GetResults(ctx context.Context, survey *entities.Survey) (*entities.SurveyResults, error) {
+	results := &entities.SurveyResults{
+		Survey: survey,
+		Counts: make([][]int, len(survey.Questions)),
+	}
+	for i, q := range survey.Questions {
+		results.Counts[i] = make([]int, len(q.Options))
+	}
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM survey_recipients WHERE survey_id = $1),
+			(SELECT COUNT(DISTINCT user_id) FROM survey_answers WHERE survey_id = $1),
+			(SELECT COUNT(*) FROM survey_recipients WHERE survey_id = $1 AND completed_at IS NOT NULL)
+	`
+
+	if err := r.db.QueryRow(ctx, query, survey.ID).Scan(&results.Sent, &results.Started, &results.Completed); err != nil {
+		return nil, fmt.Errorf("count survey recipients: %w", err)
+	}
+
+	query = `
+		SELECT question_index, option_index, COUNT(*)
+		FROM survey_answers
+		WHERE survey_id = $1
+		GROUP BY question_index, option_index
+	`
+
+	rows, err := r.db.Query(ctx, query, survey.ID)
+	if err != nil {
+		return nil, fmt.Errorf("count survey answers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var question, option, count int
+		if err := rows.Scan(&question, &option, &count); err != nil {
+			return nil, fmt.Errorf("scan survey answer count: %w", err)
+		}
+		if question < len(results.Counts) && option < len(results.Counts[question]) {
+			results.Counts[question][option] = count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate survey answer counts: %w", err)
+	}
+
+	return results, nil
+}
+
+// scanSurvey scans a surveys row selected in the column order used above.
+func scanSurvey(row pgx.Row) (*entities.Survey, error) {
+	var survey entities.Survey
+	var questions []byte
+	err := row.Scan(
+		&survey.ID,
+		&survey.Title,
+		&survey.Segment,
+		&questions,
+		&survey.CreatedBy,
+		&survey.CreatedAt,
+		&survey.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(questions, &survey.Questions); err != nil {
+		return nil, fmt.Errorf("decode survey questions: %w", err)
+	}
+	return &survey, nil
+}
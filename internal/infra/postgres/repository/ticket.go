@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+var ErrTicketNotFound = errors.New("ticket not found")
+
+// TicketRepository stores support tickets and the copies of them sent to admins.
+type TicketRepository struct {
+	db postgres.DBTX
+}
+
+// NewTicketRepository creates a new TicketRepository.
+func NewTicketRepository(db postgres.DBTX) *TicketRepository {
+	return &TicketRepository{db: db}
+}
+
+// Create stores a ticket and fills its ID, status and creation time.
+func (r *TicketRepository) Create(ctx context.Context, t *entities.Ticket) error {
+	query := `
+		INSERT INTO tickets (user_id, chat_id, text)
+		VALUES ($1, $2, $3)
+		RETURNING id, status, created_at
+	`
+
+	if err := r.db.QueryRow(ctx, query, t.UserID, t.ChatID, t.Text).Scan(&t.ID, &t.Status, &t.CreatedAt); err != nil {
+		return fmt.Errorf("create ticket: %w", err)
+	}
+
+	return nil
+}
+
+// AddMessage links a copy of the ticket sent to an admin chat with the ticket.
+func (r *TicketRepository) AddMessage(ctx context.Context, ticketID, chatID int64, messageID int) error {
+	query := `
+		INSERT INTO ticket_messages (chat_id, message_id, ticket_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, message_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, chatID, messageID, ticketID); err != nil {
+		return fmt.Errorf("add ticket message: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a ticket by ID.
+func (r *TicketRepository) Get(ctx context.Context, id int64) (*entities.Ticket, error) {
+	query := `
+		SELECT id, user_id, chat_id, text, status, created_at, answered_at, answered_by, closed_at, closed_by
+		FROM tickets
+		WHERE id = $1
+	`
+
+	t, err := scanTicket(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	return t, nil
+}
+
+// GetByMessage returns the ticket a message in an admin chat is a copy of.
+func (r *TicketRepository) GetByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Ticket, error) {
+	query := `
+		SELECT t.id, t.user_id, t.chat_id, t.text, t.status, t.created_at,
+		       t.answered_at, t.answered_by, t.closed_at, t.closed_by
+		FROM ticket_messages m
+		JOIN tickets t ON t.id = m.ticket_id
+		WHERE m.chat_id = $1 AND m.message_id = $2
+	`
+
+	t, err := scanTicket(r.db.QueryRow(ctx, query, chatID, messageID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, fmt.Errorf("get ticket by message: %w", err)
+	}
+
+	return t, nil
+}
+
+// List returns the latest tickets with the status, or of any status if it is empty.
+func (r *TicketRepository) List(ctx context.Context, status entities.TicketStatus, limit int) ([]entities.Ticket, error) {
+	query := `
+		SELECT id, user_id, chat_id, text, status, created_at, answered_at, answered_by, closed_at, closed_by
+		FROM tickets
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []entities.Ticket
+	for rows.Next() {
+		t, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan ticket: %w", err)
+		}
+		tickets = append(tickets, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// MarkAnswered records that an admin answered the ticket. A closed ticket is reopened as answered.
+func (r *TicketRepository) MarkAnswered(ctx context.Context, ticketID, adminID int64) error {
+	query := `
+		UPDATE tickets
+		SET status = 'answered', answered_at = NOW(), answered_by = $2, closed_at = NULL, closed_by = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, ticketID, adminID); err != nil {
+		return fmt.Errorf("mark ticket answered: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the ticket. It reports false if the ticket does not exist or is already closed.
+func (r *TicketRepository) Close(ctx context.Context, ticketID, adminID int64) (bool, error) {
+	query := `
+		UPDATE tickets
+		SET status = 'closed', closed_at = NOW(), closed_by = $2
+		WHERE id = $1 AND status <> 'closed'
+	`
+
+	tag, err := r.db.Exec(ctx, query, ticketID, adminID)
+	if err != nil {
+		return false, fmt.Errorf("close ticket: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scanTicket scans a tickets row selected in the column order used above.
+func scanTicket(row pgx.Row) (*entities.Ticket, error) {
+	var t entities.Ticket
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.ChatID,
+		&t.Text,
+		&t.Status,
+		&t.CreatedAt,
+		&t.AnsweredAt,
+		&t.AnsweredBy,
+		&t.ClosedAt,
+		&t.ClosedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
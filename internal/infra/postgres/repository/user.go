@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -56,6 +57,34 @@ func (r *UserRepository) Exists(ctx context.Context, userID int64) (bool, error)
 	return exists, nil
 }
 
+// TouchActivity records user activity and cancels a pending deletion warning.
+// The row is updated at most once per hour to keep the write rate low; each update
+// also counts the user's local hour in user_activity_hours.
+func (r *UserRepository) TouchActivity(ctx context.Context, userID int64, now time.Time) error {
+	query := `
+		WITH touched AS (
+			UPDATE users
+			SET last_active_at = $2,
+				deletion_warned_at = NULL
+			WHERE id = $1
+				AND (last_active_at < $2 - INTERVAL '1 hour' OR deletion_warned_at IS NOT NULL)
+			RETURNING id
+		)
+		INSERT INTO user_activity_hours (user_id, hour, hits)
+		SELECT t.id, EXTRACT(HOUR FROM $2::timestamptz AT TIME ZONE COALESCE(us.timezone, 'UTC'))::smallint, 1
+		FROM touched t
+		LEFT JOIN user_settings us ON us.user_id = t.id
+		ON CONFLICT (user_id, hour) DO UPDATE
+			SET hits = user_activity_hours.hits + 1
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, now); err != nil {
+		return fmt.Errorf("touch user activity: %w", err)
+	}
+
+	return nil
+}
+
 // GetByID retrieves a user by ID.
 func (r *UserRepository) GetByID(ctx context.Context, userID int64) (*entities.User, error) {
 	query := `
@@ -81,3 +110,74 @@ func (r *UserRepository) GetByID(ctx context.Context, userID int64) (*entities.U
 
 	return &user, nil
 }
+
+// SetReferrer attributes a user to the referrer who invited them.
+// Attribution is kept only once, never to the user themself, and only to an existing referrer;
+// it reports whether the referrer was stored.
+func (r *UserRepository) SetReferrer(ctx context.Context, userID, referrerID int64, now time.Time) (bool, error) {
+	query := `
+		UPDATE users
+		SET referred_by = $2,
+			referred_at = $3
+		WHERE id = $1
+			AND id <> $2
+			AND referred_by IS NULL
+			AND EXISTS (SELECT 1 FROM users WHERE id = $2)
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, referrerID, now)
+	if err != nil {
+		return false, fmt.Errorf("set referrer: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetReferralStats counts the users invited by the given user and those of them who started learning.
+func (r *UserRepository) GetReferralStats(ctx context.Context, userID int64) (*entities.ReferralStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM user_progress up WHERE up.user_id = u.id))
+		FROM users u
+		WHERE u.referred_by = $1
+	`
+
+	var stats entities.ReferralStats
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&stats.Invited, &stats.Activated); err != nil {
+		return nil, fmt.Errorf("get referral stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// Search returns users whose ID starts with the given digits, most recently active first.
+func (r *UserRepository) Search(ctx context.Context, idPrefix string, limit int) ([]entities.UserInfo, error) {
+	query := `
+		SELECT id, chat_id, is_active, created_at, last_active_at, referred_by
+		FROM users
+		WHERE id::text LIKE $1 || '%'
+		ORDER BY last_active_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, idPrefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.UserInfo
+	for rows.Next() {
+		var u entities.UserInfo
+		if err := rows.Scan(&u.ID, &u.ChatID, &u.IsActive, &u.CreatedAt, &u.LastActiveAt, &u.ReferredBy); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// UserAuditRepository appends to and reads the user audit log. It never updates or deletes entries.
+type UserAuditRepository struct {
+	db postgres.DBTX
+}
+
+func NewUserAuditRepository(db postgres.DBTX) *UserAuditRepository {
+	return &UserAuditRepository{db: db}
+}
+
+// Append adds an entry to the audit log.
+func (r *UserAuditRepository) Append(ctx context.Context, entry *entities.UserAuditEntry) error {
+	query := `
+		INSERT INTO user_audit_log (user_id, action, old_value, new_value, request_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(ctx, query, entry.UserID, entry.Action, nullJSON(entry.OldValue), nullJSON(entry.NewValue), entry.RequestID)
+	if err != nil {
+		return fmt.Errorf("append user audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns the latest entries of a user, newest first.
+func (r *UserAuditRepository) ListByUser(ctx context.Context, userID int64, limit int) ([]entities.UserAuditEntry, error) {
+	query := `
+		SELECT id, user_id, action, old_value, new_value, request_id, created_at
+		FROM user_audit_log
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list user audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []entities.UserAuditEntry
+	for rows.Next() {
+		var e entities.UserAuditEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Action, &e.OldValue, &e.NewValue, &e.RequestID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan user audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// nullJSON passes an empty JSON value as NULL.
+func nullJSON(v []byte) any {
+	if len(v) == 0 {
+		return nil
+	}
+	return string(v)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres"
+)
+
+// XPRepository manages users' experience points.
+type XPRepository struct {
+	db postgres.DBTX
+}
+
+// NewXPRepository creates a new XPRepository.
+func NewXPRepository(db postgres.DBTX) *XPRepository {
+	return &XPRepository{db: db}
+}
+
+// Add adds XP to a user's total and returns the new total.
+func (r *XPRepository) Add(ctx context.Context, userID int64, amount int) (int, error) {
+	query := `
+		INSERT INTO user_xp (user_id, total_xp, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			total_xp = user_xp.total_xp + EXCLUDED.total_xp,
+			updated_at = NOW()
+		RETURNING total_xp
+	`
+
+	var total int
+	if err := r.db.QueryRow(ctx, query, userID, amount).Scan(&total); err != nil {
+		return 0, fmt.Errorf("add xp: %w", err)
+	}
+
+	return total, nil
+}
+
+// Get returns a user's total XP (0 if the user has none yet).
+func (r *XPRepository) Get(ctx context.Context, userID int64) (int, error) {
+	query := `
+		SELECT total_xp
+		FROM user_xp
+		WHERE user_id = $1
+	`
+
+	var total int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&total); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get xp: %w", err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+)
+
+// maxTracedSQLLength bounds the statement text passed to query hooks.
+const maxTracedSQLLength = 500
+
+// queryCanceledCode is the SQLSTATE of a statement cancelled by statement_timeout or a cancel request.
+const queryCanceledCode = "57014"
+
+// QueryEvent describes a finished query. Arguments are left out, as they may hold user data.
+type QueryEvent struct {
+	SQL       string // statement with whitespace collapsed, truncated to maxTracedSQLLength
+	Duration  time.Duration
+	Rows      int64 // rows affected, as reported by the command tag
+	Err       error
+	TimedOut  bool   // cancelled by statement_timeout or the caller's deadline
+	RequestID string // ID of the Telegram update the query was made for, empty for background work
+}
+
+// queryTracer reports slow and timed out queries through the pool's hooks.
+type queryTracer struct {
+	threshold time.Duration
+	onSlow    func(QueryEvent)
+	onQuery   func(QueryEvent)
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql string
+	at  time.Time
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, at: time.Now()})
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	event := QueryEvent{
+		Duration: time.Since(start.at),
+		Rows:     data.CommandTag.RowsAffected(),
+		Err:      data.Err,
+		TimedOut: isTimeout(data.Err),
+	}
+	slow := t.threshold > 0 && event.Duration >= t.threshold
+	if t.onQuery == nil && (t.onSlow == nil || !slow && !event.TimedOut) {
+		return
+	}
+	event.SQL = compactSQL(start.sql)
+	event.RequestID = requestid.FromContext(ctx)
+
+	if t.onQuery != nil {
+		t.onQuery(event)
+	}
+	if t.onSlow != nil && (slow || event.TimedOut) {
+		t.onSlow(event)
+	}
+}
+
+// isTimeout reports whether err is a statement cancelled by the server or an expired context.
+func isTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == queryCanceledCode
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// compactSQL collapses whitespace so multi-line queries fit on one log line.
+func compactSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > maxTracedSQLLength {
+		// Cut on a rune boundary.
+		cut := maxTracedSQLLength
+		for cut > 0 && sql[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		sql = sql[:cut] + "…"
+	}
+	return sql
+}
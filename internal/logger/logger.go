@@ -1,18 +1,101 @@
 package logger
 
 import (
+	"sync/atomic"
+	"time"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/config"
 )
 
+// Sampling keeps the first samplingFirst entries with the same level and message in each
+// samplingTick and every samplingThereafter-th one after that, like the zap production default.
+const (
+	samplingTick       = time.Second
+	samplingFirst      = 100
+	samplingThereafter = 100
+)
+
+// Controls change a logger built by New while it is in use.
+type Controls struct {
+	level    zap.AtomicLevel
+	sampling atomic.Bool
+}
+
+// Level returns the minimum level of logged entries.
+func (c *Controls) Level() string {
+	return c.level.Level().String()
+}
+
+// SetLevel changes the minimum level: debug, info, warn or error.
+func (c *Controls) SetLevel(level string) error {
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	c.level.SetLevel(l)
+	return nil
+}
+
+// Sampling reports whether repeated entries are sampled.
+func (c *Controls) Sampling() bool {
+	return c.sampling.Load()
+}
+
+// SetSampling turns sampling of repeated entries on or off, e.g. to keep debug logs
+// readable during an incident.
+func (c *Controls) SetSampling(on bool) {
+	c.sampling.Store(on)
+}
+
 // New creates a new zap.Logger instance based on the environment configuration.
-// If the environment is "production", it returns a production logger.
+// In the prod environment it returns a production logger.
 // Otherwise, it returns a development logger for easier debugging.
-func New(cfg *config.Config) (*zap.Logger, error) {
-	if cfg.Env == "production" {
-		return zap.NewProduction()
+// The level starts at cfg.LogLevel; sampling starts on in prod and off elsewhere.
+func New(cfg *config.Config) (*zap.Logger, *Controls, error) {
+	zapConfig := zap.NewDevelopmentConfig()
+	if cfg.Env == config.EnvProd {
+		zapConfig = zap.NewProductionConfig()
+	}
+
+	level, err := zapcore.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+	controls := &Controls{level: zap.NewAtomicLevelAt(level)}
+	controls.sampling.Store(zapConfig.Sampling != nil)
+
+	zapConfig.Level = controls.level
+	zapConfig.Sampling = nil // applied by samplingCore so it can be switched
+	lg, err := zapConfig.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &samplingCore{
+			Core:    core,
+			sampled: zapcore.NewSamplerWithOptions(core, samplingTick, samplingFirst, samplingThereafter),
+			on:      &controls.sampling,
+		}
+	}))
+	if err != nil {
+		return nil, nil, err
 	}
+	return lg, controls, nil
+}
+
+// samplingCore sends entries through the sampled core while sampling is on.
+type samplingCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+	on      *atomic.Bool
+}
 
-	return zap.NewDevelopment()
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{Core: c.Core.With(fields), sampled: c.sampled.With(fields), on: c.on}
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.on.Load() {
+		return c.sampled.Check(entry, checked)
+	}
+	return c.Core.Check(entry, checked)
 }
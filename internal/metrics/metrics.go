@@ -0,0 +1,92 @@
+// Package metrics exposes application metrics to Prometheus, along with health checks and profiles.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// ScrapeTimeout bounds collectors that query the database.
+const ScrapeTimeout = 10 * time.Second
+
+// CounterVec is a counter partitioned by label values, registered with the default Prometheus registry.
+// It lets services count with Inc without depending on the Prometheus client.
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// NewCounterVec creates and registers a counter with the given label names.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		vec: promauto.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels),
+	}
+}
+
+// Inc increments the counter for the label values, given in label order.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Handler serves the metrics of the default Prometheus registry. A failing collector is
+// logged and its metrics are left out instead of failing the whole scrape.
+func Handler(logger *zap.Logger) http.Handler {
+	return promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		ErrorLog:      zap.NewStdLog(logger),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// readyTimeout bounds one readiness check.
+const readyTimeout = 2 * time.Second
+
+// Serve exposes the handler on addr at /metrics until ctx is done. /healthz reports that the
+// process is up; /readyz answers 503 while ready fails, e.g. when the database is unreachable.
+// A non-nil debug handler, such as Pprof, is served under /debug/pprof/.
+func Serve(ctx context.Context, addr string, handler http.Handler, ready func(ctx context.Context) error, debug http.Handler, logger *zap.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+	if debug != nil {
+		mux.Handle("/debug/pprof/", debug)
+	}
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		checkCtx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+
+		if err := ready(checkCtx); err != nil {
+			logger.Warn("readiness check failed", zap.Error(err))
+			http.Error(w, "not ready: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok\n")
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("metrics server started", zap.String("addr", addr), zap.Bool("pprof", debug != nil))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("metrics server failed", zap.Error(err))
+	}
+}
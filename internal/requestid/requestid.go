@@ -0,0 +1,43 @@
+// Package requestid tags the processing of one Telegram update with a short random ID,
+// so that its log entries can be found from the code a user reports to support.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+)
+
+// FieldName is the log field that holds the request ID.
+const FieldName = "request_id"
+
+type contextKey struct{}
+
+// New returns a new random request ID of 8 hex digits, short enough for a user to quote.
+func New() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b) // never fails, see crypto/rand.Read
+	return hex.EncodeToString(b)
+}
+
+// With returns a copy of ctx carrying the request ID.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID of ctx, or an empty string if it has none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Logger returns lg with the request ID of ctx as a field, or lg itself if ctx has none.
+func Logger(ctx context.Context, lg *zap.Logger) *zap.Logger {
+	id := FromContext(ctx)
+	if id == "" {
+		return lg
+	}
+	return lg.With(zap.String(FieldName, id))
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+)
+
+var (
+	// ErrConfigAdmin is returned when changing the role of an admin listed in ADMIN_IDS.
+	ErrConfigAdmin = errors.New("admin is set in configuration")
+	// ErrInvalidAdminRole is returned for an unknown role name.
+	ErrInvalidAdminRole = errors.New("invalid admin role")
+)
+
+// AdminService checks admin permissions and keeps the audit log of admin actions.
+// Admins listed in the configuration are owners; other roles are granted in the bot
+// and stored in the database.
+type AdminService struct {
+	repo   AdminRepository
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	ownerIDs []int64
+}
+
+// NewAdminService creates a new AdminService with the owners from the configuration.
+func NewAdminService(repo AdminRepository, ownerIDs []int64, logger *zap.Logger) *AdminService {
+	return &AdminService{
+		repo:     repo,
+		ownerIDs: ownerIDs,
+		logger:   logger,
+	}
+}
+
+// SetOwners replaces the owners from the configuration, e.g. after it is reloaded.
+func (s *AdminService) SetOwners(ownerIDs []int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ownerIDs = slices.Clone(ownerIDs)
+}
+
+// isOwner reports whether the user is an owner from the configuration.
+func (s *AdminService) isOwner(userID int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Contains(s.ownerIDs, userID)
+}
+
+// Role returns the admin role of a user, or an empty role if the user is not an admin.
+func (s *AdminService) Role(ctx context.Context, userID int64) (entities.AdminRole, error) {
+	if s.isOwner(userID) {
+		return entities.AdminRoleOwner, nil
+	}
+
+	admin, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, repository.ErrAdminNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return admin.Role, nil
+}
+
+// Can reports whether the user has the admin permission.
+func (s *AdminService) Can(ctx context.Context, userID int64, perm entities.AdminPermission) (bool, error) {
+	role, err := s.Role(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return role.Can(perm), nil
+}
+
+// Grant gives a user an admin role, replacing the previous one.
+func (s *AdminService) Grant(ctx context.Context, userID int64, role string, grantedBy int64) error {
+	adminRole, ok := entities.ParseAdminRole(role)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidAdminRole, role)
+	}
+	if s.isOwner(userID) {
+		return ErrConfigAdmin
+	}
+
+	return s.repo.Upsert(ctx, userID, adminRole, grantedBy)
+}
+
+// Revoke removes the admin role of a user.
+func (s *AdminService) Revoke(ctx context.Context, userID int64) error {
+	if s.isOwner(userID) {
+		return ErrConfigAdmin
+	}
+	return s.repo.Delete(ctx, userID)
+}
+
+// List returns owners from the configuration followed by admins granted in the bot.
+func (s *AdminService) List(ctx context.Context) ([]entities.Admin, error) {
+	granted, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	ownerIDs := s.ownerIDs
+	s.mu.RUnlock()
+
+	admins := make([]entities.Admin, 0, len(ownerIDs)+len(granted))
+	for _, id := range ownerIDs {
+		admins = append(admins, entities.Admin{UserID: id, Role: entities.AdminRoleOwner, FromConfig: true})
+	}
+	for _, a := range granted {
+		if !slices.Contains(ownerIDs, a.UserID) {
+			admins = append(admins, a)
+		}
+	}
+
+	return admins, nil
+}
+
+// LogAction records an admin action in the application log and the audit log.
+// A failed audit write is only logged so that it never blocks the action itself.
+func (s *AdminService) LogAction(ctx context.Context, action *entities.AdminAction) {
+	requestid.Logger(ctx, s.logger).Info("admin action",
+		zap.Int64("admin_id", action.AdminID),
+		zap.String("action", action.Action),
+		zap.Int64("target_user_id", action.TargetUserID),
+		zap.String("details", action.Details),
+	)
+
+	if err := s.repo.LogAction(ctx, action); err != nil {
+		requestid.Logger(ctx, s.logger).Error("failed to write admin audit log",
+			zap.Int64("admin_id", action.AdminID),
+			zap.String("action", action.Action),
+			zap.Error(err),
+		)
+	}
+}
+
+// RecentActions returns the latest admin actions, newest first.
+func (s *AdminService) RecentActions(ctx context.Context, limit int) ([]entities.AdminAction, error) {
+	return s.repo.ListActions(ctx, limit)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Web admin token lifetimes.
+const (
+	adminLoginTTL   = 10 * time.Minute
+	AdminSessionTTL = 12 * time.Hour
+)
+
+// Kinds of signed web admin tokens; a login link cannot be used as a session and vice versa.
+const (
+	adminTokenLogin   = "login"
+	adminTokenSession = "session"
+)
+
+var (
+	ErrAdminWebDisabled  = errors.New("web admin is disabled")
+	ErrAdminTokenInvalid = errors.New("invalid admin token")
+)
+
+// AdminSessionService signs the short-lived login links sent with /admin_web and the
+// session cookies of the web admin. Tokens are stateless: "<admin id>.<expiry>.<hmac>".
+// Permissions are not part of the token and are checked on every request.
+type AdminSessionService struct {
+	key []byte
+	url string
+}
+
+// NewAdminSessionService creates a new AdminSessionService. secret keys the signatures;
+// url is the public address of the web admin, empty if it is not served.
+func NewAdminSessionService(secret, url string) *AdminSessionService {
+	mac := hmac.New(sha256.New, []byte("AdminWeb"))
+	mac.Write([]byte(secret))
+	return &AdminSessionService{key: mac.Sum(nil), url: url}
+}
+
+// LoginLink returns a link that signs the admin in to the web admin. It is valid for a few minutes.
+func (s *AdminSessionService) LoginLink(adminID int64) (string, error) {
+	if s.url == "" {
+		return "", ErrAdminWebDisabled
+	}
+
+	token := s.sign(adminTokenLogin, adminID, time.Now().Add(adminLoginTTL))
+	return strings.TrimSuffix(s.url, "/") + "/login?token=" + url.QueryEscape(token), nil
+}
+
+// Login exchanges a login link token for a session token and its expiry.
+func (s *AdminSessionService) Login(token string) (string, time.Time, error) {
+	adminID, err := s.verify(adminTokenLogin, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expires := time.Now().Add(AdminSessionTTL)
+	return s.sign(adminTokenSession, adminID, expires), expires, nil
+}
+
+// Authenticate returns the admin a session token belongs to.
+func (s *AdminSessionService) Authenticate(session string) (int64, error) {
+	return s.verify(adminTokenSession, session)
+}
+
+// sign creates a token of the kind for the admin that expires at the given time.
+func (s *AdminSessionService) sign(kind string, adminID int64, expires time.Time) string {
+	payload := fmt.Sprintf("%d.%d", adminID, expires.Unix())
+	return payload + "." + hex.EncodeToString(s.mac(kind, payload))
+}
+
+// verify checks the signature and expiry of a token of the kind and returns its admin.
+func (s *AdminSessionService) verify(kind, token string) (int64, error) {
+	payload, signature, ok := cutLast(token, ".")
+	if !ok {
+		return 0, ErrAdminTokenInvalid
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, s.mac(kind, payload)) {
+		return 0, ErrAdminTokenInvalid
+	}
+
+	id, exp, ok := strings.Cut(payload, ".")
+	if !ok {
+		return 0, ErrAdminTokenInvalid
+	}
+	adminID, err1 := strconv.ParseInt(id, 10, 64)
+	expires, err2 := strconv.ParseInt(exp, 10, 64)
+	if err1 != nil || err2 != nil || time.Now().Unix() > expires {
+		return 0, ErrAdminTokenInvalid
+	}
+
+	return adminID, nil
+}
+
+// mac signs the payload of a token of the kind.
+func (s *AdminSessionService) mac(kind, payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(kind + ":" + payload))
+	return mac.Sum(nil)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loginToken extracts the token from a login link.
+func loginToken(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse login link %q: %v", link, err)
+	}
+	return u.Query().Get("token")
+}
+
+func TestAdminSessionLogin(t *testing.T) {
+	s := NewAdminSessionService("secret", "https://admin.example.com/")
+
+	link, err := s.LoginLink(42)
+	if err != nil {
+		t.Fatalf("LoginLink() error = %v", err)
+	}
+	if !strings.HasPrefix(link, "https://admin.example.com/login?token=") {
+		t.Fatalf("LoginLink() = %q, want a link to /login", link)
+	}
+
+	session, expires, err := s.Login(loginToken(t, link))
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if d := time.Until(expires); d <= AdminSessionTTL-time.Minute || d > AdminSessionTTL {
+		t.Errorf("Login() expires in %s, want %s", d, AdminSessionTTL)
+	}
+
+	adminID, err := s.Authenticate(session)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if adminID != 42 {
+		t.Errorf("Authenticate() = %d, want 42", adminID)
+	}
+}
+
+func TestAdminSessionLoginLinkDisabled(t *testing.T) {
+	s := NewAdminSessionService("secret", "")
+	if _, err := s.LoginLink(42); !errors.Is(err, ErrAdminWebDisabled) {
+		t.Fatalf("LoginLink() error = %v, want %v", err, ErrAdminWebDisabled)
+	}
+}
+
+func TestAdminSessionRejectsTokens(t *testing.T) {
+	s := NewAdminSessionService("secret", "https://admin.example.com")
+	other := NewAdminSessionService("other-secret", "https://admin.example.com")
+
+	valid := s.sign(adminTokenSession, 42, time.Now().Add(time.Hour))
+	payload, signature, _ := cutLast(valid, ".")
+	flipped := []byte(signature)
+	if flipped[0] == '0' {
+		flipped[0] = '1'
+	} else {
+		flipped[0] = '0'
+	}
+
+	tests := []struct {
+		name    string
+		session string
+	}{
+		{"expired", s.sign(adminTokenSession, 42, time.Now().Add(-time.Second))},
+		{"another admin in payload", strings.Replace(payload, "42.", "43.", 1) + "." + signature},
+		{"later expiry in payload", "42.99999999999." + signature},
+		{"changed signature", payload + "." + string(flipped)},
+		{"signature not hex", payload + ".zz"},
+		{"missing signature", payload},
+		{"empty", ""},
+		{"signed with another secret", other.sign(adminTokenSession, 42, time.Now().Add(time.Hour))},
+		{"login token as session", s.sign(adminTokenLogin, 42, time.Now().Add(time.Hour))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if adminID, err := s.Authenticate(tt.session); !errors.Is(err, ErrAdminTokenInvalid) {
+				t.Fatalf("Authenticate() = %d, %v, want %v", adminID, err, ErrAdminTokenInvalid)
+			}
+		})
+	}
+}
+
+func TestAdminSessionLoginRejectsTokens(t *testing.T) {
+	s := NewAdminSessionService("secret", "https://admin.example.com")
+	other := NewAdminSessionService("other-secret", "https://admin.example.com")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired link", s.sign(adminTokenLogin, 42, time.Now().Add(-time.Second))},
+		{"link from another secret", other.sign(adminTokenLogin, 42, time.Now().Add(adminLoginTTL))},
+		{"session as link", s.sign(adminTokenSession, 42, time.Now().Add(AdminSessionTTL))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := s.Login(tt.token); !errors.Is(err, ErrAdminTokenInvalid) {
+				t.Fatalf("Login() error = %v, want %v", err, ErrAdminTokenInvalid)
+			}
+		})
+	}
+}
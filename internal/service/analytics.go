@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+)
+
+// AnalyticsService records product analytics events.
+type AnalyticsService struct {
+	repo   EventRepository
+	logger *zap.Logger
+}
+
+// NewAnalyticsService creates a new AnalyticsService.
+func NewAnalyticsService(repo EventRepository, logger *zap.Logger) *AnalyticsService {
+	return &AnalyticsService{repo: repo, logger: logger}
+}
+
+// Track records an event for the user. Analytics must never break the user flow,
+// so failures are only logged.
+func (s *AnalyticsService) Track(ctx context.Context, userID int64, name entities.EventName, properties map[string]any) {
+	err := s.repo.Create(ctx, &entities.Event{
+		UserID:     userID,
+		Name:       name,
+		Properties: properties,
+		CreatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		requestid.Logger(ctx, s.logger).Warn("failed to track event",
+			zap.Int64("user_id", userID),
+			zap.String("event", string(name)),
+			zap.Error(err),
+		)
+	}
+}
+
+// OnboardingFunnel summarizes onboarding conversion from the events recorded within the period.
+// Every step 1..OnboardingSteps is present, including steps nobody reached.
+func (s *AnalyticsService) OnboardingFunnel(ctx context.Context, period time.Duration) (*entities.OnboardingFunnel, error) {
+	since := time.Now().UTC().Add(-period)
+
+	counts, err := s.repo.GetOnboardingFunnel(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel := &entities.OnboardingFunnel{
+		Since: since,
+		Steps: make([]entities.OnboardingFunnelStep, entities.OnboardingSteps),
+	}
+	for i := range funnel.Steps {
+		funnel.Steps[i].Step = i + 1
+	}
+	for _, c := range counts {
+		switch {
+		case c.Step >= 1 && c.Step <= entities.OnboardingSteps:
+			funnel.Steps[c.Step-1] = c
+		case c.Step == entities.OnboardingSteps+1:
+			funnel.Finished = c.Shown
+		}
+	}
+
+	return funnel, nil
+}
+
+// DailyActiveUsers returns the number of users with any event for each of the last days UTC days,
+// oldest first and including today; days without activity are zero.
+func (s *AnalyticsService) DailyActiveUsers(ctx context.Context, days int) ([]entities.DailyCount, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.repo.GetDailyActiveUsers(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[time.Time]int, len(counts))
+	for _, c := range counts {
+		byDay[c.Day.UTC()] = c.Count
+	}
+
+	series := make([]entities.DailyCount, days)
+	for i := range series {
+		day := since.AddDate(0, 0, i)
+		series[i] = entities.DailyCount{Day: day, Count: byDay[day]}
+	}
+
+	return series, nil
+}
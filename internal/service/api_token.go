@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// apiTokenPrefix marks bot API tokens so they are easy to recognize in configs and leaks.
+const apiTokenPrefix = "ahb_"
+
+var (
+	ErrAPIDisabled     = errors.New("http api is disabled")
+	ErrAPITokenInvalid = errors.New("invalid api token")
+)
+
+// APITokenService issues and checks personal tokens for the HTTP API.
+type APITokenService struct {
+	repo    APITokenRepository
+	enabled bool
+}
+
+// NewAPITokenService creates a new APITokenService; enabled reports whether the HTTP API is served.
+func NewAPITokenService(repo APITokenRepository, enabled bool) *APITokenService {
+	return &APITokenService{repo: repo, enabled: enabled}
+}
+
+// Issue creates a new token for the user, revoking the previous one. The token is
+// returned once and only its hash is stored.
+func (s *APITokenService) Issue(ctx context.Context, userID int64) (string, error) {
+	if !s.enabled {
+		return "", ErrAPIDisabled
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	if err := s.repo.Save(ctx, userID, hashAPIToken(token), time.Now().UTC()); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// Revoke deletes the user's token. It reports whether there was one.
+func (s *APITokenService) Revoke(ctx context.Context, userID int64) (bool, error) {
+	return s.repo.Delete(ctx, userID)
+}
+
+// Authenticate returns the ID of the user the token belongs to.
+func (s *APITokenService) Authenticate(ctx context.Context, token string) (int64, error) {
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return 0, ErrAPITokenInvalid
+	}
+
+	userID, err := s.repo.Touch(ctx, hashAPIToken(token), time.Now().UTC())
+	if errors.Is(err, repository.ErrAPITokenNotFound) {
+		return 0, ErrAPITokenInvalid
+	}
+	return userID, err
+}
+
+// hashAPIToken returns the stored form of a token. Tokens are random, so a plain hash is enough.
+func hashAPIToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// blockListReloadInterval is how often the block list is re-read, so changes made on another instance apply.
+const blockListReloadInterval = time.Minute
+
+// maxBlockReasonLen caps the length of a block reason in runes.
+const maxBlockReasonLen = 200
+
+var ErrCannotBlockAdmin = errors.New("admins cannot be blocked")
+
+// BlockListService keeps the users blocked by admins. The list is held in memory, so
+// checking every incoming update does not reach the database.
+type BlockListService struct {
+	repo   BlockListRepository
+	admins *AdminService
+	logger *zap.Logger
+
+	mu      sync.RWMutex
+	blocked map[int64]struct{}
+}
+
+// NewBlockListService creates a new BlockListService; admins cannot be blocked.
+func NewBlockListService(repo BlockListRepository, admins *AdminService, logger *zap.Logger) *BlockListService {
+	return &BlockListService{repo: repo, admins: admins, logger: logger, blocked: make(map[int64]struct{})}
+}
+
+// Load reads the block list from the database.
+func (s *BlockListService) Load(ctx context.Context) error {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[int64]struct{}, len(users))
+	for _, u := range users {
+		blocked[u.UserID] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.blocked = blocked
+	s.mu.Unlock()
+	return nil
+}
+
+// Start re-reads the block list until the context is cancelled. It runs on every instance.
+func (s *BlockListService) Start(ctx context.Context) {
+	ticker := time.NewTicker(blockListReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil {
+				s.logger.Error("failed to reload block list", zap.Error(err))
+			}
+		}
+	}
+}
+
+// IsBlocked reports whether the user is blocked.
+func (s *BlockListService) IsBlocked(userID int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.blocked[userID]
+	return ok
+}
+
+// Block blocks a user; queued notifications for them are dropped.
+func (s *BlockListService) Block(ctx context.Context, userID int64, reason string, blockedBy int64) error {
+	role, err := s.admins.Role(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if role != "" {
+		return ErrCannotBlockAdmin
+	}
+
+	reason = strings.TrimSpace(reason)
+	if r := []rune(reason); len(r) > maxBlockReasonLen {
+		reason = string(r[:maxBlockReasonLen])
+	}
+
+	if err := s.repo.Block(ctx, &entities.BlockedUser{UserID: userID, Reason: reason, BlockedBy: blockedBy}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.blocked[userID] = struct{}{}
+	s.mu.Unlock()
+	return nil
+}
+
+// Unblock unblocks a user and reports whether they were blocked.
+func (s *BlockListService) Unblock(ctx context.Context, userID int64) (bool, error) {
+	removed, err := s.repo.Unblock(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	delete(s.blocked, userID)
+	s.mu.Unlock()
+	return removed, nil
+}
+
+// List returns the blocked users, most recently blocked first.
+func (s *BlockListService) List(ctx context.Context) ([]entities.BlockedUser, error) {
+	return s.repo.List(ctx)
+}
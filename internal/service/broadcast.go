@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+var (
+	ErrBroadcastEmpty   = errors.New("broadcast is empty")
+	ErrBroadcastTooLong = errors.New("broadcast is too long")
+	ErrBroadcastSegment = errors.New("unknown broadcast segment")
+)
+
+// BroadcastService sends admin messages to user segments through the notification queue.
+type BroadcastService struct {
+	repo BroadcastRepository
+}
+
+// NewBroadcastService creates a new BroadcastService.
+func NewBroadcastService(repo BroadcastRepository) *BroadcastService {
+	return &BroadcastService{repo: repo}
+}
+
+// Send validates the message and queues it for every active user of the segment.
+func (s *BroadcastService) Send(ctx context.Context, adminID int64, segment, text string) (*entities.Broadcast, error) {
+	seg, ok := entities.ParseSurveySegment(strings.ToLower(strings.TrimSpace(segment)))
+	if !ok {
+		return nil, ErrBroadcastSegment
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrBroadcastEmpty
+	}
+	if utf8.RuneCountInString(text) > entities.MaxBroadcastLength {
+		return nil, ErrBroadcastTooLong
+	}
+
+	broadcast := &entities.Broadcast{
+		Segment:   seg,
+		Text:      text,
+		CreatedBy: adminID,
+	}
+	if err := s.repo.Enqueue(ctx, broadcast); err != nil {
+		return nil, err
+	}
+	return broadcast, nil
+}
+
+// List returns the latest broadcasts, newest first.
+func (s *BroadcastService) List(ctx context.Context, limit int) ([]entities.Broadcast, error) {
+	return s.repo.List(ctx, limit)
+}
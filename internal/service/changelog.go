@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// changelogLanguages are the languages announcements are prepared in.
+var changelogLanguages = []string{"ru", "en"}
+
+// ChangelogService shows release notes and announces new releases to subscribers.
+type ChangelogService struct {
+	notes  ReleaseNotesRepository
+	repo   ChangelogRepository
+	logger *zap.Logger
+}
+
+// NewChangelogService creates a new ChangelogService.
+func NewChangelogService(notes ReleaseNotesRepository, repo ChangelogRepository, logger *zap.Logger) *ChangelogService {
+	return &ChangelogService{notes: notes, repo: repo, logger: logger}
+}
+
+// Latest returns up to limit releases, newest first.
+func (s *ChangelogService) Latest(limit int) []entities.Release {
+	return s.notes.Latest(limit)
+}
+
+// IsSubscribed reports whether the user receives announcements.
+func (s *ChangelogService) IsSubscribed(ctx context.Context, userID int64) (bool, error) {
+	return s.repo.IsSubscribed(ctx, userID)
+}
+
+// SetSubscribed opts the user in to or out of announcements.
+func (s *ChangelogService) SetSubscribed(ctx context.Context, userID int64, subscribed bool) error {
+	if subscribed {
+		return s.repo.Subscribe(ctx, userID)
+	}
+	return s.repo.Unsubscribe(ctx, userID)
+}
+
+// AnnounceLatest queues an announcement of the newest release for subscribers.
+// It runs on startup; only the first instance started with a release announces it.
+func (s *ChangelogService) AnnounceLatest(ctx context.Context) error {
+	latest := s.notes.Latest(1)
+	if len(latest) == 0 {
+		return nil
+	}
+	release := latest[0]
+
+	payloads := make(map[string][]byte, len(changelogLanguages))
+	for _, lang := range changelogLanguages {
+		payload, err := json.Marshal(entities.ChangelogAnnouncement{
+			Version:  release.Version,
+			Language: lang,
+			Notes:    release.NotesFor(lang),
+		})
+		if err != nil {
+			return fmt.Errorf("encode changelog announcement: %w", err)
+		}
+		payloads[lang] = payload
+	}
+
+	claimed, queued, err := s.repo.EnqueueAnnouncement(ctx, release.Version, payloads)
+	if err != nil {
+		return err
+	}
+
+	if claimed {
+		s.logger.Info("release announced",
+			zap.String("version", release.Version),
+			zap.Int("subscribers", queued),
+		)
+	}
+	return nil
+}
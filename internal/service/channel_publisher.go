@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// ChannelSchedule is a Telegram channel or group that receives the name of the day at a fixed local time.
+type ChannelSchedule struct {
+	ChatID   int64
+	At       time.Duration // posting time as an offset from local midnight
+	Location *time.Location
+	Language string // language of the post, "ru" or "en"
+	Quiz     bool   // follow the post with a mini-quiz, groups only
+}
+
+// NewChannelSchedule builds a schedule from an "HH:MM" posting time and a timezone
+// accepted by entities.ParseTimezoneLocation. An empty language means Russian.
+func NewChannelSchedule(chatID int64, at, tz, lang string) (ChannelSchedule, error) {
+	if chatID == 0 {
+		return ChannelSchedule{}, errors.New("channel chat_id is required")
+	}
+
+	tod, err := time.Parse("15:04", at)
+	if err != nil {
+		return ChannelSchedule{}, fmt.Errorf("invalid posting time %q: %w", at, err)
+	}
+
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		return ChannelSchedule{}, err
+	}
+
+	if lang == "" {
+		lang = entities.DefaultGroupLanguage
+	}
+
+	return ChannelSchedule{
+		ChatID:   chatID,
+		At:       time.Duration(tod.Hour())*time.Hour + time.Duration(tod.Minute())*time.Minute,
+		Location: loc,
+		Language: lang,
+	}, nil
+}
+
+// isDue reports whether the posting time of the channel's local day of now has come.
+func (c ChannelSchedule) isDue(now time.Time) bool {
+	local := now.In(c.Location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.Location)
+	return !local.Before(midnight.Add(c.At))
+}
+
+// ChannelPublisher posts a name card to Telegram channels from the configuration and
+// to groups with daily posting enabled once a day, going through all 99 names in order
+// separately for each chat. A post missed while the bot was down is sent later the same day.
+type ChannelPublisher struct {
+	postRepo  ChannelPostRepository
+	groupRepo GroupChatRepository
+	nameRepo  NameRepository
+	poster    ChannelPoster
+	channels  []ChannelSchedule
+	logger    *zap.Logger
+}
+
+// NewChannelPublisher creates a new channel publisher for the given channels.
+func NewChannelPublisher(
+	postRepo ChannelPostRepository,
+	groupRepo GroupChatRepository,
+	nameRepo NameRepository,
+	channels []ChannelSchedule,
+	logger *zap.Logger,
+) *ChannelPublisher {
+	return &ChannelPublisher{
+		postRepo:  postRepo,
+		groupRepo: groupRepo,
+		nameRepo:  nameRepo,
+		channels:  channels,
+		logger:    logger,
+	}
+}
+
+// SetPoster sets the poster (called after handler is created).
+func (p *ChannelPublisher) SetPoster(poster ChannelPoster) {
+	p.poster = poster
+}
+
+// Start checks every minute for channels due a post until the context is cancelled.
+func (p *ChannelPublisher) Start(ctx context.Context) {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	_, err := c.AddFunc("* * * * *", func() {
+		p.PublishDue(ctx, time.Now())
+	})
+	if err != nil {
+		p.logger.Error("failed to add channel publisher cron job", zap.Error(err))
+		return
+	}
+
+	c.Start()
+	p.logger.Info("channel publisher started", zap.Int("configured_channels", len(p.channels)))
+
+	<-ctx.Done()
+
+	c.Stop()
+	p.logger.Info("channel publisher stopped")
+}
+
+// PublishDue posts the name of the day to every channel and group whose posting time
+// has come and that has no post for its current day yet.
+func (p *ChannelPublisher) PublishDue(ctx context.Context, now time.Time) {
+	for _, ch := range append(p.channels, p.groupSchedules(ctx)...) {
+		if !ch.isDue(now) {
+			continue
+		}
+
+		if err := p.publish(ctx, ch, entities.LocalDay(now, ch.Location)); err != nil {
+			p.logger.Error("failed to publish name of the day",
+				zap.Int64("chat_id", ch.ChatID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// groupSchedules returns schedules of groups with daily posting enabled.
+func (p *ChannelPublisher) groupSchedules(ctx context.Context) []ChannelSchedule {
+	groups, err := p.groupRepo.ListDailyPost(ctx)
+	if err != nil {
+		p.logger.Error("failed to list groups with daily posting", zap.Error(err))
+		return nil
+	}
+
+	schedules := make([]ChannelSchedule, 0, len(groups))
+	for _, g := range groups {
+		schedule, err := NewChannelSchedule(g.ChatID, g.PostTime, g.Timezone, g.LanguageCode)
+		if err != nil {
+			p.logger.Warn("invalid group posting schedule",
+				zap.Int64("chat_id", g.ChatID),
+				zap.Error(err),
+			)
+			continue
+		}
+		schedule.Quiz = g.DailyQuiz
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules
+}
+
+// publish posts the next name to a channel unless it already has a post for day.
+func (p *ChannelPublisher) publish(ctx context.Context, ch ChannelSchedule, day time.Time) error {
+	last, err := p.postRepo.GetLast(ctx, ch.ChatID)
+	if err != nil && !errors.Is(err, repository.ErrChannelPostNotFound) {
+		return err
+	}
+	if last != nil && !last.PostDate.Before(day) {
+		return nil
+	}
+
+	lastName, err := p.postRepo.GetLastPublishedName(ctx, ch.ChatID)
+	if err != nil {
+		return err
+	}
+	nameNumber := entities.NextChannelName(lastName)
+
+	// Claim the day first so that a second instance or a slow send cannot post twice.
+	reserved, err := p.postRepo.Reserve(ctx, ch.ChatID, day, nameNumber)
+	if err != nil || !reserved {
+		return err
+	}
+
+	name, err := p.nameRepo.GetByNumber(nameNumber)
+	if err != nil {
+		_ = p.postRepo.Release(ctx, ch.ChatID, day)
+		return fmt.Errorf("get name %d: %w", nameNumber, err)
+	}
+
+	messageID, err := p.poster.PostNameOfTheDay(ctx, ch.ChatID, name, day, ch.Language)
+	if err != nil {
+		if errors.Is(err, ErrNotificationUndeliverable) {
+			// The bot cannot post to this channel; keep the claim so the day is
+			// skipped instead of retried every minute.
+			return fmt.Errorf("channel rejected the post: %w", err)
+		}
+		if relErr := p.postRepo.Release(ctx, ch.ChatID, day); relErr != nil {
+			p.logger.Warn("failed to release channel post", zap.Error(relErr))
+		}
+		return fmt.Errorf("post name of the day: %w", err)
+	}
+
+	if err := p.postRepo.MarkPublished(ctx, ch.ChatID, day, messageID); err != nil {
+		return err
+	}
+
+	p.logger.Info("name of the day published",
+		zap.Int64("chat_id", ch.ChatID),
+		zap.Int("name_number", nameNumber),
+	)
+
+	if ch.Quiz {
+		// The post is already out, so a failed quiz is not retried.
+		if err := p.publishQuiz(ctx, ch, day, name); err != nil {
+			p.logger.Error("failed to publish group quiz",
+				zap.Int64("chat_id", ch.ChatID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// publishQuiz sends a mini-quiz after the name of the day. It asks about a random name
+// posted to the chat on an earlier day, or about today's name while there are none.
+func (p *ChannelPublisher) publishQuiz(ctx context.Context, ch ChannelSchedule, day time.Time, today *entities.Name) error {
+	posted, err := p.postRepo.ListPublishedNamesBefore(ctx, ch.ChatID, day)
+	if err != nil {
+		return err
+	}
+
+	name := today
+	if len(posted) > 0 {
+		number := posted[rand.Intn(len(posted))]
+		if name, err = p.nameRepo.GetByNumber(number); err != nil {
+			return fmt.Errorf("get name %d: %w", number, err)
+		}
+	}
+
+	allNames, err := p.nameRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("get all names: %w", err)
+	}
+	options, correctIndex := NewOptionGenerator(allNames).GenerateOptions(name, entities.QuestionTypeArabic)
+
+	pollID, err := p.poster.PostQuiz(ctx, ch.ChatID, &entities.ChannelQuiz{
+		Name:         name,
+		Options:      options,
+		CorrectIndex: correctIndex,
+	}, ch.Language)
+	if err != nil {
+		return fmt.Errorf("post quiz: %w", err)
+	}
+
+	return p.postRepo.SetQuiz(ctx, ch.ChatID, day, name.Number, pollID)
+}
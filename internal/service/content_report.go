@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+var (
+	ErrContentReportEmpty    = errors.New("content report is empty")
+	ErrContentReportTooLong  = errors.New("content report is too long")
+	ErrContentReportResolved = errors.New("content report is already resolved")
+)
+
+// ContentReportService stores user reports about errors on name cards and their review by admins.
+type ContentReportService struct {
+	repo       ContentReportRepository
+	recipients []int64
+}
+
+// NewContentReportService creates a new ContentReportService. Reports are sent for review
+// to the same chats as feedback.
+func NewContentReportService(repo ContentReportRepository, recipients []int64) *ContentReportService {
+	return &ContentReportService{repo: repo, recipients: recipients}
+}
+
+// Recipients returns the chats reports are sent to for review.
+func (s *ContentReportService) Recipients() []int64 {
+	return s.recipients
+}
+
+// Submit validates and stores a report about the field of a name card.
+func (s *ContentReportService) Submit(
+	ctx context.Context,
+	userID, chatID int64,
+	nameNumber int,
+	field entities.ContentReportField,
+	text string,
+) (*entities.ContentReport, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrContentReportEmpty
+	}
+	if utf8.RuneCountInString(text) > entities.MaxContentReportLength {
+		return nil, ErrContentReportTooLong
+	}
+
+	report := &entities.ContentReport{
+		UserID:     userID,
+		ChatID:     chatID,
+		NameNumber: nameNumber,
+		Field:      field,
+		Text:       text,
+	}
+	if err := s.repo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Resolve accepts or dismisses an open report and returns it with the new status.
+// It returns ErrContentReportResolved if another admin has already resolved it.
+func (s *ContentReportService) Resolve(ctx context.Context, id int64, accept bool, adminID int64) (*entities.ContentReport, error) {
+	status := entities.ContentReportDismissed
+	if accept {
+		status = entities.ContentReportAccepted
+	}
+
+	ok, err := s.repo.Resolve(ctx, id, status, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return report, ErrContentReportResolved
+	}
+	return report, nil
+}
+
+// ListOpen returns the oldest reports waiting for review.
+func (s *ContentReportService) ListOpen(ctx context.Context, limit int) ([]entities.ContentReport, error) {
+	return s.repo.ListOpen(ctx, limit)
+}
@@ -16,6 +16,49 @@ type UserRepository interface {
 	Save(ctx context.Context, user *entities.User) (bool, error)
 	// Exists checks if a user with the given ID exists.
 	Exists(ctx context.Context, userID int64) (bool, error)
+	// TouchActivity records that the user interacted with the bot.
+	TouchActivity(ctx context.Context, userID int64, now time.Time) error
+	// SetReferrer attributes a user to the referrer who invited them.
+	SetReferrer(ctx context.Context, userID, referrerID int64, now time.Time) (bool, error)
+	// GetReferralStats counts the users invited by the given user.
+	GetReferralStats(ctx context.Context, userID int64) (*entities.ReferralStats, error)
+	// Search returns users whose ID starts with the given digits.
+	Search(ctx context.Context, idPrefix string, limit int) ([]entities.UserInfo, error)
+}
+
+// RetentionRepository defines the interface for pruning outdated data.
+type RetentionRepository interface {
+	PruneQuizHistory(ctx context.Context, before time.Time) (int64, error)
+	ArchiveQuizAnswers(ctx context.Context, before time.Time, limit int) (int64, error)
+	PruneDeliveredNotifications(ctx context.Context, before time.Time) (int64, error)
+	PruneReminderLog(ctx context.Context, before time.Time) (int64, error)
+	GetInactiveUsersBatch(ctx context.Context, inactiveSince time.Time, afterUserID int64, limit int) ([]*entities.InactiveUser, error)
+	MarkDeletionWarned(ctx context.Context, userID int64, warnedAt time.Time) error
+	DeleteWarnedUsers(ctx context.Context, warnedBefore time.Time) (int64, error)
+}
+
+// RetentionCohortRepository computes and stores weekly retention cohorts.
+type RetentionCohortRepository interface {
+	Refresh(ctx context.Context, since, now time.Time) (int64, error)
+	List(ctx context.Context, limit int) ([]entities.RetentionCohort, error)
+}
+
+// RetentionNotifier warns users before their data is deleted.
+type RetentionNotifier interface {
+	SendInactivityWarning(userID, chatID int64, deleteAt time.Time) error
+}
+
+// RetentionReportNotifier posts the retention cohort summary to an admin chat.
+type RetentionReportNotifier interface {
+	SendRetentionReport(chatID int64, cohorts []entities.RetentionCohort) error
+}
+
+// ChannelPoster publishes the name of the day to a Telegram channel or group.
+type ChannelPoster interface {
+	// PostNameOfTheDay posts the name card for day and returns the ID of the posted message.
+	PostNameOfTheDay(ctx context.Context, chatID int64, name *entities.Name, day time.Time, lang string) (int, error)
+	// PostQuiz sends a mini-quiz to a group and returns the ID of the Telegram poll.
+	PostQuiz(ctx context.Context, chatID int64, quiz *entities.ChannelQuiz, lang string) (string, error)
 }
 
 // NameRepository defines operations for accessing Allah's names.
@@ -29,6 +72,29 @@ type NameRepository interface {
 	GetByNumbers(numbers []int) ([]entities.Name, error)
 }
 
+// NameContentStore holds the loaded names whose texts admins can replace.
+type NameContentStore interface {
+	GetByNumber(number int) (*entities.Name, error)
+	Replace(name *entities.Name) error
+}
+
+// NameCache is a cached copy of the names list that must be dropped when names change.
+type NameCache interface {
+	Invalidate()
+}
+
+// NameOverrideRepository stores name card texts edited by admins.
+type NameOverrideRepository interface {
+	List(ctx context.Context) ([]entities.Name, error)
+	Save(ctx context.Context, name *entities.Name, adminID int64) error
+}
+
+// NameDatasetRepository stores the names dataset in Postgres.
+type NameDatasetRepository interface {
+	List(ctx context.Context) ([]*entities.Name, error)
+	UpsertMany(ctx context.Context, names []*entities.Name) error
+}
+
 // ProgressRepository defines operations for user progress tracking.
 type ProgressRepository interface {
 	// GetNamesDueForReview retrieves names due for review according to SRS.
@@ -43,9 +109,28 @@ type ProgressRepository interface {
 	GetLearningNames(ctx context.Context, userID int64, limit int) ([]int, error)
 	GetRandomReinforcementNames(ctx context.Context, userID int64, limit int) ([]int, error)
 	Upsert(ctx context.Context, progress *entities.UserProgress) error
+	// UpsertMany creates or updates several progress records in one statement.
+	UpsertMany(ctx context.Context, progress []*entities.UserProgress) error
 	GetNewNames(ctx context.Context, userID int64, limit int) ([]int, error)
-	GetStreak(ctx context.Context, userID int64, nameNumber int) (int, error)
+	// GetStreaks returns the streaks of several names; names without progress are omitted.
+	GetStreaks(ctx context.Context, userID int64, nums []int) (map[int]int, error)
+	// MarkIntroducedMany stamps introduced_at on the progress of names that have none yet.
+	MarkIntroducedMany(ctx context.Context, userID int64, nums []int) error
 	GetByNumbers(ctx context.Context, userID int64, nums []int) (map[int]*entities.UserProgress, error)
+	// GetPage returns up to limit progress records after afterNameNumber, in name order.
+	GetPage(ctx context.Context, userID int64, afterNameNumber, limit int) ([]*entities.UserProgress, error)
+	// GetPhases returns the learning phases of several names; names without progress are omitted.
+	GetPhases(ctx context.Context, userID int64, nums []int) (map[int]entities.Phase, error)
+	// GetDueTimes returns the review times of names due before until.
+	GetDueTimes(ctx context.Context, userID int64, until time.Time) ([]time.Time, error)
+	// GetNameAnswerStats returns quiz answer statistics for a single name.
+	GetNameAnswerStats(ctx context.Context, userID int64, nameNumber int) (*repository.NameAnswerStats, error)
+	// GetPeriodStats returns quiz activity and newly mastered names within a time range.
+	GetPeriodStats(ctx context.Context, userID int64, from, to time.Time, offsetSec int) (*repository.PeriodStats, error)
+	// GetQuestionTypeStats returns quiz answer statistics grouped by question type.
+	GetQuestionTypeStats(ctx context.Context, userID int64) ([]repository.QuestionTypeStats, error)
+	// GetActivityDays returns quiz answers grouped by local day.
+	GetActivityDays(ctx context.Context, userID int64, from, to time.Time, offsetSec int) ([]repository.ActivityDay, error)
 }
 
 // QuizRepository defines operations for quiz session and answer persistence.
@@ -56,9 +141,11 @@ type QuizRepository interface {
 	GetSessionForUpdate(ctx context.Context, sessionID, userID int64) (*entities.QuizSession, error)
 	GetQuestionByOrder(ctx context.Context, sessionID int64, order int) (*entities.QuizQuestion, error)
 	SaveAnswer(ctx context.Context, answer *entities.QuizAnswer) error
+	RecordAnswerAndUpdateSRS(ctx context.Context, answer *entities.QuizAnswer, progress *entities.UserProgress) error
 	UpdateSession(ctx context.Context, session *entities.QuizSession) error
 	GetActiveSessionByUserID(ctx context.Context, userID int64) (*entities.QuizSession, error)
 	IsFirstQuiz(ctx context.Context, userID int64) (bool, error)
+	ListAnswers(ctx context.Context, userID int64) ([]*entities.QuizAnswer, error)
 }
 
 // SettingsRepository defines operations for user settings persistence.
@@ -74,6 +161,13 @@ type SettingsRepository interface {
 	UpdateLearningMode(ctx context.Context, userID int64, learningMode string) error
 	UpsertDefaults(ctx context.Context, userID int64) error
 	UpdateTimezone(ctx context.Context, userID int64, timezone string) error
+	UpdateCalendar(ctx context.Context, userID int64, calendar entities.Calendar) error
+	UpdateTargetDate(ctx context.Context, userID int64, targetDate *time.Time) error
+}
+
+// SettingsCache drops cached settings after they are written around the cache, e.g. in a transaction.
+type SettingsCache interface {
+	Invalidate(userID int64)
 }
 
 // ReminderRepository manages reminder persistence.
@@ -85,15 +179,71 @@ type ReminderRepository interface {
 	// Upsert creates or updates reminder settings.
 	Upsert(ctx context.Context, rem *entities.UserReminders) error
 	GetDueReminder(ctx context.Context, userID int64) (*entities.ReminderWithUser, error)
-	GetDueRemindersBatch(ctx context.Context, now time.Time, limit, offset int) ([]*entities.ReminderWithUser, error)
+	GetReminderWithUser(ctx context.Context, userID int64) (*entities.ReminderWithUser, error)
+	ClaimDueRemindersBatch(ctx context.Context, now time.Time, claimTTL time.Duration, limit int) ([]*entities.ReminderWithUser, error)
 	UpdateAfterSend(ctx context.Context, userID int64, sentAt time.Time, nextSendAt time.Time, lastKind entities.ReminderKind) error
 	RescheduleNext(ctx context.Context, userID int64, nextSendAt time.Time) error
+	GetDigestRecipientsBatch(ctx context.Context, limit, offset int) ([]*entities.DigestRecipient, error)
+	MarkDigestSent(ctx context.Context, userID int64, sentAt time.Time) error
+	GetActivityHours(ctx context.Context, userID int64) (entities.ActivityHours, error)
+	GetStreakAlertCandidatesBatch(ctx context.Context, limit, offset int) ([]*entities.StreakAlertCandidate, error)
+	MarkStreakAlertSent(ctx context.Context, userID int64, sentAt time.Time) error
+	// GetReminderCandidates reads today's plan with its progress and the most overdue name in one query.
+	GetReminderCandidates(ctx context.Context, userID int64, dateUTC time.Time, masteryStreak int) (*entities.ReminderCandidates, error)
+}
+
+// ReminderLogRepository stores the history of notification delivery attempts.
+type ReminderLogRepository interface {
+	Record(ctx context.Context, entry *entities.ReminderLogEntry) error
+	GetDeliveryStats(ctx context.Context, since time.Time) (*entities.DeliveryStats, error)
+}
+
+// OverviewRepository aggregates bot usage counters.
+type OverviewRepository interface {
+	GetOverview(ctx context.Context, now time.Time) (*entities.BotOverview, error)
+}
+
+// DeliveryCounter counts delivery attempts by notification kind and outcome.
+type DeliveryCounter interface {
+	Inc(labelValues ...string)
+}
+
+// NotificationJobRepository persists queued notifications for the delivery workers.
+type NotificationJobRepository interface {
+	// Enqueue stores a new pending job.
+	Enqueue(ctx context.Context, job *entities.NotificationJob) error
+	// ClaimDue locks due jobs for this worker and counts the delivery attempt.
+	ClaimDue(ctx context.Context, now time.Time, lockTTL time.Duration, limit int) ([]*entities.NotificationJob, error)
+	// MarkDelivered records that the job was sent.
+	MarkDelivered(ctx context.Context, id int64, deliveredAt time.Time) error
+	// Retry schedules another delivery attempt.
+	Retry(ctx context.Context, id int64, scheduledAt time.Time, lastError string) error
+	// Fail marks a job as permanently failed.
+	Fail(ctx context.Context, id int64, lastError string) error
 }
 
 // ReminderNotifier sends reminder notifications to users.
 type ReminderNotifier interface {
 	// SendReminder sends a reminder message to a user.
-	SendReminder(userID, chatID int64, payload entities.ReminderPayload) error
+	SendReminder(ctx context.Context, userID, chatID int64, payload entities.ReminderPayload) error
+	// SendDailyDigest sends the daily plan and due reviews in a single message.
+	SendDailyDigest(ctx context.Context, userID, chatID int64, digest entities.DailyDigest) error
+	// SendWeeklyDigest sends the weekly progress digest to a user.
+	SendWeeklyDigest(ctx context.Context, userID, chatID int64, digest entities.WeeklyDigest) error
+	// SendStreakAlert warns a user that their daily streak is about to break.
+	SendStreakAlert(ctx context.Context, userID, chatID int64, streak int, hoursLeft int) error
+	// SendSurvey sends the first question of a survey to a user.
+	SendSurvey(ctx context.Context, userID, chatID int64, invite entities.SurveyInvite) error
+	// SendChangelog announces a new release to a subscriber.
+	SendChangelog(ctx context.Context, userID, chatID int64, announcement entities.ChangelogAnnouncement) error
+	// SendBroadcast sends an admin broadcast to a user.
+	SendBroadcast(ctx context.Context, userID, chatID int64, message entities.BroadcastMessage) error
+}
+
+// WebhookSender posts queued learning events to the webhook endpoint.
+type WebhookSender interface {
+	// Send posts an encoded WebhookEvent.
+	Send(payload []byte) error
 }
 
 type DailyNameRepository interface {
@@ -107,6 +257,162 @@ type DailyNameRepository interface {
 	GetNamesCountByDate(ctx context.Context, userID int64, dateUTC time.Time) (int, error)
 	AddNameForDate(ctx context.Context, userID int64, dateUTC time.Time, nameNumber int) error
 	GetCarryOverUnfinishedFromPast(ctx context.Context, userID int64, todayDateUTC time.Time, limit int) ([]int, error)
+	SetOrderForDate(ctx context.Context, userID int64, dateUTC time.Time, order []int) error
+	ReplaceNameForDate(ctx context.Context, userID int64, dateUTC time.Time, oldName, newName int) (bool, error)
+	MarkStudied(ctx context.Context, userID int64, dateUTC time.Time, nameNumber int) (bool, error)
+	GetStudiedByDate(ctx context.Context, userID int64, dateUTC time.Time) ([]int, error)
+}
+
+// ChannelPostRepository tracks names of the day posted to Telegram channels.
+type ChannelPostRepository interface {
+	GetLast(ctx context.Context, chatID int64) (*entities.ChannelPost, error)
+	GetLastPublishedName(ctx context.Context, chatID int64) (int, error)
+	Reserve(ctx context.Context, chatID int64, postDate time.Time, nameNumber int) (bool, error)
+	MarkPublished(ctx context.Context, chatID int64, postDate time.Time, messageID int) error
+	ListPublishedNamesBefore(ctx context.Context, chatID int64, postDate time.Time) ([]int, error)
+	SetQuiz(ctx context.Context, chatID int64, postDate time.Time, nameNumber int, pollID string) error
+	UpdateQuizResults(ctx context.Context, pollID string, voters, correct int) error
+	GetQuizStats(ctx context.Context, chatID int64) (entities.ChannelQuizStats, error)
+	Release(ctx context.Context, chatID int64, postDate time.Time) error
+}
+
+// AdminRepository stores admin roles and the audit log of admin actions.
+type AdminRepository interface {
+	Get(ctx context.Context, userID int64) (*entities.Admin, error)
+	List(ctx context.Context) ([]entities.Admin, error)
+	Upsert(ctx context.Context, userID int64, role entities.AdminRole, addedBy int64) error
+	Delete(ctx context.Context, userID int64) error
+	LogAction(ctx context.Context, action *entities.AdminAction) error
+	ListActions(ctx context.Context, limit int) ([]entities.AdminAction, error)
+}
+
+// UserAuditRepository stores the append-only log of changes users make to their own state.
+type UserAuditRepository interface {
+	Append(ctx context.Context, entry *entities.UserAuditEntry) error
+	ListByUser(ctx context.Context, userID int64, limit int) ([]entities.UserAuditEntry, error)
+}
+
+// BlockListRepository stores the users blocked by admins.
+type BlockListRepository interface {
+	Block(ctx context.Context, user *entities.BlockedUser) error
+	Unblock(ctx context.Context, userID int64) (bool, error)
+	List(ctx context.Context) ([]entities.BlockedUser, error)
+}
+
+// EphemeralMessageRepository stores bot messages that are deleted from chats after a while.
+type EphemeralMessageRepository interface {
+	Track(ctx context.Context, msg *entities.EphemeralMessage) error
+	Forget(ctx context.Context, chatID int64, messageID int) error
+	TakeDue(ctx context.Context, now time.Time, limit int) ([]entities.EphemeralMessage, error)
+}
+
+// MessageExpirer removes an outdated bot message from a chat.
+type MessageExpirer interface {
+	// DeleteMessage deletes the message.
+	DeleteMessage(chatID int64, messageID int) error
+	// EditOutMessage replaces the text of the message with a note that it is outdated and drops its buttons.
+	EditOutMessage(chatID int64, messageID int) error
+}
+
+// TicketRepository stores support tickets and their copies in admin chats.
+type TicketRepository interface {
+	Create(ctx context.Context, t *entities.Ticket) error
+	AddMessage(ctx context.Context, ticketID, chatID int64, messageID int) error
+	Get(ctx context.Context, id int64) (*entities.Ticket, error)
+	GetByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Ticket, error)
+	List(ctx context.Context, status entities.TicketStatus, limit int) ([]entities.Ticket, error)
+	MarkAnswered(ctx context.Context, ticketID, adminID int64) error
+	Close(ctx context.Context, ticketID, adminID int64) (bool, error)
+}
+
+// ContentReportRepository stores reports about errors on name cards.
+type ContentReportRepository interface {
+	Create(ctx context.Context, report *entities.ContentReport) error
+	Get(ctx context.Context, id int64) (*entities.ContentReport, error)
+	Resolve(ctx context.Context, id int64, status entities.ContentReportStatus, adminID int64) (bool, error)
+	ListOpen(ctx context.Context, limit int) ([]entities.ContentReport, error)
+}
+
+// SurveyRepository stores surveys, their recipients and answers.
+type SurveyRepository interface {
+	Create(ctx context.Context, survey *entities.Survey) error
+	Get(ctx context.Context, id int64) (*entities.Survey, error)
+	List(ctx context.Context, limit int) ([]entities.Survey, error)
+	EnqueueInvites(ctx context.Context, survey *entities.Survey, payload []byte) (int, error)
+	SaveAnswer(ctx context.Context, surveyID, userID int64, question, option int) error
+	MarkCompleted(ctx context.Context, surveyID, userID int64) error
+	GetResults(ctx context.Context, survey *entities.Survey) (*entities.SurveyResults, error)
+}
+
+// ReleaseNotesRepository provides release notes shipped with the bot.
+type ReleaseNotesRepository interface {
+	Latest(limit int) []entities.Release
+}
+
+// ChangelogRepository stores "what's new" subscriptions and announced releases.
+type ChangelogRepository interface {
+	IsSubscribed(ctx context.Context, userID int64) (bool, error)
+	Subscribe(ctx context.Context, userID int64) error
+	Unsubscribe(ctx context.Context, userID int64) error
+	EnqueueAnnouncement(ctx context.Context, version string, payloads map[string][]byte) (bool, int, error)
+}
+
+// EventRepository stores analytics events.
+type EventRepository interface {
+	Create(ctx context.Context, event *entities.Event) error
+	GetOnboardingFunnel(ctx context.Context, since time.Time) ([]entities.OnboardingFunnelStep, error)
+	GetDailyActiveUsers(ctx context.Context, since time.Time) ([]entities.DailyCount, error)
+}
+
+// BroadcastRepository stores broadcasts and queues their notifications.
+type BroadcastRepository interface {
+	Enqueue(ctx context.Context, broadcast *entities.Broadcast) error
+	List(ctx context.Context, limit int) ([]entities.Broadcast, error)
+}
+
+// APITokenRepository stores hashes of personal HTTP API tokens.
+type APITokenRepository interface {
+	Save(ctx context.Context, userID int64, tokenHash []byte, now time.Time) error
+	Delete(ctx context.Context, userID int64) (bool, error)
+	Touch(ctx context.Context, tokenHash []byte, now time.Time) (int64, error)
+}
+
+// GroupChatRepository manages settings of group chats.
+type GroupChatRepository interface {
+	Get(ctx context.Context, chatID int64) (*entities.GroupChat, error)
+	Create(ctx context.Context, group *entities.GroupChat) error
+	Update(ctx context.Context, group *entities.GroupChat) error
+	Delete(ctx context.Context, chatID int64) error
+	ListDailyPost(ctx context.Context) ([]entities.GroupChat, error)
+}
+
+// FavoritesRepository manages names bookmarked by users.
+type FavoritesRepository interface {
+	Add(ctx context.Context, userID int64, nameNumber int) error
+	Remove(ctx context.Context, userID int64, nameNumber int) error
+	Exists(ctx context.Context, userID int64, nameNumber int) (bool, error)
+	List(ctx context.Context, userID int64) ([]int, error)
+}
+
+// NotesRepository manages personal notes attached to names.
+type NotesRepository interface {
+	Get(ctx context.Context, userID int64, nameNumber int) (*entities.NameNote, error)
+	Upsert(ctx context.Context, userID int64, nameNumber int, text string) error
+	Delete(ctx context.Context, userID int64, nameNumber int) error
+	List(ctx context.Context, userID int64) ([]*entities.NameNote, error)
+}
+
+// StreakRepository manages daily activity streaks.
+type StreakRepository interface {
+	Get(ctx context.Context, userID int64) (*entities.UserStreak, error)
+	GetForUpdate(ctx context.Context, userID int64) (*entities.UserStreak, error)
+	Upsert(ctx context.Context, s *entities.UserStreak) error
+}
+
+// XPRepository manages users' experience points.
+type XPRepository interface {
+	Add(ctx context.Context, userID int64, amount int) (int, error)
+	Get(ctx context.Context, userID int64) (int, error)
 }
 
 type ResetRepository interface {
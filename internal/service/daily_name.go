@@ -2,18 +2,40 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
 	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+var (
+	// ErrNameNotPlanned is returned when a name is not in today's plan.
+	ErrNameNotPlanned = errors.New("name is not planned for today")
+	// ErrNoNameToSwap is returned when every name not yet introduced is already planned for today.
+	ErrNoNameToSwap = errors.New("no name left to swap in")
 )
 
 type DailyNameService struct {
+	tr            Transactor
 	dailyNameRepo DailyNameRepository
 	progressRepo  ProgressRepository
+	audit         *UserAuditService
 }
 
-func NewDailyNameService(dailyNameRepo DailyNameRepository, progressRepo ProgressRepository) *DailyNameService {
+// NewDailyNameService creates a new DailyNameService. Changes users make to their
+// plan are recorded in the user audit log.
+func NewDailyNameService(tr Transactor, dailyNameRepo DailyNameRepository, progressRepo ProgressRepository, audit *UserAuditService) *DailyNameService {
 	return &DailyNameService{
+		tr:            tr,
 		dailyNameRepo: dailyNameRepo,
 		progressRepo:  progressRepo,
+		audit:         audit,
 	}
 }
 
@@ -40,6 +62,67 @@ func (s *DailyNameService) EnsureTodayPlan(ctx context.Context, userID int64, tz
 		return err
 	}
 
+	_, err = s.topUpPlan(ctx, userID, todayDateUTC, planned, namesPerDay)
+	return err
+}
+
+// TodayReplan describes today's plan after the names-per-day quota changed.
+type TodayReplan struct {
+	Quota   int // new names-per-day quota
+	Planned int // names in today's plan after the change
+	Added   int // names added to today's plan to reach the new quota
+}
+
+// ReplanToday applies a changed names-per-day quota to today's plan right away.
+// A larger quota tops the plan up (debt first, then new names); a smaller one keeps
+// the names already planned and takes effect tomorrow. A plan that has not been
+// built yet today is left alone: it is built with the new quota on first use.
+func (s *DailyNameService) ReplanToday(ctx context.Context, userID int64, tz string, namesPerDay int) (*TodayReplan, error) {
+	if namesPerDay <= 0 {
+		namesPerDay = 1
+	}
+
+	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
+
+	planned, err := s.dailyNameRepo.GetNamesByDate(ctx, userID, todayDateUTC)
+	if err != nil {
+		return nil, err
+	}
+
+	replan := &TodayReplan{Quota: namesPerDay, Planned: len(planned)}
+	if len(planned) == 0 {
+		return replan, nil
+	}
+
+	added, err := s.topUpPlan(ctx, userID, todayDateUTC, planned, namesPerDay)
+	if err != nil {
+		return nil, err
+	}
+	replan.Added = added
+	replan.Planned += added
+
+	if added > 0 {
+		s.audit.Record(ctx, userID, entities.AuditPlanReplan, map[string]int{"planned": len(planned)}, map[string]int{"quota": namesPerDay, "planned": replan.Planned})
+	}
+
+	return replan, nil
+}
+
+// topUpPlan adds names to the plan for date until it holds namesPerDay names:
+// unfinished names from past days first, then names not introduced yet.
+// Names added to the plan are marked introduced. It returns the number of names added.
+func (s *DailyNameService) topUpPlan(ctx context.Context, userID int64, date time.Time, planned []int, namesPerDay int) (int, error) {
+	added, err := s.fillPlan(ctx, userID, date, planned, namesPerDay)
+	if len(added) > 0 {
+		if markErr := s.progressRepo.MarkIntroducedMany(ctx, userID, added); markErr != nil && err == nil {
+			err = markErr
+		}
+	}
+	return len(added), err
+}
+
+// fillPlan does the work of topUpPlan and returns the names it added, also on error.
+func (s *DailyNameService) fillPlan(ctx context.Context, userID int64, date time.Time, planned []int, namesPerDay int) ([]int, error) {
 	plannedSet := make(map[int]struct{}, len(planned))
 	for _, n := range planned {
 		plannedSet[n] = struct{}{}
@@ -47,57 +130,219 @@ func (s *DailyNameService) EnsureTodayPlan(ctx context.Context, userID int64, tz
 
 	remaining := namesPerDay - len(planned)
 	if remaining <= 0 {
-		return nil
+		return nil, nil
 	}
+	var added []int
 
-	debt, err := s.dailyNameRepo.GetCarryOverUnfinishedFromPast(ctx, userID, todayDateUTC, remaining)
+	debt, err := s.dailyNameRepo.GetCarryOverUnfinishedFromPast(ctx, userID, date, remaining)
 	if err != nil {
-		return err
+		return added, err
 	}
 	for _, n := range debt {
 		if _, exists := plannedSet[n]; exists {
 			continue
 		}
-		if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
-			return err
+		if err := s.dailyNameRepo.AddNameForDate(ctx, userID, date, n); err != nil {
+			return added, err
 		}
 		plannedSet[n] = struct{}{}
+		added = append(added, n)
 		remaining--
 		if remaining == 0 {
-			return nil
+			return added, nil
 		}
 	}
 
 	for remaining > 0 {
 		newNums, err := s.progressRepo.GetNamesForIntroduction(ctx, userID, remaining)
 		if err != nil {
-			return err
+			return added, err
 		}
 		if len(newNums) == 0 {
-			return nil
+			return added, nil
 		}
 
-		added := 0
+		addedNew := 0
 		for _, n := range newNums {
 			if _, exists := plannedSet[n]; exists {
 				continue
 			}
-			if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
-				return err
+			if err := s.dailyNameRepo.AddNameForDate(ctx, userID, date, n); err != nil {
+				return added, err
 			}
 			plannedSet[n] = struct{}{}
-			added++
+			added = append(added, n)
+			addedNew++
 			remaining--
 			if remaining == 0 {
-				return nil
+				return added, nil
+			}
+		}
+
+		if addedNew == 0 {
+			return added, nil
+		}
+	}
+
+	return added, nil
+}
+
+// ShuffleToday puts today's names in random order.
+func (s *DailyNameService) ShuffleToday(ctx context.Context, userID int64, tz string) error {
+	return s.reorderToday(ctx, userID, tz, func(names []int) {
+		rand.Shuffle(len(names), func(i, j int) {
+			names[i], names[j] = names[j], names[i]
+		})
+	})
+}
+
+// SortToday puts today's names back in ascending order of their numbers.
+func (s *DailyNameService) SortToday(ctx context.Context, userID int64, tz string) error {
+	return s.reorderToday(ctx, userID, tz, slices.Sort[[]int])
+}
+
+// MoveTodayName moves a name delta positions later in today's order (earlier if negative)
+// and returns its new position. The position is clamped to the plan.
+func (s *DailyNameService) MoveTodayName(ctx context.Context, userID int64, tz string, nameNumber, delta int) (int, error) {
+	pos := -1
+	err := s.reorderToday(ctx, userID, tz, func(names []int) {
+		from := slices.Index(names, nameNumber)
+		if from < 0 {
+			return
+		}
+		pos = min(max(from+delta, 0), len(names)-1)
+		for i := from; i < pos; i++ {
+			names[i], names[i+1] = names[i+1], names[i]
+		}
+		for i := from; i > pos; i-- {
+			names[i], names[i-1] = names[i-1], names[i]
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	if pos < 0 {
+		return 0, ErrNameNotPlanned
+	}
+	return pos, nil
+}
+
+// SwapTodayName replaces a planned name with the next name not introduced yet, keeping its
+// position in today's order, and returns the replacement. The swapped-out name goes back to
+// the pool of names not introduced yet.
+func (s *DailyNameService) SwapTodayName(ctx context.Context, userID int64, tz string, nameNumber int) (int, error) {
+	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
+
+	planned, err := s.dailyNameRepo.GetNamesByDate(ctx, userID, todayDateUTC)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(planned, nameNumber) {
+		return 0, ErrNameNotPlanned
+	}
+
+	// Planned names are marked introduced, but look past them in case one is not.
+	candidates, err := s.progressRepo.GetNamesForIntroduction(ctx, userID, len(planned)+1)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, n := range candidates {
+		if slices.Contains(planned, n) {
+			continue
+		}
+
+		err := s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			ok, err := repository.NewDailyNameRepository(tx).ReplaceNameForDate(ctx, userID, todayDateUTC, nameNumber, n)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrNameNotPlanned
+			}
+
+			progressRepoTx := repository.NewProgressRepository(tx)
+			if err := progressRepoTx.MarkIntroducedMany(ctx, userID, []int{n}); err != nil {
+				return err
+			}
+			return progressRepoTx.UnmarkIntroduced(ctx, userID, nameNumber)
+		})
+		if err != nil {
+			return 0, err
+		}
+		s.audit.Record(ctx, userID, entities.AuditPlanSwap, nameNumber, n)
+		return n, nil
+	}
+
+	return 0, ErrNoNameToSwap
+}
+
+// MarkTodayStudied ticks a planned name off today's checklist and records a
+// self-review for it, the same as «Помню» on a reminder card. Marking a name twice
+// records a single review; the result reports whether this call marked it. The mark
+// and the review are written in one transaction, so a failed review leaves the name
+// unmarked.
+func (s *DailyNameService) MarkTodayStudied(ctx context.Context, userID int64, tz string, nameNumber int) (bool, error) {
+	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
+
+	var marked bool
+	err := s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		progressRepoTx := repository.NewProgressRepository(tx)
+
+		var err error
+		marked, err = repository.NewDailyNameRepository(tx).MarkStudied(ctx, userID, todayDateUTC, nameNumber)
+		if err != nil || !marked {
+			return err
+		}
+
+		progress, err := progressRepoTx.Get(ctx, userID, nameNumber)
+		if err != nil {
+			if !errors.Is(err, repository.ErrProgressNotFound) {
+				return fmt.Errorf("get progress: %w", err)
 			}
+			progress = entities.NewUserProgress(userID, nameNumber)
 		}
 
-		if added == 0 {
-			return nil
+		progress.RecordSelfReview(true, time.Now())
+
+		if err := progressRepoTx.Upsert(ctx, progress); err != nil {
+			return fmt.Errorf("upsert progress: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return marked, nil
+}
+
+// GetTodayStudied returns today's names ticked off on the checklist.
+func (s *DailyNameService) GetTodayStudied(ctx context.Context, userID int64, tz string) ([]int, error) {
+	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
+	return s.dailyNameRepo.GetStudiedByDate(ctx, userID, todayDateUTC)
+}
+
+// reorderToday applies reorder to today's names and stores the new order.
+func (s *DailyNameService) reorderToday(ctx context.Context, userID int64, tz string, reorder func(names []int)) error {
+	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
+
+	names, err := s.dailyNameRepo.GetNamesByDate(ctx, userID, todayDateUTC)
+	if err != nil {
+		return err
+	}
+
+	order := slices.Clone(names)
+	reorder(order)
+	if slices.Equal(order, names) {
+		return nil
 	}
 
+	if err := s.dailyNameRepo.SetOrderForDate(ctx, userID, todayDateUTC, order); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditPlanReorder, names, order)
 	return nil
 }
 
@@ -108,7 +353,12 @@ func (s *DailyNameService) GetTodayNamesTZ(ctx context.Context, userID int64, tz
 
 func (s *DailyNameService) AddTodayNameTZ(ctx context.Context, userID int64, tz string, nameNumber int) error {
 	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
-	return s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, nameNumber)
+	if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, nameNumber); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditPlanAdd, nil, nameNumber)
+	return nil
 }
 
 func (s *DailyNameService) GetTodayNames(ctx context.Context, userID int64) ([]int, error) {
@@ -128,9 +378,19 @@ func (s *DailyNameService) GetOldestUnfinishedName(ctx context.Context, userID i
 }
 
 func (s *DailyNameService) AddTodayName(ctx context.Context, userID int64, nameNumber int) error {
-	return s.dailyNameRepo.AddTodayName(ctx, userID, nameNumber)
+	if err := s.dailyNameRepo.AddTodayName(ctx, userID, nameNumber); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditPlanAdd, nil, nameNumber)
+	return nil
 }
 
 func (s *DailyNameService) RemoveTodayName(ctx context.Context, userID int64, nameNumber int) error {
-	return s.dailyNameRepo.RemoveTodayName(ctx, userID, nameNumber)
+	if err := s.dailyNameRepo.RemoveTodayName(ctx, userID, nameNumber); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditPlanRemove, nameNumber, nil)
+	return nil
 }
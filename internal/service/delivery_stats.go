@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// DeliveryStatsService reports aggregate usage and notification delivery statistics.
+type DeliveryStatsService struct {
+	logRepo      ReminderLogRepository
+	overviewRepo OverviewRepository
+}
+
+// NewDeliveryStatsService creates a new delivery stats service.
+func NewDeliveryStatsService(logRepo ReminderLogRepository, overviewRepo OverviewRepository) *DeliveryStatsService {
+	return &DeliveryStatsService{logRepo: logRepo, overviewRepo: overviewRepo}
+}
+
+// GetOverview returns the current usage overview: users, activity, quizzes, reminders and errors.
+func (s *DeliveryStatsService) GetOverview(ctx context.Context) (*entities.BotOverview, error) {
+	return s.overviewRepo.GetOverview(ctx, time.Now().UTC())
+}
+
+// GetDeliveryStats returns delivery attempts over the last period and the current queue size.
+func (s *DeliveryStatsService) GetDeliveryStats(ctx context.Context, period time.Duration) (*entities.DeliveryStats, error) {
+	return s.logRepo.GetDeliveryStats(ctx, time.Now().UTC().Add(-period))
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// digestPeriod is the time range covered by the weekly digest.
+const digestPeriod = 7 * 24 * time.Hour
+
+// sendWeeklyDigests sends the weekly digest to opted-in users whose local
+// digest time has come. It is called every hour by the scheduler.
+func (s *ReminderService) sendWeeklyDigests(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	job := notifyJob[*entities.DigestRecipient]{
+		name:  "weekly digests",
+		fetch: s.reminderRepo.GetDigestRecipientsBatch,
+		due:   func(dr *entities.DigestRecipient) bool { return dr.IsDigestDue(now) },
+		process: func(ctx context.Context, dr *entities.DigestRecipient) error {
+			return s.processDigest(ctx, dr, now)
+		},
+		userID: func(dr *entities.DigestRecipient) int64 { return dr.UserID },
+	}
+
+	if _, err := job.run(ctx, s.logger); err != nil {
+		return fmt.Errorf("get digest recipients batch: %w", err)
+	}
+	return nil
+}
+
+// processDigest builds and queues the weekly digest for a single user.
+func (s *ReminderService) processDigest(ctx context.Context, dr *entities.DigestRecipient, now time.Time) error {
+	digest, err := s.BuildWeeklyDigest(ctx, dr.UserID, dr.Timezone, now)
+	if err != nil {
+		return fmt.Errorf("build weekly digest: %w", err)
+	}
+
+	return s.enqueue(ctx, entities.NotificationWeeklyDigest, dr.UserID, dr.ChatID, digest, now,
+		func(ctx context.Context, repo *repository.ReminderRepository) error {
+			if err := repo.MarkDigestSent(ctx, dr.UserID, now); err != nil {
+				return fmt.Errorf("mark digest sent: %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// BuildWeeklyDigest collects the user's learning summary for the seven days before now.
+func (s *ReminderService) BuildWeeklyDigest(ctx context.Context, userID int64, timezone string, now time.Time) (*entities.WeeklyDigest, error) {
+	loc, err := entities.ParseTimezoneLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	_, offsetSec := now.In(loc).Zone()
+
+	weekStart := now.Add(-digestPeriod)
+
+	current, err := s.progressRepo.GetPeriodStats(ctx, userID, weekStart, now, offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get current week stats: %w", err)
+	}
+
+	prev, err := s.progressRepo.GetPeriodStats(ctx, userID, weekStart.Add(-digestPeriod), weekStart, offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get previous week stats: %w", err)
+	}
+
+	stats, err := s.progressRepo.GetStats(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get progress stats: %w", err)
+	}
+
+	streak, err := s.streakRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrStreakNotFound) {
+		return nil, fmt.Errorf("get streak: %w", err)
+	}
+
+	digest := &entities.WeeklyDigest{
+		Date:          entities.LocalDay(now, loc),
+		Mastered:      current.Mastered,
+		TotalMastered: stats.Learned,
+		Reviews:       current.Answers,
+		Correct:       current.Correct,
+		PrevReviews:   prev.Answers,
+		PrevCorrect:   prev.Correct,
+	}
+	if streak != nil {
+		digest.CurrentStreak = streak.Current(entities.LocalDay(now, loc))
+		digest.BestStreak = streak.BestStreak
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// ExportService collects all personal data of a user for export.
+type ExportService struct {
+	settingsRepo  SettingsRepository
+	reminderRepo  ReminderRepository
+	progressRepo  ProgressRepository
+	quizRepo      QuizRepository
+	favoritesRepo FavoritesRepository
+	notesRepo     NotesRepository
+	streakRepo    StreakRepository
+	xpRepo        XPRepository
+	nameRepo      NameRepository
+}
+
+// NewExportService creates a new ExportService.
+func NewExportService(
+	settingsRepo SettingsRepository,
+	reminderRepo ReminderRepository,
+	progressRepo ProgressRepository,
+	quizRepo QuizRepository,
+	favoritesRepo FavoritesRepository,
+	notesRepo NotesRepository,
+	streakRepo StreakRepository,
+	xpRepo XPRepository,
+	nameRepo NameRepository,
+) *ExportService {
+	return &ExportService{
+		settingsRepo:  settingsRepo,
+		reminderRepo:  reminderRepo,
+		progressRepo:  progressRepo,
+		quizRepo:      quizRepo,
+		favoritesRepo: favoritesRepo,
+		notesRepo:     notesRepo,
+		streakRepo:    streakRepo,
+		xpRepo:        xpRepo,
+		nameRepo:      nameRepo,
+	}
+}
+
+// UserExport is a snapshot of all personal data stored for a user.
+type UserExport struct {
+	UserID      int64              `json:"user_id"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	Settings    *ExportSettings    `json:"settings,omitempty"`
+	Reminders   *ExportReminders   `json:"reminders,omitempty"`
+	Streak      ExportStreak       `json:"streak"`
+	TotalXP     int                `json:"total_xp"`
+	Favorites   []int              `json:"favorites"`
+	Notes       []ExportNote       `json:"notes"`
+	Progress    []ExportProgress   `json:"progress"`
+	QuizHistory []ExportQuizAnswer `json:"quiz_history"`
+}
+
+// ExportSettings contains user preferences.
+type ExportSettings struct {
+	NamesPerDay  int    `json:"names_per_day"`
+	QuizMode     string `json:"quiz_mode"`
+	LearningMode string `json:"learning_mode"`
+	Language     string `json:"language"`
+	Timezone     string `json:"timezone"`
+	Calendar     string `json:"calendar,omitempty"`
+	TargetDate   string `json:"target_date,omitempty"` // YYYY-MM-DD
+}
+
+// ExportReminders contains reminder preferences.
+type ExportReminders struct {
+	Enabled       bool   `json:"enabled"`
+	IntervalHours int    `json:"interval_hours"`
+	StartTime     string `json:"start_time"`
+	EndTime       string `json:"end_time"`
+	WeeklyDigest  bool   `json:"weekly_digest"`
+	Style         string `json:"style,omitempty"`
+	Content       string `json:"content,omitempty"`
+}
+
+// ExportStreak contains daily streak data.
+type ExportStreak struct {
+	Current        int        `json:"current"`
+	Best           int        `json:"best"`
+	LastActiveDate *time.Time `json:"last_active_date,omitempty"`
+}
+
+// ExportNote is a personal note attached to a name.
+type ExportNote struct {
+	NameNumber int       `json:"name_number"`
+	Text       string    `json:"text"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ExportProgress is the SRS state of a single name.
+type ExportProgress struct {
+	NameNumber     int        `json:"name_number"`
+	Phase          string     `json:"phase"`
+	Ease           float64    `json:"ease"`
+	Streak         int        `json:"streak"`
+	IntervalDays   int        `json:"interval_days"`
+	ReviewCount    int        `json:"review_count"`
+	CorrectCount   int        `json:"correct_count"`
+	FirstSeenAt    *time.Time `json:"first_seen_at,omitempty"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	NextReviewAt   *time.Time `json:"next_review_at,omitempty"`
+}
+
+// ExportQuizAnswer is a single answered quiz question.
+type ExportQuizAnswer struct {
+	SessionID     int64     `json:"session_id"`
+	NameNumber    int       `json:"name_number"`
+	QuestionType  string    `json:"question_type"`
+	UserAnswer    string    `json:"user_answer"`
+	CorrectAnswer string    `json:"correct_answer"`
+	IsCorrect     bool      `json:"is_correct"`
+	AnsweredAt    time.Time `json:"answered_at"`
+}
+
+// Export collects settings, progress per name, quiz history and other personal data of a user.
+func (s *ExportService) Export(ctx context.Context, userID int64) (*UserExport, error) {
+	export := &UserExport{
+		UserID:      userID,
+		ExportedAt:  time.Now().UTC(),
+		Favorites:   []int{},
+		Notes:       []ExportNote{},
+		Progress:    []ExportProgress{},
+		QuizHistory: []ExportQuizAnswer{},
+	}
+
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrSettingsNotFound) {
+		return nil, fmt.Errorf("get settings: %w", err)
+	}
+	if settings != nil {
+		export.Settings = &ExportSettings{
+			NamesPerDay:  settings.NamesPerDay,
+			QuizMode:     settings.QuizMode,
+			LearningMode: settings.LearningMode,
+			Language:     settings.LanguageCode,
+			Timezone:     settings.Timezone,
+			Calendar:     string(settings.Calendar),
+		}
+		if settings.TargetDate != nil {
+			export.Settings.TargetDate = settings.TargetDate.Format(time.DateOnly)
+		}
+	}
+
+	reminder, err := s.reminderRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrReminderNotFound) {
+		return nil, fmt.Errorf("get reminder: %w", err)
+	}
+	if reminder != nil {
+		export.Reminders = &ExportReminders{
+			Enabled:       reminder.IsEnabled,
+			IntervalHours: reminder.IntervalHours,
+			StartTime:     reminder.StartTime,
+			EndTime:       reminder.EndTime,
+			WeeklyDigest:  reminder.WeeklyDigest,
+			Style:         string(reminder.Style),
+			Content:       string(reminder.Content),
+		}
+	}
+
+	streak, err := s.streakRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrStreakNotFound) {
+		return nil, fmt.Errorf("get streak: %w", err)
+	}
+	if streak != nil {
+		export.Streak = ExportStreak{
+			Current:        streak.CurrentStreak,
+			Best:           streak.BestStreak,
+			LastActiveDate: streak.LastActiveDate,
+		}
+	}
+
+	if export.TotalXP, err = s.xpRepo.Get(ctx, userID); err != nil {
+		return nil, fmt.Errorf("get xp: %w", err)
+	}
+
+	favorites, err := s.favoritesRepo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list favorites: %w", err)
+	}
+	export.Favorites = append(export.Favorites, favorites...)
+
+	notes, err := s.notesRepo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	for _, n := range notes {
+		export.Notes = append(export.Notes, ExportNote{
+			NameNumber: n.NameNumber,
+			Text:       n.Text,
+			UpdatedAt:  n.UpdatedAt,
+		})
+	}
+
+	progress, err := s.allProgress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get progress: %w", err)
+	}
+	for _, p := range progress {
+		export.Progress = append(export.Progress, ExportProgress{
+			NameNumber:     p.NameNumber,
+			Phase:          string(p.Phase),
+			Ease:           p.Ease,
+			Streak:         p.Streak,
+			IntervalDays:   p.IntervalDays,
+			ReviewCount:    p.ReviewCount,
+			CorrectCount:   p.CorrectCount,
+			FirstSeenAt:    p.FirstSeenAt,
+			LastReviewedAt: p.LastReviewedAt,
+			NextReviewAt:   p.NextReviewAt,
+		})
+	}
+
+	answers, err := s.quizRepo.ListAnswers(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list answers: %w", err)
+	}
+	for _, a := range answers {
+		export.QuizHistory = append(export.QuizHistory, ExportQuizAnswer{
+			SessionID:     a.SessionID,
+			NameNumber:    a.NameNumber,
+			QuestionType:  a.QuestionType,
+			UserAnswer:    a.UserAnswer,
+			CorrectAnswer: a.CorrectAnswer,
+			IsCorrect:     a.IsCorrect,
+			AnsweredAt:    a.AnsweredAt,
+		})
+	}
+
+	return export, nil
+}
+
+// progressPageSize is the number of progress records read per query when listing all of them.
+const progressPageSize = 50
+
+// allProgress reads every progress record of a user page by page, in name order.
+func (s *ExportService) allProgress(ctx context.Context, userID int64) ([]*entities.UserProgress, error) {
+	var all []*entities.UserProgress
+	after := 0
+	for {
+		page, err := s.progressRepo.GetPage(ctx, userID, after, progressPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < progressPageSize {
+			return all, nil
+		}
+		after = page[len(page)-1].NameNumber
+	}
+}
+
+// JSON encodes the export as an indented JSON document.
+func (e *UserExport) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal export: %w", err)
+	}
+	return data, nil
+}
+
+// ProgressCSV encodes progress per name as CSV.
+func (e *UserExport) ProgressCSV() ([]byte, error) {
+	rows := [][]string{{
+		"name_number", "phase", "ease", "streak", "interval_days",
+		"review_count", "correct_count", "first_seen_at", "last_reviewed_at", "next_review_at",
+	}}
+	for _, p := range e.Progress {
+		rows = append(rows, []string{
+			strconv.Itoa(p.NameNumber),
+			p.Phase,
+			strconv.FormatFloat(p.Ease, 'f', 2, 64),
+			strconv.Itoa(p.Streak),
+			strconv.Itoa(p.IntervalDays),
+			strconv.Itoa(p.ReviewCount),
+			strconv.Itoa(p.CorrectCount),
+			formatExportTime(p.FirstSeenAt),
+			formatExportTime(p.LastReviewedAt),
+			formatExportTime(p.NextReviewAt),
+		})
+	}
+	return encodeCSV(rows)
+}
+
+// QuizHistoryCSV encodes the quiz answer history as CSV.
+func (e *UserExport) QuizHistoryCSV() ([]byte, error) {
+	rows := [][]string{{
+		"answered_at", "session_id", "name_number", "question_type",
+		"user_answer", "correct_answer", "is_correct",
+	}}
+	for _, a := range e.QuizHistory {
+		rows = append(rows, []string{
+			a.AnsweredAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(a.SessionID, 10),
+			strconv.Itoa(a.NameNumber),
+			a.QuestionType,
+			a.UserAnswer,
+			a.CorrectAnswer,
+			strconv.FormatBool(a.IsCorrect),
+		})
+	}
+	return encodeCSV(rows)
+}
+
+func encodeCSV(rows [][]string) ([]byte, error) {
+	return encodeDelimited(rows, ',')
+}
+
+func encodeDelimited(rows [][]string, comma rune) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = comma
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("write csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// ankiDeckName is the deck the exported notes are imported into.
+const ankiDeckName = "Asma ul-Husna"
+
+// ankiColumns are the note fields of the Anki text export. Scheduling columns
+// mirror Anki card properties: interval in days, ease factor in permille and due date.
+var ankiColumns = []string{
+	"Front", "Back", "Number", "Arabic", "Transliteration", "Translation", "Meaning",
+	"Phase", "Interval", "Ease", "Due", "Reviews", "Lapses", "Tags",
+}
+
+// AnkiDeck builds a tab-separated Anki text import file with all 99 names and
+// the user's SRS state of each name, so the schedule survives a migration to Anki.
+func (s *ExportService) AnkiDeck(ctx context.Context, userID int64) ([]byte, error) {
+	names, err := s.nameRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("get all names: %w", err)
+	}
+
+	progress, err := s.allProgress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get progress: %w", err)
+	}
+
+	byNumber := make(map[int]*entities.UserProgress, len(progress))
+	for _, p := range progress {
+		byNumber[p.NameNumber] = p
+	}
+
+	rows := make([][]string, 0, len(names))
+	for _, n := range names {
+		rows = append(rows, ankiRow(n, byNumber[n.Number]))
+	}
+
+	data, err := encodeDelimited(rows, '\t')
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("#separator:tab\n")
+	buf.WriteString("#html:true\n")
+	buf.WriteString("#deck:" + ankiDeckName + "\n")
+	buf.WriteString("#columns:" + strings.Join(ankiColumns, "\t") + "\n")
+	buf.WriteString("#tags column:" + strconv.Itoa(len(ankiColumns)) + "\n")
+	buf.Write(data)
+
+	return buf.Bytes(), nil
+}
+
+// ankiRow maps a name and its SRS state to Anki note fields.
+func ankiRow(n *entities.Name, p *entities.UserProgress) []string {
+	front := fmt.Sprintf(`<div dir="rtl">%s</div><div>%s</div>`,
+		html.EscapeString(n.ArabicName), html.EscapeString(n.Transliteration))
+	back := fmt.Sprintf("<b>%s</b><br>%s",
+		html.EscapeString(n.Translation), html.EscapeString(n.Meaning))
+
+	phase := string(entities.PhaseNew)
+	var interval, ease, reviews, lapses, due string
+	if p != nil {
+		phase = string(p.Phase)
+		interval = strconv.Itoa(p.IntervalDays)
+		ease = strconv.Itoa(int(math.Round(p.Ease * 1000)))
+		reviews = strconv.Itoa(p.ReviewCount)
+		lapses = strconv.Itoa(max(p.ReviewCount-p.CorrectCount, 0))
+		if p.NextReviewAt != nil {
+			due = p.NextReviewAt.UTC().Format(time.DateOnly)
+		}
+	}
+
+	tags := "asma-ul-husna phase::" + phase
+
+	return []string{
+		front,
+		back,
+		strconv.Itoa(n.Number),
+		n.ArabicName,
+		n.Transliteration,
+		n.Translation,
+		n.Meaning,
+		phase,
+		interval,
+		ease,
+		due,
+		reviews,
+		lapses,
+		tags,
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// FavoritesService provides business logic for bookmarked names.
+type FavoritesService struct {
+	repository FavoritesRepository
+	nameRepo   NameRepository
+}
+
+// NewFavoritesService creates a new FavoritesService.
+func NewFavoritesService(repository FavoritesRepository, nameRepo NameRepository) *FavoritesService {
+	return &FavoritesService{repository: repository, nameRepo: nameRepo}
+}
+
+// Toggle adds the name to favorites or removes it if already there.
+// It returns true if the name is a favorite after the call.
+func (s *FavoritesService) Toggle(ctx context.Context, userID int64, nameNumber int) (bool, error) {
+	exists, err := s.repository.Exists(ctx, userID, nameNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if exists {
+		if err := s.repository.Remove(ctx, userID, nameNumber); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	if err := s.repository.Add(ctx, userID, nameNumber); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IsFavorite reports whether the name is in user's favorites.
+func (s *FavoritesService) IsFavorite(ctx context.Context, userID int64, nameNumber int) (bool, error) {
+	return s.repository.Exists(ctx, userID, nameNumber)
+}
+
+// List returns user's favorite names ordered by number.
+func (s *FavoritesService) List(ctx context.Context, userID int64) ([]*entities.Name, error) {
+	nums, err := s.repository.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]*entities.Name, 0, len(nums))
+	for _, n := range nums {
+		name, err := s.nameRepo.GetByNumber(n)
+		if err != nil {
+			return nil, fmt.Errorf("get favorite name %d: %w", n, err)
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
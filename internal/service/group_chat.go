@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// ErrInvalidGroupSetting is returned for an unsupported group setting value.
+var ErrInvalidGroupSetting = errors.New("invalid group setting")
+
+// GroupChatService manages settings of group chats the bot was added to.
+type GroupChatService struct {
+	repo     GroupChatRepository
+	postRepo ChannelPostRepository
+}
+
+// NewGroupChatService creates a new GroupChatService.
+func NewGroupChatService(repo GroupChatRepository, postRepo ChannelPostRepository) *GroupChatService {
+	return &GroupChatService{repo: repo, postRepo: postRepo}
+}
+
+// GetOrCreate retrieves group settings, registering the group with defaults on first use.
+func (s *GroupChatService) GetOrCreate(ctx context.Context, chatID int64, title string) (*entities.GroupChat, error) {
+	group, err := s.repo.Get(ctx, chatID)
+	if err == nil {
+		return group, nil
+	}
+	if !errors.Is(err, repository.ErrGroupChatNotFound) {
+		return nil, err
+	}
+
+	group = entities.NewGroupChat(chatID, title)
+	if err := s.repo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, chatID)
+}
+
+// Remove forgets a group, e.g. after the bot was removed from it.
+func (s *GroupChatService) Remove(ctx context.Context, chatID int64) error {
+	return s.repo.Delete(ctx, chatID)
+}
+
+// SetLanguage sets the language of name cards and bot messages in the group.
+func (s *GroupChatService) SetLanguage(ctx context.Context, chatID int64, lang string) error {
+	if lang != "ru" && lang != "en" {
+		return fmt.Errorf("%w: language %q", ErrInvalidGroupSetting, lang)
+	}
+	return s.update(ctx, chatID, func(g *entities.GroupChat) { g.LanguageCode = lang })
+}
+
+// SetDailyPost turns posting the name of the day to the group on or off.
+func (s *GroupChatService) SetDailyPost(ctx context.Context, chatID int64, enabled bool) error {
+	return s.update(ctx, chatID, func(g *entities.GroupChat) { g.DailyPost = enabled })
+}
+
+// SetDailyQuiz turns the mini-quiz after the daily post on or off.
+func (s *GroupChatService) SetDailyQuiz(ctx context.Context, chatID int64, enabled bool) error {
+	return s.update(ctx, chatID, func(g *entities.GroupChat) { g.DailyQuiz = enabled })
+}
+
+// RecordQuizResults stores the answer counts of a mini-quiz poll reported by Telegram.
+func (s *GroupChatService) RecordQuizResults(ctx context.Context, pollID string, voters, correct int) error {
+	return s.postRepo.UpdateQuizResults(ctx, pollID, voters, correct)
+}
+
+// GetQuizStats returns mini-quiz answer totals of a group.
+func (s *GroupChatService) GetQuizStats(ctx context.Context, chatID int64) (entities.ChannelQuizStats, error) {
+	return s.postRepo.GetQuizStats(ctx, chatID)
+}
+
+// SetPostTime sets the local time of the daily post, HH:MM.
+func (s *GroupChatService) SetPostTime(ctx context.Context, chatID int64, at string) error {
+	if _, err := time.Parse("15:04", at); err != nil {
+		return fmt.Errorf("%w: post time %q", ErrInvalidGroupSetting, at)
+	}
+	return s.update(ctx, chatID, func(g *entities.GroupChat) { g.PostTime = at })
+}
+
+// SetTimezone sets the timezone of the daily post time.
+func (s *GroupChatService) SetTimezone(ctx context.Context, chatID int64, tz string) error {
+	normalized, err := entities.NormalizeTimezone(tz)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidGroupSetting, err)
+	}
+	return s.update(ctx, chatID, func(g *entities.GroupChat) { g.Timezone = normalized })
+}
+
+// update applies a change to the stored group settings.
+func (s *GroupChatService) update(ctx context.Context, chatID int64, apply func(g *entities.GroupChat)) error {
+	group, err := s.repo.Get(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	apply(group)
+	return s.repo.Update(ctx, group)
+}
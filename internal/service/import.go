@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// maxXPPerReview is the most XP one quiz answer can earn: a correct answer to a due
+// name with the full daily streak bonus.
+const maxXPPerReview = entities.XPCorrectAnswer + entities.XPReviewBonus + entities.XPMaxStreakBonus
+
+var (
+	ErrImportInvalid     = errors.New("import file is not a valid export")
+	ErrImportForeignUser = errors.New("import file belongs to another user")
+)
+
+// ImportService restores data from a previous /export JSON document.
+type ImportService struct {
+	tr            Transactor
+	settingsCache SettingsCache
+}
+
+// NewImportService creates a new ImportService; settings written in its transactions are dropped from settingsCache.
+func NewImportService(tr Transactor, settingsCache SettingsCache) *ImportService {
+	return &ImportService{tr: tr, settingsCache: settingsCache}
+}
+
+// ImportResult describes what was restored from an export.
+type ImportResult struct {
+	SettingsRestored bool
+	ProgressRestored int // names whose progress was restored
+	ProgressKept     int // names where the current progress is newer and was kept
+	FavoritesAdded   int
+	NotesAdded       int
+}
+
+// Import validates the export document and merges it into the user's data.
+// Merging is conservative: progress of a name is restored only when the export has
+// more reviews than the current record, existing notes are never overwritten,
+// favorites are only added, and XP and streak never decrease. XP and the best streak
+// are not taken from the file as is: they are capped at what the quiz answers stored
+// for the user and the account age allow.
+func (s *ImportService) Import(ctx context.Context, userID int64, data []byte) (*ImportResult, error) {
+	var export UserExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImportInvalid, err)
+	}
+
+	if export.UserID == 0 || export.ExportedAt.IsZero() {
+		return nil, ErrImportInvalid
+	}
+	if export.UserID != userID {
+		return nil, ErrImportForeignUser
+	}
+
+	result := &ImportResult{}
+
+	err := s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := importSettings(ctx, repository.NewSettingsRepository(tx), userID, export.Settings, result); err != nil {
+			return err
+		}
+
+		if err := importProgress(ctx, repository.NewProgressRepository(tx), userID, export.Progress, result); err != nil {
+			return err
+		}
+
+		if err := importFavoritesAndNotes(ctx, tx, userID, &export, result); err != nil {
+			return err
+		}
+
+		return importStreakAndXP(ctx, tx, userID, &export, time.Now())
+	})
+	s.settingsCache.Invalidate(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importSettings restores valid settings values; invalid values are skipped.
+func importSettings(ctx context.Context, repo *repository.SettingsRepository, userID int64, st *ExportSettings, result *ImportResult) error {
+	if st == nil {
+		return nil
+	}
+
+	if err := repo.UpsertDefaults(ctx, userID); err != nil {
+		return fmt.Errorf("upsert default settings: %w", err)
+	}
+
+	if st.NamesPerDay >= 1 && st.NamesPerDay <= 20 {
+		if err := repo.UpdateNamesPerDay(ctx, userID, st.NamesPerDay); err != nil {
+			return fmt.Errorf("update names per day: %w", err)
+		}
+	}
+
+	switch st.QuizMode {
+	case "new", "review", "mixed", "favorites":
+		if err := repo.UpdateQuizMode(ctx, userID, st.QuizMode); err != nil {
+			return fmt.Errorf("update quiz mode: %w", err)
+		}
+	}
+
+	switch entities.LearningMode(st.LearningMode) {
+	case entities.ModeGuided, entities.ModeFree:
+		if err := repo.UpdateLearningMode(ctx, userID, st.LearningMode); err != nil {
+			return fmt.Errorf("update learning mode: %w", err)
+		}
+	}
+
+	if tz, err := entities.NormalizeTimezone(st.Timezone); err == nil {
+		if err := repo.UpdateTimezone(ctx, userID, tz); err != nil {
+			return fmt.Errorf("update timezone: %w", err)
+		}
+	}
+
+	if calendar := entities.Calendar(st.Calendar); calendar.IsValid() {
+		if err := repo.UpdateCalendar(ctx, userID, calendar); err != nil {
+			return fmt.Errorf("update calendar: %w", err)
+		}
+	}
+
+	// A target date that has passed since the export is dropped.
+	if date, err := time.Parse(time.DateOnly, st.TargetDate); err == nil && !date.Before(userToday(st.Timezone)) {
+		if err := repo.UpdateTargetDate(ctx, userID, &date); err != nil {
+			return fmt.Errorf("update target date: %w", err)
+		}
+	}
+
+	result.SettingsRestored = true
+	return nil
+}
+
+// importProgress restores per-name SRS state where the export is ahead of the current data.
+func importProgress(ctx context.Context, repo *repository.ProgressRepository, userID int64, items []ExportProgress, result *ImportResult) error {
+	nums := make([]int, 0, len(items))
+	for _, item := range items {
+		nums = append(nums, item.NameNumber)
+	}
+
+	byNumber, err := repo.GetByNumbers(ctx, userID, nums)
+	if err != nil {
+		return fmt.Errorf("get progress: %w", err)
+	}
+
+	restored := make(map[int]*entities.UserProgress)
+	for _, item := range items {
+		p, ok := progressFromExport(userID, item)
+		if !ok {
+			continue
+		}
+
+		if existing := byNumber[p.NameNumber]; existing != nil && existing.ReviewCount >= p.ReviewCount {
+			result.ProgressKept++
+			continue
+		}
+
+		byNumber[p.NameNumber] = p
+		restored[p.NameNumber] = p
+		result.ProgressRestored++
+	}
+
+	batch := make([]*entities.UserProgress, 0, len(restored))
+	for _, p := range restored {
+		batch = append(batch, p)
+	}
+	if err := repo.UpsertMany(ctx, batch); err != nil {
+		return fmt.Errorf("upsert progress: %w", err)
+	}
+
+	return nil
+}
+
+// progressFromExport validates an exported progress item and converts it to an entity.
+func progressFromExport(userID int64, item ExportProgress) (*entities.UserProgress, bool) {
+	if item.NameNumber < 1 || item.NameNumber > 99 {
+		return nil, false
+	}
+
+	phase := entities.Phase(item.Phase)
+	switch phase {
+	case entities.PhaseNew, entities.PhaseLearning, entities.PhaseMastered:
+	default:
+		return nil, false
+	}
+
+	if item.ReviewCount < 0 || item.CorrectCount < 0 || item.CorrectCount > item.ReviewCount ||
+		item.Streak < 0 || item.IntervalDays < 0 {
+		return nil, false
+	}
+
+	return &entities.UserProgress{
+		UserID:         userID,
+		NameNumber:     item.NameNumber,
+		Phase:          phase,
+		Ease:           min(max(item.Ease, 1.3), 2.5),
+		Streak:         item.Streak,
+		IntervalDays:   min(item.IntervalDays, entities.MaxIntervalDays),
+		NextReviewAt:   item.NextReviewAt,
+		ReviewCount:    item.ReviewCount,
+		CorrectCount:   item.CorrectCount,
+		FirstSeenAt:    item.FirstSeenAt,
+		LastReviewedAt: item.LastReviewedAt,
+	}, true
+}
+
+// importFavoritesAndNotes adds missing favorites and notes without touching existing ones.
+func importFavoritesAndNotes(ctx context.Context, tx pgx.Tx, userID int64, export *UserExport, result *ImportResult) error {
+	favRepo := repository.NewFavoritesRepository(tx)
+	notesRepo := repository.NewNotesRepository(tx)
+
+	favorites, err := favRepo.List(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list favorites: %w", err)
+	}
+	isFavorite := make(map[int]bool, len(favorites))
+	for _, n := range favorites {
+		isFavorite[n] = true
+	}
+
+	for _, n := range export.Favorites {
+		if n < 1 || n > 99 || isFavorite[n] {
+			continue
+		}
+		if err := favRepo.Add(ctx, userID, n); err != nil {
+			return fmt.Errorf("add favorite: %w", err)
+		}
+		isFavorite[n] = true
+		result.FavoritesAdded++
+	}
+
+	notes, err := notesRepo.List(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list notes: %w", err)
+	}
+	hasNote := make(map[int]bool, len(notes))
+	for _, n := range notes {
+		hasNote[n.NameNumber] = true
+	}
+
+	for _, n := range export.Notes {
+		if n.NameNumber < 1 || n.NameNumber > 99 || hasNote[n.NameNumber] ||
+			n.Text == "" || utf8.RuneCountInString(n.Text) > entities.MaxNoteLength {
+			continue
+		}
+		if err := notesRepo.Upsert(ctx, userID, n.NameNumber, n.Text); err != nil {
+			return fmt.Errorf("upsert note: %w", err)
+		}
+		hasNote[n.NameNumber] = true
+		result.NotesAdded++
+	}
+
+	return nil
+}
+
+// importStreakAndXP raises XP and the best streak towards the exported values. XP is
+// capped at the most the user's stored quiz answers could have earned and the best
+// streak at the days since the user signed up, so an edited file cannot inflate either.
+func importStreakAndXP(ctx context.Context, tx pgx.Tx, userID int64, export *UserExport, now time.Time) error {
+	answers, err := repository.NewQuizRepository(tx).CountAnswers(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("count answers: %w", err)
+	}
+
+	xpRepo := repository.NewXPRepository(tx)
+	currentXP, err := xpRepo.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get xp: %w", err)
+	}
+	if totalXP := importedXP(export, answers); totalXP > currentXP {
+		if _, err := xpRepo.Add(ctx, userID, totalXP-currentXP); err != nil {
+			return fmt.Errorf("add xp: %w", err)
+		}
+	}
+
+	user, err := repository.NewUserRepository(tx).GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	daysSinceSignup := int(now.Sub(user.CreatedAt)/(24*time.Hour)) + 1
+
+	streakRepo := repository.NewStreakRepository(tx)
+	streak, err := streakRepo.GetForUpdate(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrStreakNotFound) {
+			return fmt.Errorf("get streak: %w", err)
+		}
+		streak = entities.NewUserStreak(userID)
+	}
+
+	if best := min(export.Streak.Best, daysSinceSignup); best > streak.BestStreak {
+		streak.BestStreak = best
+		if err := streakRepo.Upsert(ctx, streak); err != nil {
+			return fmt.Errorf("upsert streak: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// importedXP returns the XP to restore from an export: its total XP capped at the most
+// the given number of stored quiz answers could have earned. Review counts in the file
+// are not trusted, since they are as easy to edit as the XP itself.
+func importedXP(export *UserExport, answers int) int {
+	return min(export.TotalXP, answers*maxXPPerReview)
+}
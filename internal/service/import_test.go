@@ -0,0 +1,50 @@
+package service
+
+import "testing"
+
+func TestImportedXP(t *testing.T) {
+	inflated := []ExportProgress{
+		{NameNumber: 1, Phase: "mastered", ReviewCount: 1_000_000, CorrectCount: 1_000_000},
+		{NameNumber: 2, Phase: "learning", ReviewCount: 500_000, CorrectCount: 0},
+	}
+
+	tests := []struct {
+		name    string
+		export  UserExport
+		answers int
+		want    int
+	}{
+		{
+			name:    "below the cap",
+			export:  UserExport{TotalXP: 120},
+			answers: 10,
+			want:    120,
+		},
+		{
+			name:    "capped by stored answers",
+			export:  UserExport{TotalXP: 100_000},
+			answers: 3,
+			want:    3 * maxXPPerReview,
+		},
+		{
+			name:    "inflated review count is ignored",
+			export:  UserExport{TotalXP: 50_000_000, Progress: inflated},
+			answers: 2,
+			want:    2 * maxXPPerReview,
+		},
+		{
+			name:    "no stored answers",
+			export:  UserExport{TotalXP: 1000, Progress: inflated},
+			answers: 0,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := importedXP(&tt.export, tt.answers); got != tt.want {
+				t.Errorf("importedXP() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
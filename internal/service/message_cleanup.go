@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+)
+
+// Message cleanup tuning.
+const (
+	messageCleanupInterval  = 10 * time.Minute
+	messageCleanupBatchSize = 100
+	// telegramDeleteWindow is how long after sending Telegram lets a bot delete a message.
+	telegramDeleteWindow = 48 * time.Hour
+)
+
+// MessageCleanupPolicy configures when bot messages are removed. Zero durations keep
+// the corresponding messages.
+type MessageCleanupPolicy struct {
+	After         time.Duration // age after which quiz questions, prompts and reminders are deleted
+	ReminderTTL   time.Duration // age after which reminders expire instead, 0 to use After
+	EditReminders bool          // expired reminders are edited out instead of deleted
+}
+
+// reminderTTL returns the age after which reminders expire.
+func (p MessageCleanupPolicy) reminderTTL() time.Duration {
+	if p.ReminderTTL > 0 {
+		return p.ReminderTTL
+	}
+	return p.After
+}
+
+// MessageCleanupService deletes quiz questions, input prompts and reminders from chats
+// once they are older than the configured age, so that chats do not fill up with
+// messages nobody needs any more. Reminders may expire sooner and be edited out instead.
+type MessageCleanupService struct {
+	repo    EphemeralMessageRepository
+	expirer MessageExpirer
+	policy  MessageCleanupPolicy
+	logger  *zap.Logger
+}
+
+// NewMessageCleanupService creates a new MessageCleanupService.
+func NewMessageCleanupService(repo EphemeralMessageRepository, policy MessageCleanupPolicy, logger *zap.Logger) *MessageCleanupService {
+	return &MessageCleanupService{repo: repo, policy: policy, logger: logger}
+}
+
+// SetExpirer sets the message expirer (called after handler is created).
+func (s *MessageCleanupService) SetExpirer(expirer MessageExpirer) {
+	s.expirer = expirer
+}
+
+// Track remembers a sent message for removal. A failed write is only logged: the
+// message then simply stays in the chat.
+func (s *MessageCleanupService) Track(ctx context.Context, chatID int64, messageID int, kind entities.EphemeralKind) {
+	ttl := s.policy.After
+	if kind == entities.EphemeralReminder {
+		ttl = s.policy.reminderTTL()
+	}
+	if ttl <= 0 || messageID == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	msg := &entities.EphemeralMessage{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Kind:        kind,
+		SentAt:      now,
+		DeleteAfter: now.Add(ttl),
+	}
+	if err := s.repo.Track(ctx, msg); err != nil {
+		requestid.Logger(ctx, s.logger).Error("failed to track ephemeral message",
+			zap.Int64("chat_id", chatID),
+			zap.Int("message_id", messageID),
+			zap.String("kind", string(kind)),
+			zap.Error(err),
+		)
+	}
+}
+
+// Forget stops tracking a message that was removed before its time, e.g. a reminder
+// replaced by the next one.
+func (s *MessageCleanupService) Forget(ctx context.Context, chatID int64, messageID int) {
+	if err := s.repo.Forget(ctx, chatID, messageID); err != nil {
+		requestid.Logger(ctx, s.logger).Warn("failed to forget ephemeral message",
+			zap.Int64("chat_id", chatID),
+			zap.Int("message_id", messageID),
+			zap.Error(err),
+		)
+	}
+}
+
+// Start removes due messages periodically until the context is cancelled.
+func (s *MessageCleanupService) Start(ctx context.Context) {
+	if s.policy.After <= 0 && s.policy.reminderTTL() <= 0 {
+		return
+	}
+
+	s.logger.Info("message cleanup started",
+		zap.Duration("after", s.policy.After),
+		zap.Duration("reminder_ttl", s.policy.reminderTTL()),
+		zap.Bool("edit_reminders", s.policy.EditReminders),
+	)
+	defer s.logger.Info("message cleanup stopped")
+
+	ticker := time.NewTicker(messageCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Cleanup(ctx, time.Now().UTC()); err != nil {
+				s.logger.Error("failed to clean up messages", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Cleanup removes the messages that are due, batch by batch. Messages already gone or
+// too old for Telegram to delete are dropped; removal is not retried.
+func (s *MessageCleanupService) Cleanup(ctx context.Context, now time.Time) error {
+	if s.expirer == nil {
+		return nil
+	}
+
+	removed, failed := 0, 0
+	for {
+		msgs, err := s.repo.TakeDue(ctx, now, messageCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range msgs {
+			edit := m.Kind == entities.EphemeralReminder && s.policy.EditReminders
+			if !edit && now.Sub(m.SentAt) >= telegramDeleteWindow {
+				continue
+			}
+
+			if err := s.remove(m, edit); err != nil {
+				// Usually the user already deleted the message or the chat.
+				s.logger.Debug("failed to remove ephemeral message",
+					zap.Int64("chat_id", m.ChatID),
+					zap.Int("message_id", m.MessageID),
+					zap.String("kind", string(m.Kind)),
+					zap.Error(err),
+				)
+				failed++
+				continue
+			}
+			removed++
+		}
+
+		if len(msgs) < messageCleanupBatchSize {
+			break
+		}
+	}
+
+	if removed > 0 || failed > 0 {
+		s.logger.Info("ephemeral messages cleaned up", zap.Int("removed", removed), zap.Int("failed", failed))
+	}
+	return nil
+}
+
+// remove edits out or deletes a message.
+func (s *MessageCleanupService) remove(m entities.EphemeralMessage, edit bool) error {
+	if edit {
+		return s.expirer.EditOutMessage(m.ChatID, m.MessageID)
+	}
+	return s.expirer.DeleteMessage(m.ChatID, m.MessageID)
+}
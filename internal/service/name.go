@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"sync"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 )
 
 // NameService provides business logic for working with Allah's names.
+// The full list is cached until Invalidate is called after the dataset changes.
 type NameService struct {
 	repository NameRepository
+
+	mu  sync.RWMutex
+	all []*entities.Name
 }
 
 // NewNameService creates a new NameService with the provided repository.
@@ -26,11 +31,31 @@ func (s *NameService) GetRandom(ctx context.Context) (*entities.Name, error) {
 	return s.repository.GetRandom()
 }
 
-// GetAll retrieves all names from the repository.
+// GetAll retrieves all names, from the cache if it is filled.
+// The returned slice is shared and must not be modified.
 func (s *NameService) GetAll(ctx context.Context) ([]*entities.Name, error) {
+	s.mu.RLock()
+	all := s.all
+	s.mu.RUnlock()
+	if all != nil {
+		return all, nil
+	}
+
 	names, err := s.repository.GetAll()
 	if err != nil {
 		return nil, err
 	}
+
+	s.mu.Lock()
+	s.all = names
+	s.mu.Unlock()
+
 	return names, nil
 }
+
+// Invalidate drops the cached list, so the next GetAll reads the reloaded dataset.
+func (s *NameService) Invalidate() {
+	s.mu.Lock()
+	s.all = nil
+	s.mu.Unlock()
+}
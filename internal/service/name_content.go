@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// nameContentReloadInterval is how often edits are re-read, so edits made on another instance show up.
+const nameContentReloadInterval = time.Minute
+
+var ErrNameContentEmpty = errors.New("name card field is empty")
+
+// NameContentService applies name card texts edited by admins on top of the names JSON file.
+type NameContentService struct {
+	names     NameContentStore
+	cache     NameCache
+	overrides NameOverrideRepository
+	logger    *zap.Logger
+}
+
+// NewNameContentService creates a new NameContentService; cache is invalidated whenever a name changes.
+func NewNameContentService(names NameContentStore, cache NameCache, overrides NameOverrideRepository, logger *zap.Logger) *NameContentService {
+	return &NameContentService{names: names, cache: cache, overrides: overrides, logger: logger}
+}
+
+// Apply replaces the texts of the loaded names with the stored edits.
+func (s *NameContentService) Apply(ctx context.Context) error {
+	overrides, err := s.overrides.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range overrides {
+		if _, err := s.replace(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start re-applies the stored edits until the context is cancelled. It runs on every instance.
+func (s *NameContentService) Start(ctx context.Context) {
+	ticker := time.NewTicker(nameContentReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Apply(ctx); err != nil {
+				s.logger.Error("failed to apply name edits", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Update validates and stores new texts of a name card and applies them at once.
+// The audio of the name is kept.
+func (s *NameContentService) Update(ctx context.Context, adminID int64, edit entities.Name) (*entities.Name, error) {
+	edit.ArabicName = strings.TrimSpace(edit.ArabicName)
+	edit.Transliteration = strings.TrimSpace(edit.Transliteration)
+	edit.Translation = strings.TrimSpace(edit.Translation)
+	edit.Meaning = strings.TrimSpace(edit.Meaning)
+
+	if edit.ArabicName == "" || edit.Transliteration == "" || edit.Translation == "" || edit.Meaning == "" {
+		return nil, ErrNameContentEmpty
+	}
+	if _, err := s.names.GetByNumber(edit.Number); err != nil {
+		return nil, err
+	}
+
+	if err := s.overrides.Save(ctx, &edit, adminID); err != nil {
+		return nil, err
+	}
+
+	return s.replace(edit)
+}
+
+// replace swaps the texts of the loaded name for those of the edit.
+// Unchanged names are left alone, so periodic reloads do not drop the cache.
+func (s *NameContentService) replace(edit entities.Name) (*entities.Name, error) {
+	current, err := s.names.GetByNumber(edit.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *current
+	updated.ArabicName = edit.ArabicName
+	updated.Transliteration = edit.Transliteration
+	updated.Translation = edit.Translation
+	updated.Meaning = edit.Meaning
+	if updated == *current {
+		return current, nil
+	}
+
+	if err := s.names.Replace(&updated); err != nil {
+		return nil, err
+	}
+	s.cache.Invalidate()
+
+	return &updated, nil
+}
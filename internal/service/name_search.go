@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// minFuzzyQueryLen is the minimal query length (in runes) for typo-tolerant matching.
+const minFuzzyQueryLen = 4
+
+// nameMatch is a search hit with its relevance; lower rank is better.
+type nameMatch struct {
+	name *entities.Name
+	rank int
+}
+
+// Search returns names matching the query by number, Arabic script, transliteration,
+// translation, meaning or the latin audio key (e.g. "rahman").
+// Matching is case- and diacritic-insensitive; exact substring hits come
+// first, then typo-tolerant hits. An empty query matches all names.
+func (s *NameService) Search(ctx context.Context, query string) ([]*entities.Name, error) {
+	names, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := normalizeSearchText(query)
+	if q == "" {
+		return names, nil
+	}
+
+	if n, err := strconv.Atoi(strings.TrimPrefix(q, "#")); err == nil {
+		for _, name := range names {
+			if name.Number == n {
+				return []*entities.Name{name}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	var matches []nameMatch
+	for _, name := range names {
+		if rank, ok := matchName(name, q); ok {
+			matches = append(matches, nameMatch{name: name, rank: rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+
+	result := make([]*entities.Name, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.name)
+	}
+
+	return result, nil
+}
+
+// matchName reports whether the name matches the normalized query and its rank:
+// 0 for title fields, 1 for meaning, 2+ for fuzzy matches by edit distance.
+func matchName(name *entities.Name, q string) (int, bool) {
+	titles := []string{
+		normalizeSearchText(name.ArabicName),
+		normalizeSearchText(name.Transliteration),
+		normalizeSearchText(name.Translation),
+		normalizeSearchText(strings.TrimSuffix(name.Audio, ".mp3")),
+	}
+	meaning := normalizeSearchText(name.Meaning)
+
+	for _, f := range titles {
+		if strings.Contains(f, q) || strings.Contains(strings.ReplaceAll(f, " ", ""), q) {
+			return 0, true
+		}
+	}
+	if strings.Contains(meaning, q) {
+		return 1, true
+	}
+
+	if len([]rune(q)) < minFuzzyQueryLen {
+		return 0, false
+	}
+
+	best := -1
+	for _, f := range append(titles, meaning) {
+		if d, ok := fuzzyDistance(q, f); ok && (best < 0 || d < best) {
+			best = d
+		}
+	}
+	if best < 0 {
+		return 0, false
+	}
+
+	return 2 + best, true
+}
+
+// fuzzyDistance matches every query word against the closest word (or word prefix)
+// of the text and returns the total edit distance if each word is within tolerance.
+func fuzzyDistance(q, text string) (int, bool) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	total := 0
+	for _, qw := range strings.Fields(q) {
+		qr := []rune(qw)
+		maxDist := fuzzyTolerance(len(qr))
+
+		best := -1
+		for _, w := range words {
+			wr := []rune(w)
+			d := levenshtein(qr, wr)
+			if len(wr) > len(qr) {
+				// Allow an unfinished word: compare with a prefix of the same length.
+				if pd := levenshtein(qr, wr[:len(qr)]); pd < d {
+					d = pd
+				}
+			}
+			if best < 0 || d < best {
+				best = d
+			}
+		}
+
+		if best < 0 || best > maxDist {
+			return 0, false
+		}
+		total += best
+	}
+
+	return total, true
+}
+
+// fuzzyTolerance returns the allowed edit distance for a word of n runes.
+func fuzzyTolerance(n int) int {
+	switch {
+	case n < minFuzzyQueryLen:
+		return 0
+	case n < 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// arabicFolder folds Arabic letter variants that are commonly typed interchangeably:
+// hamza carriers and alef forms to bare letters, alef maqsura to ya, ta marbuta to ha.
+var arabicFolder = strings.NewReplacer(
+	"أ", "ا", "إ", "ا", "آ", "ا", "ٱ", "ا",
+	"ؤ", "و",
+	"ئ", "ي", "ى", "ي",
+	"ة", "ه",
+	"ء", "",
+	"ـ", "", // tatweel
+)
+
+// normalizeSearchText lower-cases s, strips diacritics (including Arabic tashkeel),
+// folds Arabic hamza/alef forms and "ё" to "е", and replaces punctuation
+// (hyphens, apostrophes, etc.) with single spaces.
+func normalizeSearchText(s string) string {
+	// Fold precomposed hamza forms first: NFD would split them into a bare
+	// letter plus a combining hamza, which is then dropped with other marks.
+	s = arabicFolder.Replace(s)
+
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if out, _, err := transform.String(t, s); err == nil {
+		s = out
+	}
+
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "ё", "е")
+	s = arabicFolder.Replace(s)
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '#' {
+			return r
+		}
+		return ' '
+	}, s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// NameFieldChange is one field of a name that differs between the table and the dataset.
+type NameFieldChange struct {
+	Number int
+	Field  string
+	Old    string
+	New    string
+}
+
+// NameSeedDiff describes how the names table differs from a dataset.
+type NameSeedDiff struct {
+	Added     []int             // names missing from the table
+	Changed   []NameFieldChange // fields that differ, by name and field
+	Unchanged int               // names already equal to the dataset
+	Extra     []int             // names in the table but not in the dataset; they are left alone
+}
+
+// Empty reports whether the table already matches the dataset.
+func (d *NameSeedDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0
+}
+
+// NameSeedService loads the names dataset into the names table.
+type NameSeedService struct {
+	repo NameDatasetRepository
+}
+
+// NewNameSeedService creates a new NameSeedService.
+func NewNameSeedService(repo NameDatasetRepository) *NameSeedService {
+	return &NameSeedService{repo: repo}
+}
+
+// Seed compares names with the table and, unless dryRun is set, upserts the names that
+// are missing or differ in one statement. It returns the differences found.
+func (s *NameSeedService) Seed(ctx context.Context, names []*entities.Name, dryRun bool) (*NameSeedDiff, error) {
+	stored, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[int]*entities.Name, len(stored))
+	for _, n := range stored {
+		byNumber[n.Number] = n
+	}
+
+	diff := &NameSeedDiff{}
+	seen := make(map[int]bool, len(names))
+	var upserts []*entities.Name
+
+	for _, n := range names {
+		if n.Number < 1 || n.Number > 99 {
+			return nil, fmt.Errorf("name %d: %w", n.Number, repository.ErrInvalidNumber)
+		}
+		if seen[n.Number] {
+			return nil, fmt.Errorf("name %d appears twice in the dataset", n.Number)
+		}
+		seen[n.Number] = true
+
+		old, ok := byNumber[n.Number]
+		if !ok {
+			diff.Added = append(diff.Added, n.Number)
+			upserts = append(upserts, n)
+			continue
+		}
+
+		changes := nameFieldChanges(old, n)
+		if len(changes) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, changes...)
+		upserts = append(upserts, n)
+	}
+
+	for _, n := range stored {
+		if !seen[n.Number] {
+			diff.Extra = append(diff.Extra, n.Number)
+		}
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+
+	if err := s.repo.UpsertMany(ctx, upserts); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// nameFieldChanges lists the fields of a name that differ between old and new.
+func nameFieldChanges(old, new *entities.Name) []NameFieldChange {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"arabic", old.ArabicName, new.ArabicName},
+		{"transliteration", old.Transliteration, new.Transliteration},
+		{"translation", old.Translation, new.Translation},
+		{"meaning", old.Meaning, new.Meaning},
+		{"audio", old.Audio, new.Audio},
+	}
+
+	var changes []NameFieldChange
+	for _, f := range fields {
+		if f.old != f.new {
+			changes = append(changes, NameFieldChange{Number: new.Number, Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return changes
+}
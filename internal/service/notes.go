@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+var (
+	ErrNoteEmpty   = errors.New("note is empty")
+	ErrNoteTooLong = errors.New("note is too long")
+)
+
+// NotesService provides business logic for personal notes on names.
+type NotesService struct {
+	repository NotesRepository
+}
+
+// NewNotesService creates a new NotesService.
+func NewNotesService(repository NotesRepository) *NotesService {
+	return &NotesService{repository: repository}
+}
+
+// Get returns user's note for a name or nil if there is none.
+func (s *NotesService) Get(ctx context.Context, userID int64, nameNumber int) (*entities.NameNote, error) {
+	note, err := s.repository.Get(ctx, userID, nameNumber)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoteNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return note, nil
+}
+
+// Save validates and stores user's note for a name, replacing the previous one.
+func (s *NotesService) Save(ctx context.Context, userID int64, nameNumber int, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrNoteEmpty
+	}
+	if utf8.RuneCountInString(text) > entities.MaxNoteLength {
+		return ErrNoteTooLong
+	}
+
+	return s.repository.Upsert(ctx, userID, nameNumber, text)
+}
+
+// Delete removes user's note for a name.
+func (s *NotesService) Delete(ctx context.Context, userID int64, nameNumber int) error {
+	return s.repository.Delete(ctx, userID, nameNumber)
+}
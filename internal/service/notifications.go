@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+// Notification worker tuning.
+const (
+	notificationPollInterval = 5 * time.Second
+	notificationBatchSize    = 50
+	notificationConcurrency  = 10
+	notificationJobTimeout   = 30 * time.Second // one delivery, including the sender's own retries
+
+	// notificationLockTTL outlives delivering a whole batch: jobs run notificationConcurrency
+	// at a time for about notificationJobTimeout each. The margin covers a Telegram request
+	// that was already in flight at the timeout, which cannot be interrupted, and bookkeeping.
+	// A lock that expired mid-batch would let another worker send the same job again.
+	notificationLockTTL = (notificationBatchSize+notificationConcurrency-1)/notificationConcurrency*notificationJobTimeout + 2*time.Minute
+)
+
+// ErrNotificationUndeliverable marks a send error that retrying will not fix,
+// e.g. the user blocked the bot or the chat no longer exists.
+var ErrNotificationUndeliverable = errors.New("notification undeliverable")
+
+// RetryAfterError is a send error that asks to try again no earlier than After,
+// e.g. Telegram flood control with a wait longer than the sender sits out itself.
+type RetryAfterError struct {
+	After time.Duration
+	Err   error
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("retry after %s: %v", e.After, e.Err)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// NotificationWorker delivers queued notifications. Jobs are claimed with row locks,
+// so a worker may run on every bot instance, and unfinished jobs survive restarts.
+// Every attempt is recorded in the reminder log and counted by kind and outcome.
+type NotificationWorker struct {
+	jobRepo    NotificationJobRepository
+	logRepo    ReminderLogRepository
+	deliveries DeliveryCounter
+	notifier   ReminderNotifier
+	webhooks   WebhookSender
+	logger     *zap.Logger
+}
+
+// NewNotificationWorker creates a new notification worker.
+func NewNotificationWorker(
+	jobRepo NotificationJobRepository,
+	logRepo ReminderLogRepository,
+	deliveries DeliveryCounter,
+	webhooks WebhookSender,
+	logger *zap.Logger,
+) *NotificationWorker {
+	return &NotificationWorker{
+		jobRepo:    jobRepo,
+		logRepo:    logRepo,
+		deliveries: deliveries,
+		webhooks:   webhooks,
+		logger:     logger,
+	}
+}
+
+// SetNotifier sets the notifier (called after handler is created).
+func (w *NotificationWorker) SetNotifier(notifier ReminderNotifier) {
+	w.notifier = notifier
+}
+
+// Start polls for due jobs until the context is cancelled.
+func (w *NotificationWorker) Start(ctx context.Context) {
+	w.logger.Info("notification worker started")
+	defer w.logger.Info("notification worker stopped")
+
+	ticker := time.NewTicker(notificationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.processDue(ctx); err != nil {
+				w.logger.Error("failed to process notification jobs", zap.Error(err))
+			}
+		}
+	}
+}
+
+// processDue claims and delivers due jobs batch by batch.
+func (w *NotificationWorker) processDue(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+
+		jobs, err := w.jobRepo.ClaimDue(ctx, now, notificationLockTTL, notificationBatchSize)
+		if err != nil {
+			return fmt.Errorf("claim due jobs: %w", err)
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		sem := make(chan struct{}, notificationConcurrency)
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				w.handle(ctx, job)
+			}()
+		}
+		wg.Wait()
+
+		if len(jobs) < notificationBatchSize {
+			return nil
+		}
+	}
+}
+
+// handle delivers a job and records the outcome: delivered on success, back off or give up on failure.
+func (w *NotificationWorker) handle(ctx context.Context, job *entities.NotificationJob) {
+	entry := &entities.ReminderLogEntry{
+		UserID:  job.UserID,
+		JobID:   job.ID,
+		Kind:    job.Kind,
+		Attempt: job.Attempts,
+	}
+
+	deliverCtx, cancel := context.WithTimeout(ctx, notificationJobTimeout)
+	err := w.deliver(deliverCtx, job, entry)
+	cancel()
+	if err == nil {
+		w.record(ctx, entry, entities.DeliveryDelivered, nil)
+
+		// If this fails the job is sent again once its lock expires: delivery is at least once.
+		if err := w.jobRepo.MarkDelivered(ctx, job.ID, time.Now().UTC()); err != nil {
+			w.logger.Error("failed to mark notification job delivered", zap.Int64("job_id", job.ID), zap.Error(err))
+		}
+		return
+	}
+
+	if job.CanRetry() && !errors.Is(err, ErrNotificationUndeliverable) {
+		w.record(ctx, entry, entities.DeliveryRetry, err)
+
+		delay := job.RetryDelay()
+		var retryAfter *RetryAfterError
+		if errors.As(err, &retryAfter) {
+			delay = max(delay, retryAfter.After)
+		}
+
+		next := time.Now().UTC().Add(delay)
+		w.logger.Warn("notification delivery failed, will retry",
+			zap.Int64("job_id", job.ID),
+			zap.Int64("user_id", job.UserID),
+			zap.Int("attempts", job.Attempts),
+			zap.Time("next_attempt", next),
+			zap.Error(err),
+		)
+		if err := w.jobRepo.Retry(ctx, job.ID, next, err.Error()); err != nil {
+			w.logger.Error("failed to reschedule notification job", zap.Int64("job_id", job.ID), zap.Error(err))
+		}
+		return
+	}
+
+	w.logger.Error("notification delivery failed, giving up",
+		zap.Int64("job_id", job.ID),
+		zap.Int64("user_id", job.UserID),
+		zap.Int("attempts", job.Attempts),
+		zap.Error(err),
+	)
+	w.record(ctx, entry, entities.DeliveryFailed, err)
+	if err := w.jobRepo.Fail(ctx, job.ID, err.Error()); err != nil {
+		w.logger.Error("failed to mark notification job failed", zap.Int64("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// record stores the attempt in the reminder log and counts it.
+// Losing a log entry must not affect delivery, so errors are only logged.
+func (w *NotificationWorker) record(ctx context.Context, entry *entities.ReminderLogEntry, outcome entities.DeliveryOutcome, sendErr error) {
+	entry.Outcome = outcome
+	entry.CreatedAt = time.Now().UTC()
+	if sendErr != nil {
+		entry.Error = sendErr.Error()
+	}
+
+	w.deliveries.Inc(string(entry.Kind), string(outcome))
+
+	if err := w.logRepo.Record(ctx, entry); err != nil {
+		w.logger.Error("failed to record notification attempt", zap.Int64("job_id", entry.JobID), zap.Error(err))
+	}
+}
+
+// deliver decodes the payload and sends it through the notifier; webhook events go to the webhook sender as is.
+// Reminder details are copied into entry for the delivery log.
+func (w *NotificationWorker) deliver(ctx context.Context, job *entities.NotificationJob, entry *entities.ReminderLogEntry) error {
+	if job.Kind == entities.NotificationWebhook {
+		return w.webhooks.Send(job.Payload)
+	}
+
+	if w.notifier == nil {
+		return fmt.Errorf("notifier not initialized")
+	}
+
+	switch job.Kind {
+	case entities.NotificationReminder:
+		var payload entities.ReminderPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decode reminder payload: %w", err)
+		}
+		entry.ReminderKind = payload.Kind
+		entry.NameNumber = payload.Name.Number
+		return w.notifier.SendReminder(ctx, job.UserID, job.ChatID, payload)
+
+	case entities.NotificationDailyDigest:
+		var digest entities.DailyDigest
+		if err := json.Unmarshal(job.Payload, &digest); err != nil {
+			return fmt.Errorf("decode daily digest payload: %w", err)
+		}
+		return w.notifier.SendDailyDigest(ctx, job.UserID, job.ChatID, digest)
+
+	case entities.NotificationWeeklyDigest:
+		var digest entities.WeeklyDigest
+		if err := json.Unmarshal(job.Payload, &digest); err != nil {
+			return fmt.Errorf("decode weekly digest payload: %w", err)
+		}
+		return w.notifier.SendWeeklyDigest(ctx, job.UserID, job.ChatID, digest)
+
+	case entities.NotificationStreakAlert:
+		var alert entities.StreakAlert
+		if err := json.Unmarshal(job.Payload, &alert); err != nil {
+			return fmt.Errorf("decode streak alert payload: %w", err)
+		}
+		return w.notifier.SendStreakAlert(ctx, job.UserID, job.ChatID, alert.Streak, alert.HoursLeft)
+
+	case entities.NotificationSurvey:
+		var invite entities.SurveyInvite
+		if err := json.Unmarshal(job.Payload, &invite); err != nil {
+			return fmt.Errorf("decode survey payload: %w", err)
+		}
+		return w.notifier.SendSurvey(ctx, job.UserID, job.ChatID, invite)
+
+	case entities.NotificationChangelog:
+		var announcement entities.ChangelogAnnouncement
+		if err := json.Unmarshal(job.Payload, &announcement); err != nil {
+			return fmt.Errorf("decode changelog payload: %w", err)
+		}
+		return w.notifier.SendChangelog(ctx, job.UserID, job.ChatID, announcement)
+
+	case entities.NotificationBroadcast:
+		var message entities.BroadcastMessage
+		if err := json.Unmarshal(job.Payload, &message); err != nil {
+			return fmt.Errorf("decode broadcast payload: %w", err)
+		}
+		return w.notifier.SendBroadcast(ctx, job.UserID, job.ChatID, message)
+
+	default:
+		return fmt.Errorf("unknown notification kind: %q", job.Kind)
+	}
+}
+
+// newNotificationJob encodes the payload into a job due at the given time.
+func newNotificationJob(kind entities.NotificationKind, userID, chatID int64, payload any, at time.Time) (*entities.NotificationJob, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", kind, err)
+	}
+
+	return &entities.NotificationJob{
+		UserID:      userID,
+		ChatID:      chatID,
+		Kind:        kind,
+		Payload:     data,
+		ScheduledAt: at,
+	}, nil
+}
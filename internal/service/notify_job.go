@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Paging and concurrency limits of the hourly notification jobs.
+const (
+	notifyBatchSize     = 100 // candidates fetched per page
+	notifyMaxConcurrent = 10  // candidates processed at once
+)
+
+// notifyJob pages through the candidates of an hourly notification job, such as the
+// weekly digest or the streak alert, and processes the due ones concurrently.
+type notifyJob[T any] struct {
+	name    string                                                    // plural noun used in logs
+	fetch   func(ctx context.Context, limit, offset int) ([]T, error) // one page of candidates
+	due     func(c T) bool                                            // whether the candidate is notified now
+	process func(ctx context.Context, c T) error                      // queues the notification
+	userID  func(c T) int64                                           // for logging failures
+}
+
+// run processes every due candidate and returns how many were queued. A failure of
+// one candidate is logged and does not stop the others.
+func (j notifyJob[T]) run(ctx context.Context, logger *zap.Logger) (int, error) {
+	totalSent := 0
+
+	for offset := 0; ; offset += notifyBatchSize {
+		candidates, err := j.fetch(ctx, notifyBatchSize, offset)
+		if err != nil {
+			return totalSent, err
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		totalSent += j.processBatch(ctx, logger, candidates)
+
+		if len(candidates) < notifyBatchSize {
+			break
+		}
+	}
+
+	if totalSent > 0 {
+		logger.Info(j.name+" processed", zap.Int("total_sent", totalSent))
+	}
+
+	return totalSent, nil
+}
+
+// processBatch processes the due candidates of one page, at most notifyMaxConcurrent at a time.
+func (j notifyJob[T]) processBatch(ctx context.Context, logger *zap.Logger, candidates []T) int {
+	sem := make(chan struct{}, notifyMaxConcurrent)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sent := 0
+
+	for _, c := range candidates {
+		if !j.due(c) {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{} // Acquire
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }() // Release
+
+			if err := j.process(ctx, c); err != nil {
+				logger.Error("failed to process "+j.name,
+					zap.Int64("user_id", j.userID(c)),
+					zap.Error(err))
+				return
+			}
+
+			mu.Lock()
+			sent++
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return sent
+}
@@ -27,19 +27,7 @@ func (g *OptionGenerator) GenerateOptions(
 	options := make([]string, 4)
 
 	// Get the correct answer based on question type
-	var correctAnswer string
-	switch questionType {
-	case entities.QuestionTypeTranslation:
-		correctAnswer = correctName.ArabicName
-	case entities.QuestionTypeTransliteration:
-		correctAnswer = correctName.Translation
-	case entities.QuestionTypeMeaning:
-		correctAnswer = correctName.Transliteration
-	case entities.QuestionTypeArabic:
-		correctAnswer = correctName.Translation
-	default:
-		correctAnswer = correctName.Translation
-	}
+	correctAnswer := questionType.AnswerFor(correctName)
 
 	// Generate 3 wrong options
 	wrongOptions := g.generateWrongOptions(correctName, questionType, 3)
@@ -89,19 +77,7 @@ func (g *OptionGenerator) generateWrongOptions(correctName *entities.Name, quest
 			continue
 		}
 
-		var optionText string
-		switch questionType {
-		case entities.QuestionTypeTranslation:
-			optionText = candidate.ArabicName
-		case entities.QuestionTypeTransliteration:
-			optionText = candidate.Translation
-		case entities.QuestionTypeMeaning:
-			optionText = candidate.Transliteration
-		case entities.QuestionTypeArabic:
-			optionText = candidate.Translation
-		default:
-			optionText = candidate.Translation
-		}
+		optionText := questionType.AnswerFor(candidate)
 
 		// Avoid duplicates
 		isDuplicate := false
@@ -125,3 +101,44 @@ func (g *OptionGenerator) generateWrongOptions(correctName *entities.Name, quest
 
 	return wrongOptions
 }
+
+// GenerateNameOptions picks 4 names with distinct answer texts, including the correct one.
+// Returns: option names and the index of the correct name (0-3).
+func (g *OptionGenerator) GenerateNameOptions(
+	correctName *entities.Name,
+	questionType entities.QuestionType,
+) ([]entities.Name, int) {
+	used := map[string]bool{questionType.AnswerFor(correctName): true}
+
+	candidates := make([]*entities.Name, 0, len(g.allNames))
+	for _, name := range g.allNames {
+		if name.Number != correctName.Number {
+			candidates = append(candidates, name)
+		}
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	wrong := make([]entities.Name, 0, 3)
+	for _, candidate := range candidates {
+		if len(wrong) == 3 {
+			break
+		}
+		text := questionType.AnswerFor(candidate)
+		if used[text] {
+			continue
+		}
+		used[text] = true
+		wrong = append(wrong, *candidate)
+	}
+
+	correctIndex := rand.Intn(len(wrong) + 1)
+	options := make([]entities.Name, 0, len(wrong)+1)
+	options = append(options, wrong[:correctIndex]...)
+	options = append(options, *correctName)
+	options = append(options, wrong[correctIndex:]...)
+
+	return options, correctIndex
+}
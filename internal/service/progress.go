@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
@@ -13,16 +14,22 @@ import (
 type ProgressService struct {
 	progressRepo ProgressRepository
 	settingsRepo SettingsRepository
+	streakRepo   StreakRepository
+	xpRepo       XPRepository
 }
 
 // NewProgressService creates a new ProgressService.
 func NewProgressService(
 	progressRepo ProgressRepository,
 	settingsRepo SettingsRepository,
+	streakRepo StreakRepository,
+	xpRepo XPRepository,
 ) *ProgressService {
 	return &ProgressService{
 		progressRepo: progressRepo,
 		settingsRepo: settingsRepo,
+		streakRepo:   streakRepo,
+		xpRepo:       xpRepo,
 	}
 }
 
@@ -38,6 +45,15 @@ type ProgressSummary struct {
 	NewCount       int
 	LearningCount  int
 	MasteredCount  int
+	DueTomorrow    int // names due for review tomorrow (local day)
+	DueThisWeek    int // names due for review within the next 7 days, excluding today
+	CurrentStreak  int // consecutive active days, including today or yesterday
+	BestStreak     int
+	ActiveToday    bool // whether the user already answered a quiz today
+	TotalXP        int
+	Level          entities.Level
+	ByQuestionType []repository.QuestionTypeStats // accuracy per quiz question type
+	Target         *entities.TargetPlan           // nil without a target date
 }
 
 // GetProgressSummary calculates and returns a summary of user progress.
@@ -55,6 +71,14 @@ func (s *ProgressService) GetProgressSummary(ctx context.Context, userID int64)
 		settings = entities.NewUserSettings(userID)
 	}
 
+	loc, err := entities.ParseTimezoneLocation(settings.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	today := entities.LocalDay(time.Now(), loc)
+	target := settings.TargetPlan(today, stats.NotStarted)
+	settings.ApplyTargetPace(today, stats.NotStarted)
+
 	learned := stats.Learned
 	inProgress := stats.InProgress
 	notStarted := stats.NotStarted
@@ -62,6 +86,45 @@ func (s *ProgressService) GetProgressSummary(ctx context.Context, userID int64)
 	percentage := float64(learned) / 99.0 * 100
 	daysToComplete := settings.DaysToComplete(learned)
 
+	forecast, err := s.dueForecast(ctx, userID, settings.Timezone, 7)
+	if err != nil {
+		return nil, err
+	}
+
+	var dueTomorrow, dueThisWeek int
+	for i, day := range forecast {
+		if i == 0 {
+			continue
+		}
+		if i == 1 {
+			dueTomorrow = day.Count
+		}
+		dueThisWeek += day.Count
+	}
+
+	streak, err := s.streakRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrStreakNotFound) {
+		return nil, fmt.Errorf("get streak: %w", err)
+	}
+
+	var currentStreak, bestStreak int
+	var activeToday bool
+	if streak != nil {
+		currentStreak = streak.Current(today)
+		bestStreak = streak.BestStreak
+		activeToday = streak.LastActiveDate != nil && streak.LastActiveDate.Equal(today)
+	}
+
+	totalXP, err := s.xpRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get xp: %w", err)
+	}
+
+	byType, err := s.progressRepo.GetQuestionTypeStats(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get question type stats: %w", err)
+	}
+
 	return &ProgressSummary{
 		Learned:        learned,
 		InProgress:     inProgress,
@@ -73,6 +136,53 @@ func (s *ProgressService) GetProgressSummary(ctx context.Context, userID int64)
 		NewCount:       stats.NewCount,
 		LearningCount:  stats.LearningCount,
 		MasteredCount:  stats.MasteredCount,
+		DueTomorrow:    dueTomorrow,
+		DueThisWeek:    dueThisWeek,
+		CurrentStreak:  currentStreak,
+		BestStreak:     bestStreak,
+		ActiveToday:    activeToday,
+		TotalXP:        totalXP,
+		Level:          entities.LevelForXP(totalXP),
+		ByQuestionType: byType,
+		Target:         target,
+	}, nil
+}
+
+// NameStats contains personal statistics of a user for a single name.
+type NameStats struct {
+	NameNumber     int
+	Progress       *entities.UserProgress // nil if the name has not been studied yet
+	TotalAnswers   int
+	CorrectAnswers int
+	LastAnsweredAt *time.Time
+}
+
+// Accuracy returns the percentage of correct quiz answers for the name.
+func (s *NameStats) Accuracy() float64 {
+	if s.TotalAnswers == 0 {
+		return 0
+	}
+	return float64(s.CorrectAnswers) / float64(s.TotalAnswers) * 100
+}
+
+// GetNameStats collects SRS progress and quiz answer statistics for a single name.
+func (s *ProgressService) GetNameStats(ctx context.Context, userID int64, nameNumber int) (*NameStats, error) {
+	progress, err := s.GetProgress(ctx, userID, nameNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := s.progressRepo.GetNameAnswerStats(ctx, userID, nameNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get name answer stats: %w", err)
+	}
+
+	return &NameStats{
+		NameNumber:     nameNumber,
+		Progress:       progress,
+		TotalAnswers:   answers.Total,
+		CorrectAnswers: answers.Correct,
+		LastAnsweredAt: answers.LastAnsweredAt,
 	}, nil
 }
 
@@ -89,18 +199,37 @@ func (s *ProgressService) GetProgress(ctx context.Context, userID int64, nameNum
 	return progress, nil
 }
 
+// RecordSelfReview records a "remember"/"forgot" self-assessment for a name and returns the updated progress.
+func (s *ProgressService) RecordSelfReview(ctx context.Context, userID int64, nameNumber int, remembered bool) (*entities.UserProgress, error) {
+	progress, err := s.progressRepo.Get(ctx, userID, nameNumber)
+	if err != nil {
+		if !errors.Is(err, repository.ErrProgressNotFound) {
+			return nil, fmt.Errorf("get progress: %w", err)
+		}
+		progress = entities.NewUserProgress(userID, nameNumber)
+	}
+
+	progress.RecordSelfReview(remembered, time.Now())
+
+	if err := s.progressRepo.Upsert(ctx, progress); err != nil {
+		return nil, fmt.Errorf("upsert progress: %w", err)
+	}
+
+	return progress, nil
+}
+
 func (s *ProgressService) GetByNumbers(ctx context.Context, userID int64, nums []int) (map[int]*entities.UserProgress, error) {
 	return s.progressRepo.GetByNumbers(ctx, userID, nums)
 }
 
-func (s *ProgressService) GetStreak(ctx context.Context, userID int64, nameNumber int) (int, error) {
-	streak, err := s.progressRepo.GetStreak(ctx, userID, nameNumber)
-	if err != nil {
-		if errors.Is(err, repository.ErrProgressNotFound) {
-			return 0, nil
-		}
-	}
-	return streak, nil
+// GetPhases returns the learning phases of several names; names without progress are omitted.
+func (s *ProgressService) GetPhases(ctx context.Context, userID int64, nums []int) (map[int]entities.Phase, error) {
+	return s.progressRepo.GetPhases(ctx, userID, nums)
+}
+
+// GetStreaks returns the streaks of several names; names without progress are omitted.
+func (s *ProgressService) GetStreaks(ctx context.Context, userID int64, nums []int) (map[int]int, error) {
+	return s.progressRepo.GetStreaks(ctx, userID, nums)
 }
 
 // GetDueNames retrieves all names that are due for review.
@@ -132,3 +261,183 @@ func (s *ProgressService) GetNewNames(ctx context.Context, userID int64, limit i
 
 	return names, nil
 }
+
+// GetDueForecast returns the review forecast for the given number of days starting from
+// the user's local today. Every day is present in the result, days without reviews have zero count.
+func (s *ProgressService) GetDueForecast(ctx context.Context, userID int64, days int) ([]repository.DueForecastDay, error) {
+	tz, err := s.userTimezone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dueForecast(ctx, userID, tz, days)
+}
+
+// userTimezone returns the user's timezone, falling back to UTC when settings are missing.
+func (s *ProgressService) userTimezone(ctx context.Context, userID int64) (string, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrSettingsNotFound) {
+			return "", fmt.Errorf("get settings: %w", err)
+		}
+		return "UTC", nil
+	}
+	if settings.Timezone == "" {
+		return "UTC", nil
+	}
+
+	return settings.Timezone, nil
+}
+
+// MonthlyReport compares a user's quiz activity in the current month with the previous one.
+// Months follow the user's calendar: Gregorian or Hijri.
+type MonthlyReport struct {
+	Month      time.Time              // first day of the current local month
+	Calendar   entities.Calendar      // calendar the months are taken from
+	HijriMonth entities.HijriDate     // first day of the current Hijri month, set for the Hijri calendar
+	Current    repository.PeriodStats // current month to date
+	Previous   repository.PeriodStats // whole previous month
+}
+
+// GetMonthlyReport builds a recap of the current local month compared with the previous month.
+func (s *ProgressService) GetMonthlyReport(ctx context.Context, userID int64) (*MonthlyReport, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrSettingsNotFound) {
+		return nil, fmt.Errorf("get settings: %w", err)
+	}
+
+	tz, calendar := "UTC", entities.CalendarGregorian
+	if settings != nil {
+		if settings.Timezone != "" {
+			tz = settings.Timezone
+		}
+		if settings.Calendar.IsValid() {
+			calendar = settings.Calendar
+		}
+	}
+
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().UTC()
+	localNow := now.In(loc)
+	_, offsetSec := localNow.Zone()
+
+	report := &MonthlyReport{Calendar: calendar}
+
+	var monthStart, prevStart time.Time
+	if calendar == entities.CalendarHijri {
+		report.HijriMonth = entities.ToHijri(localNow).MonthStart(0)
+		monthStart = report.HijriMonth.In(loc)
+		prevStart = report.HijriMonth.MonthStart(-1).In(loc)
+	} else {
+		monthStart = time.Date(localNow.Year(), localNow.Month(), 1, 0, 0, 0, 0, loc)
+		prevStart = monthStart.AddDate(0, -1, 0)
+	}
+	report.Month = monthStart
+
+	current, err := s.progressRepo.GetPeriodStats(ctx, userID, monthStart.UTC(), now, offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get current month stats: %w", err)
+	}
+
+	previous, err := s.progressRepo.GetPeriodStats(ctx, userID, prevStart.UTC(), monthStart.UTC(), offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get previous month stats: %w", err)
+	}
+
+	report.Current = *current
+	report.Previous = *previous
+
+	return report, nil
+}
+
+// dueForecast builds a per-day review forecast in the given timezone.
+func (s *ProgressService) dueForecast(ctx context.Context, userID int64, tz string, days int) ([]repository.DueForecastDay, error) {
+	if days <= 0 {
+		return nil, nil
+	}
+
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().UTC()
+	localNow := now.In(loc)
+
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	until := today.AddDate(0, 0, days).UTC()
+
+	dueTimes, err := s.progressRepo.GetDueTimes(ctx, userID, until)
+	if err != nil {
+		return nil, fmt.Errorf("get due forecast: %w", err)
+	}
+
+	// Each review is placed on its own local day, so days after a DST change use the new offset.
+	// Overdue names count towards today.
+	counts := make(map[string]int, days)
+	for _, t := range dueTimes {
+		if t.Before(now) {
+			t = now
+		}
+		counts[t.In(loc).Format(time.DateOnly)]++
+	}
+
+	forecast := make([]repository.DueForecastDay, 0, days)
+	for i := 0; i < days; i++ {
+		day := today.AddDate(0, 0, i)
+		forecast = append(forecast, repository.DueForecastDay{
+			Date:  day,
+			Count: counts[day.Format(time.DateOnly)],
+		})
+	}
+
+	return forecast, nil
+}
+
+// ActivityCalendar describes on which days of the current local month a user studied.
+type ActivityCalendar struct {
+	Month   time.Time   // first day of the month
+	Today   int         // current day of the month
+	Answers map[int]int // quiz answers by day of the month
+}
+
+// GetActivityCalendar returns quiz activity per day of the current local month.
+func (s *ProgressService) GetActivityCalendar(ctx context.Context, userID int64) (*ActivityCalendar, error) {
+	tz, err := s.userTimezone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().UTC()
+	localNow := now.In(loc)
+	_, offsetSec := localNow.Zone()
+
+	monthStart := time.Date(localNow.Year(), localNow.Month(), 1, 0, 0, 0, 0, loc)
+
+	days, err := s.progressRepo.GetActivityDays(ctx, userID, monthStart.UTC(), now, offsetSec)
+	if err != nil {
+		return nil, fmt.Errorf("get activity days: %w", err)
+	}
+
+	answers := make(map[int]int, len(days))
+	for _, d := range days {
+		if d.Date.Month() == monthStart.Month() {
+			answers[d.Date.Day()] += d.Answers
+		}
+	}
+
+	return &ActivityCalendar{
+		Month:   monthStart,
+		Today:   localNow.Day(),
+		Answers: answers,
+	}, nil
+}
@@ -13,6 +13,7 @@ type QuestionSelector struct {
 	progressRepo  ProgressRepository
 	settingsRepo  SettingsRepository
 	dailyNameRepo DailyNameRepository
+	favoritesRepo FavoritesRepository
 
 	rng *rand.Rand
 }
@@ -22,11 +23,13 @@ func NewQuestionSelector(
 	progressRepo ProgressRepository,
 	settingsRepo SettingsRepository,
 	dailyNameRepo DailyNameRepository,
+	favoritesRepo FavoritesRepository,
 ) *QuestionSelector {
 	return &QuestionSelector{
 		progressRepo:  progressRepo,
 		settingsRepo:  settingsRepo,
 		dailyNameRepo: dailyNameRepo,
+		favoritesRepo: favoritesRepo,
 		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -43,6 +46,11 @@ func (s *QuestionSelector) SelectQuestions(
 		return nil, nil
 	}
 
+	// Favorites mode does not depend on the learning mode.
+	if quizMode == "favorites" {
+		return s.favoritesOnly(ctx, userID, total)
+	}
+
 	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
 	if err != nil || settings == nil {
 		settings = &entities.UserSettings{LearningMode: string(entities.ModeGuided)}
@@ -108,6 +116,16 @@ func (s *QuestionSelector) guidedNew(ctx context.Context, userID int64, total in
 	return uniqueKeepOrder(out), nil
 }
 
+// favoritesOnly selects random names from user's favorites.
+func (s *QuestionSelector) favoritesOnly(ctx context.Context, userID int64, total int) ([]int, error) {
+	favorites, err := s.favoritesRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return takeFirst(s.shuffled(favorites), total), nil
+}
+
 // reviewOnly selects due first, then due learning, then reinforcement (mastered and not due).
 func (s *QuestionSelector) reviewOnly(ctx context.Context, userID int64, total int) ([]int, error) {
 	var out []int
@@ -255,14 +273,14 @@ func (s *QuestionSelector) freeMixed(ctx context.Context, userID int64, total in
 // filterNotMasteredByStreak keeps names that are not mastered according to the streak threshold.
 // If progress does not exist, the name is treated as not mastered.
 func (s *QuestionSelector) filterNotMasteredByStreak(ctx context.Context, userID int64, nums []int) ([]int, error) {
+	streaks, err := s.progressRepo.GetStreaks(ctx, userID, nums)
+	if err != nil {
+		return nil, err
+	}
+
 	out := make([]int, 0, len(nums))
 	for _, n := range nums {
-		streak, err := s.progressRepo.GetStreak(ctx, userID, n)
-		if err != nil {
-			out = append(out, n)
-			continue
-		}
-		if streak < entities.MinStreakForMastery {
+		if streaks[n] < entities.MinStreakForMastery {
 			out = append(out, n)
 		}
 	}
@@ -36,6 +36,7 @@ type QuizService struct {
 	questionSelector *QuestionSelector
 	optionGenerator  *OptionGenerator
 	answerValidator  *AnswerValidator
+	webhooks         *WebhookService
 	logger           *zap.Logger
 }
 
@@ -47,6 +48,8 @@ func NewQuizService(
 	quizRepo QuizRepository,
 	settingsRepo SettingsRepository,
 	dailyNameRepo DailyNameRepository,
+	favoritesRepo FavoritesRepository,
+	webhooks *WebhookService,
 	logger *zap.Logger,
 ) *QuizService {
 	return &QuizService{
@@ -56,8 +59,9 @@ func NewQuizService(
 		settingsRepo:  settingsRepo,
 		dailyNameRepo: dailyNameRepo,
 
-		questionSelector: NewQuestionSelector(progressRepo, settingsRepo, dailyNameRepo),
+		questionSelector: NewQuestionSelector(progressRepo, settingsRepo, dailyNameRepo, favoritesRepo),
 		answerValidator:  NewAnswerValidator(),
+		webhooks:         webhooks,
 		logger:           logger,
 	}
 }
@@ -71,11 +75,25 @@ type AnswerResult struct {
 	Score             int
 	Total             int
 	SessionID         int64
+	CurrentStreak     int // daily activity streak after this answer
+	StreakMilestone   int // non-zero if this answer reached a streak milestone
+	XPGained          int // XP awarded for this answer, including streak bonus
+	TotalXP           int
+	Level             entities.Level
+	LeveledUp         bool
 }
 
-// StartQuizSession creates a new quiz session with questions.
+// StartQuizSession creates a new quiz session with questions using the quiz mode from user settings.
 func (s *QuizService) StartQuizSession(
 	ctx context.Context, userID int64, totalQuestions int,
+) (*entities.QuizSession, []entities.Name, error) {
+	return s.StartQuizSessionWithMode(ctx, userID, totalQuestions, "")
+}
+
+// StartQuizSessionWithMode creates a new quiz session with questions for the given quiz mode.
+// An empty quizMode falls back to the mode from user settings.
+func (s *QuizService) StartQuizSessionWithMode(
+	ctx context.Context, userID int64, totalQuestions int, quizMode string,
 ) (*entities.QuizSession, []entities.Name, error) {
 	// Abandon any old active sessions
 	if err := s.quizRepo.AbandonOldSessions(ctx, userID); err != nil {
@@ -92,8 +110,12 @@ func (s *QuizService) StartQuizSession(
 		settings = entities.NewUserSettings(userID)
 	}
 
+	if quizMode == "" {
+		quizMode = settings.QuizMode
+	}
+
 	// Select questions using smart algorithm
-	nameNumbers, err := s.questionSelector.SelectQuestions(ctx, userID, totalQuestions, settings.QuizMode)
+	nameNumbers, err := s.questionSelector.SelectQuestions(ctx, userID, totalQuestions, quizMode)
 	if err != nil {
 		return nil, nil, fmt.Errorf("select questions: %w", err)
 	}
@@ -126,7 +148,7 @@ func (s *QuizService) StartQuizSession(
 		UserID:             userID,
 		CurrentQuestionNum: 1,
 		TotalQuestions:     len(names),
-		QuizMode:           settings.QuizMode,
+		QuizMode:           quizMode,
 		SessionStatus:      "active",
 		StartedAt:          time.Now(),
 		Version:            0,
@@ -189,11 +211,15 @@ func (s *QuizService) SubmitAnswer(
 		return nil, fmt.Errorf("invalid option index: %w", err)
 	}
 
+	activityDay := s.localToday(ctx, userID)
+
 	var res *AnswerResult
 
 	err = s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		quizRepoTx := repository.NewQuizRepository(tx)
 		progressRepoTx := repository.NewProgressRepository(tx)
+		streakRepoTx := repository.NewStreakRepository(tx)
+		xpRepoTx := repository.NewXPRepository(tx)
 
 		// Get session with lock
 		session, err := quizRepoTx.GetSessionForUpdate(ctx, sessionID, userID)
@@ -229,14 +255,11 @@ func (s *QuizService) SubmitAnswer(
 			AnsweredAt:    time.Now(),
 		}
 
-		if err := quizRepoTx.SaveAnswer(ctx, answer); err != nil {
-			return fmt.Errorf("save answer: %w", err)
-		}
-
-		// Update progress (SRS)
+		// Save answer and update progress (SRS)
 		quality := entities.DetermineQuality(isCorrect, true)
-		if err := s.updateProgressTx(ctx, progressRepoTx, userID, currentQuestion.NameNumber, quality); err != nil {
-			return fmt.Errorf("update progress: %w", err)
+		wasDue, err := s.recordAnswerTx(ctx, tx, quizRepoTx, progressRepoTx, answer, quality)
+		if err != nil {
+			return fmt.Errorf("record answer: %w", err)
 		}
 
 		// Update session
@@ -258,6 +281,32 @@ func (s *QuizService) SubmitAnswer(
 			return fmt.Errorf("update session: %w", err)
 		}
 
+		if session.IsCompleted() {
+			if err := s.webhooks.enqueueTx(ctx, tx, userID, entities.WebhookQuizCompleted, entities.QuizCompletedData{
+				SessionID: session.ID,
+				Mode:      session.QuizMode,
+				Score:     session.CorrectAnswers,
+				Total:     session.TotalQuestions,
+			}, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		streak, grew, err := registerActivityTx(ctx, streakRepoTx, userID, activityDay)
+		if err != nil {
+			return fmt.Errorf("register activity: %w", err)
+		}
+
+		xpGained := entities.XPForAnswer(isCorrect, wasDue)
+		if grew {
+			xpGained += entities.XPForStreakDay(streak.CurrentStreak)
+		}
+		totalXP, err := xpRepoTx.Add(ctx, userID, xpGained)
+		if err != nil {
+			return fmt.Errorf("add xp: %w", err)
+		}
+		level := entities.LevelForXP(totalXP)
+
 		res = &AnswerResult{
 			IsCorrect:         isCorrect,
 			CorrectAnswer:     currentQuestion.CorrectAnswer,
@@ -266,6 +315,152 @@ func (s *QuizService) SubmitAnswer(
 			Score:             session.CorrectAnswers,
 			Total:             session.TotalQuestions,
 			SessionID:         sessionID,
+			CurrentStreak:     streak.CurrentStreak,
+			XPGained:          xpGained,
+			TotalXP:           totalXP,
+			Level:             level,
+			LeveledUp:         entities.LevelForXP(totalXP-xpGained).Level < level.Level,
+		}
+		if grew && entities.IsStreakMilestone(streak.CurrentStreak) {
+			res.StreakMilestone = streak.CurrentStreak
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// reminderQuizMode marks one-question sessions answered from a reminder message.
+const reminderQuizMode = "reminder"
+
+// AnswerReminderQuestion records an answer given right in a reminder message.
+// The answer is stored as a completed one-question session, so it counts for SRS,
+// streak, XP and statistics like any quiz answer.
+func (s *QuizService) AnswerReminderQuestion(
+	ctx context.Context,
+	userID int64,
+	nameNumber int,
+	questionType entities.QuestionType,
+	chosenNumber int,
+) (*AnswerResult, error) {
+	name, err := s.nameRepo.GetByNumber(nameNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get name: %w", err)
+	}
+	chosen, err := s.nameRepo.GetByNumber(chosenNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get chosen name: %w", err)
+	}
+
+	correctAnswer := questionType.AnswerFor(name)
+	userAnswer := questionType.AnswerFor(chosen)
+	isCorrect := userAnswer == correctAnswer
+
+	activityDay := s.localToday(ctx, userID)
+
+	var res *AnswerResult
+
+	err = s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		quizRepoTx := repository.NewQuizRepository(tx)
+		progressRepoTx := repository.NewProgressRepository(tx)
+		streakRepoTx := repository.NewStreakRepository(tx)
+		xpRepoTx := repository.NewXPRepository(tx)
+
+		now := time.Now()
+
+		session := &entities.QuizSession{
+			UserID:             userID,
+			CurrentQuestionNum: 1,
+			TotalQuestions:     1,
+			QuizMode:           reminderQuizMode,
+			SessionStatus:      "active",
+			StartedAt:          now,
+		}
+		sessionID, err := quizRepoTx.Create(ctx, session)
+		if err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+		session.ID = sessionID
+
+		options := []string{correctAnswer}
+		if !isCorrect {
+			options = append(options, userAnswer)
+		}
+		questionID, err := quizRepoTx.CreateQuestion(ctx, &entities.QuizQuestion{
+			SessionID:     sessionID,
+			QuestionOrder: 1,
+			NameNumber:    nameNumber,
+			QuestionType:  string(questionType),
+			CorrectAnswer: correctAnswer,
+			Options:       options,
+			CorrectIndex:  0,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("create question: %w", err)
+		}
+
+		answer := &entities.QuizAnswer{
+			UserID:        userID,
+			SessionID:     sessionID,
+			QuestionID:    questionID,
+			NameNumber:    nameNumber,
+			UserAnswer:    userAnswer,
+			CorrectAnswer: correctAnswer,
+			QuestionType:  string(questionType),
+			IsCorrect:     isCorrect,
+			AnsweredAt:    now,
+		}
+
+		quality := entities.DetermineQuality(isCorrect, true)
+		wasDue, err := s.recordAnswerTx(ctx, tx, quizRepoTx, progressRepoTx, answer, quality)
+		if err != nil {
+			return fmt.Errorf("record answer: %w", err)
+		}
+
+		if isCorrect {
+			session.IncrementCorrectAnswers()
+		}
+		session.IncrementQuestion()
+		session.MarkCompleted(now)
+		if err := quizRepoTx.UpdateSession(ctx, session); err != nil {
+			return fmt.Errorf("update session: %w", err)
+		}
+
+		streak, grew, err := registerActivityTx(ctx, streakRepoTx, userID, activityDay)
+		if err != nil {
+			return fmt.Errorf("register activity: %w", err)
+		}
+
+		xpGained := entities.XPForAnswer(isCorrect, wasDue)
+		if grew {
+			xpGained += entities.XPForStreakDay(streak.CurrentStreak)
+		}
+		totalXP, err := xpRepoTx.Add(ctx, userID, xpGained)
+		if err != nil {
+			return fmt.Errorf("add xp: %w", err)
+		}
+		level := entities.LevelForXP(totalXP)
+
+		res = &AnswerResult{
+			IsCorrect:         isCorrect,
+			CorrectAnswer:     correctAnswer,
+			NameNumber:        nameNumber,
+			IsSessionComplete: true,
+			Score:             session.CorrectAnswers,
+			Total:             session.TotalQuestions,
+			SessionID:         sessionID,
+			CurrentStreak:     streak.CurrentStreak,
+			XPGained:          xpGained,
+			TotalXP:           totalXP,
+			Level:             level,
+			LeveledUp:         entities.LevelForXP(totalXP-xpGained).Level < level.Level,
+		}
+		if grew && entities.IsStreakMilestone(streak.CurrentStreak) {
+			res.StreakMilestone = streak.CurrentStreak
 		}
 		return nil
 	})
@@ -315,18 +510,7 @@ func (s *QuizService) randomQuestionType() entities.QuestionType {
 
 // getCorrectAnswerByType returns the correct answer based on question type.
 func (s *QuizService) getCorrectAnswerByType(name *entities.Name, questionType entities.QuestionType) string {
-	switch questionType {
-	case entities.QuestionTypeTranslation:
-		return name.ArabicName
-	case entities.QuestionTypeTransliteration:
-		return name.Translation
-	case entities.QuestionTypeMeaning:
-		return name.Transliteration
-	case entities.QuestionTypeArabic:
-		return name.Translation
-	default:
-		return name.Translation
-	}
+	return questionType.AnswerFor(name)
 }
 
 // validateAnswer checks if the selected option matches the correct answer.
@@ -351,27 +535,90 @@ func (s *QuizService) validateAnswer(selectedOption string, name *entities.Name,
 	return s.answerValidator.Validate(selectedOption, correctAnswer)
 }
 
-// updateProgress updates user progress with SRS algorithm.
-func (s *QuizService) updateProgressTx(
+// localToday returns the current local day of the user, falling back to UTC.
+func (s *QuizService) localToday(ctx context.Context, userID int64) time.Time {
+	loc := time.UTC
+	if settings, err := s.settingsRepo.GetByUserID(ctx, userID); err == nil {
+		if l, err := entities.ParseTimezoneLocation(settings.Timezone); err == nil {
+			loc = l
+		}
+	}
+	return entities.LocalDay(time.Now(), loc)
+}
+
+// registerActivityTx records user activity for the day and returns the updated streak
+// and whether it grew.
+func registerActivityTx(
 	ctx context.Context,
-	progressRepo ProgressRepository,
+	streakRepo StreakRepository,
 	userID int64,
-	nameNumber int,
+	day time.Time,
+) (*entities.UserStreak, bool, error) {
+	streak, err := streakRepo.GetForUpdate(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrStreakNotFound) {
+			return nil, false, err
+		}
+		streak = entities.NewUserStreak(userID)
+	}
+
+	if !streak.RegisterActivity(day) {
+		return streak, false, nil
+	}
+
+	if err := streakRepo.Upsert(ctx, streak); err != nil {
+		return nil, false, err
+	}
+
+	return streak, true, nil
+}
+
+// recordAnswerTx saves an answer and updates the progress of its name with SRS algorithm
+// in one round trip, then queues a webhook when the name gets mastered.
+// It reports whether the name was due for review before the update.
+func (s *QuizService) recordAnswerTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	quizRepo QuizRepository,
+	progressRepo ProgressRepository,
+	answer *entities.QuizAnswer,
 	quality entities.AnswerQuality,
-) error {
+) (bool, error) {
+	now := time.Now()
+	userID, nameNumber := answer.UserID, answer.NameNumber
+
 	// Get existing progress
 	progress, err := progressRepo.Get(ctx, userID, nameNumber)
 	if err != nil {
 		if !errors.Is(err, repository.ErrProgressNotFound) {
-			return err
+			return false, err
 		}
 		// Create new progress
 		progress = entities.NewUserProgress(userID, nameNumber)
 	}
 
+	wasDue := progress.NextReviewAt != nil && !progress.NextReviewAt.After(now)
+	wasMastered := progress.IsLearned()
+
 	// Update SRS
-	now := time.Now()
 	progress.UpdateSRS(quality, now)
 
-	return progressRepo.Upsert(ctx, progress)
+	if err := quizRepo.RecordAnswerAndUpdateSRS(ctx, answer, progress); err != nil {
+		return false, err
+	}
+
+	if progress.IsLearned() && !wasMastered && s.webhooks.Enabled() {
+		stats, err := progressRepo.GetStats(ctx, userID)
+		if err != nil {
+			return false, fmt.Errorf("get stats: %w", err)
+		}
+		if err := s.webhooks.enqueueTx(ctx, tx, userID, entities.WebhookNameMastered, entities.NameMasteredData{
+			NameNumber:    nameNumber,
+			MasteredCount: stats.MasteredCount,
+		}, now); err != nil {
+			return false, err
+		}
+	}
+
+	return wasDue, nil
 }
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 
@@ -17,54 +18,68 @@ import (
 
 // ReminderService handles reminder business logic with batch processing.
 type ReminderService struct {
+	tr            Transactor
 	reminderRepo  ReminderRepository
 	progressRepo  ProgressRepository
 	settingsRepo  SettingsRepository
 	nameRepo      NameRepository
 	dailyNameRepo DailyNameRepository
-	notifier      ReminderNotifier
+	streakRepo    StreakRepository
+	audit         *UserAuditService
 	logger        *zap.Logger
 }
 
 // NewReminderService creates a new reminder service.
 func NewReminderService(
+	tr Transactor,
 	reminderRepo ReminderRepository,
 	progressRepo ProgressRepository,
 	settingsRepo SettingsRepository,
 	nameRepo NameRepository,
 	dailyNameRepo DailyNameRepository,
+	streakRepo StreakRepository,
+	audit *UserAuditService,
 	logger *zap.Logger,
 ) *ReminderService {
 	return &ReminderService{
+		tr:            tr,
 		reminderRepo:  reminderRepo,
 		progressRepo:  progressRepo,
 		settingsRepo:  settingsRepo,
 		nameRepo:      nameRepo,
 		dailyNameRepo: dailyNameRepo,
+		streakRepo:    streakRepo,
+		audit:         audit,
 		logger:        logger,
 	}
 }
 
-// SetNotifier sets the notifier (called after handler is created).
-func (s *ReminderService) SetNotifier(notifier ReminderNotifier) {
-	s.notifier = notifier
-}
-
 // Start begins the reminder scheduling loop.
 func (s *ReminderService) Start(ctx context.Context) {
 	s.logger.Info("reminder service started")
 
 	c := cron.New(cron.WithLocation(time.UTC))
 
-	_, err := c.AddFunc("0 * * * *", func() {
-		s.logger.Info("cron triggered: processing hourly reminders")
-		if err := s.sendHourlyReminders(ctx); err != nil {
-			s.logger.Error("failed to send hourly reminders", zap.Error(err))
+	jobs := []struct {
+		spec string
+		name string
+		run  func(ctx context.Context) error
+	}{
+		// Regular slots are on the hour; prayer-anchored ones can fall at any minute.
+		{"*/5 * * * *", "due reminders", s.sendDueReminders},
+		{"0 * * * *", "weekly digests", s.sendWeeklyDigests},
+		{"0 * * * *", "streak alerts", s.sendStreakAlerts},
+	}
+	for _, job := range jobs {
+		_, err := c.AddFunc(job.spec, func() {
+			if err := job.run(ctx); err != nil {
+				s.logger.Error("failed to send "+job.name, zap.Error(err))
+			}
+		})
+		if err != nil {
+			s.logger.Error("failed to add cron job", zap.String("job", job.name), zap.Error(err))
+			return
 		}
-	})
-	if err != nil {
-		s.logger.Error("failed to add cron job", zap.Error(err))
-		return
 	}
 
 	c.Start()
@@ -76,20 +91,25 @@ func (s *ReminderService) Start(ctx context.Context) {
 	s.logger.Info("reminder service stopped")
 }
 
-// sendHourlyReminders processes and sends all due reminders in batches.
-func (s *ReminderService) sendHourlyReminders(ctx context.Context) error {
+// reminderClaimTTL is how long a claimed reminder stays reserved for the instance that claimed it.
+// Claims are released once the reminder is sent or rescheduled, so the TTL only matters when an
+// instance stops mid-batch or the reminder turned out not to be sendable yet.
+const reminderClaimTTL = 10 * time.Minute
+
+// sendDueReminders claims and sends all due reminders in batches.
+// Claiming instead of paging with OFFSET lets several bot instances share the work without double sends.
+func (s *ReminderService) sendDueReminders(ctx context.Context) error {
 	const batchSize = 100
-	offset := 0
 	totalSent := 0
 	now := time.Now().UTC()
 
-	s.logger.Info("processing hourly reminders", zap.Time("now", now))
+	s.logger.Debug("processing due reminders", zap.Time("now", now))
 
 	for {
-		// Fetch reminders in batches
-		reminders, err := s.reminderRepo.GetDueRemindersBatch(ctx, now, batchSize, offset)
+		// Claimed rows are skipped by later claims, so each call returns the next batch.
+		reminders, err := s.reminderRepo.ClaimDueRemindersBatch(ctx, now, reminderClaimTTL, batchSize)
 		if err != nil {
-			return fmt.Errorf("get due reminders batch: %w", err)
+			return fmt.Errorf("claim due reminders batch: %w", err)
 		}
 
 		if len(reminders) == 0 {
@@ -103,8 +123,6 @@ func (s *ReminderService) sendHourlyReminders(ctx context.Context) error {
 		if len(reminders) < batchSize {
 			break // Last batch
 		}
-
-		offset += batchSize
 	}
 
 	s.logger.Info("reminders processed",
@@ -153,6 +171,15 @@ func (s *ReminderService) processReminder(
 	rwu *entities.ReminderWithUser,
 	now time.Time,
 ) error {
+	// 0. On a quiet day move the reminder to the first slot of the next regular day,
+	// otherwise it would fire right after local midnight.
+	if rwu.IsQuietDay(now) {
+		if err := s.reminderRepo.RescheduleNext(ctx, rwu.UserID, scheduleOf(rwu).CalculateNextSendAt(rwu.Timezone, now)); err != nil {
+			return fmt.Errorf("reschedule quiet day: %w", err)
+		}
+		return nil
+	}
+
 	// 1. Check if we can send now (time window + interval check)
 	if !rwu.CanSendNow(now) {
 		s.logger.Debug("reminder not due yet",
@@ -162,19 +189,18 @@ func (s *ReminderService) processReminder(
 		return nil
 	}
 
-	// 2. Build statistics for the message
-	stats, err := s.buildReminderStats(ctx, rwu)
-	if err != nil {
-		return fmt.Errorf("build reminder stats: %w", err)
+	// Digest style sends the whole day in one message instead of a single name.
+	if rwu.Style == entities.ReminderStyleDigest {
+		return s.sendDailyDigest(ctx, rwu, now)
 	}
 
-	// 3. Select name by priority
-	name, kind, err := s.selectNameForReminder(ctx, rwu.UserID, stats, rwu.LastKind)
+	// 2. Pick a name and build the notification; NotificationWorker delivers it
+	payload, err := s.buildReminderPayload(ctx, rwu)
 	if err != nil {
-		return fmt.Errorf("select name for reminder: %w", err)
+		return err
 	}
 
-	if name == nil {
+	if payload == nil {
 		s.logger.Debug("no name to send", zap.Int64("user_id", rwu.UserID))
 
 		nextSendAt := nextHourUTC(now)
@@ -185,58 +211,284 @@ func (s *ReminderService) processReminder(
 		return nil
 	}
 
-	// 4. Send notification via delivery layer
-	if s.notifier == nil {
-		s.logger.Error("notifier not set, cannot send reminder")
-		return fmt.Errorf("notifier not initialized")
+	// 3. Calculate next send time, leaning toward the hours the user is usually active
+	schedule := scheduleOf(rwu)
+	nextSendAt := schedule.CalculateNextSendAt(rwu.Timezone, now)
+	if activity, err := s.reminderRepo.GetActivityHours(ctx, rwu.UserID); err != nil {
+		s.logger.Warn("failed to get activity hours", zap.Int64("user_id", rwu.UserID), zap.Error(err))
+	} else {
+		nextSendAt = schedule.BiasToActiveHours(nextSendAt, rwu.Timezone, now, activity)
+	}
+
+	nextLastKind := nextKindForAlternation(rwu.LastKind, payload.Kind)
+
+	// 4. Queue the notification together with the schedule update
+	err = s.enqueue(ctx, entities.NotificationReminder, rwu.UserID, rwu.ChatID, payload, now,
+		func(ctx context.Context, repo *repository.ReminderRepository) error {
+			if err := repo.UpdateAfterSend(ctx, rwu.UserID, now, nextSendAt, nextLastKind); err != nil {
+				return fmt.Errorf("update after send: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("reminder queued",
+		zap.Int64("user_id", rwu.UserID),
+		zap.Int("name_number", payload.Name.Number),
+		zap.Time("next_send_at", nextSendAt),
+	)
+
+	return nil
+}
+
+// buildReminderPayload selects a name for the reminder and builds the notification.
+// It returns nil if there is nothing to remind about.
+func (s *ReminderService) buildReminderPayload(ctx context.Context, rwu *entities.ReminderWithUser) (*entities.ReminderPayload, error) {
+	stats, err := s.buildReminderStats(ctx, rwu)
+	if err != nil {
+		return nil, fmt.Errorf("build reminder stats: %w", err)
+	}
+
+	// Select name by priority
+	name, kind, err := s.selectNameForReminder(ctx, rwu.UserID, stats, rwu.LastKind, rwu.Content)
+	if err != nil {
+		return nil, fmt.Errorf("select name for reminder: %w", err)
+	}
+	if name == nil {
+		return nil, nil
 	}
 
 	payload := &entities.ReminderPayload{
-		Kind:  kind,
-		Name:  *name,
-		Stats: *stats,
+		Kind:     kind,
+		Name:     *name,
+		Stats:    *stats,
+		Language: rwu.Language,
 	}
 
-	if err := s.notifier.SendReminder(rwu.UserID, rwu.ChatID, *payload); err != nil {
-		return fmt.Errorf("send notification: %w", err)
+	// Names the user has already seen get a question answerable right in the reminder.
+	if kind != entities.ReminderKindNew {
+		question, err := s.buildReminderQuestion(name)
+		if err != nil {
+			s.logger.Warn("failed to build reminder question", zap.Int64("user_id", rwu.UserID), zap.Error(err))
+		}
+		payload.Question = question
 	}
 
-	// 5. Calculate next send time and update
-	reminder := &entities.UserReminders{
+	return payload, nil
+}
+
+// PreviewReminder builds the reminder the scheduler would produce for the user right now,
+// without queuing it or touching the schedule.
+func (s *ReminderService) PreviewReminder(ctx context.Context, userID int64) (*entities.ReminderPreview, error) {
+	if _, err := s.GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	rwu, err := s.reminderRepo.GetReminderWithUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get reminder: %w", err)
+	}
+
+	now := time.Now().UTC()
+	preview := &entities.ReminderPreview{
+		Enabled:    rwu.IsEnabled,
+		Style:      rwu.Style,
+		QuietDay:   rwu.IsQuietDay(now),
+		DueNow:     rwu.CanSendNow(now),
+		NextSendAt: rwu.NextSendAt,
+		Timezone:   rwu.Timezone,
+	}
+
+	if rwu.Style == entities.ReminderStyleDigest {
+		digest, err := s.buildDailyDigest(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("build daily digest: %w", err)
+		}
+		if len(digest.Planned) > 0 || digest.DueToday > 0 {
+			preview.Digest = digest
+		}
+		return preview, nil
+	}
+
+	preview.Reminder, err = s.buildReminderPayload(ctx, rwu)
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// enqueue queues a notification for NotificationWorker in the same transaction as the
+// state change it announces (outbox), so a change is recorded if and only if its
+// notification is queued.
+func (s *ReminderService) enqueue(
+	ctx context.Context,
+	kind entities.NotificationKind,
+	userID, chatID int64,
+	payload any,
+	now time.Time,
+	record func(ctx context.Context, repo *repository.ReminderRepository) error,
+) error {
+	job, err := newNotificationJob(kind, userID, chatID, payload, now)
+	if err != nil {
+		return err
+	}
+
+	return s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := repository.NewNotificationJobRepository(tx).Enqueue(ctx, job); err != nil {
+			return fmt.Errorf("enqueue %s: %w", kind, err)
+		}
+		return record(ctx, repository.NewRemindersRepository(tx))
+	})
+}
+
+// scheduleOf returns the scheduling part of a due reminder for computing the next slot.
+func scheduleOf(rwu *entities.ReminderWithUser) *entities.UserReminders {
+	return &entities.UserReminders{
 		UserID:        rwu.UserID,
 		IntervalHours: rwu.IntervalHours,
 		StartTime:     rwu.StartTime,
 		EndTime:       rwu.EndTime,
+		Quiet:         rwu.Quiet,
+		Style:         rwu.Style,
+		Prayer:        rwu.Prayer,
+	}
+}
+
+// buildReminderQuestion builds a multiple-choice question about the name.
+func (s *ReminderService) buildReminderQuestion(name *entities.Name) (*entities.ReminderQuestion, error) {
+	allNames, err := s.nameRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("get all names: %w", err)
 	}
-	nextSendAt := reminder.CalculateNextSendAt(rwu.Timezone, now)
 
-	nextLastKind := nextKindForAlternation(rwu.LastKind, kind)
+	questionType := questionTypes[rand.Intn(len(questionTypes))]
+	options, correctIndex := NewOptionGenerator(allNames).GenerateNameOptions(name, questionType)
+
+	return &entities.ReminderQuestion{
+		Type:         questionType,
+		Options:      options,
+		CorrectIndex: correctIndex,
+	}, nil
+}
+
+// maxDigestReviews limits how many due names the daily digest lists.
+const maxDigestReviews = 10
 
-	if err := s.reminderRepo.UpdateAfterSend(ctx, rwu.UserID, now, nextSendAt, nextLastKind); err != nil {
-		return fmt.Errorf("update after send: %w", err)
+// sendDailyDigest sends today's plan and due reviews as a single message.
+func (s *ReminderService) sendDailyDigest(ctx context.Context, rwu *entities.ReminderWithUser, now time.Time) error {
+	nextSendAt := scheduleOf(rwu).CalculateNextSendAt(rwu.Timezone, now)
+
+	digest, err := s.buildDailyDigest(ctx, rwu.UserID)
+	if err != nil {
+		return fmt.Errorf("build daily digest: %w", err)
 	}
 
-	s.logger.Info("reminder sent successfully",
+	if len(digest.Planned) == 0 && digest.DueToday == 0 {
+		s.logger.Debug("nothing for daily digest", zap.Int64("user_id", rwu.UserID))
+		if err := s.reminderRepo.RescheduleNext(ctx, rwu.UserID, nextSendAt); err != nil {
+			return fmt.Errorf("reschedule next send: %w", err)
+		}
+		return nil
+	}
+
+	err = s.enqueue(ctx, entities.NotificationDailyDigest, rwu.UserID, rwu.ChatID, digest, now,
+		func(ctx context.Context, repo *repository.ReminderRepository) error {
+			if err := repo.UpdateAfterSend(ctx, rwu.UserID, now, nextSendAt, rwu.LastKind); err != nil {
+				return fmt.Errorf("update after send: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("daily digest queued",
 		zap.Int64("user_id", rwu.UserID),
-		zap.Int("name_number", name.Number),
+		zap.Int("planned", len(digest.Planned)),
+		zap.Int("due", digest.DueToday),
 		zap.Time("next_send_at", nextSendAt),
 	)
 
 	return nil
 }
 
+// buildDailyDigest ensures today's plan exists and collects it together with due reviews.
+func (s *ReminderService) buildDailyDigest(ctx context.Context, userID int64) (*entities.DailyDigest, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user settings: %w", err)
+	}
+	if err := applyTargetPace(ctx, s.progressRepo, settings); err != nil {
+		return nil, err
+	}
+
+	tz := "UTC"
+	namesPerDay := 1
+	if settings != nil {
+		if settings.Timezone != "" {
+			tz = settings.Timezone
+		}
+		if settings.NamesPerDay > 0 {
+			namesPerDay = settings.NamesPerDay
+		}
+	}
+
+	daily := NewDailyNameService(s.tr, s.dailyNameRepo, s.progressRepo, s.audit)
+	if err := daily.EnsureTodayPlan(ctx, userID, tz, namesPerDay); err != nil {
+		return nil, fmt.Errorf("ensure today plan: %w", err)
+	}
+
+	plannedNums, err := daily.GetTodayNamesTZ(ctx, userID, tz)
+	if err != nil {
+		return nil, fmt.Errorf("get today names: %w", err)
+	}
+
+	stats, err := s.progressRepo.GetStats(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get progress stats: %w", err)
+	}
+
+	reviewNums, err := s.progressRepo.GetNamesDueForReview(ctx, userID, maxDigestReviews)
+	if err != nil {
+		return nil, fmt.Errorf("get names due for review: %w", err)
+	}
+
+	planned, err := s.nameRepo.GetByNumbers(plannedNums)
+	if err != nil {
+		return nil, fmt.Errorf("get planned names: %w", err)
+	}
+
+	reviews, err := s.nameRepo.GetByNumbers(reviewNums)
+	if err != nil {
+		return nil, fmt.Errorf("get review names: %w", err)
+	}
+
+	return &entities.DailyDigest{
+		Planned:  planned,
+		Reviews:  reviews,
+		DueToday: max(stats.DueToday, len(reviews)),
+	}, nil
+}
+
 func nextHourUTC(t time.Time) time.Time {
 	tt := t.UTC().Truncate(time.Hour).Add(time.Hour)
 	return tt
 }
 
 // selectNameForReminder selects a name to send based on priority.
-// selectNameForReminder selects a name to send based on priority.
+// content restricts the candidates: mixed alternates between new and review names,
+// review sends only names due for review, new only today's planned names.
 func (s *ReminderService) selectNameForReminder(
 	ctx context.Context,
 	userID int64,
 	stats *entities.ReminderStats,
 	last entities.ReminderKind,
+	content entities.ReminderContent,
 ) (*entities.Name, entities.ReminderKind, error) {
 	prefer := preferredKind(last)
 
@@ -245,6 +497,9 @@ func (s *ReminderService) selectNameForReminder(
 	if err != nil {
 		return nil, "", fmt.Errorf("get user settings: %w", err)
 	}
+	if err := applyTargetPace(ctx, s.progressRepo, settings); err != nil {
+		return nil, "", err
+	}
 
 	tz := "UTC"
 	namesPerDay := 1
@@ -269,108 +524,39 @@ func (s *ReminderService) selectNameForReminder(
 	}
 	todayDateUTC := localMidnightToUTCDate(tz, time.Now())
 
-	planned, err := s.dailyNameRepo.GetNamesByDate(ctx, userID, todayDateUTC)
+	// One query reads the plan, its progress and the next due name.
+	cands, err := s.reminderRepo.GetReminderCandidates(ctx, userID, todayDateUTC, entities.MinStreakForMastery)
 	if err != nil {
-		return nil, "", fmt.Errorf("get names by date: %w", err)
+		return nil, "", err
 	}
 
-	plannedSet := make(map[int]struct{}, len(planned))
-	for _, n := range planned {
-		plannedSet[n] = struct{}{}
-	}
-
-	remaining := namesPerDay - len(planned)
-	if remaining > 0 {
-		// Carry over learning names from previous plans first.
-		if learningMode == string(entities.ModeGuided) {
-			debt, err := s.dailyNameRepo.GetCarryOverUnfinishedFromPast(ctx, userID, todayDateUTC, remaining)
-			if err != nil {
-				return nil, "", fmt.Errorf("get carry over learning: %w", err)
-			}
-			for _, n := range debt {
-				if _, exists := plannedSet[n]; exists {
-					continue
-				}
-				if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
-					return nil, "", fmt.Errorf("add name for date: %w", err)
-				}
-				plannedSet[n] = struct{}{}
-				remaining--
-				if remaining == 0 {
-					break
-				}
-			}
+	if len(cands.Planned) < namesPerDay {
+		added, err := s.topUpTodayPlan(ctx, userID, todayDateUTC, cands.Planned, namesPerDay, learningMode == string(entities.ModeGuided))
+		if err != nil {
+			return nil, "", err
 		}
-
-		// Fill the rest with not-yet-introduced names.
-		for remaining > 0 {
-			newNums, err := s.progressRepo.GetNamesForIntroduction(ctx, userID, remaining)
+		if added > 0 {
+			cands, err = s.reminderRepo.GetReminderCandidates(ctx, userID, todayDateUTC, entities.MinStreakForMastery)
 			if err != nil {
-				return nil, "", fmt.Errorf("get names for introduction: %w", err)
-			}
-			if len(newNums) == 0 {
-				break
-			}
-
-			added := 0
-			for _, n := range newNums {
-				if _, exists := plannedSet[n]; exists {
-					continue
-				}
-				if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
-					return nil, "", fmt.Errorf("add name for date: %w", err)
-				}
-				plannedSet[n] = struct{}{}
-				added++
-				remaining--
-				if remaining == 0 {
-					break
-				}
-			}
-			if added == 0 {
-				break
+				return nil, "", err
 			}
 		}
 	}
 
 	// Priority 1: Due names (SRS).
 	var reviewName *entities.Name
-	if stats != nil && stats.DueToday > 0 {
-		nameNumber, err := s.progressRepo.GetNextDueName(ctx, userID)
+	if content != entities.ReminderContentNew && stats != nil && stats.DueToday > 0 && cands.DueName > 0 {
+		name, err := s.nameRepo.GetByNumber(cands.DueName)
 		if err != nil {
-			return nil, "", fmt.Errorf("get next due name: %w", err)
-		}
-		if nameNumber > 0 {
-			name, err := s.nameRepo.GetByNumber(nameNumber)
-			if err != nil {
-				return nil, "", fmt.Errorf("get name by number: %w", err)
-			}
-			reviewName = name
+			return nil, "", fmt.Errorf("get name by number: %w", err)
 		}
+		reviewName = name
 	}
 
 	// Priority 2: Today's names (plan-based), but only not-mastered.
 	var studyName *entities.Name
-	todayNames, err := s.dailyNameRepo.GetTodayNames(ctx, userID)
-	if err != nil {
-		return nil, "", fmt.Errorf("get today names: %w", err)
-	}
-
-	candidates := make([]int, 0, len(todayNames))
-	for _, n := range todayNames {
-		streak, err := s.progressRepo.GetStreak(ctx, userID, n)
-		if err != nil {
-			// No progress means not mastered yet.
-			candidates = append(candidates, n)
-			continue
-		}
-		if streak < entities.MinStreakForMastery {
-			candidates = append(candidates, n)
-		}
-	}
-
-	if len(candidates) > 0 {
-		nameNumber := candidates[rand.Intn(len(candidates))]
+	if len(cands.Study) > 0 {
+		nameNumber := cands.Study[rand.Intn(len(cands.Study))]
 		name, err := s.nameRepo.GetByNumber(nameNumber)
 		if err != nil {
 			return nil, "", fmt.Errorf("get name by number: %w", err)
@@ -381,22 +567,29 @@ func (s *ReminderService) selectNameForReminder(
 	// "New" is defined as a planned name that has no progress record yet.
 	// This keeps ReminderService read-only and makes "new" depend on the daily plan.
 	var newName *entities.Name
-	for _, n := range todayNames {
-		_, err := s.progressRepo.Get(ctx, userID, n)
-		if err == nil {
-			continue
+	if len(cands.New) > 0 {
+		name, err := s.nameRepo.GetByNumber(cands.New[0])
+		if err != nil {
+			return nil, "", fmt.Errorf("get name by number: %w", err)
 		}
-		// Treat not found as "new"; other errors should be returned.
-		if !errors.Is(err, repository.ErrProgressNotFound) {
-			return nil, "", fmt.Errorf("get progress: %w", err)
+		newName = name
+	}
+
+	switch content {
+	case entities.ReminderContentReview:
+		if reviewName != nil {
+			return reviewName, entities.ReminderKindReview, nil
 		}
+		return nil, "", nil
 
-		nm, err := s.nameRepo.GetByNumber(n)
-		if err != nil {
-			return nil, "", fmt.Errorf("get name by number: %w", err)
+	case entities.ReminderContentNew:
+		if newName != nil {
+			return newName, entities.ReminderKindNew, nil
+		}
+		if studyName != nil {
+			return studyName, entities.ReminderKindStudy, nil
 		}
-		newName = nm
-		break
+		return nil, "", nil
 	}
 
 	// prefer NEW
@@ -427,6 +620,80 @@ func (s *ReminderService) selectNameForReminder(
 	return nil, "", nil
 }
 
+// topUpTodayPlan adds names to today's plan until it holds namesPerDay names: in guided
+// mode unfinished names from past days first, then names not introduced yet.
+// Names added to the plan are marked introduced. It returns the number of names added.
+func (s *ReminderService) topUpTodayPlan(ctx context.Context, userID int64, todayDateUTC time.Time, planned []int, namesPerDay int, guided bool) (int, error) {
+	plannedSet := make(map[int]struct{}, len(planned))
+	for _, n := range planned {
+		plannedSet[n] = struct{}{}
+	}
+
+	remaining := namesPerDay - len(planned)
+	var addedNums []int
+
+	// Carry over learning names from previous plans first.
+	if remaining > 0 && guided {
+		debt, err := s.dailyNameRepo.GetCarryOverUnfinishedFromPast(ctx, userID, todayDateUTC, remaining)
+		if err != nil {
+			return len(addedNums), fmt.Errorf("get carry over learning: %w", err)
+		}
+		for _, n := range debt {
+			if _, exists := plannedSet[n]; exists {
+				continue
+			}
+			if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
+				return len(addedNums), fmt.Errorf("add name for date: %w", err)
+			}
+			plannedSet[n] = struct{}{}
+			addedNums = append(addedNums, n)
+			remaining--
+			if remaining == 0 {
+				break
+			}
+		}
+	}
+
+	// Fill the rest with not-yet-introduced names.
+	for remaining > 0 {
+		newNums, err := s.progressRepo.GetNamesForIntroduction(ctx, userID, remaining)
+		if err != nil {
+			return len(addedNums), fmt.Errorf("get names for introduction: %w", err)
+		}
+		if len(newNums) == 0 {
+			break
+		}
+
+		added := 0
+		for _, n := range newNums {
+			if _, exists := plannedSet[n]; exists {
+				continue
+			}
+			if err := s.dailyNameRepo.AddNameForDate(ctx, userID, todayDateUTC, n); err != nil {
+				return len(addedNums), fmt.Errorf("add name for date: %w", err)
+			}
+			plannedSet[n] = struct{}{}
+			added++
+			addedNums = append(addedNums, n)
+			remaining--
+			if remaining == 0 {
+				break
+			}
+		}
+		if added == 0 {
+			break
+		}
+	}
+
+	if len(addedNums) > 0 {
+		if err := s.progressRepo.MarkIntroducedMany(ctx, userID, addedNums); err != nil {
+			return len(addedNums), err
+		}
+	}
+
+	return len(addedNums), nil
+}
+
 func nextKindForAlternation(prev entities.ReminderKind, sent entities.ReminderKind) entities.ReminderKind {
 	if sent == entities.ReminderKindStudy {
 		if prev == "" {
@@ -470,6 +737,13 @@ func (s *ReminderService) buildReminderStats(
 
 	daysToComplete := 0
 	if settings != nil {
+		if settings.TargetDate != nil {
+			loc, err := entities.ParseTimezoneLocation(settings.Timezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			settings.ApplyTargetPace(entities.LocalDay(time.Now(), loc), stats.NotStarted)
+		}
 		daysToComplete = settings.DaysToComplete(stats.Learned)
 	}
 
@@ -535,6 +809,7 @@ func (s *ReminderService) ToggleReminder(ctx context.Context, userID int64) erro
 	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
 		return fmt.Errorf("upsert reminder: %w", err)
 	}
+	s.audit.Record(ctx, userID, entities.AuditReminderEnabled, !reminder.IsEnabled, reminder.IsEnabled)
 
 	s.logger.Info("reminder toggled",
 		zap.Int64("user_id", userID),
@@ -544,27 +819,48 @@ func (s *ReminderService) ToggleReminder(ctx context.Context, userID int64) erro
 	return nil
 }
 
-// SnoozeReminder postpones the next reminder to the next scheduler tick after the given duration.
-// The tick is aligned to the user's configured reminder interval (e.g., every 2h/4h/6h).
-// SnoozeReminder postpones the next reminder to the next full UTC hour.
+// SnoozeReminder postpones the next reminder according to the chosen option
+// and returns the new send time in the user's timezone.
 // Works with the hourly cron dispatcher.
-func (s *ReminderService) SnoozeReminder(ctx context.Context, userID int64) error {
+func (s *ReminderService) SnoozeReminder(ctx context.Context, userID int64, option entities.SnoozeOption) (time.Time, error) {
 	reminder, err := s.GetByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("get reminder: %w", err)
+		return time.Time{}, fmt.Errorf("get reminder: %w", err)
+	}
+
+	tz := "UTC"
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err == nil && settings != nil && settings.Timezone != "" {
+		tz = settings.Timezone
+	}
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
 	}
 
 	nowUTC := time.Now().UTC()
-	next := nowUTC.Truncate(time.Hour).Add(time.Hour)
+	next, err := reminder.SnoozeUntil(option, tz, nowUTC)
+	if err != nil {
+		return time.Time{}, err
+	}
 
+	wasEnabled := reminder.IsEnabled
 	reminder.IsEnabled = true
 	reminder.NextSendAt = &next
 	reminder.UpdatedAt = nowUTC
 
 	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
-		return fmt.Errorf("upsert reminder: %w", err)
+		return time.Time{}, fmt.Errorf("upsert reminder: %w", err)
 	}
-	return nil
+	s.audit.Record(ctx, userID, entities.AuditReminderEnabled, wasEnabled, true)
+
+	s.logger.Info("reminder snoozed",
+		zap.Int64("user_id", userID),
+		zap.String("option", string(option)),
+		zap.Time("next_send_at", next),
+	)
+
+	return next.In(loc), nil
 }
 
 // DisableReminder disables reminders for a user.
@@ -574,12 +870,14 @@ func (s *ReminderService) DisableReminder(ctx context.Context, userID int64) err
 		return fmt.Errorf("get reminder: %w", err)
 	}
 
+	wasEnabled := reminder.IsEnabled
 	reminder.IsEnabled = false
 	reminder.UpdatedAt = time.Now()
 
 	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
 		return fmt.Errorf("upsert reminder: %w", err)
 	}
+	s.audit.Record(ctx, userID, entities.AuditReminderEnabled, wasEnabled, false)
 
 	s.logger.Info("reminder disabled", zap.Int64("user_id", userID))
 
@@ -607,6 +905,7 @@ func (s *ReminderService) SetReminderIntervalHours(ctx context.Context, userID i
 		intervalHours = 1
 	}
 
+	oldInterval, wasEnabled := reminder.IntervalHours, reminder.IsEnabled
 	reminder.IntervalHours = intervalHours
 	reminder.IsEnabled = true
 	reminder.UpdatedAt = time.Now().UTC()
@@ -618,6 +917,8 @@ func (s *ReminderService) SetReminderIntervalHours(ctx context.Context, userID i
 	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
 		return fmt.Errorf("upsert reminder: %w", err)
 	}
+	s.audit.Record(ctx, userID, entities.AuditReminderInterval, oldInterval, intervalHours)
+	s.audit.Record(ctx, userID, entities.AuditReminderEnabled, wasEnabled, true)
 
 	s.logger.Info("reminder frequency set",
 		zap.Int64("user_id", userID),
@@ -666,6 +967,7 @@ func (s *ReminderService) SetReminderTimeWindow(
 
 	nowUTC := time.Now().UTC()
 
+	oldWindow, wasEnabled := auditTimeWindow(reminder.StartTime, reminder.EndTime), reminder.IsEnabled
 	reminder.StartTime = startTime
 	reminder.EndTime = endTime
 	reminder.IsEnabled = true
@@ -678,6 +980,8 @@ func (s *ReminderService) SetReminderTimeWindow(
 	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
 		return fmt.Errorf("upsert reminder: %w", err)
 	}
+	s.audit.Record(ctx, userID, entities.AuditReminderWindow, oldWindow, auditTimeWindow(startTime, endTime))
+	s.audit.Record(ctx, userID, entities.AuditReminderEnabled, wasEnabled, true)
 
 	s.logger.Info("reminder time window set",
 		zap.Int64("user_id", userID),
@@ -689,3 +993,216 @@ func (s *ReminderService) SetReminderTimeWindow(
 
 	return nil
 }
+
+// Reschedule recalculates next_send_at, e.g. after the user's timezone changed.
+func (s *ReminderService) Reschedule(ctx context.Context, userID int64) error {
+	reminder, err := s.reminderRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReminderNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get reminder: %w", err)
+	}
+
+	tz := "UTC"
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err == nil && settings != nil && settings.Timezone != "" {
+		tz = settings.Timezone
+	}
+
+	next := reminder.CalculateNextSendAt(tz, time.Now().UTC())
+	reminder.NextSendAt = &next
+
+	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
+		return fmt.Errorf("upsert reminder: %w", err)
+	}
+
+	return nil
+}
+
+// ToggleQuietWeekday mutes or unmutes reminders on a weekday.
+func (s *ReminderService) ToggleQuietWeekday(ctx context.Context, userID int64, weekday time.Weekday) error {
+	return s.updateQuietDays(ctx, userID, func(q *entities.QuietDays) {
+		q.ToggleWeekday(weekday)
+	})
+}
+
+// ToggleQuietDate mutes or unmutes reminders on a specific calendar date.
+func (s *ReminderService) ToggleQuietDate(ctx context.Context, userID int64, date time.Time) error {
+	return s.updateQuietDays(ctx, userID, func(q *entities.QuietDays) {
+		q.ToggleDate(date)
+	})
+}
+
+// updateQuietDays applies a change to quiet days, drops past dates and reschedules the next reminder.
+func (s *ReminderService) updateQuietDays(ctx context.Context, userID int64, apply func(q *entities.QuietDays)) error {
+	return s.updateReminder(ctx, userID, func(r *entities.UserReminders, loc *time.Location, nowUTC time.Time) {
+		apply(&r.Quiet)
+		r.Quiet.DropPast(entities.LocalDay(nowUTC, loc))
+	})
+}
+
+// SetPrayerLocation stores the coordinates used to calculate prayer times.
+// The first location also selects Maghrib so the prayer mode starts working right away.
+func (s *ReminderService) SetPrayerLocation(ctx context.Context, userID int64, latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("invalid coordinates: %f, %f", latitude, longitude)
+	}
+
+	return s.updateReminder(ctx, userID, func(r *entities.UserReminders, _ *time.Location, _ time.Time) {
+		if !r.Prayer.HasLocation() && r.Prayer.Prayers == 0 {
+			r.Prayer.Toggle(entities.Maghrib)
+		}
+		r.Prayer.Latitude = &latitude
+		r.Prayer.Longitude = &longitude
+	})
+}
+
+// TogglePrayerAnchor selects or deselects a prayer to send reminders after.
+func (s *ReminderService) TogglePrayerAnchor(ctx context.Context, userID int64, prayer entities.Prayer) error {
+	if prayer < entities.Fajr || prayer > entities.Isha {
+		return fmt.Errorf("unknown prayer: %d", prayer)
+	}
+
+	return s.updateReminder(ctx, userID, func(r *entities.UserReminders, _ *time.Location, _ time.Time) {
+		r.Prayer.Toggle(prayer)
+	})
+}
+
+// SetPrayerOffset sets how many minutes after the prayer the reminder is sent.
+func (s *ReminderService) SetPrayerOffset(ctx context.Context, userID int64, minutes int) error {
+	if minutes < 0 || minutes > entities.MaxPrayerOffsetMinutes {
+		return fmt.Errorf("invalid prayer offset: %d", minutes)
+	}
+
+	return s.updateReminder(ctx, userID, func(r *entities.UserReminders, _ *time.Location, _ time.Time) {
+		r.Prayer.OffsetMinutes = minutes
+	})
+}
+
+// updateReminder applies a change to the user's reminder settings and reschedules the next reminder.
+func (s *ReminderService) updateReminder(
+	ctx context.Context,
+	userID int64,
+	apply func(r *entities.UserReminders, loc *time.Location, nowUTC time.Time),
+) error {
+	reminder, err := s.GetOrCreate(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get reminder: %w", err)
+	}
+
+	tz := "UTC"
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err == nil && settings != nil && settings.Timezone != "" {
+		tz = settings.Timezone
+	}
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	nowUTC := time.Now().UTC()
+
+	apply(reminder, loc, nowUTC)
+	reminder.UpdatedAt = nowUTC
+
+	next := reminder.CalculateNextSendAt(tz, nowUTC)
+	reminder.NextSendAt = &next
+
+	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
+		return fmt.Errorf("upsert reminder: %w", err)
+	}
+
+	return nil
+}
+
+// SetReminderStyle switches between one name per ping and a single daily digest.
+func (s *ReminderService) SetReminderStyle(ctx context.Context, userID int64, style entities.ReminderStyle) error {
+	if style != entities.ReminderStyleName && style != entities.ReminderStyleDigest {
+		return fmt.Errorf("unknown reminder style: %q", style)
+	}
+
+	reminder, err := s.GetOrCreate(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get reminder: %w", err)
+	}
+
+	tz := "UTC"
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err == nil && settings != nil && settings.Timezone != "" {
+		tz = settings.Timezone
+	}
+
+	nowUTC := time.Now().UTC()
+
+	reminder.Style = style
+	reminder.UpdatedAt = nowUTC
+
+	next := reminder.CalculateNextSendAt(tz, nowUTC)
+	reminder.NextSendAt = &next
+
+	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
+		return fmt.Errorf("upsert reminder: %w", err)
+	}
+
+	s.logger.Info("reminder style changed",
+		zap.Int64("user_id", userID),
+		zap.String("style", string(style)),
+	)
+
+	return nil
+}
+
+// SetReminderContent sets which names single-name reminders contain.
+func (s *ReminderService) SetReminderContent(ctx context.Context, userID int64, content entities.ReminderContent) error {
+	if !content.IsValid() {
+		return fmt.Errorf("unknown reminder content: %q", content)
+	}
+
+	reminder, err := s.GetOrCreate(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get reminder: %w", err)
+	}
+
+	reminder.Content = content
+	reminder.UpdatedAt = time.Now().UTC()
+
+	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
+		return fmt.Errorf("upsert reminder: %w", err)
+	}
+
+	s.logger.Info("reminder content changed",
+		zap.Int64("user_id", userID),
+		zap.String("content", string(content)),
+	)
+
+	return nil
+}
+
+// ToggleWeeklyDigest enables or disables the weekly progress digest for a user.
+func (s *ReminderService) ToggleWeeklyDigest(ctx context.Context, userID int64) error {
+	reminder, err := s.GetOrCreate(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get reminder: %w", err)
+	}
+
+	reminder.WeeklyDigest = !reminder.WeeklyDigest
+	reminder.UpdatedAt = time.Now()
+
+	if err := s.reminderRepo.Upsert(ctx, reminder); err != nil {
+		return fmt.Errorf("upsert reminder: %w", err)
+	}
+	s.audit.Record(ctx, userID, entities.AuditWeeklyDigest, !reminder.WeeklyDigest, reminder.WeeklyDigest)
+
+	s.logger.Info("weekly digest toggled",
+		zap.Int64("user_id", userID),
+		zap.Bool("enabled", reminder.WeeklyDigest),
+	)
+
+	return nil
+}
+
+// auditTimeWindow is a reminder time window as stored in the audit log.
+func auditTimeWindow(startTime, endTime string) map[string]string {
+	return map[string]string{"start": startTime, "end": endTime}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5"
 
@@ -10,23 +11,52 @@ import (
 )
 
 type ResetService struct {
-	tr Transactor
+	tr            Transactor
+	settingsCache SettingsCache
+	audit         *UserAuditService
 }
 
 func NewResetService(
 	tr Transactor,
+	settingsCache SettingsCache,
+	audit *UserAuditService,
 ) *ResetService {
 	return &ResetService{
-		tr: tr,
+		tr:            tr,
+		settingsCache: settingsCache,
+		audit:         audit,
 	}
 }
 
+// resetSnapshot is what a reset discarded, as stored in the user audit log.
+type resetSnapshot struct {
+	Settings  *entities.UserSettings  `json:"settings,omitempty"`
+	Reminders *entities.UserReminders `json:"reminders,omitempty"`
+}
+
+// ResetUser erases the progress of a user and restores default settings and reminders.
+// The settings and reminders it replaced are kept in the user audit log.
 func (s *ResetService) ResetUser(ctx context.Context, userID int64) error {
-	return s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+	defer s.settingsCache.Invalidate(userID)
+
+	var old resetSnapshot
+	err := s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		resetRepo := repository.NewResetRepository(tx)
 		settingsRepo := repository.NewSettingsRepository(tx)
 		reminderRepo := repository.NewRemindersRepository(tx)
 
+		settings, err := settingsRepo.GetByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, repository.ErrSettingsNotFound) {
+			return err
+		}
+		old.Settings = settings
+
+		reminders, err := reminderRepo.GetByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, repository.ErrReminderNotFound) {
+			return err
+		}
+		old.Reminders = reminders
+
 		if err := settingsRepo.UpsertDefaults(ctx, userID); err != nil {
 			return err
 		}
@@ -42,4 +72,25 @@ func (s *ResetService) ResetUser(ctx context.Context, userID int64) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditReset, old, nil)
+	return nil
+}
+
+// DeleteUser erases the user and all of their data.
+func (s *ResetService) DeleteUser(ctx context.Context, userID int64) error {
+	defer s.settingsCache.Invalidate(userID)
+
+	return s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		resetRepo := repository.NewResetRepository(tx)
+
+		if err := resetRepo.ResetUser(ctx, userID); err != nil {
+			return err
+		}
+
+		return resetRepo.DeleteUser(ctx, userID)
+	})
 }
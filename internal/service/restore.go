@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// Restore replaces the user's state with a snapshot produced by Export.
+// Unlike Import it does not merge: progress, favorites, notes, streak and XP
+// are wiped first and then written from the snapshot. Quiz history is not
+// restored because sessions cannot be rebuilt from answers alone.
+func (s *ImportService) Restore(ctx context.Context, userID int64, data []byte) (*ImportResult, error) {
+	var snapshot UserExport
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImportInvalid, err)
+	}
+
+	if snapshot.UserID == 0 || snapshot.ExportedAt.IsZero() {
+		return nil, ErrImportInvalid
+	}
+	if snapshot.UserID != userID {
+		return nil, ErrImportForeignUser
+	}
+
+	result := &ImportResult{}
+
+	err := s.tr.WithinTx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		exists, err := repository.NewUserRepository(tx).Exists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repository.ErrUserNotFound
+		}
+
+		resetRepo := repository.NewResetRepository(tx)
+		if err := resetRepo.ResetUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := resetRepo.ClearPersonalData(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := importSettings(ctx, repository.NewSettingsRepository(tx), userID, snapshot.Settings, result); err != nil {
+			return err
+		}
+
+		if err := restoreReminders(ctx, repository.NewRemindersRepository(tx), userID, &snapshot); err != nil {
+			return err
+		}
+
+		if err := importProgress(ctx, repository.NewProgressRepository(tx), userID, snapshot.Progress, result); err != nil {
+			return err
+		}
+
+		if err := importFavoritesAndNotes(ctx, tx, userID, &snapshot, result); err != nil {
+			return err
+		}
+
+		return restoreStreakAndXP(ctx, tx, userID, &snapshot)
+	})
+	s.settingsCache.Invalidate(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restoreReminders writes reminder preferences from the snapshot; invalid values fall back to defaults.
+func restoreReminders(ctx context.Context, repo *repository.ReminderRepository, userID int64, snapshot *UserExport) error {
+	rem := entities.NewUserReminders(userID)
+
+	if r := snapshot.Reminders; r != nil {
+		rem.IsEnabled = r.Enabled
+		rem.WeeklyDigest = r.WeeklyDigest
+		if style := entities.ReminderStyle(r.Style); style == entities.ReminderStyleDigest {
+			rem.Style = style
+		}
+		if content := entities.ReminderContent(r.Content); content.IsValid() {
+			rem.Content = content
+		}
+		if r.IntervalHours >= 1 && r.IntervalHours <= 24 {
+			rem.IntervalHours = r.IntervalHours
+		}
+		if isValidTimeOfDay(r.StartTime) && isValidTimeOfDay(r.EndTime) {
+			rem.StartTime = r.StartTime
+			rem.EndTime = r.EndTime
+		}
+	}
+
+	if rem.IsEnabled {
+		timezone := "UTC"
+		if snapshot.Settings != nil && snapshot.Settings.Timezone != "" {
+			timezone = snapshot.Settings.Timezone
+		}
+		next := rem.CalculateNextSendAt(timezone, time.Now().UTC())
+		rem.NextSendAt = &next
+	}
+
+	if err := repo.Upsert(ctx, rem); err != nil {
+		return fmt.Errorf("upsert reminders: %w", err)
+	}
+
+	return nil
+}
+
+// restoreStreakAndXP writes the streak and XP from the snapshot.
+func restoreStreakAndXP(ctx context.Context, tx pgx.Tx, userID int64, snapshot *UserExport) error {
+	if snapshot.TotalXP > 0 {
+		if _, err := repository.NewXPRepository(tx).Add(ctx, userID, snapshot.TotalXP); err != nil {
+			return fmt.Errorf("add xp: %w", err)
+		}
+	}
+
+	if snapshot.Streak.Current < 0 || snapshot.Streak.Best < snapshot.Streak.Current {
+		return nil
+	}
+
+	streak := entities.NewUserStreak(userID)
+	streak.CurrentStreak = snapshot.Streak.Current
+	streak.BestStreak = snapshot.Streak.Best
+	streak.LastActiveDate = snapshot.Streak.LastActiveDate
+
+	if err := repository.NewStreakRepository(tx).Upsert(ctx, streak); err != nil {
+		return fmt.Errorf("upsert streak: %w", err)
+	}
+
+	return nil
+}
+
+// isValidTimeOfDay reports whether s is a time of day in "HH:MM:SS" format.
+func isValidTimeOfDay(s string) bool {
+	_, err := time.Parse("15:04:05", s)
+	return err == nil
+}
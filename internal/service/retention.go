@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// RetentionPolicy configures how long data is kept. Zero values disable the
+// corresponding cleanup.
+type RetentionPolicy struct {
+	QuizHistoryDays int // quiz sessions and answers older than this are pruned
+	QuizArchiveDays int // quiz answers older than this are moved to the archive table
+	InactiveMonths  int // users inactive this long are warned and then deleted
+	WarningDays     int // grace period between the warning and deletion
+}
+
+// deliveredNotificationsKeep is how long delivered notification jobs stay in the outbox.
+const deliveredNotificationsKeep = 7 * 24 * time.Hour
+
+// reminderLogKeep is how long delivery attempts stay in the reminder log.
+const reminderLogKeep = 90 * 24 * time.Hour
+
+// quizArchiveBatchSize is the number of quiz answers moved to the archive per statement.
+const quizArchiveBatchSize = 5000
+
+// RetentionService periodically removes outdated quiz history and inactive users.
+type RetentionService struct {
+	retentionRepo RetentionRepository
+	policy        RetentionPolicy
+	notifier      RetentionNotifier
+	logger        *zap.Logger
+}
+
+// NewRetentionService creates a new retention service.
+func NewRetentionService(
+	retentionRepo RetentionRepository,
+	policy RetentionPolicy,
+	logger *zap.Logger,
+) *RetentionService {
+	return &RetentionService{
+		retentionRepo: retentionRepo,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+// SetNotifier sets the notifier (called after handler is created).
+func (s *RetentionService) SetNotifier(notifier RetentionNotifier) {
+	s.notifier = notifier
+}
+
+// Start runs the cleanup once a day until the context is cancelled.
+func (s *RetentionService) Start(ctx context.Context) {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	_, err := c.AddFunc("30 3 * * *", func() {
+		if err := s.Cleanup(ctx, time.Now().UTC()); err != nil {
+			s.logger.Error("failed to run retention cleanup", zap.Error(err))
+		}
+	})
+	if err != nil {
+		s.logger.Error("failed to add retention cron job", zap.Error(err))
+		return
+	}
+
+	c.Start()
+	s.logger.Info("retention service started",
+		zap.Int("quiz_history_days", s.policy.QuizHistoryDays),
+		zap.Int("quiz_archive_days", s.policy.QuizArchiveDays),
+		zap.Int("inactive_months", s.policy.InactiveMonths),
+		zap.Int("warning_days", s.policy.WarningDays),
+	)
+
+	<-ctx.Done()
+
+	c.Stop()
+	s.logger.Info("retention service stopped")
+}
+
+// Cleanup prunes delivered notifications, the reminder log and old quiz history, archives
+// older quiz answers, deletes users whose warning period has expired and warns newly inactive users.
+func (s *RetentionService) Cleanup(ctx context.Context, now time.Time) error {
+	pruned, err := s.retentionRepo.PruneDeliveredNotifications(ctx, now.Add(-deliveredNotificationsKeep))
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		s.logger.Info("delivered notifications pruned", zap.Int64("jobs", pruned))
+	}
+
+	pruned, err = s.retentionRepo.PruneReminderLog(ctx, now.Add(-reminderLogKeep))
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		s.logger.Info("reminder log pruned", zap.Int64("entries", pruned))
+	}
+
+	if s.policy.QuizHistoryDays > 0 {
+		pruned, err := s.retentionRepo.PruneQuizHistory(ctx, now.AddDate(0, 0, -s.policy.QuizHistoryDays))
+		if err != nil {
+			return err
+		}
+		if pruned > 0 {
+			s.logger.Info("quiz history pruned", zap.Int64("sessions", pruned))
+		}
+	}
+
+	if s.policy.QuizArchiveDays > 0 {
+		archived, err := s.archiveQuizAnswers(ctx, now.AddDate(0, 0, -s.policy.QuizArchiveDays))
+		if err != nil {
+			return err
+		}
+		if archived > 0 {
+			s.logger.Info("quiz answers archived", zap.Int64("answers", archived))
+		}
+	}
+
+	if s.policy.InactiveMonths <= 0 {
+		return nil
+	}
+
+	// Delete first so that users warned in this run get the full grace period.
+	deleted, err := s.retentionRepo.DeleteWarnedUsers(ctx, now.AddDate(0, 0, -s.policy.WarningDays))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.logger.Info("inactive users deleted", zap.Int64("users", deleted))
+	}
+
+	warned, err := s.warnInactiveUsers(ctx, now)
+	if err != nil {
+		return err
+	}
+	if warned > 0 {
+		s.logger.Info("inactive users warned", zap.Int("users", warned))
+	}
+
+	return nil
+}
+
+// archiveQuizAnswers moves quiz answers given before the cutoff to the archive in batches,
+// so each statement holds its locks briefly.
+func (s *RetentionService) archiveQuizAnswers(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		moved, err := s.retentionRepo.ArchiveQuizAnswers(ctx, before, quizArchiveBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += moved
+		if moved < quizArchiveBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// warnInactiveUsers sends a deletion warning to users inactive for the configured period.
+func (s *RetentionService) warnInactiveUsers(ctx context.Context, now time.Time) (int, error) {
+	const batchSize = 100
+	var afterUserID int64
+	warned := 0
+
+	inactiveSince := now.AddDate(0, -s.policy.InactiveMonths, 0)
+	deleteAt := now.AddDate(0, 0, s.policy.WarningDays)
+
+	for {
+		users, err := s.retentionRepo.GetInactiveUsersBatch(ctx, inactiveSince, afterUserID, batchSize)
+		if err != nil {
+			return warned, fmt.Errorf("get inactive users batch: %w", err)
+		}
+
+		for _, u := range users {
+			if s.notifier != nil {
+				// A user who blocked the bot cannot be warned; they are still
+				// deleted after the grace period.
+				if err := s.notifier.SendInactivityWarning(u.UserID, u.ChatID, deleteAt); err != nil {
+					s.logger.Warn("failed to send inactivity warning",
+						zap.Int64("user_id", u.UserID),
+						zap.Error(err),
+					)
+				}
+			}
+
+			if err := s.retentionRepo.MarkDeletionWarned(ctx, u.UserID, now); err != nil {
+				return warned, fmt.Errorf("mark deletion warned: %w", err)
+			}
+
+			warned++
+			afterUserID = u.UserID
+		}
+
+		if len(users) < batchSize {
+			break
+		}
+	}
+
+	return warned, nil
+}
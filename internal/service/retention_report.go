@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+const (
+	// cohortRefreshWeeks is how many recent signup weeks are recomputed on every run;
+	// older cohorts have all their values final.
+	cohortRefreshWeeks = 8
+	// cohortReportWeeks is the number of cohorts in the monthly summary.
+	cohortReportWeeks = 12
+)
+
+// RetentionReportService computes weekly retention cohorts every night and posts
+// a summary to the admin chat on the first day of each month.
+type RetentionReportService struct {
+	repo       RetentionCohortRepository
+	recipients []int64
+	notifier   RetentionReportNotifier
+	logger     *zap.Logger
+}
+
+// NewRetentionReportService creates a new RetentionReportService; recipients are the chats the summary is posted to.
+func NewRetentionReportService(repo RetentionCohortRepository, recipients []int64, logger *zap.Logger) *RetentionReportService {
+	return &RetentionReportService{repo: repo, recipients: recipients, logger: logger}
+}
+
+// SetNotifier sets the notifier (called after handler is created).
+func (s *RetentionReportService) SetNotifier(notifier RetentionReportNotifier) {
+	s.notifier = notifier
+}
+
+// Start refreshes cohorts daily and posts the monthly summary until the context is cancelled.
+func (s *RetentionReportService) Start(ctx context.Context) {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	_, err := c.AddFunc("0 4 * * *", func() {
+		if err := s.Refresh(ctx, time.Now().UTC()); err != nil {
+			s.logger.Error("failed to refresh retention cohorts", zap.Error(err))
+		}
+	})
+	if err != nil {
+		s.logger.Error("failed to add retention cohorts cron job", zap.Error(err))
+		return
+	}
+
+	// Runs after the nightly refresh, so the summary includes the last day of the month.
+	_, err = c.AddFunc("0 6 1 * *", func() {
+		if err := s.PostSummary(ctx); err != nil {
+			s.logger.Error("failed to post retention report", zap.Error(err))
+		}
+	})
+	if err != nil {
+		s.logger.Error("failed to add retention report cron job", zap.Error(err))
+		return
+	}
+
+	c.Start()
+	s.logger.Info("retention report service started")
+
+	<-ctx.Done()
+
+	c.Stop()
+	s.logger.Info("retention report service stopped")
+}
+
+// Refresh recomputes the cohorts of the last cohortRefreshWeeks signup weeks.
+func (s *RetentionReportService) Refresh(ctx context.Context, now time.Time) error {
+	cohorts, err := s.repo.Refresh(ctx, weekStart(now).AddDate(0, 0, -7*cohortRefreshWeeks), now)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("retention cohorts refreshed", zap.Int64("cohorts", cohorts))
+	return nil
+}
+
+// PostSummary sends the latest cohorts to every recipient chat.
+func (s *RetentionReportService) PostSummary(ctx context.Context) error {
+	if s.notifier == nil || len(s.recipients) == 0 {
+		return nil
+	}
+
+	cohorts, err := s.repo.List(ctx, cohortReportWeeks)
+	if err != nil {
+		return err
+	}
+
+	for _, chatID := range s.recipients {
+		if err := s.notifier.SendRetentionReport(chatID, cohorts); err != nil {
+			s.logger.Warn("failed to send retention report",
+				zap.Int64("chat_id", chatID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// List returns the latest cohorts, newest first.
+func (s *RetentionReportService) List(ctx context.Context, limit int) ([]entities.RetentionCohort, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// weekStart returns Monday 00:00 UTC of the week containing t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
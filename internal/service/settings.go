@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
 	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
@@ -10,15 +12,19 @@ import (
 
 // SettingsService provides business logic for user settings management.
 type SettingsService struct {
-	repository SettingsRepository
+	repository   SettingsRepository
+	progressRepo ProgressRepository
+	audit        *UserAuditService
 }
 
-// NewSettingsService creates a new SettingsService with the provided repository.
-func NewSettingsService(repository SettingsRepository) *SettingsService {
-	return &SettingsService{repository: repository}
+// NewSettingsService creates a new SettingsService with the provided repositories.
+// Changes are recorded in the user audit log.
+func NewSettingsService(repository SettingsRepository, progressRepo ProgressRepository, audit *UserAuditService) *SettingsService {
+	return &SettingsService{repository: repository, progressRepo: progressRepo, audit: audit}
 }
 
 // GetOrCreate retrieves user settings or creates default settings if they don't exist.
+// With a target date NamesPerDay holds the pace required today.
 func (s *SettingsService) GetOrCreate(ctx context.Context, userID int64) (*entities.UserSettings, error) {
 	settings, err := s.repository.GetByUserID(ctx, userID)
 	if err != nil {
@@ -33,23 +39,153 @@ func (s *SettingsService) GetOrCreate(ctx context.Context, userID int64) (*entit
 		return nil, err
 	}
 
+	if err := applyTargetPace(ctx, s.progressRepo, settings); err != nil {
+		return nil, err
+	}
+
 	return settings, nil
 }
 
-// UpdateNamesPerDay updates the number of names to learn per day.
+// UpdateNamesPerDay sets a fixed number of names to learn per day, replacing a target date.
 func (s *SettingsService) UpdateNamesPerDay(ctx context.Context, userID int64, namesPerDay int) error {
-	return s.repository.UpdateNamesPerDay(ctx, userID, namesPerDay)
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateNamesPerDay(ctx, userID, namesPerDay); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditNamesPerDay, settingsField(old, func(st *entities.UserSettings) any { return st.NamesPerDay }), namesPerDay)
+	if old != nil && old.TargetDate != nil {
+		s.audit.Record(ctx, userID, entities.AuditTargetDate, auditDate(old.TargetDate), nil)
+	}
+	return nil
 }
 
 // UpdateQuizMode updates the quiz mode setting.
 func (s *SettingsService) UpdateQuizMode(ctx context.Context, userID int64, quizMode string) error {
-	return s.repository.UpdateQuizMode(ctx, userID, quizMode)
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateQuizMode(ctx, userID, quizMode); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditQuizMode, settingsField(old, func(st *entities.UserSettings) any { return st.QuizMode }), quizMode)
+	return nil
 }
 
 func (s *SettingsService) UpdateLearningMode(ctx context.Context, userID int64, learningMode string) error {
-	return s.repository.UpdateLearningMode(ctx, userID, learningMode)
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateLearningMode(ctx, userID, learningMode); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditLearningMode, settingsField(old, func(st *entities.UserSettings) any { return st.LearningMode }), learningMode)
+	return nil
 }
 
 func (s *SettingsService) UpdateTimezone(ctx context.Context, userID int64, timezone string) error {
-	return s.repository.UpdateTimezone(ctx, userID, timezone)
+	tz, err := entities.NormalizeTimezone(timezone)
+	if err != nil {
+		return err
+	}
+
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateTimezone(ctx, userID, tz); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditTimezone, settingsField(old, func(st *entities.UserSettings) any { return st.Timezone }), tz)
+	return nil
+}
+
+// UpdateCalendar sets the calendar that month-based boundaries follow.
+func (s *SettingsService) UpdateCalendar(ctx context.Context, userID int64, calendar entities.Calendar) error {
+	if !calendar.IsValid() {
+		return fmt.Errorf("unknown calendar: %q", calendar)
+	}
+
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateCalendar(ctx, userID, calendar); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditCalendar, settingsField(old, func(st *entities.UserSettings) any { return st.Calendar }), calendar)
+	return nil
+}
+
+// ErrTargetDateInPast is returned when the chosen target date has already passed.
+var ErrTargetDateInPast = errors.New("target date is in the past")
+
+// SetTargetDate sets the date by which all names should be started; from then on the
+// names-per-day quota is recalculated every day. A nil date returns to the fixed quota.
+func (s *SettingsService) SetTargetDate(ctx context.Context, userID int64, date *time.Time) error {
+	if date != nil {
+		settings, err := s.GetOrCreate(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		target := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		if target.Before(userToday(settings.Timezone)) {
+			return ErrTargetDateInPast
+		}
+		date = &target
+	}
+
+	old := s.stored(ctx, userID)
+	if err := s.repository.UpdateTargetDate(ctx, userID, date); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, entities.AuditTargetDate, settingsField(old, func(st *entities.UserSettings) any { return auditDate(st.TargetDate) }), auditDate(date))
+	return nil
+}
+
+// stored returns the settings as stored, without the target pace applied, or nil if
+// they cannot be read. It provides the values before a change for the audit log.
+func (s *SettingsService) stored(ctx context.Context, userID int64) *entities.UserSettings {
+	settings, err := s.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil
+	}
+	return settings
+}
+
+// settingsField returns a value of settings for the audit log, or nil if settings are unknown.
+func settingsField(settings *entities.UserSettings, field func(*entities.UserSettings) any) any {
+	if settings == nil {
+		return nil
+	}
+	return field(settings)
+}
+
+// auditDate formats an optional date for the audit log.
+func auditDate(date *time.Time) any {
+	if date == nil {
+		return nil
+	}
+	return date.Format("2006-01-02")
+}
+
+// applyTargetPace replaces the names-per-day quota with the pace needed to start
+// the names not started yet by the user's target date.
+func applyTargetPace(ctx context.Context, progressRepo ProgressRepository, settings *entities.UserSettings) error {
+	if settings == nil || settings.TargetDate == nil {
+		return nil
+	}
+
+	stats, err := progressRepo.GetStats(ctx, settings.UserID)
+	if err != nil {
+		return fmt.Errorf("get progress stats: %w", err)
+	}
+
+	settings.ApplyTargetPace(userToday(settings.Timezone), stats.NotStarted)
+	return nil
+}
+
+// userToday returns the current local calendar date in tz at midnight UTC.
+func userToday(tz string) time.Time {
+	loc, err := entities.ParseTimezoneLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+	return entities.LocalDay(time.Now(), loc)
 }
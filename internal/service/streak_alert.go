@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// sendStreakAlerts warns users whose streak breaks at local midnight and who have
+// not been active today. It runs every hour independently of interval-based reminders.
+func (s *ReminderService) sendStreakAlerts(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	job := notifyJob[*entities.StreakAlertCandidate]{
+		name:  "streak alerts",
+		fetch: s.reminderRepo.GetStreakAlertCandidatesBatch,
+		due:   func(c *entities.StreakAlertCandidate) bool { return c.IsAlertDue(now) },
+		process: func(ctx context.Context, c *entities.StreakAlertCandidate) error {
+			return s.processStreakAlert(ctx, c, now)
+		},
+		userID: func(c *entities.StreakAlertCandidate) int64 { return c.UserID },
+	}
+
+	if _, err := job.run(ctx, s.logger); err != nil {
+		return fmt.Errorf("get streak alert candidates batch: %w", err)
+	}
+	return nil
+}
+
+// processStreakAlert queues the streak-protection reminder for a single user.
+func (s *ReminderService) processStreakAlert(ctx context.Context, c *entities.StreakAlertCandidate, now time.Time) error {
+	alert := entities.StreakAlert{
+		Streak:    c.Streak.CurrentStreak,
+		HoursLeft: entities.StreakAlertHoursLeft,
+	}
+
+	return s.enqueue(ctx, entities.NotificationStreakAlert, c.UserID, c.ChatID, alert, now,
+		func(ctx context.Context, repo *repository.ReminderRepository) error {
+			if err := repo.MarkStreakAlertSent(ctx, c.UserID, now); err != nil {
+				return fmt.Errorf("mark streak alert sent: %w", err)
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+var (
+	ErrSurveySegment        = errors.New("unknown survey segment")
+	ErrSurveyFormat         = errors.New("invalid survey definition")
+	ErrSurveyAnswerNotFound = errors.New("survey question or option not found")
+)
+
+// SurveyService creates surveys, sends them to user segments through the
+// notification queue and collects answers.
+type SurveyService struct {
+	repo SurveyRepository
+}
+
+// NewSurveyService creates a new SurveyService.
+func NewSurveyService(repo SurveyRepository) *SurveyService {
+	return &SurveyService{repo: repo}
+}
+
+// Create parses and stores a survey for the segment. The definition starts with the title line,
+// followed by pairs of lines: a question and its options separated by "|".
+func (s *SurveyService) Create(ctx context.Context, adminID int64, segment, definition string) (*entities.Survey, error) {
+	seg, ok := entities.ParseSurveySegment(strings.ToLower(strings.TrimSpace(segment)))
+	if !ok {
+		return nil, ErrSurveySegment
+	}
+
+	title, questions, err := parseSurveyDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	survey := &entities.Survey{
+		Title:     title,
+		Segment:   seg,
+		Questions: questions,
+		CreatedBy: adminID,
+	}
+	if err := s.repo.Create(ctx, survey); err != nil {
+		return nil, err
+	}
+	return survey, nil
+}
+
+// Send queues the first question of the survey for users of its segment who have not received it.
+// Sending again reaches only users who joined the segment since. It returns the number of users queued.
+func (s *SurveyService) Send(ctx context.Context, surveyID int64) (int, error) {
+	survey, err := s.repo.Get(ctx, surveyID)
+	if err != nil {
+		return 0, err
+	}
+
+	payload, err := json.Marshal(entities.SurveyInvite{
+		SurveyID:  survey.ID,
+		Title:     survey.Title,
+		Question:  survey.Questions[0],
+		Questions: len(survey.Questions),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode survey invite: %w", err)
+	}
+
+	return s.repo.EnqueueInvites(ctx, survey, payload)
+}
+
+// Answer stores the user's answer and returns the survey. done is set when it was the last question.
+func (s *SurveyService) Answer(
+	ctx context.Context,
+	userID, surveyID int64,
+	question, option int,
+) (survey *entities.Survey, done bool, err error) {
+	survey, err = s.repo.Get(ctx, surveyID)
+	if err != nil {
+		return nil, false, err
+	}
+	if question < 0 || question >= len(survey.Questions) ||
+		option < 0 || option >= len(survey.Questions[question].Options) {
+		return nil, false, ErrSurveyAnswerNotFound
+	}
+
+	if err := s.repo.SaveAnswer(ctx, surveyID, userID, question, option); err != nil {
+		return nil, false, err
+	}
+
+	if question < len(survey.Questions)-1 {
+		return survey, false, nil
+	}
+
+	if err := s.repo.MarkCompleted(ctx, surveyID, userID); err != nil {
+		return nil, false, err
+	}
+	return survey, true, nil
+}
+
+// Results aggregates the answers to the survey.
+func (s *SurveyService) Results(ctx context.Context, surveyID int64) (*entities.SurveyResults, error) {
+	survey, err := s.repo.Get(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetResults(ctx, survey)
+}
+
+// List returns the latest surveys.
+func (s *SurveyService) List(ctx context.Context, limit int) ([]entities.Survey, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// parseSurveyDefinition splits a survey definition into the title and questions.
+func parseSurveyDefinition(definition string) (string, []entities.SurveyQuestion, error) {
+	var lines []string
+	for _, line := range strings.Split(definition, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) < 3 || len(lines)%2 == 0 {
+		return "", nil, fmt.Errorf("%w: expected a title and question/options line pairs", ErrSurveyFormat)
+	}
+	if (len(lines)-1)/2 > entities.MaxSurveyQuestions {
+		return "", nil, fmt.Errorf("%w: more than %d questions", ErrSurveyFormat, entities.MaxSurveyQuestions)
+	}
+
+	questions := make([]entities.SurveyQuestion, 0, (len(lines)-1)/2)
+	for i := 1; i < len(lines); i += 2 {
+		text := lines[i]
+		if utf8.RuneCountInString(text) > entities.MaxSurveyQuestionLength {
+			return "", nil, fmt.Errorf("%w: question %d is too long", ErrSurveyFormat, len(questions)+1)
+		}
+
+		var options []string
+		for _, opt := range strings.Split(lines[i+1], "|") {
+			opt = strings.TrimSpace(opt)
+			if opt == "" {
+				continue
+			}
+			if utf8.RuneCountInString(opt) > entities.MaxSurveyOptionLength {
+				return "", nil, fmt.Errorf("%w: option %q is too long", ErrSurveyFormat, opt)
+			}
+			options = append(options, opt)
+		}
+		if len(options) < entities.MinSurveyOptions || len(options) > entities.MaxSurveyOptions {
+			return "", nil, fmt.Errorf("%w: question %d needs %d to %d options",
+				ErrSurveyFormat, len(questions)+1, entities.MinSurveyOptions, entities.MaxSurveyOptions)
+		}
+
+		questions = append(questions, entities.SurveyQuestion{Text: text, Options: options})
+	}
+
+	return lines[0], questions, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+var (
+	ErrTicketEmpty   = errors.New("ticket is empty")
+	ErrTicketTooLong = errors.New("ticket is too long")
+	ErrTicketClosed  = errors.New("ticket is already closed")
+)
+
+// TicketService stores support tickets from /feedback and tracks their state.
+type TicketService struct {
+	repo       TicketRepository
+	recipients []int64
+}
+
+// NewTicketService creates a new TicketService. Tickets are delivered to the
+// recipients: an admin chat or the private chats of owners.
+func NewTicketService(repo TicketRepository, recipients []int64) *TicketService {
+	return &TicketService{repo: repo, recipients: recipients}
+}
+
+// Recipients returns the chats tickets are sent to.
+func (s *TicketService) Recipients() []int64 {
+	return s.recipients
+}
+
+// Submit validates and stores a new open ticket.
+func (s *TicketService) Submit(ctx context.Context, userID, chatID int64, text string) (*entities.Ticket, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrTicketEmpty
+	}
+	if utf8.RuneCountInString(text) > entities.MaxTicketLength {
+		return nil, ErrTicketTooLong
+	}
+
+	t := &entities.Ticket{UserID: userID, ChatID: chatID, Text: text}
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TrackMessage remembers a copy of the ticket sent to an admin chat, so that replies to it can be relayed.
+func (s *TicketService) TrackMessage(ctx context.Context, ticketID, chatID int64, messageID int) error {
+	return s.repo.AddMessage(ctx, ticketID, chatID, messageID)
+}
+
+// FindByMessage returns the ticket an admin chat message is a copy of, or nil if it is not one.
+func (s *TicketService) FindByMessage(ctx context.Context, chatID int64, messageID int) (*entities.Ticket, error) {
+	t, err := s.repo.GetByMessage(ctx, chatID, messageID)
+	if errors.Is(err, repository.ErrTicketNotFound) {
+		return nil, nil
+	}
+	return t, err
+}
+
+// MarkAnswered records that an admin answered the ticket.
+func (s *TicketService) MarkAnswered(ctx context.Context, ticketID, adminID int64) error {
+	return s.repo.MarkAnswered(ctx, ticketID, adminID)
+}
+
+// Close closes the ticket and returns it. It returns ErrTicketClosed if it was already closed.
+func (s *TicketService) Close(ctx context.Context, ticketID, adminID int64) (*entities.Ticket, error) {
+	ok, err := s.repo.Close(ctx, ticketID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.repo.Get(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return t, ErrTicketClosed
+	}
+	return t, nil
+}
+
+// List returns the latest tickets with the status, or of any status if it is empty.
+func (s *TicketService) List(ctx context.Context, status entities.TicketStatus, limit int) ([]entities.Ticket, error) {
+	return s.repo.List(ctx, status, limit)
+}
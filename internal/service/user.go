@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -49,6 +52,32 @@ func (s *UserService) EnsureUser(ctx context.Context, userID, chatID int64) (boo
 	return created, err
 }
 
+// TouchActivity records that the user interacted with the bot.
+func (s *UserService) TouchActivity(ctx context.Context, userID int64) error {
+	return s.userRepo.TouchActivity(ctx, userID, time.Now().UTC())
+}
+
 func (s *UserService) Exists(ctx context.Context, userID int64) (bool, error) {
 	return s.userRepo.Exists(ctx, userID)
 }
+
+// AttributeReferral records that the user joined via the referrer's link.
+// It reports false if the user was already attributed or the referrer is unknown.
+func (s *UserService) AttributeReferral(ctx context.Context, userID, referrerID int64) (bool, error) {
+	return s.userRepo.SetReferrer(ctx, userID, referrerID, time.Now().UTC())
+}
+
+// GetReferralStats returns how many users the user invited and how many of them started learning.
+func (s *UserService) GetReferralStats(ctx context.Context, userID int64) (*entities.ReferralStats, error) {
+	return s.userRepo.GetReferralStats(ctx, userID)
+}
+
+// Search returns users whose Telegram ID starts with the query, most recently active first.
+// A query that is not a number matches nobody.
+func (s *UserService) Search(ctx context.Context, query string, limit int) ([]entities.UserInfo, error) {
+	query = strings.TrimSpace(query)
+	if _, err := strconv.ParseInt(query, 10, 64); err != nil {
+		return nil, nil
+	}
+	return s.userRepo.Search(ctx, query, limit)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/requestid"
+)
+
+// UserAuditService keeps the audit log of changes users make to their settings,
+// reminders and daily plan, so that support can see what happened to an account.
+type UserAuditService struct {
+	repo   UserAuditRepository
+	logger *zap.Logger
+}
+
+// NewUserAuditService creates a new UserAuditService.
+func NewUserAuditService(repo UserAuditRepository, logger *zap.Logger) *UserAuditService {
+	return &UserAuditService{repo: repo, logger: logger}
+}
+
+// Record appends a change to the audit log with the values before and after it,
+// stored as JSON; a nil value is stored as NULL. A change that leaves the value as
+// it was is skipped. A failed write is only logged so that it never blocks the change
+// itself. Record does nothing on a nil service.
+func (s *UserAuditService) Record(ctx context.Context, userID int64, action string, oldValue, newValue any) {
+	if s == nil {
+		return
+	}
+
+	entry := &entities.UserAuditEntry{
+		UserID:    userID,
+		Action:    action,
+		RequestID: requestid.FromContext(ctx),
+	}
+
+	var err error
+	if entry.OldValue, err = auditJSON(oldValue); err == nil {
+		entry.NewValue, err = auditJSON(newValue)
+	}
+	if err != nil {
+		requestid.Logger(ctx, s.logger).Error("failed to encode user audit values",
+			zap.Int64("user_id", userID),
+			zap.String("action", action),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if entry.OldValue != nil && bytes.Equal(entry.OldValue, entry.NewValue) {
+		return
+	}
+
+	if err := s.repo.Append(ctx, entry); err != nil {
+		requestid.Logger(ctx, s.logger).Error("failed to write user audit log",
+			zap.Int64("user_id", userID),
+			zap.String("action", action),
+			zap.Error(err),
+		)
+	}
+}
+
+// History returns the latest changes of a user, newest first.
+func (s *UserAuditService) History(ctx context.Context, userID int64, limit int) ([]entities.UserAuditEntry, error) {
+	return s.repo.ListByUser(ctx, userID, limit)
+}
+
+// auditJSON encodes an audit value, returning nil for a nil value or nil pointer.
+func auditJSON(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) == "null" {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/infra/postgres/repository"
+)
+
+// webhookTimeout bounds one webhook request, so a slow endpoint cannot stall the notification worker.
+const webhookTimeout = 10 * time.Second
+
+// WebhookService publishes learning events to an external endpoint. Events are queued
+// as notification jobs in the transaction that produced them and sent by the notification
+// worker, which retries failures and counts deliveries under the "webhook" kind.
+type WebhookService struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookService creates a new WebhookService; an empty url disables webhooks.
+func NewWebhookService(url, secret string) *WebhookService {
+	return &WebhookService{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Enabled reports whether a webhook endpoint is configured.
+func (s *WebhookService) Enabled() bool {
+	return s.url != ""
+}
+
+// enqueueTx queues an event within tx. It does nothing when webhooks are disabled.
+func (s *WebhookService) enqueueTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int64,
+	eventType entities.WebhookEventType,
+	data any,
+	at time.Time,
+) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("generate event id: %w", err)
+	}
+
+	event := entities.WebhookEvent{
+		ID:        hex.EncodeToString(id),
+		Type:      eventType,
+		UserID:    userID,
+		CreatedAt: at.UTC(),
+		Data:      data,
+	}
+
+	// Webhooks are not sent to a chat; the private chat ID keeps the job like any other.
+	job, err := newNotificationJob(entities.NotificationWebhook, userID, userID, event, at)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.NewNotificationJobRepository(tx).Enqueue(ctx, job); err != nil {
+		return fmt.Errorf("enqueue %s webhook: %w", eventType, err)
+	}
+	return nil
+}
+
+// Send posts a queued event to the endpoint. The body is signed with the secret:
+// X-Webhook-Signature is "sha256=" followed by the hex HMAC-SHA256 of
+// "<X-Webhook-Timestamp>.<body>". Any response other than 2xx is an error, so the job is retried.
+func (s *WebhookService) Send(payload []byte) error {
+	if !s.Enabled() {
+		return fmt.Errorf("%w: webhooks are disabled", ErrNotificationUndeliverable)
+	}
+
+	var event entities.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("decode webhook event: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotificationUndeliverable, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(event.Type))
+	req.Header.Set("X-Webhook-ID", event.ID)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("post webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
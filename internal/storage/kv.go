@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// kvSweepSize is the number of entries after which MemoryKV drops expired ones.
+const kvSweepSize = 10000
+
+// KV stores JSON-encoded values with an expiry. MemoryKV keeps them in the process;
+// RedisKV shares them between bot replicas.
+type KV interface {
+	// Get decodes the value of key into v and reports whether it was found.
+	Get(ctx context.Context, key string, v any) (bool, error)
+	// GetMany decodes the values of keys into vs, which is as long as keys, in one
+	// round trip and reports which keys were found.
+	GetMany(ctx context.Context, keys []string, vs []any) ([]bool, error)
+	// Set stores v under key for ttl; zero ttl keeps it until it is deleted.
+	Set(ctx context.Context, key string, v any, ttl time.Duration) error
+	// Delete removes key.
+	Delete(ctx context.Context, key string) error
+}
+
+type kvEntry struct {
+	data      []byte
+	expiresAt time.Time // zero if the entry does not expire
+}
+
+// MemoryKV is a KV kept in process memory.
+type MemoryKV struct {
+	mu      sync.Mutex
+	entries map[string]kvEntry
+}
+
+// NewMemoryKV creates an empty MemoryKV.
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{entries: make(map[string]kvEntry)}
+}
+
+func (s *MemoryKV) Get(ctx context.Context, key string, v any) (bool, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	if ok && !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		ok = false
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(entry.data, v); err != nil {
+		return false, fmt.Errorf("decode %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *MemoryKV) GetMany(ctx context.Context, keys []string, vs []any) ([]bool, error) {
+	found := make([]bool, len(keys))
+	for i, key := range keys {
+		ok, err := s.Get(ctx, key, vs[i])
+		if err != nil {
+			return nil, err
+		}
+		found[i] = ok
+	}
+	return found, nil
+}
+
+func (s *MemoryKV) Set(ctx context.Context, key string, v any, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+
+	entry := kvEntry{data: data}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) >= kvSweepSize {
+		s.sweep(time.Now())
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryKV) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// sweep removes expired entries. s.mu must be held.
+func (s *MemoryKV) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// RedisKV is a KV stored in Redis under a common key prefix.
+type RedisKV struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisKV creates a RedisKV; prefix separates the bot's keys from other data in the database.
+func NewRedisKV(client *redis.Client, prefix string) *RedisKV {
+	return &RedisKV{client: client, prefix: prefix}
+}
+
+func (s *RedisKV) Get(ctx context.Context, key string, v any) (bool, error) {
+	data, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("decode %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *RedisKV) GetMany(ctx context.Context, keys []string, vs []any) ([]bool, error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.prefix + key
+	}
+
+	replies, err := s.client.MGet(ctx, prefixed...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("mget: %w", err)
+	}
+
+	found := make([]bool, len(keys))
+	for i, reply := range replies {
+		data, ok := reply.(string)
+		if !ok {
+			continue // nil for a missing key
+		}
+		if err := json.Unmarshal([]byte(data), vs[i]); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", keys[i], err)
+		}
+		found[i] = true
+	}
+	return found, nil
+}
+
+func (s *RedisKV) Set(ctx context.Context, key string, v any, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+
+	if err := s.client.Set(ctx, s.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("set %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *RedisKV) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aliskhannn/asma-ul-husna-bot/internal/domain/entities"
+)
+
+const (
+	// kvTimeout bounds one KV call made by the storages below, whose methods take no context.
+	kvTimeout = 2 * time.Second
+	// quizStateTTL is how long an abandoned quiz keeps its questions and message ID.
+	quizStateTTL = 24 * time.Hour
+	// reminderMessageTTL is how long the last reminder message is remembered for replacing it.
+	reminderMessageTTL = 7 * 24 * time.Hour
+)
+
+// KVQuizStorage is a QuizStorage kept in a KV, so with RedisKV every replica sees the same quizzes.
+// Failed calls are logged and read as missing state.
+type KVQuizStorage struct {
+	kv     KV
+	logger *zap.Logger
+}
+
+// NewKVQuizStorage creates a new KVQuizStorage.
+func NewKVQuizStorage(kv KV, logger *zap.Logger) *KVQuizStorage {
+	return &KVQuizStorage{kv: kv, logger: logger}
+}
+
+// Store saves a list of questions for a given session ID.
+func (s *KVQuizStorage) Store(sessionID int64, names []entities.Name) {
+	kvSet(s.kv, s.logger, quizNamesKey(sessionID), names, quizStateTTL)
+}
+
+// Get retrieves the list of questions for a given session ID.
+func (s *KVQuizStorage) Get(sessionID int64) []entities.Name {
+	var names []entities.Name
+	kvGet(s.kv, s.logger, quizNamesKey(sessionID), &names)
+	return names
+}
+
+// Delete removes questions for a given session ID.
+func (s *KVQuizStorage) Delete(sessionID int64) {
+	kvDelete(s.kv, s.logger, quizNamesKey(sessionID))
+	kvDelete(s.kv, s.logger, quizMessageKey(sessionID))
+}
+
+// StoreMessageID saves the message ID of the last quiz question.
+func (s *KVQuizStorage) StoreMessageID(sessionID int64, messageID int) {
+	kvSet(s.kv, s.logger, quizMessageKey(sessionID), messageID, quizStateTTL)
+}
+
+// GetMessageID retrieves the message ID of the last quiz question.
+func (s *KVQuizStorage) GetMessageID(sessionID int64) (int, bool) {
+	var messageID int
+	ok := kvGet(s.kv, s.logger, quizMessageKey(sessionID), &messageID)
+	return messageID, ok
+}
+
+// DeleteMessageID removes the stored message ID.
+func (s *KVQuizStorage) DeleteMessageID(sessionID int64) {
+	kvDelete(s.kv, s.logger, quizMessageKey(sessionID))
+}
+
+// KVReminderStorage is a ReminderStorage kept in a KV.
+// Failed calls are logged and read as missing state.
+type KVReminderStorage struct {
+	kv     KV
+	logger *zap.Logger
+}
+
+// NewKVReminderStorage creates a new KVReminderStorage.
+func NewKVReminderStorage(kv KV, logger *zap.Logger) *KVReminderStorage {
+	return &KVReminderStorage{kv: kv, logger: logger}
+}
+
+func (s *KVReminderStorage) Store(userID int64, chatID int64, messageID int) {
+	kvSet(s.kv, s.logger, reminderMessageKey(userID), ReminderMessage{
+		ChatID:    chatID,
+		MessageID: messageID,
+		SentAt:    time.Now(),
+	}, reminderMessageTTL)
+}
+
+func (s *KVReminderStorage) Get(userID int64) (ReminderMessage, bool) {
+	var msg ReminderMessage
+	ok := kvGet(s.kv, s.logger, reminderMessageKey(userID), &msg)
+	return msg, ok
+}
+
+func (s *KVReminderStorage) Delete(userID int64) {
+	kvDelete(s.kv, s.logger, reminderMessageKey(userID))
+}
+
+func quizNamesKey(sessionID int64) string {
+	return "quiz:names:" + strconv.FormatInt(sessionID, 10)
+}
+
+func quizMessageKey(sessionID int64) string {
+	return "quiz:message:" + strconv.FormatInt(sessionID, 10)
+}
+
+func reminderMessageKey(userID int64) string {
+	return "reminder:message:" + strconv.FormatInt(userID, 10)
+}
+
+// kvGet reads key into v; errors are logged and reported as a miss.
+func kvGet(kv KV, logger *zap.Logger, key string, v any) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), kvTimeout)
+	defer cancel()
+
+	ok, err := kv.Get(ctx, key, v)
+	if err != nil {
+		logger.Warn("failed to read state", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return ok
+}
+
+// kvSet stores v under key; errors are logged.
+func kvSet(kv KV, logger *zap.Logger, key string, v any, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), kvTimeout)
+	defer cancel()
+
+	if err := kv.Set(ctx, key, v, ttl); err != nil {
+		logger.Warn("failed to store state", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// kvDelete removes key; errors are logged.
+func kvDelete(kv KV, logger *zap.Logger, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), kvTimeout)
+	defer cancel()
+
+	if err := kv.Delete(ctx, key); err != nil {
+		logger.Warn("failed to delete state", zap.String("key", key), zap.Error(err))
+	}
+}
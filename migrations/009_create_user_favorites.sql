@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_favorites
+(
+    user_id     BIGINT      NOT NULL,
+    name_number SMALLINT    NOT NULL CHECK (name_number BETWEEN 1 AND 99),
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, name_number),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+ALTER TABLE user_settings
+    DROP CONSTRAINT IF EXISTS user_settings_quiz_mode_check;
+ALTER TABLE user_settings
+    ADD CONSTRAINT user_settings_quiz_mode_check
+        CHECK (quiz_mode IN ('new', 'review', 'mixed', 'favorites'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE user_settings
+SET quiz_mode = 'mixed'
+WHERE quiz_mode = 'favorites';
+
+ALTER TABLE user_settings
+    DROP CONSTRAINT IF EXISTS user_settings_quiz_mode_check;
+ALTER TABLE user_settings
+    ADD CONSTRAINT user_settings_quiz_mode_check
+        CHECK (quiz_mode IN ('new', 'review', 'mixed'));
+
+DROP TABLE IF EXISTS user_favorites;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_name_notes
+(
+    user_id     BIGINT      NOT NULL,
+    name_number SMALLINT    NOT NULL CHECK (name_number BETWEEN 1 AND 99),
+    note        TEXT        NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    updated_at  timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, name_number),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_name_notes;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_streaks
+(
+    user_id          BIGINT PRIMARY KEY,
+    current_streak   INTEGER     NOT NULL DEFAULT 0,
+    best_streak      INTEGER     NOT NULL DEFAULT 0,
+    last_active_date DATE,
+    updated_at       timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_streaks;
+-- +goose StatementEnd
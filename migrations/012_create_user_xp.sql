@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_xp
+(
+    user_id    BIGINT PRIMARY KEY,
+    total_xp   BIGINT      NOT NULL DEFAULT 0 CHECK (total_xp >= 0),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_xp_total ON user_xp (total_xp DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_xp_total;
+DROP TABLE IF EXISTS user_xp;
+-- +goose StatementEnd
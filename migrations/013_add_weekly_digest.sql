@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS weekly_digest_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS last_digest_sent_at   timestamptz DEFAULT NULL;
+
+ALTER TABLE user_progress
+    ADD COLUMN IF NOT EXISTS mastered_at timestamptz DEFAULT NULL;
+
+UPDATE user_progress
+SET mastered_at = last_reviewed_at
+WHERE phase = 'mastered'
+  AND mastered_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_user_progress_mastered_at
+    ON user_progress (user_id, mastered_at)
+    WHERE mastered_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_user_reminders_weekly_digest
+    ON user_reminders (user_id)
+    WHERE weekly_digest_enabled = true;
+
+COMMENT ON COLUMN user_progress.mastered_at IS
+    'When the name last moved to the mastered phase';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_reminders_weekly_digest;
+DROP INDEX IF EXISTS idx_user_progress_mastered_at;
+
+ALTER TABLE user_progress
+    DROP COLUMN IF EXISTS mastered_at;
+
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS last_digest_sent_at,
+    DROP COLUMN IF EXISTS weekly_digest_enabled;
+-- +goose StatementEnd
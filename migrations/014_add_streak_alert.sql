@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS last_streak_alert_at timestamptz DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS last_streak_alert_at;
+-- +goose StatementEnd
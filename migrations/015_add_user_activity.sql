@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS last_active_at     timestamptz NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS deletion_warned_at timestamptz DEFAULT NULL;
+
+UPDATE users u
+SET last_active_at = COALESCE(GREATEST(
+        u.created_at,
+        (SELECT MAX(qa.answered_at) FROM quiz_answers qa WHERE qa.user_id = u.id),
+        (SELECT MAX(up.last_reviewed_at) FROM user_progress up WHERE up.user_id = u.id)
+    ), NOW());
+
+CREATE INDEX IF NOT EXISTS idx_users_last_active ON users (last_active_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_last_active;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS last_active_at,
+    DROP COLUMN IF EXISTS deletion_warned_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Whole-hour offsets like "UTC+3" become IANA "Etc/GMT-3" (POSIX sign is inverted).
+-- Other offsets are kept; they are still understood and replaced once the user picks a city.
+UPDATE user_settings
+SET timezone = CASE
+    WHEN substring(timezone FROM '^UTC[+-](\d{1,2})(?::00)?$')::int = 0 THEN 'UTC'
+    WHEN substring(timezone FROM 4 FOR 1) = '+'
+        THEN 'Etc/GMT-' || substring(timezone FROM '^UTC[+-](\d{1,2})(?::00)?$')::int
+    ELSE 'Etc/GMT+' || substring(timezone FROM '^UTC[+-](\d{1,2})(?::00)?$')::int
+END
+WHERE timezone ~ '^UTC[+-]\d{1,2}(:00)?$';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE user_settings
+SET timezone = CASE
+    WHEN substring(timezone FROM 8 FOR 1) = '-' THEN 'UTC+' || substring(timezone FROM 9)
+    ELSE 'UTC-' || substring(timezone FROM 9)
+END
+WHERE timezone ~ '^Etc/GMT[+-]\d{1,2}$';
+-- +goose StatementEnd
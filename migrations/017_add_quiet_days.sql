@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS quiet_weekdays smallint NOT NULL DEFAULT 0, -- bitmask, bit N = weekday N (0 = Sunday)
+    ADD COLUMN IF NOT EXISTS quiet_dates    date[]   NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS quiet_weekdays,
+    DROP COLUMN IF EXISTS quiet_dates;
+-- +goose StatementEnd
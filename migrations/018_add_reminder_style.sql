@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS style text NOT NULL DEFAULT 'name'; -- 'name' (one name per ping) or 'digest' (one morning summary)
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS style;
+-- +goose StatementEnd
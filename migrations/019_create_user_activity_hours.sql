@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_activity_hours
+(
+    user_id bigint   NOT NULL,
+    hour    smallint NOT NULL CHECK ( hour BETWEEN 0 AND 23 ), -- local hour of day
+    hits    integer  NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, hour),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_activity_hours;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS prayer_anchors        smallint NOT NULL DEFAULT 0,  -- bitmask, bit N = prayer N (0 = Fajr ... 4 = Isha)
+    ADD COLUMN IF NOT EXISTS prayer_offset_minutes smallint NOT NULL DEFAULT 30, -- minutes after the prayer starts
+    ADD COLUMN IF NOT EXISTS latitude              double precision,
+    ADD COLUMN IF NOT EXISTS longitude             double precision;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS prayer_anchors,
+    DROP COLUMN IF EXISTS prayer_offset_minutes,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS longitude;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS claimed_at timestamptz; -- set while a bot instance is dispatching the reminder
+
+CREATE INDEX IF NOT EXISTS idx_user_reminders_due
+    ON user_reminders (next_send_at)
+    WHERE is_enabled = true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_reminders_due;
+
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS claimed_at;
+-- +goose StatementEnd
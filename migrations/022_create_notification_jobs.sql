@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notification_jobs
+(
+    id           bigserial PRIMARY KEY,
+    user_id      bigint      NOT NULL,
+    chat_id      bigint      NOT NULL,
+    kind         text        NOT NULL,                   -- 'reminder' | 'daily_digest'
+    payload      jsonb       NOT NULL,
+    status       text        NOT NULL DEFAULT 'pending', -- 'pending' | 'failed'; delivered jobs are deleted
+    attempts     int         NOT NULL DEFAULT 0,
+    scheduled_at timestamptz NOT NULL DEFAULT NOW(),
+    locked_until timestamptz,                            -- set while a worker is delivering the job
+    last_error   text,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_jobs_pending
+    ON notification_jobs (scheduled_at)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_notification_jobs_user
+    ON notification_jobs (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_jobs;
+-- +goose StatementEnd
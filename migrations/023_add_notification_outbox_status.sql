@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Delivered jobs are kept as 'delivered' (pruned by the retention cleanup) instead of being deleted.
+ALTER TABLE notification_jobs
+    ADD COLUMN IF NOT EXISTS delivered_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_notification_jobs_delivered
+    ON notification_jobs (delivered_at)
+    WHERE status = 'delivered';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM notification_jobs WHERE status = 'delivered';
+
+DROP INDEX IF EXISTS idx_notification_jobs_delivered;
+
+ALTER TABLE notification_jobs
+    DROP COLUMN IF EXISTS delivered_at;
+-- +goose StatementEnd
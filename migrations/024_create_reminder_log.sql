@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reminder_log
+(
+    id            bigserial PRIMARY KEY,
+    user_id       bigint      NOT NULL,
+    job_id        bigint,                -- notification_jobs.id, kept after the job is pruned
+    kind          text        NOT NULL,  -- notification kind: 'reminder', 'daily_digest', ...
+    reminder_kind text,                  -- 'new' | 'review' | 'study' for single-name reminders
+    name_number   int,
+    outcome       text        NOT NULL,  -- 'delivered' | 'retry' | 'failed'
+    attempt       int         NOT NULL,
+    error         text,
+    created_at    timestamptz NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_log_created_at
+    ON reminder_log (created_at);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_log_user
+    ON reminder_log (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reminder_log;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    ADD COLUMN IF NOT EXISTS content text NOT NULL DEFAULT 'mixed'; -- 'mixed' (alternate), 'review' (SRS reviews only) or 'new' (today's names only)
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_reminders
+    DROP COLUMN IF EXISTS content;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS calendar text NOT NULL DEFAULT 'gregorian'; -- 'gregorian' or 'hijri': months used by the monthly report
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS calendar;
+-- +goose StatementEnd
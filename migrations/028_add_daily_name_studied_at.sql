@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_daily_name
+    ADD COLUMN IF NOT EXISTS studied_at TIMESTAMP; -- set when the user ticks the name off on the /today checklist
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_daily_name
+    DROP COLUMN IF EXISTS studied_at;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS channel_posts
+(
+    chat_id      bigint      NOT NULL, -- Telegram channel the name of the day is posted to
+    post_date    date        NOT NULL, -- channel's local calendar day
+    name_number  int         NOT NULL,
+    message_id   bigint,               -- NULL until the post is sent, or if the channel rejected it
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    published_at timestamptz,
+
+    PRIMARY KEY (chat_id, post_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS channel_posts;
+-- +goose StatementEnd
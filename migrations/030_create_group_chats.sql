@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS group_chats
+(
+    chat_id       bigint PRIMARY KEY,
+    title         text        NOT NULL DEFAULT '',
+    language_code text        NOT NULL DEFAULT 'ru',
+    daily_post    boolean     NOT NULL DEFAULT FALSE, -- post the name of the day to the group
+    post_time     text        NOT NULL DEFAULT '08:00',
+    timezone      text        NOT NULL DEFAULT 'UTC',
+    created_at    timestamptz NOT NULL DEFAULT NOW(),
+    updated_at    timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_chats_daily_post
+    ON group_chats (chat_id) WHERE daily_post;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS group_chats;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE group_chats
+    ADD COLUMN IF NOT EXISTS daily_quiz boolean NOT NULL DEFAULT FALSE; -- follow the daily post with a mini-quiz
+
+ALTER TABLE channel_posts
+    ADD COLUMN IF NOT EXISTS quiz_name_number int,          -- name asked about in the mini-quiz, NULL if none was sent
+    ADD COLUMN IF NOT EXISTS quiz_poll_id     text,         -- Telegram poll of the mini-quiz
+    ADD COLUMN IF NOT EXISTS quiz_voters      int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS quiz_correct     int NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_posts_quiz_poll_id
+    ON channel_posts (quiz_poll_id) WHERE quiz_poll_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_channel_posts_quiz_poll_id;
+
+ALTER TABLE channel_posts
+    DROP COLUMN IF EXISTS quiz_correct,
+    DROP COLUMN IF EXISTS quiz_voters,
+    DROP COLUMN IF EXISTS quiz_poll_id,
+    DROP COLUMN IF EXISTS quiz_name_number;
+
+ALTER TABLE group_chats
+    DROP COLUMN IF EXISTS daily_quiz;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS admins
+(
+    user_id    bigint PRIMARY KEY,
+    role       text        NOT NULL CHECK (role IN ('owner', 'operator', 'editor', 'support')),
+    added_by   bigint      NOT NULL, -- admin who granted the role
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS admin_actions
+(
+    id             bigserial PRIMARY KEY,
+    admin_id       bigint      NOT NULL,
+    action         text        NOT NULL, -- admin command without the slash
+    target_user_id bigint,               -- user the action was applied to, if any
+    details        text        NOT NULL DEFAULT '',
+    created_at     timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_actions_created_at
+    ON admin_actions (created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS admin_actions;
+DROP TABLE IF EXISTS admins;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS feedback
+(
+    id          bigserial PRIMARY KEY,
+    user_id     bigint      NOT NULL,
+    chat_id     bigint      NOT NULL, -- private chat the answer is relayed to
+    text        text        NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    answered_at timestamptz,
+    answered_by bigint,               -- admin who answered last
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Copies of feedback sent to admins; a reply to one of them is relayed to the user.
+CREATE TABLE IF NOT EXISTS feedback_messages
+(
+    chat_id     bigint NOT NULL,
+    message_id  bigint NOT NULL,
+    feedback_id bigint NOT NULL REFERENCES feedback (id) ON DELETE CASCADE,
+    PRIMARY KEY (chat_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_user_id
+    ON feedback (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS feedback_messages;
+DROP TABLE IF EXISTS feedback;
+-- +goose StatementEnd
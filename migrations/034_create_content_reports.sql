@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS content_reports
+(
+    id          bigserial PRIMARY KEY,
+    user_id     bigint      NOT NULL,
+    chat_id     bigint      NOT NULL, -- private chat the resolution is sent to
+    name_number smallint    NOT NULL CHECK (name_number BETWEEN 1 AND 99),
+    field       text        NOT NULL,
+    text        text        NOT NULL,
+    status      text        NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'accepted', 'dismissed')),
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    resolved_at timestamptz,
+    resolved_by bigint,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_reports_open
+    ON content_reports (created_at) WHERE status = 'open';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS content_reports;
+-- +goose StatementEnd
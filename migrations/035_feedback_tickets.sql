@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE feedback RENAME TO tickets;
+ALTER INDEX idx_feedback_user_id RENAME TO idx_tickets_user_id;
+
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS status    text NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'answered', 'closed')),
+    ADD COLUMN IF NOT EXISTS closed_at timestamptz,
+    ADD COLUMN IF NOT EXISTS closed_by bigint;
+
+UPDATE tickets
+SET status = 'answered'
+WHERE answered_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_status
+    ON tickets (status, created_at);
+
+ALTER TABLE feedback_messages RENAME TO ticket_messages;
+ALTER TABLE ticket_messages RENAME COLUMN feedback_id TO ticket_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ticket_messages RENAME COLUMN ticket_id TO feedback_id;
+ALTER TABLE ticket_messages RENAME TO feedback_messages;
+
+DROP INDEX IF EXISTS idx_tickets_status;
+
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS closed_by;
+
+ALTER INDEX idx_tickets_user_id RENAME TO idx_feedback_user_id;
+ALTER TABLE tickets RENAME TO feedback;
+-- +goose StatementEnd
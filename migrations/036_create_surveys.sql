@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS surveys
+(
+    id         bigserial PRIMARY KEY,
+    title      text        NOT NULL,
+    segment    text        NOT NULL, -- 'all' | 'active' | 'reminders' | 'new'
+    questions  jsonb       NOT NULL, -- [{"text": ..., "options": [...]}]
+    created_by bigint      NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    sent_at    timestamptz
+);
+
+-- Users a survey was sent to; completed_at is set after the last answer.
+CREATE TABLE IF NOT EXISTS survey_recipients
+(
+    survey_id    bigint      NOT NULL REFERENCES surveys (id) ON DELETE CASCADE,
+    user_id      bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    sent_at      timestamptz NOT NULL DEFAULT NOW(),
+    completed_at timestamptz,
+    PRIMARY KEY (survey_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS survey_answers
+(
+    survey_id      bigint      NOT NULL REFERENCES surveys (id) ON DELETE CASCADE,
+    user_id        bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    question_index smallint    NOT NULL,
+    option_index   smallint    NOT NULL,
+    answered_at    timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (survey_id, user_id, question_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_survey_recipients_user
+    ON survey_recipients (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_survey_answers_user
+    ON survey_answers (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS survey_answers;
+DROP TABLE IF EXISTS survey_recipients;
+DROP TABLE IF EXISTS surveys;
+-- +goose StatementEnd
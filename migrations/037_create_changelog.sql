@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users who opted in to "what's new" announcements.
+CREATE TABLE IF NOT EXISTS changelog_subscribers
+(
+    user_id    bigint PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Releases already announced; the first instance started with a new release claims it.
+CREATE TABLE IF NOT EXISTS changelog_announcements
+(
+    version      text PRIMARY KEY,
+    announced_at timestamptz NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS changelog_announcements;
+DROP TABLE IF EXISTS changelog_subscribers;
+-- +goose StatementEnd
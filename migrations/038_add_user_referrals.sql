@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Who invited the user via a "ref_<id>" /start link; set once, when the user is created.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS referred_by bigint REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS referred_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_users_referred_by ON users (referred_by) WHERE referred_by IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_referred_by;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS referred_at,
+    DROP COLUMN IF EXISTS referred_by;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Product analytics: one row per user action, queried ad hoc with SQL.
+CREATE TABLE IF NOT EXISTS events
+(
+    id         bigserial PRIMARY KEY,
+    user_id    bigint      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       text        NOT NULL,
+    properties jsonb       NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_name_created_at ON events (name, created_at);
+CREATE INDEX IF NOT EXISTS idx_events_user_id_created_at ON events (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS events;
+-- +goose StatementEnd
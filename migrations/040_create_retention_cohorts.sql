@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- D1/D7/D30 retention per signup week, refreshed by the retention report job.
+-- A dN column is NULL until day N has passed for every user of the cohort.
+CREATE TABLE IF NOT EXISTS retention_cohorts
+(
+    cohort_week date PRIMARY KEY, -- Monday of the signup week (UTC)
+    users       integer     NOT NULL,
+    d1          integer,
+    d7          integer,
+    d30         integer,
+    computed_at timestamptz NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS retention_cohorts;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Personal tokens for the HTTP API; only the SHA-256 of a token is stored.
+CREATE TABLE IF NOT EXISTS api_tokens
+(
+    user_id      bigint PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token_hash   bytea       NOT NULL UNIQUE,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    last_used_at timestamptz
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_tokens;
+-- +goose StatementEnd
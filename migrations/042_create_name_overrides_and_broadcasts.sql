@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Name card texts edited in the web admin; they replace the texts of the names JSON file.
+CREATE TABLE IF NOT EXISTS name_overrides
+(
+    number          smallint PRIMARY KEY CHECK (number BETWEEN 1 AND 99),
+    arabic          text        NOT NULL,
+    transliteration text        NOT NULL,
+    translation     text        NOT NULL,
+    meaning         text        NOT NULL,
+    updated_by      bigint      NOT NULL,
+    updated_at      timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Messages sent to a segment of users from the web admin.
+CREATE TABLE IF NOT EXISTS broadcasts
+(
+    id         bigserial PRIMARY KEY,
+    segment    text        NOT NULL,
+    text       text        NOT NULL,
+    created_by bigint      NOT NULL,
+    queued     integer     NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS broadcasts;
+DROP TABLE IF EXISTS name_overrides;
+-- +goose StatementEnd
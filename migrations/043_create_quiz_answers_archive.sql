@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Quiz answers older than retention.quiz_archive_days are moved here by the retention job,
+-- so day-to-day stats only scan recent answers. Sessions and questions of archived answers
+-- may be pruned, so only the user is referenced.
+CREATE TABLE IF NOT EXISTS quiz_answers_archive
+(
+    id             bigint PRIMARY KEY, -- quiz_answers.id
+    user_id        bigint      NOT NULL,
+    session_id     bigint      NOT NULL,
+    question_id    bigint      NOT NULL,
+    name_number    smallint    NOT NULL,
+    user_answer    text,
+    correct_answer text,
+    question_type  varchar(20),
+    is_correct     boolean     NOT NULL,
+    answered_at    timestamptz NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_quiz_answers_archive_user_name ON quiz_answers_archive (user_id, name_number);
+CREATE INDEX idx_quiz_answers_archive_date ON quiz_answers_archive (user_id, answered_at);
+CREATE INDEX idx_quiz_answers_archive_answered ON quiz_answers_archive (answered_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS quiz_answers_archive;
+-- +goose StatementEnd
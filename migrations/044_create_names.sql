@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The names dataset, loaded from the names JSON file with `husna-bot seed-names`.
+CREATE TABLE IF NOT EXISTS names
+(
+    number          smallint PRIMARY KEY CHECK (number BETWEEN 1 AND 99),
+    arabic          text        NOT NULL,
+    transliteration text        NOT NULL,
+    translation     text        NOT NULL,
+    meaning         text        NOT NULL,
+    audio           text        NOT NULL DEFAULT '',
+    updated_at      timestamptz NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS names;
+-- +goose StatementEnd
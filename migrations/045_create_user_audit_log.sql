@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Append-only log of changes users make to their settings, reminders and daily plan,
+-- with the values before and after, for investigating support reports. Rows are never
+-- updated; they go away only with the user.
+CREATE TABLE IF NOT EXISTS user_audit_log
+(
+    id         bigserial PRIMARY KEY,
+    user_id    bigint      NOT NULL,
+    action     text        NOT NULL, -- e.g. settings.timezone, reminders.enabled, plan.swap, reset
+    old_value  jsonb,                -- NULL if there was no value
+    new_value  jsonb,                -- NULL if the value was removed
+    request_id text        NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_audit_log_user_created ON user_audit_log (user_id, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bot messages that are only useful for a while (quiz questions, input prompts,
+-- reminders) and are deleted from the chat once delete_after has passed.
+CREATE TABLE IF NOT EXISTS ephemeral_messages
+(
+    chat_id      bigint      NOT NULL,
+    message_id   integer     NOT NULL,
+    kind         text        NOT NULL, -- quiz, prompt, reminder
+    sent_at      timestamptz NOT NULL DEFAULT NOW(),
+    delete_after timestamptz NOT NULL,
+
+    PRIMARY KEY (chat_id, message_id)
+);
+
+CREATE INDEX idx_ephemeral_messages_delete_after ON ephemeral_messages (delete_after);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ephemeral_messages;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users blocked by admins: their updates are ignored and they get no broadcasts or
+-- reminders. There is no foreign key, so a user can be blocked before they ever start
+-- the bot and stays blocked after deleting their data.
+CREATE TABLE IF NOT EXISTS blocked_users
+(
+    user_id    bigint PRIMARY KEY,
+    reason     text        NOT NULL DEFAULT '',
+    blocked_by bigint      NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blocked_users;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations so the bot binary can apply them itself.
+package migrations
+
+import "embed"
+
+// FS holds the goose-format SQL migrations.
+//
+//go:embed *.sql
+var FS embed.FS